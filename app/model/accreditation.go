@@ -0,0 +1,88 @@
+package model
+
+import "time"
+
+// Tingkat prestasi pada tabel akreditasi. Prestasi lokal dan provinsi dilaporkan sebagai wilayah.
+const (
+	LevelInternational = "international"
+	LevelNational      = "national"
+	LevelRegional      = "regional"
+	LevelUnknown       = "unknown"
+)
+
+// Kategori tabel akreditasi: prestasi akademik (Tabel 8.b.1) dan non-akademik (Tabel 8.b.2)
+const (
+	AccreditationAcademic    = "academic"
+	AccreditationNonAcademic = "non_academic"
+)
+
+// AccreditationFilter memilih prestasi verified satu program studi dengan tahun perolehan YearFrom–YearTo
+// (inklusif). Category, Level dan Year hanya dipakai untuk daftar bukti (drill-down).
+type AccreditationFilter struct {
+	ProgramStudy string
+	YearFrom     int
+	YearTo       int
+	Category     string
+	Level        string
+	Year         int
+}
+
+// AccreditationAchievement adalah satu prestasi verified beserta buktinya. Prestasi tim dihitung sekali;
+// Students memuat semua anggota terverifikasi dari program studi tersebut.
+type AccreditationAchievement struct {
+	AchievementID   string                  `json:"achievement_id"`
+	AchievementType string                  `json:"achievement_type"`
+	Category        string                  `json:"category"`
+	Title           string                  `json:"title"`
+	Level           string                  `json:"level"`
+	LevelText       string                  `json:"level_text,omitempty"` // isian tingkat apa adanya
+	Rank            string                  `json:"rank,omitempty"`
+	Year            int                     `json:"year"`
+	EventDate       string                  `json:"event_date,omitempty"`
+	VerifiedAt      *time.Time              `json:"verified_at,omitempty"`
+	StudentIDs      []string                `json:"student_ids"`
+	StudentNames    []string                `json:"student_names"`
+	Evidence        []AccreditationEvidence `json:"evidence"`
+	Details         map[string]interface{}  `json:"-"`
+	Attachments     []AchievementAttachment `json:"-"`
+}
+
+// AccreditationEvidence adalah satu lampiran atau tautan bukti prestasi
+type AccreditationEvidence struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	URL  string `json:"url"`
+}
+
+type AccreditationLevelCount struct {
+	International int `json:"international"`
+	National      int `json:"national"`
+	Regional      int `json:"regional"`
+	Unknown       int `json:"unknown"`
+	Total         int `json:"total"`
+}
+
+// AccreditationYear adalah jumlah prestasi satu tahun. Label mengikuti penamaan borang akreditasi: tahun
+// terakhir rentang adalah TS, sebelumnya TS-1, TS-2 dan seterusnya.
+type AccreditationYear struct {
+	Year  int    `json:"year"`
+	Label string `json:"label"`
+	AccreditationLevelCount
+}
+
+type AccreditationSummary struct {
+	Category string                  `json:"category"`
+	Title    string                  `json:"title"`
+	Years    []AccreditationYear     `json:"years"`
+	Total    AccreditationLevelCount `json:"total"`
+}
+
+type AccreditationReport struct {
+	ProgramStudy string                 `json:"program_study"`
+	YearFrom     int                    `json:"year_from"`
+	YearTo       int                    `json:"year_to"`
+	Summaries    []AccreditationSummary `json:"summaries"`
+	Achievements int                    `json:"achievements"`
+	Unclassified int                    `json:"unclassified"` // prestasi tanpa tingkat yang dikenali
+	GeneratedAt  time.Time              `json:"generated_at"`
+}
@@ -16,6 +16,7 @@ type AchievementListDTO struct {
 	Status          string    `json:"status"`               
 	Points          int       `json:"points"`               
 	CreatedAt       time.Time `json:"created_at"`           
+	DeletedAt       *time.Time `json:"deleted_at,omitempty"`
 }
 
 type AchievementDetailDTO struct {
@@ -27,6 +28,12 @@ type AchievementDetailDTO struct {
 	Details         map[string]interface{} `json:"details"`      
 	Tags            []string               `json:"tags"`
 	Attachments     []AchievementAttachment`json:"attachments"`
+	Points          int                    `json:"points"`
+	PointsMode      string                 `json:"points_mode"`
+	Members         []AchievementMember    `json:"members"`
+	DuplicateWarnings []DuplicateWarning   `json:"duplicate_warnings,omitempty"`
+	EvidenceDigest  string                 `json:"evidence_digest,omitempty"`
+	EvidenceFrozenAt *time.Time            `json:"evidence_frozen_at,omitempty"`
 	Status          string                 `json:"status"`
 	RejectionNote   *string                `json:"rejection_note,omitempty"`
 	VerifiedBy      *string                `json:"verified_by,omitempty"`
@@ -40,6 +47,7 @@ type CreateAchievementRequest struct {
 	Description     string                 `json:"description"`
 	Details         map[string]interface{} `json:"details"` 
 	Tags            []string               `json:"tags"`
+	PointsMode      string                 `json:"points_mode" validate:"omitempty,oneof=per_member split"`
 }
 
 type UpdateAchievementRequest struct {
@@ -48,6 +56,7 @@ type UpdateAchievementRequest struct {
 	Details     map[string]interface{}  `json:"details"`
 	Tags        []string                `json:"tags"` 
 	AchievementType *string				`json:"achievement_type"`
+	PointsMode  *string                 `json:"points_mode" validate:"omitempty,oneof=per_member split"`
 }
 
 type AchievementMongo struct {
@@ -60,15 +69,70 @@ type AchievementMongo struct {
 	Tags            []string                `bson:"tags"`
 	Attachments     []AchievementAttachment `bson:"attachments"` 
 	Points          int                     `bson:"points"`
+	PointsMode      string                  `bson:"points_mode,omitempty"`
+	DuplicateWarnings []DuplicateWarning    `bson:"duplicate_warnings,omitempty"`
+	EvidenceDigest  string                  `bson:"evidence_digest,omitempty"`
+	EvidenceFrozenAt *time.Time             `bson:"evidence_frozen_at,omitempty"`
 	CreatedAt       time.Time               `bson:"created_at"`
 	UpdatedAt       time.Time               `bson:"updated_at"`
 }
 
 type AchievementAttachment struct {
+	ID         string    `bson:"id,omitempty" json:"id,omitempty"`
+	Kind       string    `bson:"kind,omitempty" json:"kind,omitempty"`
 	FileName   string    `bson:"file_name" json:"file_name"`
 	FileURL    string    `bson:"file_url" json:"file_url"`
 	FileType   string    `bson:"file_type" json:"file_type"`
+	ContentHash string   `bson:"content_hash,omitempty" json:"content_hash,omitempty"`
+	Size       int64     `bson:"size,omitempty" json:"size,omitempty"`
+	StorageKey string    `bson:"storage_key,omitempty" json:"-"`
+	ScanStatus string    `bson:"scan_status,omitempty" json:"scan_status,omitempty"`
+	ScanSignature string `bson:"scan_signature,omitempty" json:"scan_signature,omitempty"`
+	ScannedAt  *time.Time `bson:"scanned_at,omitempty" json:"scanned_at,omitempty"`
+	ScanAttempts int     `bson:"scan_attempts,omitempty" json:"-"`
+	NextScanAt *time.Time `bson:"next_scan_at,omitempty" json:"-"`
+	ScanError  string    `bson:"scan_error,omitempty" json:"scan_error,omitempty"`
+	ThumbnailKey string  `bson:"thumbnail_key,omitempty" json:"-"`
+	PreviewKey   string  `bson:"preview_key,omitempty" json:"-"`
+	ThumbnailURL string  `bson:"-" json:"thumbnail_url,omitempty"`
+	PreviewURL   string  `bson:"-" json:"preview_url,omitempty"`
 	UploadedAt time.Time `bson:"uploaded_at" json:"uploaded_at"`
+	FrozenAt   *time.Time `bson:"frozen_at,omitempty" json:"frozen_at,omitempty"`
+	LinkStatus int       `bson:"link_status,omitempty" json:"link_status,omitempty"`
+	LinkTitle  string    `bson:"link_title,omitempty" json:"link_title,omitempty"`
+	LinkError  string    `bson:"link_error,omitempty" json:"link_error,omitempty"`
+	LinkCheckedAt *time.Time `bson:"link_checked_at,omitempty" json:"link_checked_at,omitempty"`
+}
+
+// IsLink menandakan lampiran berupa tautan (berita, halaman hasil lomba, DOI), bukan file
+func (a AchievementAttachment) IsLink() bool {
+	return a.Kind == AttachmentKindLink
+}
+
+type AttachmentURLResponse struct {
+	URL       string    `json:"url"`
+	ExpiresAt time.Time `json:"expires_at"`
+}
+
+// Kandidat pembanding untuk deteksi duplikasi
+type DuplicateCandidate struct {
+	AchievementID string
+	StudentID     string
+	StudentName   string
+	Status        string
+	Document      AchievementMongo
+}
+
+// Peringatan kemungkinan duplikasi yang ditampilkan ke dosen wali sebelum verifikasi
+type DuplicateWarning struct {
+	AchievementID string    `bson:"achievement_id" json:"achievement_id"`
+	StudentID     string    `bson:"student_id" json:"student_id"`
+	StudentName   string    `bson:"student_name" json:"student_name"`
+	Title         string    `bson:"title" json:"title"`
+	Status        string    `bson:"status" json:"status"`
+	Score         float64   `bson:"score" json:"score"`
+	Reasons       []string  `bson:"reasons" json:"reasons"`
+	DetectedAt    time.Time `bson:"detected_at" json:"detected_at"`
 }
 
 type AchievementReference struct {
@@ -94,4 +158,6 @@ type PaginatedAchievements struct {
 	Page       int                  `json:"page"`
 	PageSize   int                  `json:"page_size"`
 	TotalPages int                  `json:"total_pages"`
+	NextCursor string               `json:"next_cursor,omitempty"`
+	PrevCursor string               `json:"prev_cursor,omitempty"`
 }
\ No newline at end of file
@@ -0,0 +1,39 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	CommentKindComment       = "comment"
+	CommentKindClarification = "clarification_request"
+)
+
+type AchievementComment struct {
+	ID            primitive.ObjectID      `bson:"_id,omitempty" json:"id"`
+	AchievementID string                  `bson:"achievement_id" json:"achievement_id"`
+	ParentID      string                  `bson:"parent_id,omitempty" json:"parent_id,omitempty"`
+	AuthorID      string                  `bson:"author_id" json:"author_id"`
+	AuthorName    string                  `bson:"author_name" json:"author_name"`
+	AuthorRole    string                  `bson:"author_role" json:"author_role"`
+	Kind          string                  `bson:"kind" json:"kind"`
+	Body          string                  `bson:"body" json:"body"`
+	Attachments   []AchievementAttachment `bson:"attachments" json:"attachments"`
+	Resolved      bool                    `bson:"resolved" json:"resolved"`
+	ResolvedAt    *time.Time              `bson:"resolved_at,omitempty" json:"resolved_at,omitempty"`
+	CreatedAt     time.Time               `bson:"created_at" json:"created_at"`
+	Replies       []AchievementComment    `bson:"-" json:"replies,omitempty"`
+}
+
+type CreateCommentRequest struct {
+	Body     string `json:"body" form:"body" validate:"required"`
+	ParentID string `json:"parent_id" form:"parent_id"`
+	Kind     string `json:"kind" form:"kind" validate:"omitempty,oneof=comment clarification_request"`
+}
+
+type CommentThreads struct {
+	Comments           []AchievementComment `json:"comments"`
+	OpenClarifications int                  `json:"open_clarifications"`
+}
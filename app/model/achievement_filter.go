@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+const (
+	AchievementSortCreatedAt   = "created_at"
+	AchievementSortTitle       = "title"
+	AchievementSortPoints      = "points"
+	AchievementSortStudentName = "student_name"
+)
+
+// AchievementFilter adalah kriteria daftar prestasi. Field dokumen Mongo (jenis, tag, poin, judul)
+// disaring lewat proyeksi achievement_read_model di PostgreSQL.
+type AchievementFilter struct {
+	Page     int
+	PageSize int
+
+	Search          string
+	StudentID       string
+	AdvisorID       string
+	Status          string
+	AchievementType string
+	Tags            []string
+	DateFrom        *time.Time
+	DateTo          *time.Time
+	MinPoints       *int
+	MaxPoints       *int
+	ProgramStudy    string
+	AcademicYear    string
+	// Trash menampilkan hanya prestasi yang sudah dihapus; selain itu prestasi terhapus selalu disembunyikan
+	Trash bool
+
+	SortBy    string
+	SortOrder string
+}
@@ -0,0 +1,58 @@
+package model
+
+import "time"
+
+const (
+	PointsModePerMember = "per_member"
+	PointsModeSplit     = "split"
+
+	InvitationInvited   = "invited"
+	InvitationConfirmed = "confirmed"
+	InvitationDeclined  = "declined"
+
+	MemberVerificationPending  = "pending"
+	MemberVerificationVerified = "verified"
+	MemberVerificationRejected = "rejected"
+)
+
+// Anggota tim (selain pembuat) pada prestasi beregu
+type AchievementMember struct {
+	ID                 string     `json:"id"`
+	AchievementID      string     `json:"achievement_id"`
+	StudentID          string     `json:"-"`
+	StudentNumber      string     `json:"student_id"`
+	FullName           string     `json:"full_name"`
+	AdvisorID          *string    `json:"advisor_id,omitempty"`
+	InvitationStatus   string     `json:"invitation_status"`
+	VerificationStatus string     `json:"verification_status"`
+	RejectionNote      *string    `json:"rejection_note,omitempty"`
+	VerifiedBy         *string    `json:"verified_by,omitempty"`
+	VerifiedAt         *time.Time `json:"verified_at,omitempty"`
+	CreatedAt          time.Time  `json:"created_at"`
+}
+
+type InviteMembersRequest struct {
+	StudentIDs []string `json:"student_ids" validate:"required,min=1"`
+}
+
+type RespondInvitationRequest struct {
+	Accept bool `json:"accept"`
+}
+
+type RejectMemberRequest struct {
+	RejectionNote string `json:"rejection_note" validate:"required,min=5"`
+}
+
+// MemberPoints menghitung poin efektif satu peserta sesuai mode pembagian poin. participants adalah
+// pembuat ditambah anggota yang sudah konfirmasi dan diverifikasi. Sisa pembagian diberikan ke pembuat
+// sehingga jumlah poin seluruh peserta sama dengan poin prestasi.
+func MemberPoints(totalPoints int, pointsMode string, participants int, isOwner bool) int {
+	if pointsMode != PointsModeSplit || participants <= 1 {
+		return totalPoints
+	}
+	points := totalPoints / participants
+	if isOwner {
+		points += totalPoints % participants
+	}
+	return points
+}
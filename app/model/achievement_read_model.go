@@ -0,0 +1,9 @@
+package model
+
+// ReadModelRebuildResult merangkum hasil `rebuild-read-model`
+type ReadModelRebuildResult struct {
+	Projected int `json:"projected"`
+	Missing   int `json:"missing"`
+	// Pending berisi id prestasi yang dilewati karena masih punya event outbox belum terkirim
+	Pending []string `json:"pending"`
+}
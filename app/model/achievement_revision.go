@@ -0,0 +1,58 @@
+package model
+
+import (
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	RevisionEventBaseline           = "baseline"
+	RevisionEventCreated            = "created"
+	RevisionEventUpdated            = "updated"
+	RevisionEventAttachment         = "attachment_added"
+	RevisionEventAttachmentRemoved  = "attachment_removed"
+	RevisionEventAttachmentReplaced = "attachment_replaced"
+	RevisionEventSubmitted          = "submitted"
+	RevisionEventVerified           = "verified"
+	RevisionEventRejected           = "rejected"
+)
+
+// Salinan isi dokumen prestasi pada satu titik waktu (tag bson sama dengan AchievementMongo)
+type RevisionSnapshot struct {
+	AchievementType string                  `bson:"achievement_type" json:"achievement_type"`
+	Title           string                  `bson:"title" json:"title"`
+	Description     string                  `bson:"description" json:"description"`
+	Details         map[string]interface{}  `bson:"details" json:"details"`
+	Tags            []string                `bson:"tags" json:"tags"`
+	Attachments     []AchievementAttachment `bson:"attachments" json:"attachments"`
+	Points          int                     `bson:"points" json:"points"`
+	PointsMode      string                  `bson:"points_mode,omitempty" json:"points_mode,omitempty"`
+}
+
+type AchievementRevision struct {
+	ID            primitive.ObjectID `bson:"_id,omitempty" json:"id"`
+	AchievementID string             `bson:"achievement_id" json:"achievement_id"`
+	Version       int                `bson:"version" json:"version"`
+	Event         string             `bson:"event" json:"event"`
+	Status        string             `bson:"status" json:"status"`
+	ChangedBy     string             `bson:"changed_by" json:"changed_by"`
+	Snapshot      *RevisionSnapshot  `bson:"snapshot,omitempty" json:"snapshot,omitempty"`
+	CreatedAt     time.Time          `bson:"created_at" json:"created_at"`
+	// OutboxID adalah event outbox yang merekam revisi ini, agar pengulangan dispatcher tidak menggandakannya
+	OutboxID int64 `bson:"outbox_id,omitempty" json:"-"`
+}
+
+type RevisionFieldChange struct {
+	Field  string      `json:"field"`
+	Change string      `json:"change"` // added, removed, changed
+	Old    interface{} `json:"old,omitempty"`
+	New    interface{} `json:"new,omitempty"`
+}
+
+type RevisionDiff struct {
+	AchievementID string                `json:"achievement_id"`
+	From          AchievementRevision   `json:"from"`
+	To            AchievementRevision   `json:"to"`
+	Changes       []RevisionFieldChange `json:"changes"`
+}
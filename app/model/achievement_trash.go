@@ -0,0 +1,17 @@
+package model
+
+import "time"
+
+// TrashedAchievement adalah prestasi di tempat sampah yang masa retensinya sudah lewat
+type TrashedAchievement struct {
+	ID        string
+	MongoID   string
+	DeletedAt time.Time
+}
+
+// TrashPurgeSummary merangkum satu putaran penghapusan permanen
+type TrashPurgeSummary struct {
+	Purged int `json:"purged"`
+	Files  int `json:"files"`
+	Failed int `json:"failed"`
+}
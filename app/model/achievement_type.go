@@ -0,0 +1,54 @@
+package model
+
+import "time"
+
+type AchievementType struct {
+	ID                  string    `json:"id"`
+	Code                string    `json:"code"`
+	NameID              string    `json:"name_id"`
+	NameEN              string    `json:"name_en"`
+	Description         string    `json:"description"`
+	RequiredAttachments []string  `json:"required_attachments"`
+	MinPoints           int       `json:"min_points"`
+	MaxPoints           int       `json:"max_points"`
+	Aliases             []string  `json:"aliases"`
+	MaxFileSizeMB       int       `json:"max_file_size_mb"`
+	AllowedFileTypes    []string  `json:"allowed_file_types"`
+	IsActive            bool      `json:"is_active"`
+	CreatedAt           time.Time `json:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at"`
+}
+
+type CreateAchievementTypeRequest struct {
+	Code                string   `json:"code" validate:"required"`
+	NameID              string   `json:"name_id" validate:"required"`
+	NameEN              string   `json:"name_en" validate:"required"`
+	Description         string   `json:"description"`
+	RequiredAttachments []string `json:"required_attachments"`
+	MinPoints           int      `json:"min_points"`
+	MaxPoints           int      `json:"max_points"`
+	Aliases             []string `json:"aliases"`
+	MaxFileSizeMB       int      `json:"max_file_size_mb"`
+	AllowedFileTypes    []string `json:"allowed_file_types"`
+}
+
+type UpdateAchievementTypeRequest struct {
+	NameID              *string  `json:"name_id,omitempty"`
+	NameEN              *string  `json:"name_en,omitempty"`
+	Description         *string  `json:"description,omitempty"`
+	RequiredAttachments []string `json:"required_attachments,omitempty"`
+	MinPoints           *int     `json:"min_points,omitempty"`
+	MaxPoints           *int     `json:"max_points,omitempty"`
+	Aliases             []string `json:"aliases,omitempty"`
+	MaxFileSizeMB       *int     `json:"max_file_size_mb,omitempty"`
+	AllowedFileTypes    []string `json:"allowed_file_types,omitempty"`
+	IsActive            *bool    `json:"is_active,omitempty"`
+}
+
+// Hasil pemetaan satu nilai achievement_type lama ke katalog
+type AchievementTypeMapping struct {
+	OldValue string `json:"old_value"`
+	Code     string `json:"code,omitempty"`
+	Count    int64  `json:"count"`
+	Mapped   bool   `json:"mapped"`
+}
@@ -0,0 +1,10 @@
+package model
+
+// Hasil pembersihan file lampiran yang tidak lagi dirujuk dokumen mana pun
+type AttachmentGCResult struct {
+	Checked  int   `json:"checked"`
+	Orphaned int   `json:"orphaned"`
+	Deleted  int   `json:"deleted"`
+	Bytes    int64 `json:"bytes"`
+	Failed   int   `json:"failed"`
+}
@@ -0,0 +1,32 @@
+package model
+
+const (
+	IntegrityMissing      = "missing"
+	IntegritySizeMismatch = "size_mismatch"
+	IntegrityModified     = "modified"
+	IntegrityUnverifiable = "unverifiable"
+	IntegrityDigestBroken = "evidence_digest_mismatch"
+)
+
+// Dokumen (prestasi atau komentar) beserta lampirannya, dipakai untuk GC dan audit integritas
+type AttachmentDocument struct {
+	Collection     string
+	DocumentID     string
+	EvidenceDigest string
+	Attachments    []AchievementAttachment
+}
+
+type IntegrityIssue struct {
+	Collection string `json:"collection"`
+	DocumentID string `json:"document_id"`
+	FileName   string `json:"file_name,omitempty"`
+	Key        string `json:"key,omitempty"`
+	Problem    string `json:"problem"`
+	Frozen     bool   `json:"frozen"`
+}
+
+type IntegrityReport struct {
+	Checked      int              `json:"checked"`
+	Unverifiable int              `json:"unverifiable"`
+	Issues       []IntegrityIssue `json:"issues"`
+}
@@ -0,0 +1,24 @@
+package model
+
+const (
+	AttachmentKindFile = "file"
+	AttachmentKindLink = "link"
+)
+
+type AddEvidenceLinkRequest struct {
+	URL   string `json:"url" validate:"required"`
+	Title string `json:"title"`
+}
+
+// Tautan bukti yang perlu dicek ulang oleh link checker
+type LinkToCheck struct {
+	DocumentID string
+	Attachment AchievementAttachment
+}
+
+type LinkCheckSummary struct {
+	Checked int `json:"checked"`
+	Alive   int `json:"alive"`
+	Dead    int `json:"dead"`
+	Failed  int `json:"failed"`
+}
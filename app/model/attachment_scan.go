@@ -0,0 +1,26 @@
+package model
+
+const (
+	ScanStatusPending  = "pending"
+	ScanStatusClean    = "clean"
+	ScanStatusInfected = "infected"
+	ScanStatusFailed   = "failed" // pemindaian gagal berulang kali (misalnya file hilang dari storage)
+
+	QuarantinePrefix = "quarantine/"
+	PreviewPrefix    = "previews/"
+)
+
+// Lampiran yang menunggu pemindaian antivirus (lampiran prestasi maupun komentar)
+type PendingScan struct {
+	Collection string
+	DocumentID string
+	Attachment AchievementAttachment
+}
+
+type ScanSummary struct {
+	Scanned  int `json:"scanned"`
+	Clean    int `json:"clean"`
+	Infected int `json:"infected"`
+	Failed   int `json:"failed"`
+	GaveUp   int `json:"gave_up"` // gagal sampai batas percobaan dan ditandai failed
+}
@@ -0,0 +1,41 @@
+package model
+
+import "time"
+
+// Jenis event outbox prestasi. Payload berupa dokumen BSON.
+const (
+	// OutboxInsertAchievement membuat dokumen prestasi di MongoDB; payload adalah AchievementMongo lengkap
+	OutboxInsertAchievement = "achievement.insert"
+	// OutboxSetPoints menulis poin hasil verifikasi; payload berisi field points
+	OutboxSetPoints = "achievement.set_points"
+	// OutboxFreezeEvidence membekukan lampiran saat verifikasi; payload berisi evidence_digest dan frozen_at
+	OutboxFreezeEvidence = "achievement.freeze_evidence"
+	// OutboxUpdateAchievement mengubah field dokumen prestasi; payload berisi field yang di-$set
+	OutboxUpdateAchievement = "achievement.update"
+	// OutboxAddAttachment menambahkan lampiran; payload berisi attachment
+	OutboxAddAttachment = "achievement.add_attachment"
+	// OutboxRemoveAttachment menghapus lampiran; payload berisi ref (id atau file_name)
+	OutboxRemoveAttachment = "achievement.remove_attachment"
+	// OutboxReplaceAttachment mengganti lampiran ref dengan attachment
+	OutboxReplaceAttachment = "achievement.replace_attachment"
+	// OutboxRecordRevision merekam revisi dokumen setelah event sebelumnya diterapkan; payload berisi
+	// event, status, changed_by dan recorded_at
+	OutboxRecordRevision = "achievement.record_revision"
+)
+
+type OutboxEvent struct {
+	ID            int64
+	AchievementID string
+	MongoID       string
+	EventType     string
+	Payload       []byte
+	Attempts      int
+	LastError     string
+	CreatedAt     time.Time
+}
+
+// OutboxDispatchSummary merangkum satu putaran dispatcher outbox
+type OutboxDispatchSummary struct {
+	Dispatched int `json:"dispatched"`
+	Failed     int `json:"failed"`
+}
@@ -0,0 +1,17 @@
+package model
+
+// PageCursor adalah posisi satu baris pada paginasi keyset: nilai kolom urutan dan id-nya.
+// Dikirim ke klien sebagai string opaque (next_cursor/prev_cursor) dan hanya berlaku untuk urutan yang sama.
+type PageCursor struct {
+	SortBy   string `json:"s"`
+	Order    string `json:"o"`
+	Value    string `json:"v"`
+	ID       string `json:"i"`
+	Backward bool   `json:"b,omitempty"`
+}
+
+// CursorLinks berisi cursor ke halaman sesudah dan sebelum halaman saat ini (nil bila tidak ada)
+type CursorLinks struct {
+	Next *PageCursor
+	Prev *PageCursor
+}
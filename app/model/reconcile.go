@@ -0,0 +1,55 @@
+package model
+
+// Kategori inkonsistensi antara achievement_references (PostgreSQL) dan koleksi achievements (MongoDB)
+const (
+	// Dokumen Mongo tanpa baris achievement_references
+	ReconcileOrphanDocument = "orphan_document"
+	// achievement_references yang mongo_achievement_id-nya tidak menunjuk ke dokumen mana pun
+	ReconcileDanglingReference = "dangling_reference"
+	// Prestasi verified dengan poin Mongo berbeda dari poin hasil verifikasi
+	ReconcilePointsMismatch = "points_mismatch"
+	// Baris achievement_read_model hilang atau berbeda dari dokumen Mongo
+	ReconcileReadModelStale = "read_model_stale"
+	// Event outbox yang terus gagal diterapkan
+	ReconcileOutboxStuck = "outbox_stuck"
+)
+
+// ReconcileReference adalah satu baris achievement_references beserta proyeksi dan event outbox-nya
+type ReconcileReference struct {
+	ID            string
+	StudentID     string
+	MongoID       string
+	Status        string
+	PendingEvents int
+	// ReadModel nil jika baris achievement_read_model belum ada
+	ReadModel *AchievementProjection
+}
+
+// AchievementProjection adalah isi satu baris achievement_read_model
+type AchievementProjection struct {
+	AchievementType string
+	Title           string
+	Description     string
+	Tags            []string
+	Points          int
+	PointsMode      string
+}
+
+type ReconcileIssue struct {
+	Category      string `json:"category"`
+	AchievementID string `json:"achievement_id,omitempty"`
+	MongoID       string `json:"mongo_achievement_id,omitempty"`
+	Status        string `json:"status,omitempty"`
+	Detail        string `json:"detail"`
+	Fixed         bool   `json:"fixed"`
+	FixError      string `json:"fix_error,omitempty"`
+}
+
+type ReconcileReport struct {
+	References int              `json:"references"`
+	Documents  int              `json:"documents"`
+	Counts     map[string]int   `json:"counts"`
+	Pending    int              `json:"pending"` // dilewati karena event outbox belum terkirim
+	Fixed      int              `json:"fixed"`
+	Issues     []ReconcileIssue `json:"issues"`
+}
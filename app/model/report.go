@@ -2,18 +2,50 @@ package model
 
 import "time"
 
+// StatisticsFilter membatasi statistik dashboard. Rentang tanggal berlaku pada waktu prestasi dilaporkan
+// (created_at) dengan DateTo eksklusif; Faculty memfilter mahasiswa lewat tabel study_programs.
+type StatisticsFilter struct {
+	DateFrom *time.Time
+	DateTo   *time.Time
+	Faculty  string
+	TopN     int
+}
+
 type DashboardStatistics struct {
-	TotalStudents        int            `json:"total_students"`
-	TotalLecturers       int            `json:"total_lecturers"`
-	TotalAchievements    int            `json:"total_achievements"`
-	AchievementsByStatus map[string]int `json:"achievements_by_status"`
-	TopStudents          []TopStudent   `json:"top_students"`
+	TotalStudents          int                    `json:"total_students"`
+	TotalLecturers         int                    `json:"total_lecturers"`
+	TotalAchievements      int                    `json:"total_achievements"`
+	AchievementsByStatus   map[string]int         `json:"achievements_by_status"`
+	TopStudents            []TopStudent           `json:"top_students"`
+	ByType                 []StatBreakdown        `json:"by_type"`
+	ByProgramStudy         []StatBreakdown        `json:"by_program_study"`
+	ByAcademicYear         []StatBreakdown        `json:"by_academic_year"`
+	VerificationTurnaround VerificationTurnaround `json:"verification_turnaround"`
 }
 
 type TopStudent struct {
-	StudentID   string `json:"student_id"`
-	Name        string `json:"name"`
-	TotalPoints int    `json:"total_points"`
+	ID           string `json:"id"`
+	StudentID    string `json:"student_id"`
+	Name         string `json:"name"`
+	ProgramStudy string `json:"program_study"`
+	Achievements int    `json:"achievements"`
+	TotalPoints  int    `json:"total_points"`
+}
+
+// StatBreakdown adalah jumlah prestasi per nilai satu dimensi (jenis, program studi, angkatan)
+type StatBreakdown struct {
+	Key          string `json:"key"`
+	Achievements int    `json:"achievements"`
+	Verified     int    `json:"verified"`
+	Points       int    `json:"points"`
+}
+
+// VerificationTurnaround adalah lama waktu dari submit sampai verifikasi, dalam jam
+type VerificationTurnaround struct {
+	Verified     int     `json:"verified"`
+	AverageHours float64 `json:"average_hours"`
+	MedianHours  float64 `json:"median_hours"`
+	P90Hours     float64 `json:"p90_hours"`
 }
 
 type StudentReportDTO struct {
@@ -23,4 +55,4 @@ type StudentReportDTO struct {
 	PointsByType       map[string]int       `json:"points_by_type"`
 	RecentAchievements []AchievementListDTO `json:"recent_achievements"`
 	GeneratedAt        time.Time            `json:"generated_at"`
-}
\ No newline at end of file
+}
@@ -0,0 +1,117 @@
+package model
+
+import "time"
+
+// BilingualText adalah teks SKPI dalam Bahasa Indonesia dan Inggris
+type BilingualText struct {
+	ID string `json:"id"`
+	EN string `json:"en"`
+}
+
+// SKPICategory memetakan satu atau lebih kode jenis prestasi ke satu bagian SKPI. Types berisi "*"
+// menampung jenis yang tidak dipetakan kategori lain.
+type SKPICategory struct {
+	Key   string        `json:"key"`
+	Name  BilingualText `json:"name"`
+	Types []string      `json:"types"`
+}
+
+// SKPITemplate adalah teks tetap dan pemetaan kategori satu jenis SKPI. NumberFormat boleh memuat
+// {nim}, {year} dan {program}.
+type SKPITemplate struct {
+	Title          BilingualText  `json:"title"`
+	Intro          BilingualText  `json:"intro"`
+	Closing        BilingualText  `json:"closing"`
+	NumberFormat   string         `json:"number_format"`
+	Place          string         `json:"place"`
+	SignatoryName  string         `json:"signatory_name"`
+	SignatoryTitle BilingualText  `json:"signatory_title"`
+	SignatoryID    string         `json:"signatory_id"` // NIP
+	Categories     []SKPICategory `json:"categories"`
+	ExcludeTypes   []string       `json:"exclude_types"`
+}
+
+// SKPIConfig adalah kumpulan template SKPI, dibaca dari file JSON SKPI_CONFIG
+type SKPIConfig struct {
+	DefaultTemplate string                  `json:"default_template"`
+	Templates       map[string]SKPITemplate `json:"templates"`
+}
+
+// DefaultSKPIConfig adalah template bawaan: satu bagian per jenis prestasi non-akademik
+func DefaultSKPIConfig() SKPIConfig {
+	return SKPIConfig{
+		DefaultTemplate: "default",
+		Templates: map[string]SKPITemplate{
+			"default": {
+				Title: BilingualText{ID: "Surat Keterangan Pendamping Ijazah", EN: "Diploma Supplement"},
+				Intro: BilingualText{
+					ID: "Surat Keterangan Pendamping Ijazah (SKPI) ini mencantumkan prestasi non-akademik pemegangnya yang telah diverifikasi selama masa studi.",
+					EN: "This Diploma Supplement lists the holder's verified non-academic achievements during the period of study.",
+				},
+				Closing: BilingualText{
+					ID: "Surat keterangan ini diterbitkan sebagai pendamping ijazah dan bukan pengganti ijazah.",
+					EN: "This supplement accompanies the diploma and does not replace it.",
+				},
+				NumberFormat:   "SKPI/{year}/{nim}",
+				SignatoryTitle: BilingualText{ID: "Wakil Rektor Bidang Kemahasiswaan", EN: "Vice Rector for Student Affairs"},
+				Categories: []SKPICategory{
+					{Key: "competition", Name: BilingualText{ID: "Prestasi Kompetisi", EN: "Competition Achievements"}, Types: []string{"competition"}},
+					{Key: "publication", Name: BilingualText{ID: "Publikasi Ilmiah", EN: "Scientific Publications"}, Types: []string{"publication"}},
+					{Key: "organization", Name: BilingualText{ID: "Pengalaman Organisasi", EN: "Organizational Experience"}, Types: []string{"organization"}},
+					{Key: "certification", Name: BilingualText{ID: "Sertifikasi Kompetensi", EN: "Professional Certifications"}, Types: []string{"certification"}},
+					{Key: "other", Name: BilingualText{ID: "Prestasi Lainnya", EN: "Other Achievements"}, Types: []string{"other", "*"}},
+				},
+				ExcludeTypes: []string{"academic"},
+			},
+		},
+	}
+}
+
+// SKPIAchievement adalah satu prestasi terverifikasi yang dicantumkan di SKPI
+type SKPIAchievement struct {
+	AchievementID   string                 `json:"achievement_id"`
+	AchievementType string                 `json:"achievement_type"`
+	Title           string                 `json:"title"`
+	TitleEN         string                 `json:"title_en,omitempty"`
+	Level           string                 `json:"level,omitempty"`
+	EventDate       string                 `json:"event_date,omitempty"`
+	VerifiedAt      *time.Time             `json:"verified_at,omitempty"`
+	Details         map[string]interface{} `json:"-"`
+}
+
+// SKPIStudent adalah identitas pemegang SKPI
+type SKPIStudent struct {
+	ID           string `json:"id"`
+	UserID       string `json:"user_id"`
+	StudentID    string `json:"student_id"`
+	FullName     string `json:"full_name"`
+	ProgramStudy string `json:"program_study"`
+	AcademicYear string `json:"academic_year"`
+}
+
+type SKPISection struct {
+	Category SKPICategory      `json:"category"`
+	Items    []SKPIAchievement `json:"items"`
+}
+
+// SKPIDocument adalah isi SKPI satu mahasiswa sebelum dirender ke PDF atau DOCX
+type SKPIDocument struct {
+	Number   string        `json:"number"`
+	Template string        `json:"template"`
+	Student  SKPIStudent   `json:"student"`
+	Sections []SKPISection `json:"sections"`
+	IssuedAt time.Time     `json:"issued_at"`
+}
+
+// SKPICohortFilter memilih mahasiswa satu angkatan untuk pembuatan SKPI massal
+type SKPICohortFilter struct {
+	AcademicYear string
+	ProgramStudy string
+}
+
+// SKPIBatchSummary adalah hasil pembuatan SKPI massal
+type SKPIBatchSummary struct {
+	Students  int `json:"students"`
+	Generated int `json:"generated"`
+	Failed    int `json:"failed"`
+}
@@ -0,0 +1,13 @@
+package model
+
+// Pemetaan program studi ke fakultas, dipakai filter dan pengelompokan fakultas pada laporan.
+// Students adalah jumlah mahasiswa dengan program studi tersebut.
+type StudyProgram struct {
+	Name     string `json:"name"`
+	Faculty  string `json:"faculty"`
+	Students int    `json:"students"`
+}
+
+type UpdateStudyProgramRequest struct {
+	Faculty string `json:"faculty"` // kosong berarti belum dipetakan
+}
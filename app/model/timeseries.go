@@ -0,0 +1,68 @@
+package model
+
+import "time"
+
+// Ukuran bucket deret waktu. Semester mengikuti kalender akademik: Ganjil Agustus–Januari, Genap Februari–Juli.
+const (
+	BucketDay      = "day"
+	BucketWeek     = "week"
+	BucketMonth    = "month"
+	BucketSemester = "semester"
+)
+
+// Metrik deret waktu. Setiap metrik dihitung pada timestamp kejadiannya sendiri.
+const (
+	MetricCreated   = "created"   // prestasi dilaporkan (created_at)
+	MetricSubmitted = "submitted" // prestasi disubmit (submitted_at)
+	MetricVerified  = "verified"  // prestasi diverifikasi (verified_at, status verified)
+	MetricRejected  = "rejected"  // prestasi ditolak (verified_at, status rejected)
+	MetricPoints    = "points"    // poin yang diberikan saat verifikasi
+)
+
+// Dimensi pengelompokan deret waktu
+const (
+	GroupAchievementType = "achievement_type"
+	GroupProgramStudy    = "program_study"
+	GroupAcademicYear    = "academic_year"
+	GroupFaculty         = "faculty"
+)
+
+// TimeseriesFilter adalah parameter /reports/timeseries. DateTo eksklusif.
+type TimeseriesFilter struct {
+	Bucket   string
+	Metrics  []string
+	GroupBy  []string
+	DateFrom time.Time
+	DateTo   time.Time
+	Faculty  string
+}
+
+// TimeseriesRow adalah satu sel hasil agregasi: nilai metrik untuk satu bucket dan satu kombinasi grup
+type TimeseriesRow struct {
+	Bucket time.Time
+	Metric string
+	Group  []string
+	Value  int
+}
+
+type TimeseriesPoint struct {
+	Bucket time.Time      `json:"bucket"`
+	Label  string         `json:"label"`
+	Values map[string]int `json:"values"`
+}
+
+// TimeseriesSeries adalah deret untuk satu kombinasi grup; bucket tanpa data tetap ada dengan nilai 0
+type TimeseriesSeries struct {
+	Group  map[string]string `json:"group,omitempty"`
+	Totals map[string]int    `json:"totals"`
+	Points []TimeseriesPoint `json:"points"`
+}
+
+type TimeseriesResult struct {
+	Bucket   string             `json:"bucket"`
+	Metrics  []string           `json:"metrics"`
+	GroupBy  []string           `json:"group_by"`
+	DateFrom time.Time          `json:"date_from"`
+	DateTo   time.Time          `json:"date_to"`
+	Series   []TimeseriesSeries `json:"series"`
+}
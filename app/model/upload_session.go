@@ -0,0 +1,35 @@
+package model
+
+import "time"
+
+const (
+	UploadSessionActive     = "active"
+	UploadSessionCompleting = "completing" // potongan sedang digabungkan oleh satu request
+	UploadSessionCompleted  = "completed"
+
+	// Potongan upload bertahap disimpan di bawah prefix ini sampai file lengkap
+	UploadChunkPrefix = "chunks/"
+)
+
+// Sesi upload bertahap (resumable). Offset adalah jumlah byte yang sudah diterima.
+type UploadSession struct {
+	ID            string                 `bson:"_id" json:"id"`
+	AchievementID string                 `bson:"achievement_id" json:"achievement_id"`
+	UserID        string                 `bson:"user_id" json:"-"`
+	FileName      string                 `bson:"file_name" json:"file_name"`
+	Size          int64                  `bson:"size" json:"size"`
+	Offset        int64                  `bson:"offset" json:"offset"`
+	ChunkSize     int64                  `bson:"chunk_size" json:"chunk_size"`
+	Checksum      string                 `bson:"checksum,omitempty" json:"checksum,omitempty"`
+	Status        string                 `bson:"status" json:"status"`
+	Attachment    *AchievementAttachment `bson:"attachment,omitempty" json:"attachment,omitempty"`
+	CreatedAt     time.Time              `bson:"created_at" json:"created_at"`
+	UpdatedAt     time.Time              `bson:"updated_at" json:"updated_at"`
+	ExpiresAt     time.Time              `bson:"expires_at" json:"expires_at"`
+}
+
+type CreateUploadSessionRequest struct {
+	FileName string `json:"file_name"`
+	Size     int64  `json:"size"`
+	Checksum string `json:"checksum"` // SHA-256 (hex) seluruh file, opsional
+}
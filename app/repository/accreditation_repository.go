@@ -0,0 +1,117 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"time"
+
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IAccreditationRepository interface {
+	GetVerifiedAchievements(ctx context.Context, programStudy string, verifiedSince time.Time) ([]model.AccreditationAchievement, error)
+}
+
+type accreditationRepository struct {
+	pgDB    *sql.DB
+	mongoDB *mongo.Database
+}
+
+func NewAccreditationRepository(pgDB *sql.DB, mongoDB *mongo.Database) IAccreditationRepository {
+	return &accreditationRepository{pgDB, mongoDB}
+}
+
+// GetVerifiedAchievements mengambil prestasi verified yang pemiliknya atau salah satu anggota terverifikasinya
+// berasal dari program studi tersebut, satu baris per prestasi. Tahun perolehan ditentukan dari details
+// sehingga di sini hanya dibatasi waktu verifikasi; details dan lampiran dibaca dari MongoDB, yang juga
+// mengisi judul dan jenis prestasi yang belum terproyeksi.
+func (r *accreditationRepository) GetVerifiedAchievements(ctx context.Context, programStudy string, verifiedSince time.Time) ([]model.AccreditationAchievement, error) {
+	query := `
+        WITH participants AS (
+            SELECT ar.id AS achievement_id, ar.student_id
+            FROM achievement_references ar
+            WHERE ar.status = 'verified'
+            UNION
+            SELECT am.achievement_id, am.student_id
+            FROM achievement_members am
+            WHERE am.invitation_status = 'confirmed' AND am.verification_status = 'verified'
+        )
+        SELECT ar.id, ar.mongo_achievement_id, COALESCE(rm.achievement_type, ''), COALESCE(rm.title, ''), ar.verified_at,
+               array_agg(st.student_id ORDER BY st.student_id), array_agg(u.full_name ORDER BY st.student_id)
+        FROM achievement_references ar
+        LEFT JOIN achievement_read_model rm ON rm.achievement_id = ar.id
+        JOIN participants p ON p.achievement_id = ar.id
+        JOIN students st ON st.id = p.student_id
+        JOIN users u ON u.id = st.user_id
+        WHERE ar.status = 'verified' AND st.program_study = $1 AND ar.verified_at >= $2
+        GROUP BY ar.id, ar.mongo_achievement_id, rm.achievement_type, rm.title, ar.verified_at
+        ORDER BY ar.verified_at, ar.id
+    `
+	rows, err := r.pgDB.QueryContext(ctx, query, programStudy, verifiedSince)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.AccreditationAchievement
+	var mongoIDs []string
+	var oids []primitive.ObjectID
+	for rows.Next() {
+		var a model.AccreditationAchievement
+		var mongoID string
+		var verifiedAt sql.NullTime
+		if err := rows.Scan(&a.AchievementID, &mongoID, &a.AchievementType, &a.Title, &verifiedAt,
+			pq.Array(&a.StudentIDs), pq.Array(&a.StudentNames)); err != nil {
+			return nil, err
+		}
+		if verifiedAt.Valid {
+			a.VerifiedAt = &verifiedAt.Time
+		}
+		if oid, err := primitive.ObjectIDFromHex(mongoID); err == nil {
+			oids = append(oids, oid)
+		}
+		items = append(items, a)
+		mongoIDs = append(mongoIDs, mongoID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(oids) == 0 {
+		return items, nil
+	}
+
+	cursor, err := r.mongoDB.Collection("achievements").Find(ctx,
+		bson.M{"_id": bson.M{"$in": oids}},
+		options.Find().SetProjection(bson.M{"details": 1, "attachments": 1, "title": 1, "achievement_type": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	docs := make(map[string]model.AchievementMongo, len(oids))
+	for cursor.Next(ctx) {
+		var doc model.AchievementMongo
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		docs[doc.ID.Hex()] = doc
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		doc := docs[mongoIDs[i]]
+		items[i].Details = doc.Details
+		items[i].Attachments = doc.Attachments
+		if items[i].Title == "" {
+			items[i].Title, items[i].AchievementType = doc.Title, doc.AchievementType
+		}
+	}
+	return items, nil
+}
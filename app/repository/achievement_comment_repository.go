@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IAchievementCommentRepository interface {
+	Create(ctx context.Context, comment *model.AchievementComment) error
+	GetByAchievementID(ctx context.Context, achievementID string) ([]model.AchievementComment, error)
+	GetByID(ctx context.Context, id string) (*model.AchievementComment, error)
+	MarkResolved(ctx context.Context, id string) error
+}
+
+type achievementCommentRepository struct {
+	mongoDB *mongo.Database
+}
+
+func NewAchievementCommentRepository(mongoDB *mongo.Database) IAchievementCommentRepository {
+	return &achievementCommentRepository{mongoDB: mongoDB}
+}
+
+// CreateComment
+func (r *achievementCommentRepository) Create(ctx context.Context, comment *model.AchievementComment) error {
+	result, err := r.mongoDB.Collection("achievement_comments").InsertOne(ctx, comment)
+	if err != nil {
+		return err
+	}
+
+	if oid, ok := result.InsertedID.(primitive.ObjectID); ok {
+		comment.ID = oid
+	}
+	return nil
+}
+
+// GetCommentsByAchievementID (urut dari yang terlama)
+func (r *achievementCommentRepository) GetByAchievementID(ctx context.Context, achievementID string) ([]model.AchievementComment, error) {
+	opts := options.Find().SetSort(bson.D{{Key: "created_at", Value: 1}})
+
+	cursor, err := r.mongoDB.Collection("achievement_comments").Find(ctx, bson.M{"achievement_id": achievementID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	comments := []model.AchievementComment{}
+	if err := cursor.All(ctx, &comments); err != nil {
+		return nil, err
+	}
+	return comments, nil
+}
+
+// GetCommentByID
+func (r *achievementCommentRepository) GetByID(ctx context.Context, id string) (*model.AchievementComment, error) {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return nil, nil
+	}
+
+	var comment model.AchievementComment
+	err = r.mongoDB.Collection("achievement_comments").FindOne(ctx, bson.M{"_id": oid}).Decode(&comment)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &comment, nil
+}
+
+// MarkResolved menandai permintaan klarifikasi sudah dijawab
+func (r *achievementCommentRepository) MarkResolved(ctx context.Context, id string) error {
+	oid, err := primitive.ObjectIDFromHex(id)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.mongoDB.Collection("achievement_comments").UpdateOne(
+		ctx,
+		bson.M{"_id": oid},
+		bson.M{"$set": bson.M{"resolved": true, "resolved_at": time.Now()}},
+	)
+	return err
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+)
+
+type IAchievementMemberRepository interface {
+	AddMembers(ctx context.Context, achievementID string, studentIDs []string) error
+	GetByAchievementID(ctx context.Context, achievementID string) ([]model.AchievementMember, error)
+	GetByID(ctx context.Context, memberID string) (*model.AchievementMember, error)
+	Remove(ctx context.Context, memberID string) error
+	UpdateInvitation(ctx context.Context, achievementID, studentID, status string) error
+	SetVerification(ctx context.Context, memberID, status, verifiedBy string, note *string) error
+}
+
+type achievementMemberRepository struct {
+	db *sql.DB
+}
+
+func NewAchievementMemberRepository(db *sql.DB) IAchievementMemberRepository {
+	return &achievementMemberRepository{db: db}
+}
+
+const achievementMemberSelect = `
+	SELECT am.id, am.achievement_id, am.student_id, s.student_id, u.full_name, s.advisor_id,
+	       am.invitation_status, am.verification_status, am.rejection_note,
+	       am.verified_by, am.verified_at, am.created_at
+	FROM achievement_members am
+	JOIN students s ON am.student_id = s.id
+	JOIN users u ON s.user_id = u.id
+`
+
+func scanAchievementMember(row rowScanner) (*model.AchievementMember, error) {
+	var m model.AchievementMember
+	var advisorID, note, verifiedBy sql.NullString
+	var verifiedAt sql.NullTime
+
+	err := row.Scan(
+		&m.ID, &m.AchievementID, &m.StudentID, &m.StudentNumber, &m.FullName, &advisorID,
+		&m.InvitationStatus, &m.VerificationStatus, &note,
+		&verifiedBy, &verifiedAt, &m.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if advisorID.Valid {
+		m.AdvisorID = &advisorID.String
+	}
+	if note.Valid {
+		m.RejectionNote = &note.String
+	}
+	if verifiedBy.Valid {
+		m.VerifiedBy = &verifiedBy.String
+	}
+	if verifiedAt.Valid {
+		m.VerifiedAt = &verifiedAt.Time
+	}
+	return &m, nil
+}
+
+// AddMembers mengundang mahasiswa sebagai anggota tim (undangan ulang mengaktifkan kembali undangan yang ditolak)
+func (r *achievementMemberRepository) AddMembers(ctx context.Context, achievementID string, studentIDs []string) error {
+	tx, err := r.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	query := `
+		INSERT INTO achievement_members (achievement_id, student_id, invitation_status, verification_status)
+		VALUES ($1, $2, 'invited', 'pending')
+		ON CONFLICT (achievement_id, student_id)
+		DO UPDATE SET invitation_status = 'invited', updated_at = NOW()
+		WHERE achievement_members.invitation_status = 'declined'
+	`
+	for _, studentID := range studentIDs {
+		if _, err := tx.ExecContext(ctx, query, achievementID, studentID); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// GetMembersByAchievementID
+func (r *achievementMemberRepository) GetByAchievementID(ctx context.Context, achievementID string) ([]model.AchievementMember, error) {
+	rows, err := r.db.QueryContext(ctx, achievementMemberSelect+` WHERE am.achievement_id = $1 ORDER BY am.created_at ASC`, achievementID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	members := []model.AchievementMember{}
+	for rows.Next() {
+		m, err := scanAchievementMember(rows)
+		if err != nil {
+			return nil, err
+		}
+		members = append(members, *m)
+	}
+
+	return members, rows.Err()
+}
+
+// GetMemberByID
+func (r *achievementMemberRepository) GetByID(ctx context.Context, memberID string) (*model.AchievementMember, error) {
+	m, err := scanAchievementMember(r.db.QueryRowContext(ctx, achievementMemberSelect+` WHERE am.id = $1`, memberID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return m, nil
+}
+
+// RemoveMember
+func (r *achievementMemberRepository) Remove(ctx context.Context, memberID string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM achievement_members WHERE id = $1`, memberID)
+	return err
+}
+
+// UpdateInvitation
+func (r *achievementMemberRepository) UpdateInvitation(ctx context.Context, achievementID, studentID, status string) error {
+	query := `
+		UPDATE achievement_members
+		SET invitation_status = $1, updated_at = NOW()
+		WHERE achievement_id = $2 AND student_id = $3
+	`
+	_, err := r.db.ExecContext(ctx, query, status, achievementID, studentID)
+	return err
+}
+
+// SetVerification menyimpan hasil verifikasi partisipasi satu anggota oleh dosen walinya
+func (r *achievementMemberRepository) SetVerification(ctx context.Context, memberID, status, verifiedBy string, note *string) error {
+	query := `
+		UPDATE achievement_members
+		SET verification_status = $1, verified_by = $2, verified_at = NOW(),
+		    rejection_note = $3, updated_at = NOW()
+		WHERE id = $4
+	`
+	_, err := r.db.ExecContext(ctx, query, status, verifiedBy, note, memberID)
+	return err
+}
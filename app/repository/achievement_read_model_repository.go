@@ -0,0 +1,166 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// upsertReadModelQuery menulis satu baris proyeksi. Bobot pencarian: judul (A), tag (B), deskripsi (C).
+const upsertReadModelQuery = `
+        INSERT INTO achievement_read_model
+            (achievement_id, mongo_achievement_id, achievement_type, title, description, tags, points, points_mode, search_vector, projected_at)
+        VALUES ($1, $2, $3, $4, $5, $6, $7, $8,
+            setweight(to_tsvector('simple', $4), 'A') ||
+            setweight(to_tsvector('simple', array_to_string($6::text[], ' ')), 'B') ||
+            setweight(to_tsvector('simple', $5), 'C'),
+            NOW())
+        ON CONFLICT (achievement_id) DO UPDATE SET
+            mongo_achievement_id = EXCLUDED.mongo_achievement_id,
+            achievement_type     = EXCLUDED.achievement_type,
+            title                = EXCLUDED.title,
+            description          = EXCLUDED.description,
+            tags                 = EXCLUDED.tags,
+            points               = EXCLUDED.points,
+            points_mode          = EXCLUDED.points_mode,
+            search_vector        = EXCLUDED.search_vector,
+            projected_at         = EXCLUDED.projected_at
+    `
+
+// execer dipenuhi *sql.DB dan *sql.Tx
+type execer interface {
+	ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error)
+}
+
+func upsertReadModel(ctx context.Context, db execer, achievementID string, m *model.AchievementMongo) error {
+	tags := m.Tags
+	if tags == nil {
+		tags = []string{}
+	}
+	_, err := db.ExecContext(ctx, upsertReadModelQuery,
+		achievementID, m.ID.Hex(), m.AchievementType, m.Title, m.Description, pq.Array(tags), m.Points, m.PointsMode)
+	return err
+}
+
+type IAchievementReadModelRepository interface {
+	Rebuild(ctx context.Context) (model.ReadModelRebuildResult, error)
+}
+
+type achievementReadModelRepository struct {
+	pgDB    *sql.DB
+	mongoDB *mongo.Database
+}
+
+func NewAchievementReadModelRepository(pgDB *sql.DB, mongoDB *mongo.Database) IAchievementReadModelRepository {
+	return &achievementReadModelRepository{pgDB, mongoDB}
+}
+
+// Rebuild memproyeksikan ulang setiap referensi prestasi dari dokumen Mongo-nya. Referensi yang
+// dokumennya sudah hilang dicatat sebagai Missing dan barisnya dihapus dari proyeksi. Prestasi yang masih
+// punya event outbox belum terkirim dilewati dan dicatat sebagai Pending: proyeksinya lebih baru daripada
+// dokumen Mongo (atau dokumennya belum dibuat) dan akan sesuai lagi setelah dispatcher menerapkan event-nya.
+func (r *achievementReadModelRepository) Rebuild(ctx context.Context) (model.ReadModelRebuildResult, error) {
+	var result model.ReadModelRebuildResult
+
+	rows, err := r.pgDB.QueryContext(ctx, `SELECT id, mongo_achievement_id FROM achievement_references`)
+	if err != nil {
+		return result, err
+	}
+	refs := make(map[string]string)
+	for rows.Next() {
+		var id, mongoID string
+		if err := rows.Scan(&id, &mongoID); err != nil {
+			rows.Close()
+			return result, err
+		}
+		refs[mongoID] = id
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return result, err
+	}
+
+	cursor, err := r.mongoDB.Collection("achievements").Find(ctx, bson.M{})
+	if err != nil {
+		return result, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var m model.AchievementMongo
+		if err := cursor.Decode(&m); err != nil {
+			return result, err
+		}
+		id, ok := refs[m.ID.Hex()]
+		if !ok {
+			continue
+		}
+		delete(refs, m.ID.Hex())
+
+		projected, err := projectSettled(ctx, r.pgDB, id, &m)
+		if err != nil {
+			return result, err
+		}
+		if !projected {
+			result.Pending = append(result.Pending, id)
+			continue
+		}
+		result.Projected++
+	}
+	if err := cursor.Err(); err != nil {
+		return result, err
+	}
+
+	for _, id := range refs {
+		removed, err := projectSettled(ctx, r.pgDB, id, nil)
+		if err != nil {
+			return result, err
+		}
+		if !removed {
+			result.Pending = append(result.Pending, id)
+			continue
+		}
+		result.Missing++
+	}
+
+	return result, nil
+}
+
+// projectSettled menulis proyeksi prestasi dari dokumen m (atau menghapusnya bila m nil) hanya bila tidak
+// ada event outbox yang belum terkirim. Baris referensi dikunci lebih dulu seperti penulisan proyeksi lain
+// (Update, Verify), sehingga event baru tidak bisa masuk di antara pemeriksaan dan penulisan.
+func projectSettled(ctx context.Context, db *sql.DB, achievementID string, m *model.AchievementMongo) (bool, error) {
+	tx, err := db.BeginTx(ctx, nil)
+	if err != nil {
+		return false, err
+	}
+	defer tx.Rollback()
+
+	var pending bool
+	err = tx.QueryRowContext(ctx, `
+        SELECT EXISTS (SELECT 1 FROM achievement_outbox WHERE achievement_id = ar.id AND dispatched_at IS NULL)
+        FROM achievement_references ar WHERE ar.id = $1
+        FOR UPDATE
+    `, achievementID).Scan(&pending)
+	if err == sql.ErrNoRows {
+		// Referensi dihapus selama rebuild; proyeksinya ikut terhapus lewat ON DELETE CASCADE
+		return true, nil
+	}
+	if err != nil || pending {
+		return false, err
+	}
+
+	if m != nil {
+		err = upsertReadModel(ctx, tx, achievementID, m)
+	} else {
+		_, err = tx.ExecContext(ctx, `DELETE FROM achievement_read_model WHERE achievement_id = $1`, achievementID)
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, tx.Commit()
+}
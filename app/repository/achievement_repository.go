@@ -3,28 +3,36 @@ package repository
 import (
 	"context"
 	"database/sql"
-	"errors"
 	"fmt"
 	"time"
 
 	"sistem-pelaporan-prestasi-mahasiswa/app/model"
 
+	"github.com/lib/pq"
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type IAchievementRepository interface {
 	Create(ctx context.Context, achRef *model.AchievementReference, achMongo *model.AchievementMongo) error
 	GetRefByID(ctx context.Context, id string) (*model.AchievementReference, error)
 	AddAttachment(ctx context.Context, mongoID string, attachment model.AchievementAttachment) error
-	GetAll(ctx context.Context, page, pageSize int, search, studentIDFilter, advisorIDFilter, statusFilter string) ([]model.AchievementListDTO, int64, error)
+	RemoveAttachment(ctx context.Context, mongoID string, ref string) error
+	ReplaceAttachment(ctx context.Context, mongoID string, ref string, attachment model.AchievementAttachment) error
+	GetAll(ctx context.Context, filter model.AchievementFilter) ([]model.AchievementListDTO, int64, error)
+	GetAllByCursor(ctx context.Context, filter model.AchievementFilter, cursor *model.PageCursor) ([]model.AchievementListDTO, model.CursorLinks, error)
 	GetDetailByID(ctx context.Context, id string) (*model.AchievementDetailDTO, error)
 	Update(ctx context.Context, id string, mongoID string, req *model.UpdateAchievementRequest) error
     Submit(ctx context.Context, id string) error
     SoftDelete(ctx context.Context, id string) error
-	Verify(ctx context.Context, id string, lecturerID string, points int) error
+	Verify(ctx context.Context, id string, lecturerID string, advisorID string, points int, evidenceDigest string) error
     Reject(ctx context.Context, id string, lecturerID string, note string) error
+	FindDuplicateCandidates(ctx context.Context, mongoID string, limit int) (*model.AchievementMongo, []model.DuplicateCandidate, error)
+	SaveDuplicateWarnings(ctx context.Context, mongoID string, warnings []model.DuplicateWarning) error
+	CountByType(ctx context.Context) (map[string]int64, error)
+	ReplaceType(ctx context.Context, oldValue, newCode string) (int64, error)
 }
 
 type achievementRepository struct {
@@ -39,22 +47,18 @@ func NewAchievementRepository(pgDB *sql.DB, mongoDB *mongo.Database) IAchievemen
 	}
 }
 
-// Create Achievement (Mongo & Postgres)
+// Create Achievement (Postgres lalu Mongo lewat outbox). Referensi, proyeksi dan event insert
+// dokumen ditulis dalam satu transaksi; dokumen Mongo langsung dicoba ditulis setelah commit dan
+// bila gagal akan diulang oleh dispatcher outbox.
 func (r *achievementRepository) Create(ctx context.Context, achRef *model.AchievementReference, achMongo *model.AchievementMongo) error {
-	collection := r.mongoDB.Collection("achievements")
+	achMongo.ID = primitive.NewObjectID()
+	achRef.MongoAchievementID = achMongo.ID.Hex()
 
-	result, err := collection.InsertOne(ctx, achMongo)
+	tx, err := r.pgDB.BeginTx(ctx, nil)
 	if err != nil {
 		return err
 	}
-
-	oid, ok := result.InsertedID.(primitive.ObjectID)
-	if !ok {
-		return errors.New("gagal mendapatkan object id")
-	}
-
-	achMongo.ID = oid
-	achRef.MongoAchievementID = oid.Hex()
+	defer tx.Rollback()
 
 	query := `
         INSERT INTO achievement_references (student_id, mongo_achievement_id, status)
@@ -62,40 +66,96 @@ func (r *achievementRepository) Create(ctx context.Context, achRef *model.Achiev
         RETURNING id, created_at, updated_at
     `
 
-	err = r.pgDB.QueryRowContext(ctx, query, achRef.StudentID, achRef.MongoAchievementID).Scan(
+	err = tx.QueryRowContext(ctx, query, achRef.StudentID, achRef.MongoAchievementID).Scan(
 		&achRef.ID, &achRef.CreatedAt, &achRef.UpdatedAt,
 	)
+	if err != nil {
+		return err
+	}
 
+	if err := upsertReadModel(ctx, tx, achRef.ID, achMongo); err != nil {
+		return err
+	}
+
+	eventID, err := enqueueOutbox(ctx, tx, achRef.ID, achRef.MongoAchievementID, model.OutboxInsertAchievement, achMongo)
 	if err != nil {
-		_, _ = collection.DeleteOne(ctx, bson.M{"_id": oid})
 		return err
 	}
 
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	r.dispatchNow(ctx, model.OutboxEvent{ID: eventID, AchievementID: achRef.ID, MongoID: achRef.MongoAchievementID, EventType: model.OutboxInsertAchievement}, achMongo)
 	return nil
 }
 
-// Update Achievement
+// dispatchNow lihat dispatchOutboxNow
+func (r *achievementRepository) dispatchNow(ctx context.Context, e model.OutboxEvent, payload interface{}) {
+	dispatchOutboxNow(ctx, r.pgDB, r.mongoDB, e, payload)
+}
+
+// Update Achievement. Proyeksi dan event perubahan dokumen ditulis dalam satu transaksi; dokumen Mongo
+// langsung dicoba diubah setelah commit dan bila gagal akan diulang oleh dispatcher outbox.
 func (r *achievementRepository) Update(ctx context.Context, id string, mongoID string, req *model.UpdateAchievementRequest) error {
-    query := `UPDATE achievement_references SET updated_at = NOW() WHERE id = $1`
-    _, err := r.pgDB.ExecContext(ctx, query, id)
+    fields := bson.M{}
+    if req.Title != nil { fields["title"] = *req.Title }
+    if req.Description != nil { fields["description"] = *req.Description }
+    if req.Tags != nil { fields["tags"] = req.Tags }
+    if req.Details != nil { fields["details"] = req.Details }
+    if req.AchievementType != nil { fields["achievement_type"] = *req.AchievementType }
+    if req.PointsMode != nil { fields["points_mode"] = *req.PointsMode }
+
+    tx, err := r.pgDB.BeginTx(ctx, nil)
     if err != nil {
         return err
     }
+    defer tx.Rollback()
 
-    oid, _ := primitive.ObjectIDFromHex(mongoID)
-    
-    updateFields := bson.M{"updated_at": time.Now()}
-    if req.Title != nil { updateFields["title"] = *req.Title }
-    if req.Description != nil { updateFields["description"] = *req.Description }
-    if req.Tags != nil { updateFields["tags"] = req.Tags }
-    if req.Details != nil { updateFields["details"] = req.Details }
-
-    _, err = r.mongoDB.Collection("achievements").UpdateOne(
-        ctx,
-        bson.M{"_id": oid},
-        bson.M{"$set": updateFields},
-    )
-    return err
+    _, err = tx.ExecContext(ctx, `UPDATE achievement_references SET updated_at = NOW() WHERE id = $1`, id)
+    if err != nil {
+        return err
+    }
+
+    var tags interface{}
+    if req.Tags != nil {
+        tags = pq.Array(req.Tags)
+    }
+    _, err = tx.ExecContext(ctx, `
+        UPDATE achievement_read_model SET
+            title            = COALESCE($2::text, title),
+            description      = COALESCE($3::text, description),
+            tags             = COALESCE($4::text[], tags),
+            achievement_type = COALESCE($5::text, achievement_type),
+            points_mode      = COALESCE($6::text, points_mode),
+            projected_at     = NOW()
+        WHERE achievement_id = $1
+    `, id, req.Title, req.Description, tags, req.AchievementType, req.PointsMode)
+    if err != nil {
+        return err
+    }
+    _, err = tx.ExecContext(ctx, `
+        UPDATE achievement_read_model SET search_vector =
+            setweight(to_tsvector('simple', title), 'A') ||
+            setweight(to_tsvector('simple', array_to_string(tags, ' ')), 'B') ||
+            setweight(to_tsvector('simple', description), 'C')
+        WHERE achievement_id = $1
+    `, id)
+    if err != nil {
+        return err
+    }
+
+    eventID, err := enqueueOutbox(ctx, tx, id, mongoID, model.OutboxUpdateAchievement, fields)
+    if err != nil {
+        return err
+    }
+
+    if err := tx.Commit(); err != nil {
+        return err
+    }
+
+    r.dispatchNow(ctx, model.OutboxEvent{ID: eventID, AchievementID: id, MongoID: mongoID, EventType: model.OutboxUpdateAchievement}, fields)
+    return nil
 }
 
 // Get Reference
@@ -113,74 +173,202 @@ func (r *achievementRepository) GetRefByID(ctx context.Context, id string) (*mod
 	return &ach, nil
 }
 
-// Add Attachment 
-func (r *achievementRepository) AddAttachment(ctx context.Context, mongoID string, attachment model.AchievementAttachment) error {
-	collection := r.mongoDB.Collection("achievements")
+// enqueueAttachmentEvent menulis event lampiran untuk dokumen mongoID lalu langsung mencoba menerapkannya.
+// Perubahan lampiran hanya menyentuh MongoDB, sehingga event cukup ditulis tanpa transaksi.
+func (r *achievementRepository) enqueueAttachmentEvent(ctx context.Context, mongoID, eventType string, payload interface{}) error {
+	data, err := bson.Marshal(payload)
+	if err != nil {
+		return err
+	}
 
-	oid, err := primitive.ObjectIDFromHex(mongoID)
+	e := model.OutboxEvent{MongoID: mongoID, EventType: eventType}
+	err = r.pgDB.QueryRowContext(ctx, `
+        INSERT INTO achievement_outbox (achievement_id, mongo_achievement_id, event_type, payload)
+        SELECT id, mongo_achievement_id, $2, $3 FROM achievement_references WHERE mongo_achievement_id = $1
+        RETURNING id, achievement_id
+    `, mongoID, eventType, data).Scan(&e.ID, &e.AchievementID)
+	if err == sql.ErrNoRows {
+		return fmt.Errorf("referensi untuk dokumen prestasi %s tidak ditemukan", mongoID)
+	}
 	if err != nil {
 		return err
 	}
 
-	filter := bson.M{"_id": oid}
-	update := bson.M{
-		"$push": bson.M{"attachments": attachment},
-		"$set":  bson.M{"updated_at": time.Now()},
+	r.dispatchNow(ctx, e, payload)
+	return nil
+}
+
+// Add Attachment
+func (r *achievementRepository) AddAttachment(ctx context.Context, mongoID string, attachment model.AchievementAttachment) error {
+	return r.enqueueAttachmentEvent(ctx, mongoID, model.OutboxAddAttachment, bson.M{"attachment": attachment})
+}
+
+// attachmentRefFilter mencocokkan lampiran berdasarkan id, atau file_name untuk lampiran lama yang belum punya id
+func attachmentRefFilter(ref string) bson.M {
+	return bson.M{"$or": bson.A{bson.M{"id": ref}, bson.M{"file_name": ref}}}
+}
+
+func attachmentKey(a model.AchievementAttachment) string {
+	if a.ID != "" {
+		return a.ID
 	}
+	return a.FileName
+}
 
-	_, err = collection.UpdateOne(ctx, filter, update)
-	return err
+// Remove Attachment
+func (r *achievementRepository) RemoveAttachment(ctx context.Context, mongoID string, ref string) error {
+	return r.enqueueAttachmentEvent(ctx, mongoID, model.OutboxRemoveAttachment, bson.M{"ref": ref})
 }
 
-// GetAllAchievement 
-func (r *achievementRepository) GetAll(ctx context.Context, page, pageSize int, search, studentIDFilter, advisorIDFilter, statusFilter string) ([]model.AchievementListDTO, int64, error) {
-	offset := (page - 1) * pageSize
+// Replace Attachment
+func (r *achievementRepository) ReplaceAttachment(ctx context.Context, mongoID string, ref string, attachment model.AchievementAttachment) error {
+	return r.enqueueAttachmentEvent(ctx, mongoID, model.OutboxReplaceAttachment, bson.M{"ref": ref, "attachment": attachment})
+}
 
-	baseQuery := `
+// achievementListQuery menyusun FROM/WHERE daftar prestasi. Field dari dokumen Mongo (jenis, tag, poin,
+// judul, deskripsi) dibaca dari proyeksi achievement_read_model, sehingga filter, urutan dan total
+// dihitung dalam satu query SQL. Proyeksi di-LEFT JOIN agar prestasi yang belum terproyeksi (data sebelum
+// migrasi 006 sampai rebuild-read-model dijalankan) tetap muncul dengan judul kosong dan poin 0.
+func achievementListQuery(f model.AchievementFilter) (baseQuery string, args []interface{}, argCounter int) {
+	baseQuery = `
         FROM achievement_references ar
+        LEFT JOIN achievement_read_model rm ON rm.achievement_id = ar.id
         JOIN students s ON ar.student_id = s.id
         JOIN users u ON s.user_id = u.id
         WHERE 1=1
     `
-	var args []interface{}
-	argCounter := 1
+	argCounter = 1
 
-	if advisorIDFilter != "" {
-		baseQuery += fmt.Sprintf(" AND s.advisor_id = $%d", argCounter)
-		args = append(args, advisorIDFilter)
+	if f.AdvisorID != "" {
+		baseQuery += fmt.Sprintf(` AND (s.advisor_id = $%d OR EXISTS (
+			SELECT 1 FROM achievement_members am JOIN students ms ON am.student_id = ms.id
+			WHERE am.achievement_id = ar.id AND am.invitation_status = 'confirmed' AND ms.advisor_id = $%d))`, argCounter, argCounter)
+		args = append(args, f.AdvisorID)
 		argCounter++
 	}
 
-	if studentIDFilter != "" {
-		baseQuery += fmt.Sprintf(" AND ar.student_id = $%d", argCounter)
-		args = append(args, studentIDFilter)
+	if f.StudentID != "" {
+		baseQuery += fmt.Sprintf(` AND (ar.student_id = $%d OR EXISTS (
+			SELECT 1 FROM achievement_members am
+			WHERE am.achievement_id = ar.id AND am.student_id = $%d AND am.invitation_status != 'declined'))`, argCounter, argCounter)
+		args = append(args, f.StudentID)
 		argCounter++
 	}
 
-	if statusFilter != "" {
+	if f.Trash {
+		baseQuery += " AND ar.status = 'deleted'"
+	} else {
+		baseQuery += " AND ar.status != 'deleted'"
+	}
+
+	if f.Status != "" {
 		baseQuery += fmt.Sprintf(" AND ar.status = $%d", argCounter)
-		args = append(args, statusFilter)
+		args = append(args, f.Status)
+		argCounter++
+	}
+
+	if f.AchievementType != "" {
+		baseQuery += fmt.Sprintf(" AND rm.achievement_type = $%d", argCounter)
+		args = append(args, f.AchievementType)
+		argCounter++
+	}
+
+	if len(f.Tags) > 0 {
+		baseQuery += fmt.Sprintf(" AND rm.tags @> $%d", argCounter)
+		args = append(args, pq.Array(f.Tags))
+		argCounter++
+	}
+
+	if f.MinPoints != nil {
+		baseQuery += fmt.Sprintf(" AND COALESCE(rm.points, 0) >= $%d", argCounter)
+		args = append(args, *f.MinPoints)
+		argCounter++
+	}
+
+	if f.MaxPoints != nil {
+		baseQuery += fmt.Sprintf(" AND COALESCE(rm.points, 0) <= $%d", argCounter)
+		args = append(args, *f.MaxPoints)
 		argCounter++
 	}
 
-	if search != "" {
-		baseQuery += fmt.Sprintf(" AND (u.full_name ILIKE $%d OR s.student_id ILIKE $%d)", argCounter, argCounter)
-		args = append(args, "%"+search+"%")
+	if f.ProgramStudy != "" {
+		baseQuery += fmt.Sprintf(" AND s.program_study ILIKE $%d", argCounter)
+		args = append(args, f.ProgramStudy)
 		argCounter++
 	}
 
+	if f.AcademicYear != "" {
+		baseQuery += fmt.Sprintf(" AND s.academic_year = $%d", argCounter)
+		args = append(args, f.AcademicYear)
+		argCounter++
+	}
+
+	if f.DateFrom != nil {
+		baseQuery += fmt.Sprintf(" AND ar.created_at >= $%d", argCounter)
+		args = append(args, *f.DateFrom)
+		argCounter++
+	}
+
+	if f.DateTo != nil {
+		baseQuery += fmt.Sprintf(" AND ar.created_at < $%d", argCounter)
+		args = append(args, *f.DateTo)
+		argCounter++
+	}
+
+	// Pencarian mencocokkan nama/NIM mahasiswa atau teks judul, tag dan deskripsi
+	if f.Search != "" {
+		baseQuery += fmt.Sprintf(" AND (u.full_name ILIKE $%d OR s.student_id ILIKE $%d OR rm.search_vector @@ websearch_to_tsquery('simple', $%d))", argCounter, argCounter, argCounter+1)
+		args = append(args, "%"+f.Search+"%", f.Search)
+		argCounter += 2
+	}
+
+	return baseQuery, args, argCounter
+}
+
+const achievementListColumns = `
+        SELECT ar.id, ar.mongo_achievement_id, ar.status, ar.created_at, 
+               s.student_id, u.full_name, COALESCE(rm.title, ''), COALESCE(rm.achievement_type, ''),
+               COALESCE(rm.points, 0), ar.deleted_at`
+
+func scanAchievementListRow(rows *sql.Rows, extra ...interface{}) (model.AchievementListDTO, error) {
+	var a model.AchievementListDTO
+	dest := []interface{}{&a.ID, &a.MongoID, &a.Status, &a.CreatedAt, &a.StudentID, &a.StudentName, &a.Title, &a.AchievementType, &a.Points, &a.DeletedAt}
+	err := rows.Scan(append(dest, extra...)...)
+	return a, err
+}
+
+// achievementSortColumns memetakan sort_by ke kolom SQL
+var achievementSortColumns = map[string]string{
+	model.AchievementSortCreatedAt:   "ar.created_at",
+	model.AchievementSortTitle:       "COALESCE(rm.title, '')",
+	model.AchievementSortPoints:      "COALESCE(rm.points, 0)",
+	model.AchievementSortStudentName: "u.full_name",
+}
+
+// GetAllAchievement
+func (r *achievementRepository) GetAll(ctx context.Context, f model.AchievementFilter) ([]model.AchievementListDTO, int64, error) {
+	offset := (f.Page - 1) * f.PageSize
+
+	baseQuery, args, argCounter := achievementListQuery(f)
+
 	var total int64
 	countQuery := "SELECT COUNT(*) " + baseQuery
 	if err := r.pgDB.QueryRowContext(ctx, countQuery, args...).Scan(&total); err != nil {
 		return nil, 0, err
 	}
 
-	selectQuery := `
-        SELECT ar.id, ar.mongo_achievement_id, ar.status, ar.created_at, 
-               s.student_id, u.full_name 
-    ` + baseQuery + fmt.Sprintf(" ORDER BY ar.created_at DESC LIMIT $%d OFFSET $%d", argCounter, argCounter+1)
+	direction := "DESC"
+	if f.SortOrder == "ASC" {
+		direction = "ASC"
+	}
+	sortCol, ok := achievementSortColumns[f.SortBy]
+	if !ok {
+		sortCol = "ar.created_at"
+	}
+	orderBy := sortCol + " " + direction + ", ar.id " + direction
 
-	args = append(args, pageSize, offset)
+	selectQuery := achievementListColumns + baseQuery + fmt.Sprintf(" ORDER BY %s LIMIT $%d OFFSET $%d", orderBy, argCounter, argCounter+1)
+	args = append(args, f.PageSize, offset)
 
 	rows, err := r.pgDB.QueryContext(ctx, selectQuery, args...)
 	if err != nil {
@@ -189,50 +377,56 @@ func (r *achievementRepository) GetAll(ctx context.Context, page, pageSize int,
 	defer rows.Close()
 
 	var achievements []model.AchievementListDTO
-	var mongoIDs []primitive.ObjectID
-	mongoMap := make(map[string]int)
-
 	for rows.Next() {
-		var a model.AchievementListDTO
-		var mongoIDStr string
-
-		if err := rows.Scan(&a.ID, &mongoIDStr, &a.Status, &a.CreatedAt, &a.StudentID, &a.StudentName); err != nil {
+		a, err := scanAchievementListRow(rows)
+		if err != nil {
 			return nil, 0, err
 		}
-
-		a.MongoID = mongoIDStr
 		achievements = append(achievements, a)
-
-		if oid, err := primitive.ObjectIDFromHex(mongoIDStr); err == nil {
-			mongoIDs = append(mongoIDs, oid)
-			mongoMap[mongoIDStr] = len(achievements) - 1
-		}
 	}
 
-	if len(mongoIDs) == 0 {
-		return achievements, total, nil
+	return achievements, total, rows.Err()
+}
+
+// GetAllAchievementByCursor: paginasi keyset tanpa COUNT(*)
+func (r *achievementRepository) GetAllByCursor(ctx context.Context, f model.AchievementFilter, cursor *model.PageCursor) ([]model.AchievementListDTO, model.CursorLinks, error) {
+	sortBy, sortCol := f.SortBy, achievementSortColumns[f.SortBy]
+	if sortCol == "" {
+		sortBy, sortCol = model.AchievementSortCreatedAt, "ar.created_at"
 	}
+	k := keyset{sortBy: sortBy, sortCol: sortCol, idCol: "ar.id", order: f.SortOrder, cursor: cursor, limit: f.PageSize}
 
-	cursor, err := r.mongoDB.Collection("achievements").Find(ctx, bson.M{"_id": bson.M{"$in": mongoIDs}})
+	baseQuery, args, argCounter := achievementListQuery(f)
+	clause, cursorArgs, argCounter := k.condition(argCounter)
+	args = append(args, cursorArgs...)
+
+	selectQuery := achievementListColumns + k.columns() + baseQuery + clause +
+		fmt.Sprintf(" ORDER BY %s LIMIT $%d", k.orderBy(), argCounter)
+	args = append(args, k.limit+1)
+
+	rows, err := r.pgDB.QueryContext(ctx, selectQuery, args...)
 	if err != nil {
-		return nil, 0, err
+		return nil, model.CursorLinks{}, err
 	}
-	defer cursor.Close(ctx)
-
-	for cursor.Next(ctx) {
-		var m model.AchievementMongo
-		if err := cursor.Decode(&m); err != nil {
-			continue
-		}
+	defer rows.Close()
 
-		if idx, ok := mongoMap[m.ID.Hex()]; ok {
-			achievements[idx].Title = m.Title
-			achievements[idx].AchievementType = m.AchievementType
-			achievements[idx].Points = m.Points
+	var achievements []model.AchievementListDTO
+	var keys []model.PageCursor
+	for rows.Next() {
+		var value, id string
+		a, err := scanAchievementListRow(rows, &value, &id)
+		if err != nil {
+			return nil, model.CursorLinks{}, err
 		}
+		achievements = append(achievements, a)
+		keys = append(keys, k.key(value, id))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.CursorLinks{}, err
 	}
 
-	return achievements, total, nil
+	achievements, links := keysetPage(k, achievements, keys)
+	return achievements, links, nil
 }
 
 // GetDetailByID 
@@ -283,6 +477,14 @@ func (r *achievementRepository) GetDetailByID(ctx context.Context, id string) (*
 	d.Details = m.Details
 	d.Tags = m.Tags
 	d.Attachments = m.Attachments
+	d.Points = m.Points
+	d.DuplicateWarnings = m.DuplicateWarnings
+	d.EvidenceDigest = m.EvidenceDigest
+	d.EvidenceFrozenAt = m.EvidenceFrozenAt
+	d.PointsMode = m.PointsMode
+	if d.PointsMode == "" {
+		d.PointsMode = model.PointsModePerMember
+	}
 
 	return &d, nil
 }
@@ -305,6 +507,7 @@ func (r *achievementRepository) SoftDelete(ctx context.Context, id string) error
     query := `
         UPDATE achievement_references 
         SET status = 'deleted', 
+            deleted_at = NOW(),
             updated_at = NOW() 
         WHERE id = $1
     `
@@ -312,8 +515,14 @@ func (r *achievementRepository) SoftDelete(ctx context.Context, id string) error
     return err
 }
 
-// Verify Achievement
-func (r *achievementRepository) Verify(ctx context.Context, id string, lecturerID string, points int) error {
+// Verify Achievement beserta anggota tim bimbingan advisorID dalam satu transaksi; poin dan pembekuan lampiran menyusul ke Mongo lewat outbox
+func (r *achievementRepository) Verify(ctx context.Context, id string, lecturerID string, advisorID string, points int, evidenceDigest string) error {
+    tx, err := r.pgDB.BeginTx(ctx, nil)
+    if err != nil {
+        return err
+    }
+    defer tx.Rollback()
+
     query := `
         UPDATE achievement_references 
         SET status = 'verified', 
@@ -325,31 +534,49 @@ func (r *achievementRepository) Verify(ctx context.Context, id string, lecturerI
     `
     
     var mongoIDStr string
-    err := r.pgDB.QueryRowContext(ctx, query, lecturerID, id).Scan(&mongoIDStr)
+    err = tx.QueryRowContext(ctx, query, lecturerID, id).Scan(&mongoIDStr)
     if err != nil {
         return err
     }
 
-    oid, err := primitive.ObjectIDFromHex(mongoIDStr)
+    _, err = tx.ExecContext(ctx, `UPDATE achievement_read_model SET points = $2, projected_at = NOW() WHERE achievement_id = $1`, id, points)
     if err != nil {
         return err
     }
 
-    collection := r.mongoDB.Collection("achievements")
-    filter := bson.M{"_id": oid}
-    update := bson.M{
-        "$set": bson.M{
-            "points":     points,
-            "updated_at": time.Now(),
-        },
+    // Anggota terkonfirmasi yang dosen walinya sama ikut terverifikasi agar pembagian poin tim konsisten
+    _, err = tx.ExecContext(ctx, `
+        UPDATE achievement_members am
+        SET verification_status = 'verified', verified_by = $1, verified_at = NOW(), updated_at = NOW()
+        FROM students s
+        WHERE am.student_id = s.id
+          AND am.achievement_id = $2
+          AND s.advisor_id = $3
+          AND am.invitation_status = 'confirmed'
+          AND am.verification_status = 'pending'
+    `, lecturerID, id, advisorID)
+    if err != nil {
+        return err
+    }
+
+    payload := bson.M{"points": points}
+    eventID, err := enqueueOutbox(ctx, tx, id, mongoIDStr, model.OutboxSetPoints, payload)
+    if err != nil {
+        return err
     }
 
-    result, err := collection.UpdateOne(ctx, filter, update)
+    freeze := bson.M{"evidence_digest": evidenceDigest, "frozen_at": time.Now()}
+    freezeID, err := enqueueOutbox(ctx, tx, id, mongoIDStr, model.OutboxFreezeEvidence, freeze)
     if err != nil {
         return err
     }
 
-    fmt.Printf("✅ MongoDB Updated - MatchedCount: %d, ModifiedCount: %d\n", result.MatchedCount, result.ModifiedCount)
+    if err := tx.Commit(); err != nil {
+        return err
+    }
+
+    r.dispatchNow(ctx, model.OutboxEvent{ID: eventID, AchievementID: id, MongoID: mongoIDStr, EventType: model.OutboxSetPoints}, payload)
+    r.dispatchNow(ctx, model.OutboxEvent{ID: freezeID, AchievementID: id, MongoID: mongoIDStr, EventType: model.OutboxFreezeEvidence}, freeze)
     return nil
 }
 
@@ -366,4 +593,226 @@ func (r *achievementRepository) Reject(ctx context.Context, id string, lecturerI
     `
     _, err := r.pgDB.ExecContext(ctx, query, note, lecturerID, id)
     return err
-}
\ No newline at end of file
+}
+
+// FindDuplicateCandidates mengambil dokumen pembanding: semua dokumen dengan hash lampiran sama, ditambah
+// paling banyak limit dokumen terbaru dengan jenis prestasi sama
+func (r *achievementRepository) FindDuplicateCandidates(ctx context.Context, mongoID string, limit int) (*model.AchievementMongo, []model.DuplicateCandidate, error) {
+	collection := r.mongoDB.Collection("achievements")
+
+	oid, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var self model.AchievementMongo
+	if err := collection.FindOne(ctx, bson.M{"_id": oid}).Decode(&self); err != nil {
+		return nil, nil, err
+	}
+
+	var hashes []string
+	for _, a := range self.Attachments {
+		if a.ContentHash != "" {
+			hashes = append(hashes, a.ContentHash)
+		}
+	}
+
+	// Lampiran dengan hash sama diambil tanpa batas agar duplikat persis tidak tergeser kandidat
+	// judul mirip; kandidat sejenis baru ditambahkan sesudahnya sampai batas limit.
+	docs := make(map[string]model.AchievementMongo)
+	var mongoIDs []string
+	collect := func(filter bson.M, opts *options.FindOptions) error {
+		cursor, err := collection.Find(ctx, filter, opts.SetProjection(bson.M{"duplicate_warnings": 0}))
+		if err != nil {
+			return err
+		}
+		defer cursor.Close(ctx)
+
+		for cursor.Next(ctx) {
+			var m model.AchievementMongo
+			if err := cursor.Decode(&m); err != nil {
+				continue
+			}
+			if _, seen := docs[m.ID.Hex()]; seen {
+				continue
+			}
+			docs[m.ID.Hex()] = m
+			mongoIDs = append(mongoIDs, m.ID.Hex())
+		}
+		return cursor.Err()
+	}
+
+	if len(hashes) > 0 {
+		filter := bson.M{"_id": bson.M{"$ne": oid}, "attachments.content_hash": bson.M{"$in": hashes}}
+		if err := collect(filter, options.Find()); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	sameType := bson.M{"_id": bson.M{"$ne": oid}, "achievement_type": self.AchievementType}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "created_at", Value: -1}}).
+		SetLimit(int64(limit))
+	if err := collect(sameType, opts); err != nil {
+		return nil, nil, err
+	}
+
+	if len(mongoIDs) == 0 {
+		return &self, nil, nil
+	}
+
+	query := `
+        SELECT ar.id, ar.mongo_achievement_id, ar.status, s.student_id, u.full_name
+        FROM achievement_references ar
+        JOIN students s ON ar.student_id = s.id
+        JOIN users u ON s.user_id = u.id
+        WHERE ar.mongo_achievement_id = ANY($1) AND ar.status != 'deleted'
+    `
+	rows, err := r.pgDB.QueryContext(ctx, query, pq.Array(mongoIDs))
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+
+	var candidates []model.DuplicateCandidate
+	for rows.Next() {
+		var c model.DuplicateCandidate
+		var mid string
+		if err := rows.Scan(&c.AchievementID, &mid, &c.Status, &c.StudentID, &c.StudentName); err != nil {
+			return nil, nil, err
+		}
+		c.Document = docs[mid]
+		candidates = append(candidates, c)
+	}
+
+	return &self, candidates, rows.Err()
+}
+
+// SaveDuplicateWarnings
+func (r *achievementRepository) SaveDuplicateWarnings(ctx context.Context, mongoID string, warnings []model.DuplicateWarning) error {
+	oid, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+
+	if warnings == nil {
+		warnings = []model.DuplicateWarning{}
+	}
+
+	_, err = r.mongoDB.Collection("achievements").UpdateOne(
+		ctx,
+		bson.M{"_id": oid},
+		bson.M{"$set": bson.M{"duplicate_warnings": warnings}},
+	)
+	return err
+}
+
+// CountByType menghitung jumlah dokumen per nilai achievement_type (dipakai migrasi katalog)
+func (r *achievementRepository) CountByType(ctx context.Context) (map[string]int64, error) {
+	pipeline := mongo.Pipeline{
+		{{Key: "$group", Value: bson.M{
+			"_id":   "$achievement_type",
+			"count": bson.M{"$sum": 1},
+		}}},
+	}
+
+	cursor, err := r.mongoDB.Collection("achievements").Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	counts := make(map[string]int64)
+	for cursor.Next(ctx) {
+		var result struct {
+			Type  string `bson:"_id"`
+			Count int64  `bson:"count"`
+		}
+		if err := cursor.Decode(&result); err != nil {
+			return nil, err
+		}
+		counts[result.Type] = result.Count
+	}
+
+	return counts, cursor.Err()
+}
+
+// ReplaceType mengganti nilai achievement_type lama dengan code katalog. Setiap dokumen diubah lewat event
+// outbox yang ditulis dalam transaksi yang sama dengan proyeksinya. Dokumen dicari di MongoDB karena
+// prestasi lama bisa belum punya proyeksi.
+func (r *achievementRepository) ReplaceType(ctx context.Context, oldValue, newCode string) (int64, error) {
+	cursor, err := r.mongoDB.Collection("achievements").Find(ctx,
+		bson.M{"achievement_type": oldValue},
+		options.Find().SetProjection(bson.M{"_id": 1}))
+	if err != nil {
+		return 0, err
+	}
+	var mongoIDs []string
+	for cursor.Next(ctx) {
+		var doc struct {
+			ID primitive.ObjectID `bson:"_id"`
+		}
+		if err := cursor.Decode(&doc); err != nil {
+			cursor.Close(ctx)
+			return 0, err
+		}
+		mongoIDs = append(mongoIDs, doc.ID.Hex())
+	}
+	cursor.Close(ctx)
+	if err := cursor.Err(); err != nil {
+		return 0, err
+	}
+	if len(mongoIDs) == 0 {
+		return 0, nil
+	}
+
+	tx, err := r.pgDB.BeginTx(ctx, nil)
+	if err != nil {
+		return 0, err
+	}
+	defer tx.Rollback()
+
+	rows, err := tx.QueryContext(ctx,
+		`SELECT id, mongo_achievement_id FROM achievement_references WHERE mongo_achievement_id = ANY($1)`,
+		pq.Array(mongoIDs))
+	if err != nil {
+		return 0, err
+	}
+	var events []model.OutboxEvent
+	for rows.Next() {
+		e := model.OutboxEvent{EventType: model.OutboxUpdateAchievement}
+		if err := rows.Scan(&e.AchievementID, &e.MongoID); err != nil {
+			rows.Close()
+			return 0, err
+		}
+		events = append(events, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return 0, err
+	}
+
+	_, err = tx.ExecContext(ctx,
+		`UPDATE achievement_read_model SET achievement_type = $2, projected_at = NOW() WHERE achievement_type = $1`,
+		oldValue, newCode)
+	if err != nil {
+		return 0, err
+	}
+
+	payload := bson.M{"achievement_type": newCode}
+	for i := range events {
+		events[i].ID, err = enqueueOutbox(ctx, tx, events[i].AchievementID, events[i].MongoID, model.OutboxUpdateAchievement, payload)
+		if err != nil {
+			return 0, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, err
+	}
+
+	for _, e := range events {
+		r.dispatchNow(ctx, e, payload)
+	}
+	return int64(len(events)), nil
+}
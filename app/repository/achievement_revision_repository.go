@@ -0,0 +1,169 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IAchievementRevisionRepository interface {
+	Record(ctx context.Context, achievementID, mongoID, event, status, changedBy string) error
+	EnsureBaseline(ctx context.Context, achievementID, mongoID, status, changedBy string) error
+	GetByAchievementID(ctx context.Context, achievementID string) ([]model.AchievementRevision, error)
+	GetByVersion(ctx context.Context, achievementID string, version int) (*model.AchievementRevision, error)
+}
+
+// maxRevisionInsertAttempts membatasi pengulangan saat nomor versi revisi bentrok
+const maxRevisionInsertAttempts = 5
+
+type achievementRevisionRepository struct {
+	pgDB    *sql.DB
+	mongoDB *mongo.Database
+}
+
+func NewAchievementRevisionRepository(pgDB *sql.DB, mongoDB *mongo.Database) IAchievementRevisionRepository {
+	return &achievementRevisionRepository{pgDB: pgDB, mongoDB: mongoDB}
+}
+
+type revisionPayload struct {
+	Event      string    `bson:"event"`
+	Status     string    `bson:"status"`
+	ChangedBy  string    `bson:"changed_by"`
+	RecordedAt time.Time `bson:"recorded_at"`
+}
+
+// Record mengantrekan revisi ke outbox di belakang perubahan dokumen yang baru disimpan. Snapshot baru
+// diambil saat event diterapkan, yaitu setelah semua event lebih lama untuk prestasi ini masuk ke MongoDB,
+// sehingga revisi selalu memuat isi yang disimpan pengguna (revisi tidak pernah diubah).
+func (r *achievementRevisionRepository) Record(ctx context.Context, achievementID, mongoID, event, status, changedBy string) error {
+	payload := revisionPayload{Event: event, Status: status, ChangedBy: changedBy, RecordedAt: time.Now()}
+	data, err := bson.Marshal(payload)
+	if err != nil {
+		return err
+	}
+
+	e := model.OutboxEvent{AchievementID: achievementID, MongoID: mongoID, EventType: model.OutboxRecordRevision}
+	err = r.pgDB.QueryRowContext(ctx, `
+        INSERT INTO achievement_outbox (achievement_id, mongo_achievement_id, event_type, payload)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id
+    `, achievementID, mongoID, e.EventType, data).Scan(&e.ID)
+	if err != nil {
+		return err
+	}
+
+	dispatchOutboxNow(ctx, r.pgDB, r.mongoDB, e, payload)
+	return nil
+}
+
+// insertRevision menyalin isi dokumen prestasi saat ini sebagai revisi dari event outbox e
+func insertRevision(ctx context.Context, mongoDB *mongo.Database, e model.OutboxEvent, oid primitive.ObjectID) error {
+	var payload revisionPayload
+	if err := bson.Unmarshal(e.Payload, &payload); err != nil {
+		return err
+	}
+
+	collection := mongoDB.Collection("achievement_revisions")
+	n, err := collection.CountDocuments(ctx, bson.M{"achievement_id": e.AchievementID, "outbox_id": e.ID})
+	if err != nil || n > 0 {
+		return err
+	}
+
+	var snapshot model.RevisionSnapshot
+	if err := mongoDB.Collection("achievements").FindOne(ctx, bson.M{"_id": oid}).Decode(&snapshot); err != nil {
+		return err
+	}
+
+	// Nomor versi diambil dari revisi terakhir; index unik (achievement_id, version) menolak nomor
+	// ganda bila dua revisi tersimpan bersamaan, sehingga penyimpanan diulang dengan nomor berikutnya.
+	for attempt := 0; ; attempt++ {
+		version := 1
+		var last model.AchievementRevision
+		opts := options.FindOne().
+			SetSort(bson.D{{Key: "version", Value: -1}}).
+			SetProjection(bson.M{"version": 1})
+		err = collection.FindOne(ctx, bson.M{"achievement_id": e.AchievementID}, opts).Decode(&last)
+		if err == nil {
+			version = last.Version + 1
+		} else if err != mongo.ErrNoDocuments {
+			return err
+		}
+
+		_, err = collection.InsertOne(ctx, &model.AchievementRevision{
+			AchievementID: e.AchievementID,
+			Version:       version,
+			Event:         payload.Event,
+			Status:        payload.Status,
+			ChangedBy:     payload.ChangedBy,
+			Snapshot:      &snapshot,
+			CreatedAt:     payload.RecordedAt,
+			OutboxID:      e.ID,
+		})
+		if mongo.IsDuplicateKeyError(err) && attempt < maxRevisionInsertAttempts-1 {
+			continue
+		}
+		return err
+	}
+}
+
+// EnsureBaseline merekam kondisi awal prestasi lama yang belum memiliki revisi sebelum isinya diubah.
+// Revisi yang masih mengantre di outbox ikut dihitung agar baseline tidak terekam dua kali.
+func (r *achievementRevisionRepository) EnsureBaseline(ctx context.Context, achievementID, mongoID, status, changedBy string) error {
+	count, err := r.mongoDB.Collection("achievement_revisions").CountDocuments(ctx, bson.M{"achievement_id": achievementID})
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return nil
+	}
+
+	var queued bool
+	err = r.pgDB.QueryRowContext(ctx, `
+        SELECT EXISTS (SELECT 1 FROM achievement_outbox
+                       WHERE achievement_id = $1 AND event_type = $2 AND dispatched_at IS NULL)
+    `, achievementID, model.OutboxRecordRevision).Scan(&queued)
+	if err != nil || queued {
+		return err
+	}
+
+	return r.Record(ctx, achievementID, mongoID, model.RevisionEventBaseline, status, changedBy)
+}
+
+// GetRevisionsByAchievementID (tanpa snapshot, urut versi)
+func (r *achievementRevisionRepository) GetByAchievementID(ctx context.Context, achievementID string) ([]model.AchievementRevision, error) {
+	opts := options.Find().
+		SetSort(bson.D{{Key: "version", Value: 1}}).
+		SetProjection(bson.M{"snapshot": 0})
+
+	cursor, err := r.mongoDB.Collection("achievement_revisions").Find(ctx, bson.M{"achievement_id": achievementID}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	revisions := []model.AchievementRevision{}
+	if err := cursor.All(ctx, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetRevisionByVersion
+func (r *achievementRevisionRepository) GetByVersion(ctx context.Context, achievementID string, version int) (*model.AchievementRevision, error) {
+	var rev model.AchievementRevision
+	err := r.mongoDB.Collection("achievement_revisions").FindOne(ctx, bson.M{"achievement_id": achievementID, "version": version}).Decode(&rev)
+	if err != nil {
+		if err == mongo.ErrNoDocuments {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &rev, nil
+}
@@ -0,0 +1,147 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IAchievementTrashRepository interface {
+	Restore(ctx context.Context, id string) (bool, error)
+	FindExpired(ctx context.Context, deletedBefore time.Time, after *model.TrashedAchievement, limit int) ([]model.TrashedAchievement, error)
+	FindAttachments(ctx context.Context, id string, mongoID string) ([]model.AttachmentDocument, error)
+	Purge(ctx context.Context, id string, mongoID string) error
+}
+
+type achievementTrashRepository struct {
+	pgDB    *sql.DB
+	mongoDB *mongo.Database
+}
+
+func NewAchievementTrashRepository(pgDB *sql.DB, mongoDB *mongo.Database) IAchievementTrashRepository {
+	return &achievementTrashRepository{pgDB, mongoDB}
+}
+
+// Restore mengembalikan prestasi terhapus ke draft. Hanya draft yang bisa dihapus, jadi status
+// sebelum dihapus selalu draft. Mengembalikan false jika prestasi tidak ada di tempat sampah.
+func (r *achievementTrashRepository) Restore(ctx context.Context, id string) (bool, error) {
+	result, err := r.pgDB.ExecContext(ctx, `
+        UPDATE achievement_references
+        SET status = 'draft', deleted_at = NULL, updated_at = NOW()
+        WHERE id = $1 AND status = 'deleted'
+    `, id)
+	if err != nil {
+		return false, err
+	}
+	n, err := result.RowsAffected()
+	return n > 0, err
+}
+
+// FindExpired mengambil prestasi terhapus sebelum deletedBefore urut (deleted_at, id), dimulai setelah
+// after bila diisi, agar prestasi yang gagal dihapus tidak menghalangi batch berikutnya
+func (r *achievementTrashRepository) FindExpired(ctx context.Context, deletedBefore time.Time, after *model.TrashedAchievement, limit int) ([]model.TrashedAchievement, error) {
+	query := `
+        SELECT id, mongo_achievement_id, deleted_at
+        FROM achievement_references
+        WHERE status = 'deleted' AND deleted_at < $1`
+	args := []interface{}{deletedBefore, limit}
+	if after != nil {
+		query += ` AND (deleted_at, id) > ($3, $4::uuid)`
+		args = append(args, after.DeletedAt, after.ID)
+	}
+	query += `
+        ORDER BY deleted_at, id
+        LIMIT $2`
+
+	rows, err := r.pgDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.TrashedAchievement
+	for rows.Next() {
+		var t model.TrashedAchievement
+		if err := rows.Scan(&t.ID, &t.MongoID, &t.DeletedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, t)
+	}
+	return items, rows.Err()
+}
+
+// FindAttachments mengambil lampiran dokumen prestasi dan komentar-komentarnya
+func (r *achievementTrashRepository) FindAttachments(ctx context.Context, id string, mongoID string) ([]model.AttachmentDocument, error) {
+	var docs []model.AttachmentDocument
+	opts := options.FindOne().SetProjection(bson.M{"attachments": 1})
+
+	if oid, err := primitive.ObjectIDFromHex(mongoID); err == nil {
+		var doc struct {
+			Attachments []model.AchievementAttachment `bson:"attachments"`
+		}
+		err := r.mongoDB.Collection("achievements").FindOne(ctx, bson.M{"_id": oid}, opts).Decode(&doc)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return nil, err
+		}
+		if len(doc.Attachments) > 0 {
+			docs = append(docs, model.AttachmentDocument{Collection: "achievements", DocumentID: mongoID, Attachments: doc.Attachments})
+		}
+	}
+
+	cursor, err := r.mongoDB.Collection("achievement_comments").Find(ctx,
+		bson.M{"achievement_id": id, "attachments.0": bson.M{"$exists": true}},
+		options.Find().SetProjection(bson.M{"attachments": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var c struct {
+			ID          primitive.ObjectID            `bson:"_id"`
+			Attachments []model.AchievementAttachment `bson:"attachments"`
+		}
+		if err := cursor.Decode(&c); err != nil {
+			return nil, err
+		}
+		docs = append(docs, model.AttachmentDocument{Collection: "achievement_comments", DocumentID: c.ID.Hex(), Attachments: c.Attachments})
+	}
+	return docs, cursor.Err()
+}
+
+// Purge menghapus dokumen Mongo (prestasi, komentar, revisi) lalu baris PostgreSQL-nya. Baris
+// PostgreSQL dihapus terakhir sehingga purge yang gagal di tengah jalan akan diulang pada putaran
+// berikutnya; setiap langkah aman diulang.
+func (r *achievementTrashRepository) Purge(ctx context.Context, id string, mongoID string) error {
+	if oid, err := primitive.ObjectIDFromHex(mongoID); err == nil {
+		if _, err := r.mongoDB.Collection("achievements").DeleteOne(ctx, bson.M{"_id": oid}); err != nil {
+			return err
+		}
+	}
+	for _, collection := range []string{"achievement_comments", "achievement_revisions"} {
+		if _, err := r.mongoDB.Collection(collection).DeleteMany(ctx, bson.M{"achievement_id": id}); err != nil {
+			return err
+		}
+	}
+
+	tx, err := r.pgDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.ExecContext(ctx, `DELETE FROM achievement_outbox WHERE achievement_id = $1`, id); err != nil {
+		return err
+	}
+	// achievement_members dan achievement_read_model ikut terhapus lewat ON DELETE CASCADE
+	if _, err := tx.ExecContext(ctx, `DELETE FROM achievement_references WHERE id = $1 AND status = 'deleted'`, id); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,162 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"github.com/lib/pq"
+)
+
+type IAchievementTypeRepository interface {
+	GetAll(ctx context.Context, includeInactive bool) ([]model.AchievementType, error)
+	GetByID(ctx context.Context, id string) (*model.AchievementType, error)
+	Resolve(ctx context.Context, value string) (*model.AchievementType, error)
+	CheckCodeExists(ctx context.Context, code string, excludeID *string) (bool, error)
+	Create(ctx context.Context, t *model.AchievementType) error
+	Update(ctx context.Context, t *model.AchievementType) error
+	Deactivate(ctx context.Context, id string) error
+}
+
+type achievementTypeRepository struct {
+	db *sql.DB
+}
+
+func NewAchievementTypeRepository(db *sql.DB) IAchievementTypeRepository {
+	return &achievementTypeRepository{db: db}
+}
+
+const achievementTypeColumns = `
+	id, code, name_id, name_en, description, required_attachments,
+	min_points, max_points, aliases, max_file_size_mb, allowed_file_types,
+	is_active, created_at, updated_at
+`
+
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+func scanAchievementType(row rowScanner) (*model.AchievementType, error) {
+	var t model.AchievementType
+	err := row.Scan(
+		&t.ID, &t.Code, &t.NameID, &t.NameEN, &t.Description, pq.Array(&t.RequiredAttachments),
+		&t.MinPoints, &t.MaxPoints, pq.Array(&t.Aliases), &t.MaxFileSizeMB, pq.Array(&t.AllowedFileTypes),
+		&t.IsActive, &t.CreatedAt, &t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// GetAllAchievementType
+func (r *achievementTypeRepository) GetAll(ctx context.Context, includeInactive bool) ([]model.AchievementType, error) {
+	query := `SELECT ` + achievementTypeColumns + ` FROM achievement_types`
+	if !includeInactive {
+		query += ` WHERE is_active = true`
+	}
+	query += ` ORDER BY code ASC`
+
+	rows, err := r.db.QueryContext(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var types []model.AchievementType
+	for rows.Next() {
+		t, err := scanAchievementType(rows)
+		if err != nil {
+			return nil, err
+		}
+		types = append(types, *t)
+	}
+
+	return types, rows.Err()
+}
+
+// GetAchievementTypeByID
+func (r *achievementTypeRepository) GetByID(ctx context.Context, id string) (*model.AchievementType, error) {
+	query := `SELECT ` + achievementTypeColumns + ` FROM achievement_types WHERE id = $1`
+
+	t, err := scanAchievementType(r.db.QueryRowContext(ctx, query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// Resolve mencocokkan nilai bebas (code, nama, atau alias) ke entri katalog tanpa membedakan huruf besar/kecil
+func (r *achievementTypeRepository) Resolve(ctx context.Context, value string) (*model.AchievementType, error) {
+	query := `
+		SELECT ` + achievementTypeColumns + `
+		FROM achievement_types
+		WHERE LOWER(code) = LOWER(TRIM($1))
+		   OR LOWER(name_id) = LOWER(TRIM($1))
+		   OR LOWER(name_en) = LOWER(TRIM($1))
+		   OR LOWER(TRIM($1)) IN (SELECT LOWER(a) FROM unnest(aliases) AS a)
+		ORDER BY (LOWER(code) = LOWER(TRIM($1))) DESC
+		LIMIT 1
+	`
+
+	t, err := scanAchievementType(r.db.QueryRowContext(ctx, query, value))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return t, nil
+}
+
+// CheckCodeExists
+func (r *achievementTypeRepository) CheckCodeExists(ctx context.Context, code string, excludeID *string) (bool, error) {
+	query := `SELECT EXISTS(SELECT 1 FROM achievement_types WHERE LOWER(code) = LOWER($1) AND ($2::uuid IS NULL OR id != $2))`
+	var exists bool
+	err := r.db.QueryRowContext(ctx, query, code, excludeID).Scan(&exists)
+	return exists, err
+}
+
+// CreateAchievementType
+func (r *achievementTypeRepository) Create(ctx context.Context, t *model.AchievementType) error {
+	query := `
+		INSERT INTO achievement_types
+			(code, name_id, name_en, description, required_attachments, min_points, max_points, aliases,
+			 max_file_size_mb, allowed_file_types, is_active)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		RETURNING id, created_at, updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		t.Code, t.NameID, t.NameEN, t.Description, pq.Array(t.RequiredAttachments),
+		t.MinPoints, t.MaxPoints, pq.Array(t.Aliases), t.MaxFileSizeMB, pq.Array(t.AllowedFileTypes), t.IsActive,
+	).Scan(&t.ID, &t.CreatedAt, &t.UpdatedAt)
+}
+
+// UpdateAchievementType
+func (r *achievementTypeRepository) Update(ctx context.Context, t *model.AchievementType) error {
+	query := `
+		UPDATE achievement_types
+		SET name_id = $1, name_en = $2, description = $3, required_attachments = $4,
+		    min_points = $5, max_points = $6, aliases = $7, max_file_size_mb = $8,
+		    allowed_file_types = $9, is_active = $10, updated_at = NOW()
+		WHERE id = $11
+		RETURNING updated_at
+	`
+
+	return r.db.QueryRowContext(ctx, query,
+		t.NameID, t.NameEN, t.Description, pq.Array(t.RequiredAttachments),
+		t.MinPoints, t.MaxPoints, pq.Array(t.Aliases), t.MaxFileSizeMB, pq.Array(t.AllowedFileTypes), t.IsActive, t.ID,
+	).Scan(&t.UpdatedAt)
+}
+
+// Deactivate (soft delete) agar data prestasi lama yang memakai code ini tetap valid
+func (r *achievementTypeRepository) Deactivate(ctx context.Context, id string) error {
+	query := `UPDATE achievement_types SET is_active = false, updated_at = NOW() WHERE id = $1`
+	_, err := r.db.ExecContext(ctx, query, id)
+	return err
+}
@@ -0,0 +1,91 @@
+package repository
+
+import (
+	"context"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IAttachmentRepository interface {
+	ForEach(ctx context.Context, fn func(doc model.AttachmentDocument) error) error
+	FindByStorageKey(ctx context.Context, key string) (*model.AchievementAttachment, error)
+}
+
+type attachmentRepository struct {
+	mongoDB *mongo.Database
+}
+
+func NewAttachmentRepository(mongoDB *mongo.Database) IAttachmentRepository {
+	return &attachmentRepository{mongoDB: mongoDB}
+}
+
+// ForEach memanggil fn untuk setiap dokumen yang masih merujuk lampiran, termasuk prestasi yang sudah dihapus (soft delete)
+func (r *attachmentRepository) ForEach(ctx context.Context, fn func(doc model.AttachmentDocument) error) error {
+	filter := bson.M{"attachments.0": bson.M{"$exists": true}}
+	opts := options.Find().SetProjection(bson.M{"attachments": 1, "evidence_digest": 1})
+
+	for _, collection := range attachmentCollections {
+		cursor, err := r.mongoDB.Collection(collection).Find(ctx, filter, opts)
+		if err != nil {
+			return err
+		}
+
+		for cursor.Next(ctx) {
+			var doc struct {
+				ID             primitive.ObjectID            `bson:"_id"`
+				EvidenceDigest string                        `bson:"evidence_digest"`
+				Attachments    []model.AchievementAttachment `bson:"attachments"`
+			}
+			if err := cursor.Decode(&doc); err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+			err := fn(model.AttachmentDocument{
+				Collection:     collection,
+				DocumentID:     doc.ID.Hex(),
+				EvidenceDigest: doc.EvidenceDigest,
+				Attachments:    doc.Attachments,
+			})
+			if err != nil {
+				cursor.Close(ctx)
+				return err
+			}
+		}
+		err = cursor.Err()
+		cursor.Close(ctx)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// FindByStorageKey mencari lampiran yang disimpan di key (lampiran lama dicocokkan lewat file_url).
+// Mengembalikan nil bila key bukan lampiran, misalnya thumbnail atau pratinjau.
+func (r *attachmentRepository) FindByStorageKey(ctx context.Context, key string) (*model.AchievementAttachment, error) {
+	match := bson.M{"$or": bson.A{bson.M{"storage_key": key}, bson.M{"file_url": "/uploads/" + key}}}
+	opts := options.FindOne().SetProjection(bson.M{"attachments.$": 1})
+
+	for _, collection := range attachmentCollections {
+		var doc struct {
+			Attachments []model.AchievementAttachment `bson:"attachments"`
+		}
+		err := r.mongoDB.Collection(collection).FindOne(ctx, bson.M{"attachments": bson.M{"$elemMatch": match}}, opts).Decode(&doc)
+		if err == mongo.ErrNoDocuments {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if len(doc.Attachments) > 0 {
+			return &doc.Attachments[0], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,183 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IAttachmentScanRepository interface {
+	FindPending(ctx context.Context, limit int) ([]model.PendingScan, error)
+	SetResult(ctx context.Context, collection, documentID, fileName, status, signature, storageKey string) error
+	RecordFailure(ctx context.Context, collection, documentID, fileName, status string, attempts int, nextScanAt time.Time, reason string) error
+	BackfillStatus(ctx context.Context) (int64, error)
+	SetPreview(ctx context.Context, collection, documentID, fileName, thumbnailKey, previewKey string) error
+}
+
+type attachmentScanRepository struct {
+	mongoDB *mongo.Database
+}
+
+func NewAttachmentScanRepository(mongoDB *mongo.Database) IAttachmentScanRepository {
+	return &attachmentScanRepository{mongoDB: mongoDB}
+}
+
+// Koleksi yang menyimpan array attachments
+var attachmentCollections = []string{"achievements", "achievement_comments"}
+
+// Lampiran tanpa scan_status adalah lampiran lama yang diupload sebelum pemindaian diaktifkan
+// (lihat BackfillStatus). Tautan bukti tidak punya file sehingga tidak pernah dipindai. Lampiran yang
+// gagal dipindai baru diambil lagi setelah next_scan_at lewat.
+func isPendingScan(a model.AchievementAttachment, now time.Time) bool {
+	if a.IsLink() {
+		return false
+	}
+	if a.NextScanAt != nil && a.NextScanAt.After(now) {
+		return false
+	}
+	return a.ScanStatus == "" || a.ScanStatus == model.ScanStatusPending
+}
+
+// FindPending mengambil lampiran yang belum dipindai dan sudah waktunya dicoba dari semua koleksi
+func (r *attachmentScanRepository) FindPending(ctx context.Context, limit int) ([]model.PendingScan, error) {
+	now := time.Now()
+	filter := bson.M{"attachments": bson.M{"$elemMatch": bson.M{
+		"kind": bson.M{"$ne": model.AttachmentKindLink},
+		"$and": bson.A{
+			bson.M{"$or": bson.A{
+				bson.M{"scan_status": model.ScanStatusPending},
+				bson.M{"scan_status": bson.M{"$exists": false}},
+			}},
+			bson.M{"$or": bson.A{
+				bson.M{"next_scan_at": bson.M{"$exists": false}},
+				bson.M{"next_scan_at": bson.M{"$lte": now}},
+			}},
+		},
+	}}}
+	opts := options.Find().
+		SetProjection(bson.M{"attachments": 1}).
+		SetLimit(int64(limit))
+
+	var pending []model.PendingScan
+	for _, collection := range attachmentCollections {
+		cursor, err := r.mongoDB.Collection(collection).Find(ctx, filter, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		var docs []struct {
+			ID          primitive.ObjectID            `bson:"_id"`
+			Attachments []model.AchievementAttachment `bson:"attachments"`
+		}
+		err = cursor.All(ctx, &docs)
+		cursor.Close(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, doc := range docs {
+			for _, a := range doc.Attachments {
+				if isPendingScan(a, now) {
+					pending = append(pending, model.PendingScan{Collection: collection, DocumentID: doc.ID.Hex(), Attachment: a})
+				}
+			}
+		}
+		if len(pending) >= limit {
+			return pending[:limit], nil
+		}
+	}
+
+	return pending, nil
+}
+
+// SetResult menyimpan hasil pemindaian pada elemen lampiran dengan file_name yang sama
+func (r *attachmentScanRepository) SetResult(ctx context.Context, collection, documentID, fileName, status, signature, storageKey string) error {
+	oid, err := primitive.ObjectIDFromHex(documentID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.mongoDB.Collection(collection).UpdateOne(
+		ctx,
+		bson.M{"_id": oid, "attachments.file_name": fileName},
+		bson.M{
+			"$set": bson.M{
+				"attachments.$.scan_status":    status,
+				"attachments.$.scan_signature": signature,
+				"attachments.$.storage_key":    storageKey,
+				"attachments.$.scanned_at":     time.Now(),
+			},
+			"$unset": bson.M{"attachments.$.next_scan_at": "", "attachments.$.scan_error": ""},
+		},
+	)
+	return err
+}
+
+// RecordFailure mencatat percobaan pemindaian yang gagal. Status tetap pending dengan jadwal ulang
+// nextScanAt, atau failed bila batas percobaan sudah tercapai.
+func (r *attachmentScanRepository) RecordFailure(ctx context.Context, collection, documentID, fileName, status string, attempts int, nextScanAt time.Time, reason string) error {
+	oid, err := primitive.ObjectIDFromHex(documentID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.mongoDB.Collection(collection).UpdateOne(
+		ctx,
+		bson.M{"_id": oid, "attachments.file_name": fileName},
+		bson.M{"$set": bson.M{
+			"attachments.$.scan_status":   status,
+			"attachments.$.scan_attempts": attempts,
+			"attachments.$.next_scan_at":  nextScanAt,
+			"attachments.$.scan_error":    reason,
+		}},
+	)
+	return err
+}
+
+// BackfillStatus menandai lampiran file lama tanpa scan_status sebagai pending agar masuk antrean
+// pemindaian dan bisa dibedakan dari lampiran yang sudah diproses. Aman dijalankan berulang kali.
+func (r *attachmentScanRepository) BackfillStatus(ctx context.Context) (int64, error) {
+	legacy := bson.M{"scan_status": bson.M{"$exists": false}, "kind": bson.M{"$ne": model.AttachmentKindLink}}
+	opts := options.Update().SetArrayFilters(options.ArrayFilters{Filters: []interface{}{
+		bson.M{"a.scan_status": bson.M{"$exists": false}, "a.kind": bson.M{"$ne": model.AttachmentKindLink}},
+	}})
+
+	var total int64
+	for _, collection := range attachmentCollections {
+		result, err := r.mongoDB.Collection(collection).UpdateMany(
+			ctx,
+			bson.M{"attachments": bson.M{"$elemMatch": legacy}},
+			bson.M{"$set": bson.M{"attachments.$[a].scan_status": model.ScanStatusPending}},
+			opts,
+		)
+		if err != nil {
+			return total, err
+		}
+		total += result.ModifiedCount
+	}
+	return total, nil
+}
+
+// SetPreview menyimpan key thumbnail dan pratinjau pada elemen lampiran dengan file_name yang sama
+func (r *attachmentScanRepository) SetPreview(ctx context.Context, collection, documentID, fileName, thumbnailKey, previewKey string) error {
+	oid, err := primitive.ObjectIDFromHex(documentID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.mongoDB.Collection(collection).UpdateOne(
+		ctx,
+		bson.M{"_id": oid, "attachments.file_name": fileName},
+		bson.M{"$set": bson.M{
+			"attachments.$.thumbnail_key": thumbnailKey,
+			"attachments.$.preview_key":   previewKey,
+		}},
+	)
+	return err
+}
@@ -0,0 +1,92 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IEvidenceLinkRepository interface {
+	FindDue(ctx context.Context, checkedBefore time.Time, limit int) ([]model.LinkToCheck, error)
+	SetResult(ctx context.Context, documentID, linkID string, status int, title, errMsg string, checkedAt time.Time) error
+}
+
+type evidenceLinkRepository struct {
+	mongoDB *mongo.Database
+}
+
+func NewEvidenceLinkRepository(mongoDB *mongo.Database) IEvidenceLinkRepository {
+	return &evidenceLinkRepository{mongoDB: mongoDB}
+}
+
+// Tautan yang belum pernah dicek atau terakhir dicek sebelum checkedBefore
+func isLinkDue(a model.AchievementAttachment, checkedBefore time.Time) bool {
+	return a.IsLink() && (a.LinkCheckedAt == nil || a.LinkCheckedAt.Before(checkedBefore))
+}
+
+// FindDue mengambil tautan bukti yang perlu dicek ulang
+func (r *evidenceLinkRepository) FindDue(ctx context.Context, checkedBefore time.Time, limit int) ([]model.LinkToCheck, error) {
+	filter := bson.M{"attachments": bson.M{"$elemMatch": bson.M{
+		"kind": model.AttachmentKindLink,
+		"$or": bson.A{
+			bson.M{"link_checked_at": bson.M{"$exists": false}},
+			bson.M{"link_checked_at": bson.M{"$lt": checkedBefore}},
+		},
+	}}}
+	opts := options.Find().
+		SetProjection(bson.M{"attachments": 1}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.mongoDB.Collection("achievements").Find(ctx, filter, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		ID          primitive.ObjectID            `bson:"_id"`
+		Attachments []model.AchievementAttachment `bson:"attachments"`
+	}
+	if err := cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	var due []model.LinkToCheck
+	for _, doc := range docs {
+		for _, a := range doc.Attachments {
+			if isLinkDue(a, checkedBefore) {
+				due = append(due, model.LinkToCheck{DocumentID: doc.ID.Hex(), Attachment: a})
+			}
+		}
+	}
+	if len(due) > limit {
+		due = due[:limit]
+	}
+	return due, nil
+}
+
+// SetResult menyimpan snapshot status HTTP dan judul halaman pada elemen tautan dengan id yang sama
+func (r *evidenceLinkRepository) SetResult(ctx context.Context, documentID, linkID string, status int, title, errMsg string, checkedAt time.Time) error {
+	oid, err := primitive.ObjectIDFromHex(documentID)
+	if err != nil {
+		return err
+	}
+
+	_, err = r.mongoDB.Collection("achievements").UpdateOne(
+		ctx,
+		bson.M{"_id": oid, "attachments.id": linkID},
+		bson.M{"$set": bson.M{
+			"attachments.$.link_status":     status,
+			"attachments.$.link_title":      title,
+			"attachments.$.link_error":      errMsg,
+			"attachments.$.link_checked_at": checkedAt,
+		}},
+	)
+	return err
+}
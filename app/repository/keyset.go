@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"fmt"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+)
+
+// keyset menyusun paginasi keyset pada pasangan (kolom urutan, id). Tidak seperti LIMIT/OFFSET,
+// baris yang ditambah atau dihapus di halaman sebelumnya tidak membuat baris terlewat atau berulang.
+type keyset struct {
+	sortBy  string // identitas urutan yang disimpan di cursor
+	sortCol string
+	idCol   string
+	order   string // ASC atau DESC
+	cursor  *model.PageCursor
+	limit   int
+}
+
+func (k keyset) backward() bool {
+	return k.cursor != nil && k.cursor.Backward
+}
+
+// queryOrder adalah arah query sebenarnya; saat mundur (prev_cursor) urutan dibalik lalu hasilnya dibalik lagi
+func (k keyset) queryOrder() string {
+	if k.backward() == (k.order == "ASC") {
+		return "DESC"
+	}
+	return "ASC"
+}
+
+// condition mengembalikan kondisi WHERE untuk baris setelah cursor beserta argumennya
+func (k keyset) condition(argCounter int) (string, []interface{}, int) {
+	if k.cursor == nil {
+		return "", nil, argCounter
+	}
+	op := ">"
+	if k.queryOrder() == "DESC" {
+		op = "<"
+	}
+	// Nilai cursor dikirim tanpa tipe sehingga Postgres menyesuaikannya dengan tipe kolom
+	clause := fmt.Sprintf(" AND (%s, %s) %s ($%d, $%d)", k.sortCol, k.idCol, op, argCounter, argCounter+1)
+	return clause, []interface{}{k.cursor.Value, k.cursor.ID}, argCounter + 2
+}
+
+func (k keyset) orderBy() string {
+	o := k.queryOrder()
+	return fmt.Sprintf("%s %s, %s %s", k.sortCol, o, k.idCol, o)
+}
+
+// columns adalah kolom tambahan di SELECT untuk membentuk cursor tiap baris
+func (k keyset) columns() string {
+	return fmt.Sprintf(", %s::text, %s::text", k.sortCol, k.idCol)
+}
+
+func (k keyset) key(value, id string) model.PageCursor {
+	return model.PageCursor{SortBy: k.sortBy, Order: k.order, Value: value, ID: id}
+}
+
+// keysetPage memotong baris tambahan (limit+1), mengembalikan urutan tampilan dan membentuk cursor next/prev
+func keysetPage[T any](k keyset, rows []T, keys []model.PageCursor) ([]T, model.CursorLinks) {
+	hasMore := len(rows) > k.limit
+	if hasMore {
+		rows, keys = rows[:k.limit], keys[:k.limit]
+	}
+	if k.backward() {
+		for i, j := 0, len(rows)-1; i < j; i, j = i+1, j-1 {
+			rows[i], rows[j] = rows[j], rows[i]
+			keys[i], keys[j] = keys[j], keys[i]
+		}
+	}
+
+	var links model.CursorLinks
+	if len(rows) == 0 {
+		return rows, links
+	}
+	if k.backward() || hasMore {
+		next := keys[len(keys)-1]
+		links.Next = &next
+	}
+	if (k.backward() && hasMore) || (!k.backward() && k.cursor != nil) {
+		prev := keys[0]
+		prev.Backward = true
+		links.Prev = &prev
+	}
+	return rows, links
+}
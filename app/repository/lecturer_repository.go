@@ -16,6 +16,7 @@ type ILecturerRepository interface {
 	CheckExistsByID(ctx context.Context, id string) (bool, error)
 	GetAll(ctx context.Context, page, pageSize int, search, sortBy, sortOrder string) ([]model.LecturerListDTO, int64, error)
 	GetAdvisees(ctx context.Context, lecturerID string, page, pageSize int) ([]model.StudentListDTO, int64, error)
+	GetAdviseesByCursor(ctx context.Context, lecturerID string, cursor *model.PageCursor, limit int) ([]model.StudentListDTO, model.CursorLinks, error)
 }
 
 type lecturerRepository struct {
@@ -208,27 +209,21 @@ func (r *lecturerRepository) GetAdvisees(ctx context.Context, lecturerID string,
 
 	var students []model.StudentListDTO
 	for rows.Next() {
-		var student model.StudentListDTO
-		var advisorID, advisorName sql.NullString
-
-		err := rows.Scan(
-			&student.ID, &student.StudentID, &student.FullName, &student.Email,
-			&student.ProgramStudy, &student.AcademicYear, &advisorID,
-			&advisorName, &student.IsActive,
-		)
+		student, err := scanStudentListRow(rows)
 		if err != nil {
 			return nil, 0, err
 		}
-
-		if advisorID.Valid {
-			student.AdvisorID = &advisorID.String
-		}
-		if advisorName.Valid {
-			student.AdvisorName = &advisorName.String
-		}
-
 		students = append(students, student)
 	}
 
 	return students, total, rows.Err()
 }
+
+// GetAdviseesByCursor: paginasi keyset mahasiswa bimbingan, diurutkan berdasarkan nama
+func (r *lecturerRepository) GetAdviseesByCursor(ctx context.Context, lecturerID string, cursor *model.PageCursor, limit int) ([]model.StudentListDTO, model.CursorLinks, error) {
+	k := keyset{sortBy: "full_name", sortCol: "u.full_name", idCol: "u.id", order: "ASC", cursor: cursor, limit: limit}
+
+	where := `
+		WHERE s.advisor_id = $1 AND u.is_active = true`
+	return queryStudentsByCursor(ctx, r.db, k, where, []interface{}{lecturerID})
+}
@@ -0,0 +1,262 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IOutboxRepository interface {
+	FindDue(ctx context.Context, limit int) ([]model.OutboxEvent, error)
+	Apply(ctx context.Context, e model.OutboxEvent) error
+	MarkDispatched(ctx context.Context, id int64) error
+	MarkFailed(ctx context.Context, id int64, errMsg string, nextAttemptAt time.Time) error
+}
+
+type outboxRepository struct {
+	pgDB    *sql.DB
+	mongoDB *mongo.Database
+}
+
+func NewOutboxRepository(pgDB *sql.DB, mongoDB *mongo.Database) IOutboxRepository {
+	return &outboxRepository{pgDB, mongoDB}
+}
+
+// enqueueOutbox menulis event di dalam transaksi yang sama dengan perubahan PostgreSQL-nya
+func enqueueOutbox(ctx context.Context, tx *sql.Tx, achievementID, mongoID, eventType string, payload interface{}) (int64, error) {
+	data, err := bson.Marshal(payload)
+	if err != nil {
+		return 0, err
+	}
+
+	var id int64
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO achievement_outbox (achievement_id, mongo_achievement_id, event_type, payload)
+        VALUES ($1, $2, $3, $4)
+        RETURNING id
+    `, achievementID, mongoID, eventType, data).Scan(&id)
+	return id, err
+}
+
+// dispatchOutboxNow mencoba menerapkan event outbox yang baru di-commit. Kegagalan diabaikan karena
+// event sudah tersimpan dan akan diulang oleh dispatcher. Bila masih ada event lebih lama untuk
+// prestasi yang sama, event ini diserahkan ke dispatcher agar urutannya tidak terbalik.
+func dispatchOutboxNow(ctx context.Context, pgDB *sql.DB, mongoDB *mongo.Database, e model.OutboxEvent, payload interface{}) {
+	var pending bool
+	err := pgDB.QueryRowContext(ctx, `
+        SELECT EXISTS (SELECT 1 FROM achievement_outbox
+                       WHERE achievement_id = $1 AND dispatched_at IS NULL AND id < $2)
+    `, e.AchievementID, e.ID).Scan(&pending)
+	if err != nil || pending {
+		return
+	}
+
+	data, err := bson.Marshal(payload)
+	if err != nil {
+		return
+	}
+	e.Payload = data
+	if err := applyOutboxEvent(ctx, mongoDB, e); err != nil {
+		return
+	}
+	_ = markOutboxDispatched(ctx, pgDB, e.ID)
+}
+
+// applyOutboxEvent menerapkan event ke MongoDB. Setiap jenis event idempoten: insert dan penambahan
+// lampiran yang sudah pernah berhasil dianggap selesai, dan $set/$pull dengan nilai yang sama tidak
+// mengubah apa pun. Update yang tidak menemukan dokumennya dianggap gagal agar diulang dispatcher.
+func applyOutboxEvent(ctx context.Context, mongoDB *mongo.Database, e model.OutboxEvent) error {
+	oid, err := primitive.ObjectIDFromHex(e.MongoID)
+	if err != nil {
+		return err
+	}
+	collection := mongoDB.Collection("achievements")
+
+	switch e.EventType {
+	case model.OutboxInsertAchievement:
+		var doc model.AchievementMongo
+		if err := bson.Unmarshal(e.Payload, &doc); err != nil {
+			return err
+		}
+		doc.ID = oid
+		if _, err := collection.InsertOne(ctx, doc); err != nil && !mongo.IsDuplicateKeyError(err) {
+			return err
+		}
+		return nil
+
+	case model.OutboxSetPoints:
+		var payload struct {
+			Points int `bson:"points"`
+		}
+		if err := bson.Unmarshal(e.Payload, &payload); err != nil {
+			return err
+		}
+		result, err := collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{
+			"$set": bson.M{"points": payload.Points, "updated_at": time.Now()},
+		})
+		return requireMatched(result, err, e.MongoID)
+
+	case model.OutboxFreezeEvidence:
+		var payload struct {
+			EvidenceDigest string    `bson:"evidence_digest"`
+			FrozenAt       time.Time `bson:"frozen_at"`
+		}
+		if err := bson.Unmarshal(e.Payload, &payload); err != nil {
+			return err
+		}
+		result, err := collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{
+			"$set": bson.M{"evidence_digest": payload.EvidenceDigest, "evidence_frozen_at": payload.FrozenAt},
+		})
+		if err := requireMatched(result, err, e.MongoID); err != nil {
+			return err
+		}
+		// $[] gagal bila attachments bukan array (dokumen lama tanpa lampiran)
+		_, err = collection.UpdateOne(ctx, bson.M{"_id": oid, "attachments": bson.M{"$type": "array"}}, bson.M{
+			"$set": bson.M{"attachments.$[].frozen_at": payload.FrozenAt},
+		})
+		return err
+
+	case model.OutboxUpdateAchievement:
+		var fields bson.M
+		if err := bson.Unmarshal(e.Payload, &fields); err != nil {
+			return err
+		}
+		fields["updated_at"] = time.Now()
+		result, err := collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{"$set": fields})
+		return requireMatched(result, err, e.MongoID)
+
+	case model.OutboxAddAttachment:
+		var payload struct {
+			Attachment model.AchievementAttachment `bson:"attachment"`
+		}
+		if err := bson.Unmarshal(e.Payload, &payload); err != nil {
+			return err
+		}
+		ref := attachmentKey(payload.Attachment)
+		result, err := collection.UpdateOne(ctx,
+			bson.M{"_id": oid, "attachments": bson.M{"$not": bson.M{"$elemMatch": attachmentRefFilter(ref)}}},
+			bson.M{
+				"$push": bson.M{"attachments": payload.Attachment},
+				"$set":  bson.M{"updated_at": time.Now()},
+			})
+		if err != nil || result.MatchedCount == 1 {
+			return err
+		}
+		return requireAttachment(ctx, collection, oid, e.MongoID, ref)
+
+	case model.OutboxRemoveAttachment:
+		var payload struct {
+			Ref string `bson:"ref"`
+		}
+		if err := bson.Unmarshal(e.Payload, &payload); err != nil {
+			return err
+		}
+		result, err := collection.UpdateOne(ctx, bson.M{"_id": oid}, bson.M{
+			"$pull": bson.M{"attachments": attachmentRefFilter(payload.Ref)},
+			"$set":  bson.M{"updated_at": time.Now()},
+		})
+		return requireMatched(result, err, e.MongoID)
+
+	case model.OutboxReplaceAttachment:
+		var payload struct {
+			Ref        string                      `bson:"ref"`
+			Attachment model.AchievementAttachment `bson:"attachment"`
+		}
+		if err := bson.Unmarshal(e.Payload, &payload); err != nil {
+			return err
+		}
+		result, err := collection.UpdateOne(ctx,
+			bson.M{"_id": oid, "attachments": bson.M{"$elemMatch": attachmentRefFilter(payload.Ref)}},
+			bson.M{"$set": bson.M{"attachments.$": payload.Attachment, "updated_at": time.Now()}})
+		if err != nil || result.MatchedCount == 1 {
+			return err
+		}
+		// Lampiran lama sudah tidak ada: berhasil bila penggantinya sudah terpasang pada percobaan sebelumnya
+		return requireAttachment(ctx, collection, oid, e.MongoID, attachmentKey(payload.Attachment))
+
+	case model.OutboxRecordRevision:
+		return insertRevision(ctx, mongoDB, e, oid)
+	}
+
+	return fmt.Errorf("jenis event outbox '%s' tidak dikenal", e.EventType)
+}
+
+func requireMatched(result *mongo.UpdateResult, err error, mongoID string) error {
+	if err != nil {
+		return err
+	}
+	if result.MatchedCount == 0 {
+		return fmt.Errorf("dokumen prestasi %s belum ada di MongoDB", mongoID)
+	}
+	return nil
+}
+
+// requireAttachment memastikan dokumen sudah memuat lampiran ref
+func requireAttachment(ctx context.Context, collection *mongo.Collection, oid primitive.ObjectID, mongoID, ref string) error {
+	n, err := collection.CountDocuments(ctx, bson.M{"_id": oid, "attachments": bson.M{"$elemMatch": attachmentRefFilter(ref)}})
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return fmt.Errorf("lampiran %s pada dokumen prestasi %s tidak ditemukan", ref, mongoID)
+	}
+	return nil
+}
+
+func markOutboxDispatched(ctx context.Context, db *sql.DB, id int64) error {
+	_, err := db.ExecContext(ctx, `UPDATE achievement_outbox SET dispatched_at = NOW(), last_error = '' WHERE id = $1`, id)
+	return err
+}
+
+// FindDue mengambil event yang belum terkirim dan sudah waktunya dicoba, urut sesuai waktu dibuat.
+// Event yang masih punya event lebih lama untuk prestasi yang sama (misalnya sedang menunggu backoff)
+// dilewati agar insert dokumen selalu diterapkan sebelum update-nya dan update tidak saling menimpa.
+func (r *outboxRepository) FindDue(ctx context.Context, limit int) ([]model.OutboxEvent, error) {
+	rows, err := r.pgDB.QueryContext(ctx, `
+        SELECT id, achievement_id, mongo_achievement_id, event_type, payload, attempts, last_error, created_at
+        FROM achievement_outbox e
+        WHERE dispatched_at IS NULL AND next_attempt_at <= NOW()
+          AND NOT EXISTS (
+                SELECT 1 FROM achievement_outbox older
+                WHERE older.achievement_id = e.achievement_id AND older.dispatched_at IS NULL AND older.id < e.id)
+        ORDER BY id
+        LIMIT $1
+    `, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []model.OutboxEvent
+	for rows.Next() {
+		var e model.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.AchievementID, &e.MongoID, &e.EventType, &e.Payload, &e.Attempts, &e.LastError, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+func (r *outboxRepository) Apply(ctx context.Context, e model.OutboxEvent) error {
+	return applyOutboxEvent(ctx, r.mongoDB, e)
+}
+
+func (r *outboxRepository) MarkDispatched(ctx context.Context, id int64) error {
+	return markOutboxDispatched(ctx, r.pgDB, id)
+}
+
+func (r *outboxRepository) MarkFailed(ctx context.Context, id int64, errMsg string, nextAttemptAt time.Time) error {
+	_, err := r.pgDB.ExecContext(ctx, `
+        UPDATE achievement_outbox
+        SET attempts = attempts + 1, last_error = $2, next_attempt_at = $3
+        WHERE id = $1
+    `, id, errMsg, nextAttemptAt)
+	return err
+}
@@ -0,0 +1,221 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"time"
+
+	"github.com/lib/pq"
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type IReconcileRepository interface {
+	ListReferences(ctx context.Context) ([]model.ReconcileReference, error)
+	ForEachDocument(ctx context.Context, fn func(doc model.AchievementMongo) error) error
+	FindStuckOutbox(ctx context.Context, minAttempts int) ([]model.OutboxEvent, error)
+	HasReference(ctx context.Context, mongoID string) (bool, error)
+
+	RestoreReference(ctx context.Context, doc model.AchievementMongo) error
+	SoftDeleteReference(ctx context.Context, id string) error
+	SetDocumentPoints(ctx context.Context, mongoID string, points int) error
+	Project(ctx context.Context, id string, doc model.AchievementMongo) error
+	DispatchPending(ctx context.Context, achievementID string) error
+}
+
+type reconcileRepository struct {
+	pgDB    *sql.DB
+	mongoDB *mongo.Database
+}
+
+func NewReconcileRepository(pgDB *sql.DB, mongoDB *mongo.Database) IReconcileRepository {
+	return &reconcileRepository{pgDB, mongoDB}
+}
+
+// ListReferences memuat semua referensi prestasi beserta proyeksi dan jumlah event outbox yang tertunda
+func (r *reconcileRepository) ListReferences(ctx context.Context) ([]model.ReconcileReference, error) {
+	rows, err := r.pgDB.QueryContext(ctx, `
+        SELECT ar.id, ar.student_id, ar.mongo_achievement_id, ar.status,
+               (SELECT COUNT(*) FROM achievement_outbox o
+                WHERE o.achievement_id = ar.id AND o.dispatched_at IS NULL),
+               rm.achievement_id IS NOT NULL,
+               COALESCE(rm.achievement_type, ''), COALESCE(rm.title, ''), COALESCE(rm.description, ''),
+               COALESCE(rm.tags, '{}'), COALESCE(rm.points, 0), COALESCE(rm.points_mode, '')
+        FROM achievement_references ar
+        LEFT JOIN achievement_read_model rm ON rm.achievement_id = ar.id
+    `)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var refs []model.ReconcileReference
+	for rows.Next() {
+		var ref model.ReconcileReference
+		var projected bool
+		var p model.AchievementProjection
+		err := rows.Scan(&ref.ID, &ref.StudentID, &ref.MongoID, &ref.Status, &ref.PendingEvents, &projected,
+			&p.AchievementType, &p.Title, &p.Description, pq.Array(&p.Tags), &p.Points, &p.PointsMode)
+		if err != nil {
+			return nil, err
+		}
+		if projected {
+			ref.ReadModel = &p
+		}
+		refs = append(refs, ref)
+	}
+	return refs, rows.Err()
+}
+
+func (r *reconcileRepository) ForEachDocument(ctx context.Context, fn func(doc model.AchievementMongo) error) error {
+	cursor, err := r.mongoDB.Collection("achievements").Find(ctx, bson.M{})
+	if err != nil {
+		return err
+	}
+	defer cursor.Close(ctx)
+
+	for cursor.Next(ctx) {
+		var doc model.AchievementMongo
+		if err := cursor.Decode(&doc); err != nil {
+			return err
+		}
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+	return cursor.Err()
+}
+
+func (r *reconcileRepository) FindStuckOutbox(ctx context.Context, minAttempts int) ([]model.OutboxEvent, error) {
+	rows, err := r.pgDB.QueryContext(ctx, `
+        SELECT id, achievement_id, mongo_achievement_id, event_type, attempts, last_error, created_at
+        FROM achievement_outbox
+        WHERE dispatched_at IS NULL AND attempts >= $1
+        ORDER BY id
+    `, minAttempts)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []model.OutboxEvent
+	for rows.Next() {
+		var e model.OutboxEvent
+		if err := rows.Scan(&e.ID, &e.AchievementID, &e.MongoID, &e.EventType, &e.Attempts, &e.LastError, &e.CreatedAt); err != nil {
+			return nil, err
+		}
+		events = append(events, e)
+	}
+	return events, rows.Err()
+}
+
+// RestoreReference membuat ulang referensi draft untuk dokumen Mongo yatim sehingga mahasiswa
+// pemiliknya bisa melihat dan menghapusnya sendiri. Referensi yang ternyata sudah dibuat sejak
+// pemeriksaan (index unik mongo_achievement_id) dibiarkan.
+func (r *reconcileRepository) RestoreReference(ctx context.Context, doc model.AchievementMongo) error {
+	tx, err := r.pgDB.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	createdAt := doc.CreatedAt
+	if createdAt.IsZero() {
+		createdAt = time.Now()
+	}
+
+	var id string
+	err = tx.QueryRowContext(ctx, `
+        INSERT INTO achievement_references (student_id, mongo_achievement_id, status, created_at, updated_at)
+        VALUES ($1, $2, 'draft', $3, NOW())
+        ON CONFLICT (mongo_achievement_id) DO NOTHING
+        RETURNING id
+    `, doc.StudentID, doc.ID.Hex(), createdAt).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := upsertReadModel(ctx, tx, id, &doc); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// HasReference memeriksa ulang apakah dokumen sudah punya referensi, untuk dokumen yang dibuat
+// setelah daftar referensi dimuat
+func (r *reconcileRepository) HasReference(ctx context.Context, mongoID string) (bool, error) {
+	var exists bool
+	err := r.pgDB.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM achievement_references WHERE mongo_achievement_id = $1)`, mongoID).Scan(&exists)
+	return exists, err
+}
+
+func (r *reconcileRepository) SoftDeleteReference(ctx context.Context, id string) error {
+	_, err := r.pgDB.ExecContext(ctx, `UPDATE achievement_references SET status = 'deleted', deleted_at = NOW(), updated_at = NOW() WHERE id = $1`, id)
+	return err
+}
+
+func (r *reconcileRepository) SetDocumentPoints(ctx context.Context, mongoID string, points int) error {
+	oid, err := primitive.ObjectIDFromHex(mongoID)
+	if err != nil {
+		return err
+	}
+	_, err = r.mongoDB.Collection("achievements").UpdateOne(ctx, bson.M{"_id": oid}, bson.M{
+		"$set": bson.M{"points": points, "updated_at": time.Now()},
+	})
+	return err
+}
+
+// Project menulis ulang proyeksi dari doc, kecuali bila sejak pemeriksaan muncul event outbox baru
+func (r *reconcileRepository) Project(ctx context.Context, id string, doc model.AchievementMongo) error {
+	projected, err := projectSettled(ctx, r.pgDB, id, &doc)
+	if err != nil {
+		return err
+	}
+	if !projected {
+		return fmt.Errorf("prestasi %s punya event outbox yang belum terkirim", id)
+	}
+	return nil
+}
+
+// DispatchPending menerapkan event outbox yang tertunda untuk satu prestasi sesuai urutannya
+func (r *reconcileRepository) DispatchPending(ctx context.Context, achievementID string) error {
+	rows, err := r.pgDB.QueryContext(ctx, `
+        SELECT id, mongo_achievement_id, event_type, payload
+        FROM achievement_outbox
+        WHERE achievement_id = $1 AND dispatched_at IS NULL
+        ORDER BY id
+    `, achievementID)
+	if err != nil {
+		return err
+	}
+
+	var events []model.OutboxEvent
+	for rows.Next() {
+		e := model.OutboxEvent{AchievementID: achievementID}
+		if err := rows.Scan(&e.ID, &e.MongoID, &e.EventType, &e.Payload); err != nil {
+			rows.Close()
+			return err
+		}
+		events = append(events, e)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for _, e := range events {
+		if err := applyOutboxEvent(ctx, r.mongoDB, e); err != nil {
+			return err
+		}
+		if err := markOutboxDispatched(ctx, r.pgDB, e.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -3,38 +3,87 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"sistem-pelaporan-prestasi-mahasiswa/app/model"
 	"time"
 
-	"go.mongodb.org/mongo-driver/bson"
-	"go.mongodb.org/mongo-driver/bson/primitive"
-	"go.mongodb.org/mongo-driver/mongo"
+	"github.com/lib/pq"
 )
 
 type IReportRepository interface {
-	GetGlobalStats(ctx context.Context) (*model.DashboardStatistics, error)
+	GetGlobalStats(ctx context.Context, f model.StatisticsFilter) (*model.DashboardStatistics, error)
 	GetStudentReport(ctx context.Context, studentID string) (*model.StudentReportDTO, error)
+	GetTimeseries(ctx context.Context, f model.TimeseriesFilter) ([]model.TimeseriesRow, error)
+	HasFaculty(ctx context.Context, faculty string) (bool, error)
 }
 
 type reportRepository struct {
-	pgDB    *sql.DB
-	mongoDB *mongo.Database
+	pgDB *sql.DB
 }
 
-func NewReportRepository(pgDB *sql.DB, mongoDB *mongo.Database) IReportRepository {
-	return &reportRepository{pgDB, mongoDB}
+func NewReportRepository(pgDB *sql.DB) IReportRepository {
+	return &reportRepository{pgDB}
 }
 
-// GetGlobalStats 
-func (r *reportRepository) GetGlobalStats(ctx context.Context) (*model.DashboardStatistics, error) {
+// HasFaculty memeriksa apakah fakultas sudah dipetakan ke minimal satu program studi
+func (r *reportRepository) HasFaculty(ctx context.Context, faculty string) (bool, error) {
+	var exists bool
+	err := r.pgDB.QueryRowContext(ctx, `SELECT EXISTS(SELECT 1 FROM study_programs WHERE faculty ILIKE $1)`, faculty).Scan(&exists)
+	return exists, err
+}
+
+// statsScope menyusun filter statistik untuk achievement_references (ar) dan mahasiswa dengan alias
+// studentAlias. Mengembalikan klausa " AND ..." beserta argumen mulai dari $argStart.
+func statsScope(f model.StatisticsFilter, studentAlias string, argStart int) (string, []interface{}) {
+	clause := ""
+	var args []interface{}
+	n := argStart
+
+	if f.DateFrom != nil {
+		clause += fmt.Sprintf(" AND ar.created_at >= $%d", n)
+		args = append(args, *f.DateFrom)
+		n++
+	}
+	if f.DateTo != nil {
+		clause += fmt.Sprintf(" AND ar.created_at < $%d", n)
+		args = append(args, *f.DateTo)
+		n++
+	}
+	if f.Faculty != "" {
+		clause += fmt.Sprintf(" AND %s.program_study IN (SELECT name FROM study_programs WHERE faculty ILIKE $%d)", studentAlias, n)
+		args = append(args, f.Faculty)
+	}
+	return clause, args
+}
+
+// GetGlobalStats menghitung statistik dashboard. Prestasi terhapus tidak dihitung.
+func (r *reportRepository) GetGlobalStats(ctx context.Context, f model.StatisticsFilter) (*model.DashboardStatistics, error) {
 	stats := &model.DashboardStatistics{
 		AchievementsByStatus: make(map[string]int),
+		TopStudents:          []model.TopStudent{},
+	}
+
+	studentQuery := "SELECT COUNT(*) FROM students s WHERE 1=1"
+	var studentArgs []interface{}
+	if f.Faculty != "" {
+		studentQuery += " AND s.program_study IN (SELECT name FROM study_programs WHERE faculty ILIKE $1)"
+		studentArgs = append(studentArgs, f.Faculty)
+	}
+	if err := r.pgDB.QueryRowContext(ctx, studentQuery, studentArgs...).Scan(&stats.TotalStudents); err != nil {
+		return nil, err
+	}
+	if err := r.pgDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM lecturers").Scan(&stats.TotalLecturers); err != nil {
+		return nil, err
 	}
 
-	r.pgDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM students").Scan(&stats.TotalStudents)
-	r.pgDB.QueryRowContext(ctx, "SELECT COUNT(*) FROM lecturers").Scan(&stats.TotalLecturers)
+	scope, args := statsScope(f, "s", 1)
+	from := `
+        FROM achievement_references ar
+        JOIN students s ON s.id = ar.student_id
+        LEFT JOIN achievement_read_model rm ON rm.achievement_id = ar.id
+        WHERE ar.status != 'deleted'` + scope
 
-	rows, err := r.pgDB.QueryContext(ctx, "SELECT status, COUNT(*) FROM achievement_references GROUP BY status")
+	rows, err := r.pgDB.QueryContext(ctx, "SELECT ar.status, COUNT(*)"+from+" GROUP BY ar.status", args...)
 	if err != nil {
 		return nil, err
 	}
@@ -44,15 +93,133 @@ func (r *reportRepository) GetGlobalStats(ctx context.Context) (*model.Dashboard
 	for rows.Next() {
 		var status string
 		var count int
-		rows.Scan(&status, &count)
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, err
+		}
 		stats.AchievementsByStatus[status] = count
 		totalAch += count
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
 	stats.TotalAchievements = totalAch
 
+	for _, b := range []struct {
+		column string
+		target *[]model.StatBreakdown
+	}{
+		{"rm.achievement_type", &stats.ByType},
+		{"s.program_study", &stats.ByProgramStudy},
+		{"s.academic_year", &stats.ByAcademicYear},
+	} {
+		breakdown, err := r.breakdown(ctx, b.column, from, args)
+		if err != nil {
+			return nil, err
+		}
+		*b.target = breakdown
+	}
+
+	turnaround := `
+        SELECT COUNT(*),
+               COALESCE(AVG(h), 0),
+               COALESCE(percentile_cont(0.5) WITHIN GROUP (ORDER BY h), 0),
+               COALESCE(percentile_cont(0.9) WITHIN GROUP (ORDER BY h), 0)
+        FROM (SELECT EXTRACT(EPOCH FROM (ar.verified_at - ar.submitted_at)) / 3600 AS h` + from + `
+              AND ar.status = 'verified' AND ar.submitted_at IS NOT NULL AND ar.verified_at IS NOT NULL) t`
+	t := &stats.VerificationTurnaround
+	if err := r.pgDB.QueryRowContext(ctx, turnaround, args...).Scan(&t.Verified, &t.AverageHours, &t.MedianHours, &t.P90Hours); err != nil {
+		return nil, err
+	}
+
+	if f.TopN > 0 {
+		top, err := r.topStudents(ctx, f)
+		if err != nil {
+			return nil, err
+		}
+		stats.TopStudents = top
+	}
+
 	return stats, nil
 }
 
+// breakdown mengelompokkan prestasi per nilai kolom; poin hanya dihitung dari prestasi verified
+func (r *reportRepository) breakdown(ctx context.Context, column, from string, args []interface{}) ([]model.StatBreakdown, error) {
+	query := fmt.Sprintf(`
+        SELECT COALESCE(%s, ''), COUNT(*),
+               COUNT(*) FILTER (WHERE ar.status = 'verified'),
+               COALESCE(SUM(rm.points) FILTER (WHERE ar.status = 'verified'), 0)`, column) + from + `
+        GROUP BY 1
+        ORDER BY 2 DESC, 1`
+
+	rows, err := r.pgDB.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	result := []model.StatBreakdown{}
+	for rows.Next() {
+		var b model.StatBreakdown
+		if err := rows.Scan(&b.Key, &b.Achievements, &b.Verified, &b.Points); err != nil {
+			return nil, err
+		}
+		result = append(result, b)
+	}
+	return result, rows.Err()
+}
+
+// topStudents memeringkat mahasiswa berdasarkan poin prestasi verified. Poin prestasi tim dihitung
+// untuk setiap anggota yang partisipasinya terverifikasi, dengan aturan pembagian yang sama seperti
+// laporan mahasiswa (model.MemberPoints): hanya anggota terkonfirmasi dan terverifikasi yang ikut
+// dibagi, dan sisa pembagian diberikan ke pembuat.
+func (r *reportRepository) topStudents(ctx context.Context, f model.StatisticsFilter) ([]model.TopStudent, error) {
+	scope, args := statsScope(f, "st", 3)
+	query := `
+        WITH participation AS (
+            SELECT ar.student_id, ar.id AS achievement_id, true AS is_owner
+            FROM achievement_references ar
+            WHERE ar.status = 'verified'
+            UNION ALL
+            SELECT am.student_id, am.achievement_id, false
+            FROM achievement_members am
+            JOIN achievement_references ar ON ar.id = am.achievement_id
+            WHERE ar.status = 'verified' AND am.invitation_status = 'confirmed' AND am.verification_status = 'verified'
+        )
+        SELECT st.id, st.student_id, u.full_name, st.program_study, COUNT(*),
+               COALESCE(SUM(CASE WHEN rm.points_mode = $2 AND parts.n > 1
+                        THEN rm.points / parts.n + CASE WHEN p.is_owner THEN rm.points % parts.n ELSE 0 END
+                        ELSE rm.points END), 0) AS total
+        FROM participation p
+        JOIN achievement_references ar ON ar.id = p.achievement_id
+        LEFT JOIN achievement_read_model rm ON rm.achievement_id = ar.id
+        JOIN students st ON st.id = p.student_id
+        JOIN users u ON u.id = st.user_id
+        CROSS JOIN LATERAL (
+            SELECT 1 + COUNT(*) AS n FROM achievement_members c
+            WHERE c.achievement_id = ar.id AND c.invitation_status = 'confirmed' AND c.verification_status = 'verified'
+        ) parts
+        WHERE 1=1` + scope + `
+        GROUP BY st.id, st.student_id, u.full_name, st.program_study
+        ORDER BY total DESC, u.full_name
+        LIMIT $1`
+
+	rows, err := r.pgDB.QueryContext(ctx, query, append([]interface{}{f.TopN, model.PointsModeSplit}, args...)...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var top []model.TopStudent
+	for rows.Next() {
+		var s model.TopStudent
+		if err := rows.Scan(&s.ID, &s.StudentID, &s.Name, &s.ProgramStudy, &s.Achievements, &s.TotalPoints); err != nil {
+			return nil, err
+		}
+		top = append(top, s)
+	}
+	return top, rows.Err()
+}
+
 // GetStudentReport 
 func (r *reportRepository) GetStudentReport(ctx context.Context, studentID string) (*model.StudentReportDTO, error) {
 	report := &model.StudentReportDTO{
@@ -60,10 +227,22 @@ func (r *reportRepository) GetStudentReport(ctx context.Context, studentID strin
 		GeneratedAt:  time.Now(),
 	}
 
+	// Prestasi milik sendiri ditambah prestasi tim yang partisipasinya sudah diverifikasi,
+	// sehingga satu prestasi tim dihitung sekali untuk setiap anggota. Jenis dan poin dibaca
+	// dari achievement_read_model sehingga tidak perlu query ke MongoDB.
 	query := `
-        SELECT mongo_achievement_id 
-        FROM achievement_references 
-        WHERE student_id = $1 AND status = 'verified'
+        SELECT COALESCE(rm.achievement_type, ''), COALESCE(rm.points, 0), COALESCE(rm.points_mode, ''),
+               ar.student_id = $1 AS is_owner,
+               1 + (SELECT COUNT(*) FROM achievement_members c
+                    WHERE c.achievement_id = ar.id AND c.invitation_status = 'confirmed'
+                      AND c.verification_status = 'verified') AS participants
+        FROM achievement_references ar
+        LEFT JOIN achievement_read_model rm ON rm.achievement_id = ar.id
+        WHERE ar.status = 'verified'
+          AND (ar.student_id = $1 OR EXISTS (
+                SELECT 1 FROM achievement_members am
+                WHERE am.achievement_id = ar.id AND am.student_id = $1
+                  AND am.invitation_status = 'confirmed' AND am.verification_status = 'verified'))
     `
 	rows, err := r.pgDB.QueryContext(ctx, query, studentID)
 	if err != nil {
@@ -71,52 +250,105 @@ func (r *reportRepository) GetStudentReport(ctx context.Context, studentID strin
 	}
 	defer rows.Close()
 
-	var mongoIDs []primitive.ObjectID
+	totalPoints := 0
+	totalCount := 0
+
 	for rows.Next() {
-		var mid string
-		rows.Scan(&mid)
-		if oid, err := primitive.ObjectIDFromHex(mid); err == nil {
-			mongoIDs = append(mongoIDs, oid)
+		var achType, pointsMode string
+		var basePoints, participants int
+		var isOwner bool
+		if err := rows.Scan(&achType, &basePoints, &pointsMode, &isOwner, &participants); err != nil {
+			return nil, err
 		}
+
+		points := model.MemberPoints(basePoints, pointsMode, participants, isOwner)
+		report.PointsByType[achType] += points
+		totalPoints += points
+		totalCount++
 	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	report.TotalPoints = totalPoints
+	report.TotalAchievements = totalCount
 
-	if len(mongoIDs) == 0 {
-		return report, nil
+	return report, nil
+}
+
+// timeseriesBuckets memetakan ukuran bucket ke ekspresi tanggal awal bucket untuk timestamp e.ts
+var timeseriesBuckets = map[string]string{
+	model.BucketDay:   "date_trunc('day', e.ts)::date",
+	model.BucketWeek:  "date_trunc('week', e.ts)::date",
+	model.BucketMonth: "date_trunc('month', e.ts)::date",
+	model.BucketSemester: `CASE
+            WHEN EXTRACT(MONTH FROM e.ts) >= 8 THEN make_date(EXTRACT(YEAR FROM e.ts)::int, 8, 1)
+            WHEN EXTRACT(MONTH FROM e.ts) = 1 THEN make_date(EXTRACT(YEAR FROM e.ts)::int - 1, 8, 1)
+            ELSE make_date(EXTRACT(YEAR FROM e.ts)::int, 2, 1)
+        END`,
+}
+
+// timeseriesGroups memetakan dimensi group_by ke kolom SQL
+var timeseriesGroups = map[string]string{
+	model.GroupAchievementType: "COALESCE(rm.achievement_type, '')",
+	model.GroupProgramStudy:    "s.program_study",
+	model.GroupAcademicYear:    "s.academic_year",
+	model.GroupFaculty:         "COALESCE(sp.faculty, '')",
+}
+
+// GetTimeseries mengagregasi metrik per bucket waktu dalam satu kali scan achievement_references.
+// Setiap prestasi dipecah menjadi kejadian (dilaporkan, disubmit, diverifikasi/ditolak) lewat LATERAL
+// VALUES; jenis dan poin dibaca dari proyeksi achievement_read_model.
+func (r *reportRepository) GetTimeseries(ctx context.Context, f model.TimeseriesFilter) ([]model.TimeseriesRow, error) {
+	groupCols := ""
+	groupBy := "1, 2"
+	for i, g := range f.GroupBy {
+		groupCols += ", " + timeseriesGroups[g]
+		groupBy += fmt.Sprintf(", %d", i+3)
 	}
 
-	pipeline := mongo.Pipeline{
-		{{Key: "$match", Value: bson.M{"_id": bson.M{"$in": mongoIDs}}}},
-		{{Key: "$group", Value: bson.M{
-			"_id":         "$achievement_type",
-			"totalPoints": bson.M{"$sum": "$points"},
-			"count":       bson.M{"$sum": 1},
-		}}},
+	query := `
+        SELECT ` + timeseriesBuckets[f.Bucket] + ` AS bucket, e.metric` + groupCols + `, SUM(e.value)
+        FROM achievement_references ar
+        JOIN students s ON s.id = ar.student_id
+        LEFT JOIN achievement_read_model rm ON rm.achievement_id = ar.id
+        LEFT JOIN study_programs sp ON sp.name = s.program_study
+        CROSS JOIN LATERAL (VALUES
+            ('` + model.MetricCreated + `', ar.created_at, 1),
+            ('` + model.MetricSubmitted + `', ar.submitted_at, 1),
+            ('` + model.MetricVerified + `', CASE WHEN ar.status = 'verified' THEN ar.verified_at END, 1),
+            ('` + model.MetricRejected + `', CASE WHEN ar.status = 'rejected' THEN ar.verified_at END, 1),
+            ('` + model.MetricPoints + `', CASE WHEN ar.status = 'verified' THEN ar.verified_at END, COALESCE(rm.points, 0))
+        ) AS e(metric, ts, value)
+        WHERE ar.status != 'deleted'
+          AND e.metric = ANY($1)
+          AND e.ts >= $2 AND e.ts < $3`
+	args := []interface{}{pq.Array(f.Metrics), f.DateFrom, f.DateTo}
+
+	if f.Faculty != "" {
+		query += " AND sp.faculty ILIKE $4"
+		args = append(args, f.Faculty)
 	}
+	query += " GROUP BY " + groupBy + " ORDER BY 1"
 
-	cursor, err := r.mongoDB.Collection("achievements").Aggregate(ctx, pipeline)
+	rows, err := r.pgDB.QueryContext(ctx, query, args...)
 	if err != nil {
 		return nil, err
 	}
-	defer cursor.Close(ctx)
-
-	totalPoints := 0
-	totalCount := 0
+	defer rows.Close()
 
-	for cursor.Next(ctx) {
-		var result struct {
-			Type        string `bson:"_id"`
-			TotalPoints int    `bson:"totalPoints"`
-			Count       int    `bson:"count"`
+	var result []model.TimeseriesRow
+	for rows.Next() {
+		row := model.TimeseriesRow{Group: make([]string, len(f.GroupBy))}
+		dest := []interface{}{&row.Bucket, &row.Metric}
+		for i := range row.Group {
+			dest = append(dest, &row.Group[i])
 		}
-		if err := cursor.Decode(&result); err == nil {
-			report.PointsByType[result.Type] = result.TotalPoints
-			totalPoints += result.TotalPoints
-			totalCount += result.Count
+		dest = append(dest, &row.Value)
+		if err := rows.Scan(dest...); err != nil {
+			return nil, err
 		}
+		result = append(result, row)
 	}
-
-	report.TotalPoints = totalPoints
-	report.TotalAchievements = totalCount
-
-	return report, nil
+	return result, rows.Err()
 }
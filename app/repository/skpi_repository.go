@@ -0,0 +1,130 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type ISKPIRepository interface {
+	GetVerifiedAchievements(ctx context.Context, studentID string) ([]model.SKPIAchievement, error)
+	ListCohort(ctx context.Context, f model.SKPICohortFilter) ([]model.SKPIStudent, error)
+}
+
+type skpiRepository struct {
+	pgDB    *sql.DB
+	mongoDB *mongo.Database
+}
+
+func NewSKPIRepository(pgDB *sql.DB, mongoDB *mongo.Database) ISKPIRepository {
+	return &skpiRepository{pgDB, mongoDB}
+}
+
+// GetVerifiedAchievements mengambil prestasi verified milik mahasiswa ditambah prestasi tim yang
+// partisipasinya sudah diverifikasi (aturan yang sama dengan laporan mahasiswa). Judul dan jenis dibaca
+// dari achievement_read_model; details (judul Inggris, tingkat, tanggal kegiatan) dari MongoDB, yang juga
+// mengisi judul dan jenis prestasi yang belum terproyeksi.
+func (r *skpiRepository) GetVerifiedAchievements(ctx context.Context, studentID string) ([]model.SKPIAchievement, error) {
+	query := `
+        SELECT ar.id, ar.mongo_achievement_id, COALESCE(rm.achievement_type, ''), COALESCE(rm.title, ''), ar.verified_at
+        FROM achievement_references ar
+        LEFT JOIN achievement_read_model rm ON rm.achievement_id = ar.id
+        WHERE ar.status = 'verified'
+          AND (ar.student_id = $1 OR EXISTS (
+                SELECT 1 FROM achievement_members am
+                WHERE am.achievement_id = ar.id AND am.student_id = $1
+                  AND am.invitation_status = 'confirmed' AND am.verification_status = 'verified'))
+        ORDER BY ar.verified_at, ar.id
+    `
+	rows, err := r.pgDB.QueryContext(ctx, query, studentID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var items []model.SKPIAchievement
+	var mongoIDs []string
+	var oids []primitive.ObjectID
+	for rows.Next() {
+		var a model.SKPIAchievement
+		var mongoID string
+		var verifiedAt sql.NullTime
+		if err := rows.Scan(&a.AchievementID, &mongoID, &a.AchievementType, &a.Title, &verifiedAt); err != nil {
+			return nil, err
+		}
+		if verifiedAt.Valid {
+			a.VerifiedAt = &verifiedAt.Time
+		}
+		if oid, err := primitive.ObjectIDFromHex(mongoID); err == nil {
+			oids = append(oids, oid)
+		}
+		items = append(items, a)
+		mongoIDs = append(mongoIDs, mongoID)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(oids) == 0 {
+		return items, nil
+	}
+
+	cursor, err := r.mongoDB.Collection("achievements").Find(ctx,
+		bson.M{"_id": bson.M{"$in": oids}},
+		options.Find().SetProjection(bson.M{"details": 1, "title": 1, "achievement_type": 1}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	docs := make(map[string]model.AchievementMongo, len(oids))
+	for cursor.Next(ctx) {
+		var doc model.AchievementMongo
+		if err := cursor.Decode(&doc); err != nil {
+			return nil, err
+		}
+		docs[doc.ID.Hex()] = doc
+	}
+	if err := cursor.Err(); err != nil {
+		return nil, err
+	}
+
+	for i := range items {
+		doc := docs[mongoIDs[i]]
+		items[i].Details = doc.Details
+		if items[i].Title == "" {
+			items[i].Title, items[i].AchievementType = doc.Title, doc.AchievementType
+		}
+	}
+	return items, nil
+}
+
+// ListCohort mengambil mahasiswa satu angkatan, opsional dibatasi satu program studi
+func (r *skpiRepository) ListCohort(ctx context.Context, f model.SKPICohortFilter) ([]model.SKPIStudent, error) {
+	query := `
+        SELECT s.id, s.user_id, s.student_id, u.full_name, s.program_study, s.academic_year
+        FROM students s
+        JOIN users u ON u.id = s.user_id
+        WHERE s.academic_year = $1 AND ($2 = '' OR s.program_study = $2)
+        ORDER BY s.program_study, s.student_id
+    `
+	rows, err := r.pgDB.QueryContext(ctx, query, f.AcademicYear, f.ProgramStudy)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var students []model.SKPIStudent
+	for rows.Next() {
+		var s model.SKPIStudent
+		if err := rows.Scan(&s.ID, &s.UserID, &s.StudentID, &s.FullName, &s.ProgramStudy, &s.AcademicYear); err != nil {
+			return nil, err
+		}
+		students = append(students, s)
+	}
+	return students, rows.Err()
+}
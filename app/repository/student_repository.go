@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"fmt"
 
 	"sistem-pelaporan-prestasi-mahasiswa/app/model"
 )
@@ -11,9 +12,11 @@ type IStudentRepository interface {
 	Create(ctx context.Context, tx *sql.Tx, userID string, studentID, programStudy, academicYear string, advisorID *string) error
 	Update(ctx context.Context, tx *sql.Tx, userID string, studentID, programStudy, academicYear *string, advisorID *string) error
 	GetByUserID(ctx context.Context, userID string) (*model.StudentInfo, error)
+	GetByStudentNumber(ctx context.Context, studentNumber string) (*model.StudentInfo, error)
 	Delete(ctx context.Context, tx *sql.Tx, userID string) error
 	CheckStudentIDExists(ctx context.Context, studentID string, excludeUserID *string) (bool, error)
 	GetAll(ctx context.Context, page, pageSize int, search, sortBy, sortOrder string) ([]model.StudentListDTO, int64, error)
+	GetAllByCursor(ctx context.Context, search, sortBy, sortOrder string, cursor *model.PageCursor, limit int) ([]model.StudentListDTO, model.CursorLinks, error)
 	GetDetailByID(ctx context.Context, studentID string) (*model.StudentDetailDTO, error)
 	UpdateAdvisor(ctx context.Context, studentID string, advisorID *string) error
 }
@@ -44,7 +47,10 @@ func (r *studentRepository) Create(ctx context.Context, tx *sql.Tx, userID strin
 	}
 	
 	_, err := executor.ExecContext(ctx, query, userID, studentID, programStudy, academicYear, advisorID)
-	return err
+	if err != nil {
+		return err
+	}
+	return ensureStudyProgram(ctx, executor, programStudy)
 }
 
 // UpdateStudent
@@ -69,7 +75,10 @@ func (r *studentRepository) Update(ctx context.Context, tx *sql.Tx, userID strin
 	}
 	
 	_, err := executor.ExecContext(ctx, query, programStudy, academicYear, advisorID, studentID, userID)
-	return err
+	if err != nil || programStudy == nil {
+		return err
+	}
+	return ensureStudyProgram(ctx, executor, *programStudy)
 }
 
 // GetByUserID
@@ -107,6 +116,42 @@ func (r *studentRepository) GetByUserID(ctx context.Context, userID string) (*mo
 	return &info, nil
 }
 
+// GetByStudentNumber (NIM)
+func (r *studentRepository) GetByStudentNumber(ctx context.Context, studentNumber string) (*model.StudentInfo, error) {
+	query := `
+		SELECT s.id, s.student_id, s.program_study, s.academic_year, s.advisor_id,
+		       l.lecturer_id as advisor_name
+		FROM students s
+		JOIN users u ON s.user_id = u.id
+		LEFT JOIN lecturers l ON s.advisor_id = l.id
+		WHERE s.student_id = $1 AND u.is_active = true
+	`
+
+	var info model.StudentInfo
+	var advisorID, advisorName sql.NullString
+
+	err := r.db.QueryRowContext(ctx, query, studentNumber).Scan(
+		&info.ID, &info.StudentID, &info.ProgramStudy, &info.AcademicYear,
+		&advisorID, &advisorName,
+	)
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	if advisorID.Valid {
+		info.AdvisorID = &advisorID.String
+	}
+	if advisorName.Valid {
+		info.AdvisorName = &advisorName.String
+	}
+
+	return &info, nil
+}
+
 // DeleteStudent
 func (r *studentRepository) Delete(ctx context.Context, tx *sql.Tx, userID string) error {
 	query := `DELETE FROM students WHERE user_id = $1`
@@ -175,29 +220,93 @@ func (r *studentRepository) GetAll(ctx context.Context, page, pageSize int, sear
 
 	var students []model.StudentListDTO
 	for rows.Next() {
-		var student model.StudentListDTO
-		var advisorID, advisorName sql.NullString
-
-		err := rows.Scan(
-			&student.ID, &student.StudentID, &student.FullName, &student.Email,
-			&student.ProgramStudy, &student.AcademicYear, &advisorID,
-			&advisorName, &student.IsActive,
-		)
+		student, err := scanStudentListRow(rows)
 		if err != nil {
 			return nil, 0, err
 		}
+		students = append(students, student)
+	}
 
-		if advisorID.Valid {
-			student.AdvisorID = &advisorID.String
-		}
-		if advisorName.Valid {
-			student.AdvisorName = &advisorName.String
-		}
+	return students, total, rows.Err()
+}
+
+const studentListColumns = `
+		SELECT 
+			u.id, s.student_id, u.full_name, u.email,
+			s.program_study, s.academic_year, s.advisor_id,
+			u_advisor.full_name as advisor_name, u.is_active`
+
+const studentListJoins = `
+		FROM students s
+		JOIN users u ON s.user_id = u.id
+		LEFT JOIN lecturers l ON s.advisor_id = l.id
+		LEFT JOIN users u_advisor ON l.user_id = u_advisor.id`
+
+// scanStudentListRow membaca satu baris studentListColumns (ditambah kolom extra bila ada)
+func scanStudentListRow(rows *sql.Rows, extra ...interface{}) (model.StudentListDTO, error) {
+	var student model.StudentListDTO
+	var advisorID, advisorName sql.NullString
+
+	dest := []interface{}{
+		&student.ID, &student.StudentID, &student.FullName, &student.Email,
+		&student.ProgramStudy, &student.AcademicYear, &advisorID,
+		&advisorName, &student.IsActive,
+	}
+	if err := rows.Scan(append(dest, extra...)...); err != nil {
+		return student, err
+	}
 
+	if advisorID.Valid {
+		student.AdvisorID = &advisorID.String
+	}
+	if advisorName.Valid {
+		student.AdvisorName = &advisorName.String
+	}
+	return student, nil
+}
+
+// queryStudentsByCursor menjalankan paginasi keyset untuk daftar mahasiswa dengan kondisi WHERE tertentu
+func queryStudentsByCursor(ctx context.Context, db *sql.DB, k keyset, where string, args []interface{}) ([]model.StudentListDTO, model.CursorLinks, error) {
+	clause, cursorArgs, argCounter := k.condition(len(args) + 1)
+	args = append(args, cursorArgs...)
+
+	query := studentListColumns + k.columns() + studentListJoins + where + clause +
+		fmt.Sprintf(" ORDER BY %s LIMIT $%d", k.orderBy(), argCounter)
+	args = append(args, k.limit+1)
+
+	rows, err := db.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, model.CursorLinks{}, err
+	}
+	defer rows.Close()
+
+	var students []model.StudentListDTO
+	var keys []model.PageCursor
+	for rows.Next() {
+		var value, id string
+		student, err := scanStudentListRow(rows, &value, &id)
+		if err != nil {
+			return nil, model.CursorLinks{}, err
+		}
 		students = append(students, student)
+		keys = append(keys, k.key(value, id))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.CursorLinks{}, err
 	}
 
-	return students, total, rows.Err()
+	students, links := keysetPage(k, students, keys)
+	return students, links, nil
+}
+
+// GetAllStudentByCursor: paginasi keyset tanpa COUNT(*); sortBy adalah kolom yang sudah divalidasi service
+func (r *studentRepository) GetAllByCursor(ctx context.Context, search, sortBy, sortOrder string, cursor *model.PageCursor, limit int) ([]model.StudentListDTO, model.CursorLinks, error) {
+	k := keyset{sortBy: sortBy, sortCol: sortBy, idCol: "u.id", order: sortOrder, cursor: cursor, limit: limit}
+
+	where := `
+		WHERE u.is_active = true
+		  AND (u.full_name ILIKE $1 OR s.student_id ILIKE $1)`
+	return queryStudentsByCursor(ctx, r.db, k, where, []interface{}{"%" + search + "%"})
 }
 
 func (r *studentRepository) GetDetailByID(ctx context.Context, id string) (*model.StudentDetailDTO, error) {
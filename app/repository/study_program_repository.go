@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+)
+
+type IStudyProgramRepository interface {
+	GetAll(ctx context.Context) ([]model.StudyProgram, error)
+	GetByName(ctx context.Context, name string) (*model.StudyProgram, error)
+	Upsert(ctx context.Context, name, faculty string) error
+	Delete(ctx context.Context, name string) error
+}
+
+type studyProgramRepository struct {
+	db *sql.DB
+}
+
+func NewStudyProgramRepository(db *sql.DB) IStudyProgramRepository {
+	return &studyProgramRepository{db: db}
+}
+
+// ensureStudyProgram mendaftarkan program studi mahasiswa yang belum ada di study_programs (fakultas
+// kosong sampai dipetakan Admin), sehingga daftar pemetaan selalu mencakup semua program studi.
+func ensureStudyProgram(ctx context.Context, db execer, name string) error {
+	if name == "" {
+		return nil
+	}
+	_, err := db.ExecContext(ctx, `INSERT INTO study_programs (name) VALUES ($1) ON CONFLICT (name) DO NOTHING`, name)
+	return err
+}
+
+const studyProgramColumns = `
+        SELECT sp.name, sp.faculty,
+               (SELECT COUNT(*) FROM students s WHERE s.program_study = sp.name)
+        FROM study_programs sp`
+
+// GetAll
+func (r *studyProgramRepository) GetAll(ctx context.Context) ([]model.StudyProgram, error) {
+	rows, err := r.db.QueryContext(ctx, studyProgramColumns+` ORDER BY sp.faculty, sp.name`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var programs []model.StudyProgram
+	for rows.Next() {
+		var p model.StudyProgram
+		if err := rows.Scan(&p.Name, &p.Faculty, &p.Students); err != nil {
+			return nil, err
+		}
+		programs = append(programs, p)
+	}
+	return programs, rows.Err()
+}
+
+// GetByName
+func (r *studyProgramRepository) GetByName(ctx context.Context, name string) (*model.StudyProgram, error) {
+	var p model.StudyProgram
+	err := r.db.QueryRowContext(ctx, studyProgramColumns+` WHERE sp.name = $1`, name).Scan(&p.Name, &p.Faculty, &p.Students)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// Upsert menambahkan program studi atau mengganti fakultasnya
+func (r *studyProgramRepository) Upsert(ctx context.Context, name, faculty string) error {
+	_, err := r.db.ExecContext(ctx, `
+        INSERT INTO study_programs (name, faculty) VALUES ($1, $2)
+        ON CONFLICT (name) DO UPDATE SET faculty = EXCLUDED.faculty
+    `, name, faculty)
+	return err
+}
+
+// Delete
+func (r *studyProgramRepository) Delete(ctx context.Context, name string) error {
+	_, err := r.db.ExecContext(ctx, `DELETE FROM study_programs WHERE name = $1`, name)
+	return err
+}
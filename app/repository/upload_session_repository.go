@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type IUploadSessionRepository interface {
+	Create(ctx context.Context, session *model.UploadSession) error
+	GetByID(ctx context.Context, id string) (*model.UploadSession, error)
+	AdvanceOffset(ctx context.Context, id string, from, to int64, expiresAt time.Time) (bool, error)
+	ClaimCompletion(ctx context.Context, id string, staleBefore time.Time) (bool, error)
+	ReleaseCompletion(ctx context.Context, id string) error
+	Complete(ctx context.Context, id string, attachment model.AchievementAttachment) error
+	Delete(ctx context.Context, id string) error
+	FindExpired(ctx context.Context, now time.Time, limit int) ([]model.UploadSession, error)
+}
+
+type uploadSessionRepository struct {
+	mongoDB *mongo.Database
+}
+
+func NewUploadSessionRepository(mongoDB *mongo.Database) IUploadSessionRepository {
+	return &uploadSessionRepository{mongoDB: mongoDB}
+}
+
+// Create
+func (r *uploadSessionRepository) Create(ctx context.Context, session *model.UploadSession) error {
+	_, err := r.mongoDB.Collection("upload_sessions").InsertOne(ctx, session)
+	return err
+}
+
+// GetByID
+func (r *uploadSessionRepository) GetByID(ctx context.Context, id string) (*model.UploadSession, error) {
+	var session model.UploadSession
+	err := r.mongoDB.Collection("upload_sessions").FindOne(ctx, bson.M{"_id": id}).Decode(&session)
+	if err == mongo.ErrNoDocuments {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &session, nil
+}
+
+// AdvanceOffset hanya berhasil bila offset di database masih sama dengan from, sehingga dua potongan
+// yang dikirim bersamaan untuk offset yang sama tidak bisa sama-sama diterima
+func (r *uploadSessionRepository) AdvanceOffset(ctx context.Context, id string, from, to int64, expiresAt time.Time) (bool, error) {
+	res, err := r.mongoDB.Collection("upload_sessions").UpdateOne(
+		ctx,
+		bson.M{"_id": id, "offset": from, "status": model.UploadSessionActive},
+		bson.M{"$set": bson.M{"offset": to, "expires_at": expiresAt, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+// ClaimCompletion mengubah status active menjadi completing secara atomik sehingga hanya satu request yang
+// menggabungkan potongan. Klaim yang lebih lama dari staleBefore dianggap terhenti dan boleh diambil alih.
+func (r *uploadSessionRepository) ClaimCompletion(ctx context.Context, id string, staleBefore time.Time) (bool, error) {
+	res, err := r.mongoDB.Collection("upload_sessions").UpdateOne(
+		ctx,
+		bson.M{
+			"_id": id,
+			"$or": []bson.M{
+				{"status": model.UploadSessionActive},
+				{"status": model.UploadSessionCompleting, "updated_at": bson.M{"$lt": staleBefore}},
+			},
+		},
+		bson.M{"$set": bson.M{"status": model.UploadSessionCompleting, "updated_at": time.Now()}},
+	)
+	if err != nil {
+		return false, err
+	}
+	return res.ModifiedCount == 1, nil
+}
+
+// ReleaseCompletion mengembalikan sesi ke active bila penyelesaian gagal agar bisa dicoba lagi
+func (r *uploadSessionRepository) ReleaseCompletion(ctx context.Context, id string) error {
+	_, err := r.mongoDB.Collection("upload_sessions").UpdateOne(
+		ctx,
+		bson.M{"_id": id, "status": model.UploadSessionCompleting},
+		bson.M{"$set": bson.M{"status": model.UploadSessionActive, "updated_at": time.Now()}},
+	)
+	return err
+}
+
+// Complete
+func (r *uploadSessionRepository) Complete(ctx context.Context, id string, attachment model.AchievementAttachment) error {
+	_, err := r.mongoDB.Collection("upload_sessions").UpdateOne(
+		ctx,
+		bson.M{"_id": id},
+		bson.M{"$set": bson.M{"status": model.UploadSessionCompleted, "attachment": attachment, "updated_at": time.Now()}},
+	)
+	return err
+}
+
+// Delete
+func (r *uploadSessionRepository) Delete(ctx context.Context, id string) error {
+	_, err := r.mongoDB.Collection("upload_sessions").DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
+
+// FindExpired mengambil sesi yang sudah melewati expires_at, baik yang belum selesai maupun yang sudah selesai
+func (r *uploadSessionRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]model.UploadSession, error) {
+	opts := options.Find().SetLimit(int64(limit))
+	cursor, err := r.mongoDB.Collection("upload_sessions").Find(ctx, bson.M{"expires_at": bson.M{"$lt": now}}, opts)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var sessions []model.UploadSession
+	if err := cursor.All(ctx, &sessions); err != nil {
+		return nil, err
+	}
+	return sessions, nil
+}
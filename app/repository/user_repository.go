@@ -11,6 +11,7 @@ import (
 
 type IUserRepository interface {
 	GetAll(ctx context.Context, page, pageSize int, search, sortBy, sortOrder string) ([]model.User, int64, error)
+	GetAllByCursor(ctx context.Context, search, sortBy, sortOrder string, cursor *model.PageCursor, limit int) ([]model.User, model.CursorLinks, error)
 	GetByID(ctx context.Context, id string) (*model.User, error)
 	Create(ctx context.Context, tx *sql.Tx, user *model.User) error
 	Update(ctx context.Context, id string, user *model.User) error
@@ -30,10 +31,28 @@ func NewUserRepository(db *sql.DB) IUserRepository {
 	return &userRepository{db: db}
 }
 
-// GetAllUsers
-func (r *userRepository) GetAll(ctx context.Context, page, pageSize int, search, sortBy, sortOrder string) ([]model.User, int64, error) {
-	offset := (page - 1) * pageSize
+const userListColumns = `
+		SELECT 
+			u.id, u.username, u.email, u.password_hash, u.full_name, 
+			u.role_id, u.is_active, u.created_at, u.updated_at,
+			r.id, r.name,
+			COALESCE(
+				(SELECT array_agg(p.name) 
+				 FROM role_permissions rp 
+				 JOIN permissions p ON rp.permission_id = p.id 
+				 WHERE rp.role_id = r.id), 
+				'{}'
+			) as permissions`
+
+var userSortColumns = map[string]string{
+	"created_at": "u.created_at",
+	"username":   "u.username",
+	"full_name":  "u.full_name",
+	"email":      "u.email",
+}
 
+// userListFilter menyusun FROM/WHERE daftar user aktif beserta argumennya
+func userListFilter(search string) (string, []interface{}, int) {
 	baseQuery := `
 		FROM users u
 		JOIN roles r ON u.role_id = r.id
@@ -48,6 +67,26 @@ func (r *userRepository) GetAll(ctx context.Context, page, pageSize int, search,
 		args = append(args, "%"+search+"%")
 		argCounter++
 	}
+	return baseQuery, args, argCounter
+}
+
+func scanUser(rows *sql.Rows, extra ...interface{}) (model.User, error) {
+	var user model.User
+	dest := []interface{}{
+		&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.FullName,
+		&user.RoleID, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
+		&user.Role.ID, &user.Role.Name,
+		pq.Array(&user.Permissions),
+	}
+	err := rows.Scan(append(dest, extra...)...)
+	return user, err
+}
+
+// GetAllUsers
+func (r *userRepository) GetAll(ctx context.Context, page, pageSize int, search, sortBy, sortOrder string) ([]model.User, int64, error) {
+	offset := (page - 1) * pageSize
+
+	baseQuery, args, argCounter := userListFilter(search)
 
 	countQuery := "SELECT COUNT(*) " + baseQuery
 	var total int64
@@ -56,28 +95,9 @@ func (r *userRepository) GetAll(ctx context.Context, page, pageSize int, search,
 		return nil, 0, err
 	}
 
-	selectQuery := `
-		SELECT 
-			u.id, u.username, u.email, u.password_hash, u.full_name, 
-			u.role_id, u.is_active, u.created_at, u.updated_at,
-			r.id, r.name,
-			COALESCE(
-				(SELECT array_agg(p.name) 
-				 FROM role_permissions rp 
-				 JOIN permissions p ON rp.permission_id = p.id 
-				 WHERE rp.role_id = r.id), 
-				'{}'
-			) as permissions
-	` + baseQuery
-
-	validCols := map[string]string{
-		"created_at": "u.created_at",
-		"username":   "u.username",
-		"full_name":  "u.full_name",
-		"email":      "u.email",
-	}
+	selectQuery := userListColumns + baseQuery
 
-	dbCol, ok := validCols[sortBy]
+	dbCol, ok := userSortColumns[sortBy]
 	if !ok {
 		dbCol = "u.created_at"
 	}
@@ -95,13 +115,7 @@ func (r *userRepository) GetAll(ctx context.Context, page, pageSize int, search,
 
 	var users []model.User
 	for rows.Next() {
-		var user model.User
-		err := rows.Scan(
-			&user.ID, &user.Username, &user.Email, &user.PasswordHash, &user.FullName,
-			&user.RoleID, &user.IsActive, &user.CreatedAt, &user.UpdatedAt,
-			&user.Role.ID, &user.Role.Name,
-			pq.Array(&user.Permissions),
-		)
+		user, err := scanUser(rows)
 		if err != nil {
 			return nil, 0, err
 		}
@@ -111,6 +125,47 @@ func (r *userRepository) GetAll(ctx context.Context, page, pageSize int, search,
 	return users, total, nil
 }
 
+// GetAllUsersByCursor: paginasi keyset tanpa COUNT(*)
+func (r *userRepository) GetAllByCursor(ctx context.Context, search, sortBy, sortOrder string, cursor *model.PageCursor, limit int) ([]model.User, model.CursorLinks, error) {
+	dbCol, ok := userSortColumns[sortBy]
+	if !ok {
+		sortBy, dbCol = "created_at", "u.created_at"
+	}
+	k := keyset{sortBy: sortBy, sortCol: dbCol, idCol: "u.id", order: sortOrder, cursor: cursor, limit: limit}
+
+	baseQuery, args, argCounter := userListFilter(search)
+	clause, cursorArgs, argCounter := k.condition(argCounter)
+	args = append(args, cursorArgs...)
+
+	selectQuery := userListColumns + k.columns() + baseQuery + clause +
+		fmt.Sprintf(" ORDER BY %s LIMIT $%d", k.orderBy(), argCounter)
+	args = append(args, limit+1)
+
+	rows, err := r.db.QueryContext(ctx, selectQuery, args...)
+	if err != nil {
+		return nil, model.CursorLinks{}, err
+	}
+	defer rows.Close()
+
+	var users []model.User
+	var keys []model.PageCursor
+	for rows.Next() {
+		var value, id string
+		user, err := scanUser(rows, &value, &id)
+		if err != nil {
+			return nil, model.CursorLinks{}, err
+		}
+		users = append(users, user)
+		keys = append(keys, k.key(value, id))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, model.CursorLinks{}, err
+	}
+
+	users, links := keysetPage(k, users, keys)
+	return users, links, nil
+}
+
 // GetUserByID 
 func (r *userRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
 	query := `
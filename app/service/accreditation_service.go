@@ -0,0 +1,460 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// maxAccreditationYears membatasi rentang tahun satu laporan akreditasi
+const maxAccreditationYears = 10
+
+// accreditationAcademicTypes adalah jenis prestasi yang dilaporkan di tabel prestasi akademik; jenis
+// lainnya masuk tabel non-akademik
+var accreditationAcademicTypes = map[string]bool{"academic": true, "publication": true}
+
+// Kunci details untuk capaian (juara, peringkat) pada tabel akreditasi
+var accreditationRankKeys = []string{"rank", "peringkat", "juara", "result", "capaian"}
+
+var accreditationCategoryTitles = map[string]string{
+	model.AccreditationAcademic:    "Tabel 8.b.1 Prestasi Akademik Mahasiswa",
+	model.AccreditationNonAcademic: "Tabel 8.b.2 Prestasi Non-akademik Mahasiswa",
+}
+
+// accreditationSheetTitles adalah judul tabel borang pada ekspor; nama sheet XLSX maksimal 31 karakter
+var accreditationSheetTitles = map[string]string{
+	model.AccreditationAcademic:    "8.b.1 Prestasi Akademik",
+	model.AccreditationNonAcademic: "8.b.2 Prestasi Non-akademik",
+}
+
+var accreditationLevelNames = map[string]string{
+	model.LevelInternational: "Internasional",
+	model.LevelNational:      "Nasional",
+	model.LevelRegional:      "Lokal/Wilayah",
+	model.LevelUnknown:       "Tidak diketahui",
+}
+
+type IAccreditationService interface {
+	GetReport(c *fiber.Ctx) error
+	GetEvidence(c *fiber.Ctx) error
+}
+
+type AccreditationService struct {
+	accreditationRepo repository.IAccreditationRepository
+	letterhead        export.Letterhead
+}
+
+func NewAccreditationService(accreditationRepo repository.IAccreditationRepository, letterhead export.Letterhead) IAccreditationService {
+	return &AccreditationService{
+		accreditationRepo: accreditationRepo,
+		letterhead:        letterhead,
+	}
+}
+
+// GetReport godoc
+// @Summary Get accreditation report
+// @Description Count verified achievements of one program study per year (TS-n ... TS) and level (international, national, regional/local) for the academic (Tabel 8.b.1) and non-academic (Tabel 8.b.2) accreditation tables (Admin only). Team achievements are counted once. The year is taken from the event date in the achievement details, or the verification date when missing; the level from details level/tingkat. With format=xlsx the full pack is exported: summary, both tables in the standard format and the evidence list.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce text/csv
+// @Produce application/pdf
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
+// @Security BearerAuth
+// @Param program_study query string true "Program study"
+// @Param year_from query int false "First year (inclusive), default year_to - 2"
+// @Param year_to query int false "Last year (TS, inclusive), default current year"
+// @Param format query string false "Output format; alternatively send an Accept header" Enums(json, csv, xlsx, pdf, docx) default(json)
+// @Success 200 {object} helper.Response{data=model.AccreditationReport} "Accreditation report"
+// @Failure 400 {object} helper.ErrorResponse "Invalid parameter"
+// @Router /reports/accreditation [get]
+func (s *AccreditationService) GetReport(c *fiber.Ctx) error {
+	format, err := exportFormat(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	filter, err := parseAccreditationFilter(c, false)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	items, err := s.achievements(c.Context(), filter)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	report := buildAccreditationReport(filter, items)
+
+	if format != export.FormatJSON {
+		return sendExport(c, format, "akreditasi-"+safeFilename(filter.ProgramStudy), s.exportDocument(filter), accreditationExport(report, items))
+	}
+	return helper.Success(c, "Laporan akreditasi berhasil diambil", report)
+}
+
+// GetEvidence godoc
+// @Summary Get accreditation evidence
+// @Description List the verified achievements behind the accreditation report, with participants and evidence (uploaded files and links), optionally narrowed to one table cell by category, level and year (Admin only).
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce text/csv
+// @Produce application/pdf
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
+// @Security BearerAuth
+// @Param program_study query string true "Program study"
+// @Param year_from query int false "First year (inclusive), default year_to - 2"
+// @Param year_to query int false "Last year (TS, inclusive), default current year"
+// @Param category query string false "Accreditation table" Enums(academic, non_academic)
+// @Param level query string false "Achievement level" Enums(international, national, regional, unknown)
+// @Param year query int false "Only achievements obtained in this year"
+// @Param format query string false "Output format; alternatively send an Accept header" Enums(json, csv, xlsx, pdf, docx) default(json)
+// @Success 200 {object} helper.Response{data=[]model.AccreditationAchievement} "Evidence list"
+// @Failure 400 {object} helper.ErrorResponse "Invalid parameter"
+// @Router /reports/accreditation/evidence [get]
+func (s *AccreditationService) GetEvidence(c *fiber.Ctx) error {
+	format, err := exportFormat(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	filter, err := parseAccreditationFilter(c, true)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	items, err := s.achievements(c.Context(), filter)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	selected := []model.AccreditationAchievement{}
+	for _, a := range items {
+		if (filter.Category == "" || a.Category == filter.Category) &&
+			(filter.Level == "" || a.Level == filter.Level) &&
+			(filter.Year == 0 || a.Year == filter.Year) {
+			selected = append(selected, a)
+		}
+	}
+
+	if format != export.FormatJSON {
+		doc := s.exportDocument(filter)
+		return sendExport(c, format, "bukti-akreditasi-"+safeFilename(filter.ProgramStudy), doc, exportTable("Bukti Prestasi", accreditationEvidenceColumns, accreditationEvidenceRows(selected)))
+	}
+	return helper.Success(c, "Bukti prestasi akreditasi berhasil diambil", selected)
+}
+
+// achievements mengambil prestasi verified program studi lalu mengklasifikasikan kategori, tingkat dan
+// tahun perolehannya. Prestasi di luar rentang tahun dibuang.
+func (s *AccreditationService) achievements(ctx context.Context, f model.AccreditationFilter) ([]model.AccreditationAchievement, error) {
+	since := time.Date(f.YearFrom, 1, 1, 0, 0, 0, 0, time.Local)
+	items, err := s.accreditationRepo.GetVerifiedAchievements(ctx, f.ProgramStudy, since)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []model.AccreditationAchievement
+	for _, a := range items {
+		classifyAccreditation(&a)
+		if a.Year >= f.YearFrom && a.Year <= f.YearTo {
+			result = append(result, a)
+		}
+	}
+	return result, nil
+}
+
+func (s *AccreditationService) exportDocument(f model.AccreditationFilter) export.Document {
+	return export.Document{
+		Title: "Prestasi Mahasiswa untuk Akreditasi",
+		Subtitle: subtitle(
+			"Program Studi "+f.ProgramStudy,
+			fmt.Sprintf("Tahun %d–%d (%s s.d. TS)", f.YearFrom, f.YearTo, accreditationYearLabel(f.YearFrom, f.YearTo)),
+			accreditationDrillLine(f),
+		),
+		Letterhead: s.letterhead,
+	}
+}
+
+func parseAccreditationFilter(c *fiber.Ctx, drill bool) (model.AccreditationFilter, error) {
+	filter := model.AccreditationFilter{ProgramStudy: strings.TrimSpace(c.Query("program_study"))}
+	if filter.ProgramStudy == "" {
+		return filter, model.NewValidationError("program_study wajib diisi")
+	}
+
+	year := func(key string, def int) (int, error) {
+		raw := strings.TrimSpace(c.Query(key))
+		if raw == "" {
+			return def, nil
+		}
+		y, err := strconv.Atoi(raw)
+		if err != nil || y < 1900 || y > 9999 {
+			return 0, model.NewValidationError(key + " harus berupa tahun, misalnya 2024")
+		}
+		return y, nil
+	}
+	var err error
+	if filter.YearTo, err = year("year_to", time.Now().Year()); err != nil {
+		return filter, err
+	}
+	if filter.YearFrom, err = year("year_from", filter.YearTo-2); err != nil {
+		return filter, err
+	}
+	if filter.YearFrom > filter.YearTo {
+		return filter, model.NewValidationError("year_from tidak boleh setelah year_to")
+	}
+	if filter.YearTo-filter.YearFrom >= maxAccreditationYears {
+		return filter, model.NewValidationError(fmt.Sprintf("Rentang tahun maksimal %d tahun", maxAccreditationYears))
+	}
+	if !drill {
+		return filter, nil
+	}
+
+	filter.Category = c.Query("category")
+	if filter.Category != "" && accreditationCategoryTitles[filter.Category] == "" {
+		return filter, model.NewValidationError("category harus academic atau non_academic")
+	}
+	filter.Level = c.Query("level")
+	if filter.Level != "" && accreditationLevelNames[filter.Level] == "" {
+		return filter, model.NewValidationError("level harus salah satu dari international, national, regional, unknown")
+	}
+	if filter.Year, err = year("year", 0); err != nil {
+		return filter, err
+	}
+	if filter.Year != 0 && (filter.Year < filter.YearFrom || filter.Year > filter.YearTo) {
+		return filter, model.NewValidationError("year harus berada di antara year_from dan year_to")
+	}
+	return filter, nil
+}
+
+// classifyAccreditation mengisi kategori, tingkat, capaian, tahun perolehan dan daftar bukti dari details
+// dan lampiran prestasi
+func classifyAccreditation(a *model.AccreditationAchievement) {
+	a.Category = model.AccreditationNonAcademic
+	if accreditationAcademicTypes[a.AchievementType] {
+		a.Category = model.AccreditationAcademic
+	}
+	a.LevelText = detailString(a.Details, skpiLevelKeys)
+	a.Level = accreditationLevel(a.LevelText)
+	a.Rank = detailString(a.Details, accreditationRankKeys)
+	a.EventDate = eventDate(a.Details)
+
+	a.Year = 0
+	if len(a.EventDate) >= 4 {
+		if y, err := strconv.Atoi(a.EventDate[:4]); err == nil && y >= 1900 {
+			a.Year = y
+		}
+	}
+	if a.Year == 0 && a.VerifiedAt != nil {
+		a.Year = a.VerifiedAt.Year()
+	}
+
+	a.Evidence = []model.AccreditationEvidence{}
+	for _, att := range a.Attachments {
+		if att.ScanStatus == model.ScanStatusInfected || att.ScanStatus == model.ScanStatusFailed {
+			continue
+		}
+		if att.IsLink() {
+			name := att.LinkTitle
+			if name == "" {
+				name = att.FileName
+			}
+			a.Evidence = append(a.Evidence, model.AccreditationEvidence{Kind: att.Kind, Name: name, URL: att.FileURL})
+			continue
+		}
+		a.Evidence = append(a.Evidence, model.AccreditationEvidence{Kind: "file", Name: att.FileName, URL: achievementAttachmentURL(a.AchievementID, att.FileName)})
+	}
+}
+
+// accreditationLevel memetakan isian tingkat bebas ke tingkat borang akreditasi
+func accreditationLevel(text string) string {
+	t := strings.ToLower(text)
+	switch {
+	case t == "":
+		return model.LevelUnknown
+	case strings.Contains(t, "internasional"), strings.Contains(t, "international"), strings.Contains(t, "dunia"), strings.Contains(t, "asia"):
+		return model.LevelInternational
+	case strings.Contains(t, "nasional"), strings.Contains(t, "national"):
+		return model.LevelNational
+	}
+	for _, w := range []string{"regional", "wilayah", "provinsi", "lokal", "local", "kota", "kabupaten", "daerah", "universitas", "kampus", "fakultas"} {
+		if strings.Contains(t, w) {
+			return model.LevelRegional
+		}
+	}
+	return model.LevelUnknown
+}
+
+// accreditationYearLabel menamai tahun relatif terhadap TS (tahun terakhir rentang)
+func accreditationYearLabel(year, ts int) string {
+	if year == ts {
+		return "TS"
+	}
+	return fmt.Sprintf("TS-%d", ts-year)
+}
+
+func accreditationDrillLine(f model.AccreditationFilter) string {
+	var parts []string
+	if f.Category != "" {
+		parts = append(parts, accreditationCategoryTitles[f.Category])
+	}
+	if f.Level != "" {
+		parts = append(parts, "Tingkat "+accreditationLevelNames[f.Level])
+	}
+	if f.Year != 0 {
+		parts = append(parts, "Tahun "+strconv.Itoa(f.Year))
+	}
+	return strings.Join(parts, " · ")
+}
+
+func addLevel(c *model.AccreditationLevelCount, level string) {
+	switch level {
+	case model.LevelInternational:
+		c.International++
+	case model.LevelNational:
+		c.National++
+	case model.LevelRegional:
+		c.Regional++
+	default:
+		c.Unknown++
+	}
+	c.Total++
+}
+
+// buildAccreditationReport menghitung prestasi per kategori, tahun dan tingkat. Setiap tahun dalam
+// rentang selalu muncul walaupun kosong.
+func buildAccreditationReport(f model.AccreditationFilter, items []model.AccreditationAchievement) *model.AccreditationReport {
+	report := &model.AccreditationReport{
+		ProgramStudy: f.ProgramStudy,
+		YearFrom:     f.YearFrom,
+		YearTo:       f.YearTo,
+		Achievements: len(items),
+		GeneratedAt:  time.Now(),
+	}
+	for _, category := range []string{model.AccreditationAcademic, model.AccreditationNonAcademic} {
+		summary := model.AccreditationSummary{Category: category, Title: accreditationCategoryTitles[category]}
+		for y := f.YearFrom; y <= f.YearTo; y++ {
+			summary.Years = append(summary.Years, model.AccreditationYear{Year: y, Label: accreditationYearLabel(y, f.YearTo)})
+		}
+		for _, a := range items {
+			if a.Category != category {
+				continue
+			}
+			addLevel(&summary.Years[a.Year-f.YearFrom].AccreditationLevelCount, a.Level)
+			addLevel(&summary.Total, a.Level)
+		}
+		report.Summaries = append(report.Summaries, summary)
+	}
+	for _, a := range items {
+		if a.Level == model.LevelUnknown {
+			report.Unclassified++
+		}
+	}
+	return report
+}
+
+// Kolom tabel borang: tingkat ditandai "V" pada kolomnya
+var accreditationTableColumns = []export.Column{
+	{Header: "No", Width: 5, Align: export.AlignRight},
+	{Header: "Nama Kegiatan", Width: 40},
+	{Header: "Waktu Perolehan (YYYY)", Width: 12, Align: export.AlignCenter},
+	{Header: "Lokal/Wilayah", Width: 10, Align: export.AlignCenter},
+	{Header: "Nasional", Width: 10, Align: export.AlignCenter},
+	{Header: "Internasional", Width: 10, Align: export.AlignCenter},
+	{Header: "Prestasi yang Dicapai", Width: 20},
+	{Header: "Mahasiswa", Width: 30},
+}
+
+var accreditationEvidenceColumns = []export.Column{
+	{Header: "Kategori", Width: 14},
+	{Header: "Tahun", Width: 8, Align: export.AlignRight},
+	{Header: "Tingkat", Width: 14},
+	{Header: "Isian Tingkat", Width: 14},
+	{Header: "Judul", Width: 36},
+	{Header: "Jenis", Width: 14},
+	{Header: "NIM", Width: 14},
+	{Header: "Mahasiswa", Width: 24},
+	{Header: "Tanggal Kegiatan", Width: 12},
+	{Header: "Diverifikasi", Width: 16},
+	{Header: "Bukti", Width: 50},
+	{Header: "ID Prestasi", Width: 38},
+}
+
+func accreditationEvidenceRows(items []model.AccreditationAchievement) [][]interface{} {
+	rows := make([][]interface{}, len(items))
+	for i, a := range items {
+		evidence := make([]string, len(a.Evidence))
+		for j, e := range a.Evidence {
+			evidence[j] = e.Name + " (" + e.URL + ")"
+		}
+		category := "Akademik"
+		if a.Category == model.AccreditationNonAcademic {
+			category = "Non-akademik"
+		}
+		rows[i] = []interface{}{
+			category, a.Year, accreditationLevelNames[a.Level], a.LevelText, a.Title, a.AchievementType,
+			strings.Join(a.StudentIDs, "\n"), strings.Join(a.StudentNames, "\n"), a.EventDate, a.VerifiedAt,
+			strings.Join(evidence, "\n"), a.AchievementID,
+		}
+	}
+	return rows
+}
+
+// accreditationExport menulis paket akreditasi: ringkasan per tahun dan tingkat, tabel 8.b.1 dan 8.b.2
+// dalam format borang, lalu daftar bukti (satu sheet per tabel di XLSX)
+func accreditationExport(report *model.AccreditationReport, items []model.AccreditationAchievement) func(ctx context.Context, w export.Writer) error {
+	return func(ctx context.Context, w export.Writer) error {
+		var summary [][]interface{}
+		for _, s := range report.Summaries {
+			category := accreditationSheetTitles[s.Category]
+			for _, y := range s.Years {
+				summary = append(summary, []interface{}{category, y.Label, y.Year, y.Regional, y.National, y.International, y.Unknown, y.Total})
+			}
+			summary = append(summary, []interface{}{category, "Jumlah", nil, s.Total.Regional, s.Total.National, s.Total.International, s.Total.Unknown, s.Total.Total})
+		}
+		err := writeTable(w, "Ringkasan", []export.Column{
+			{Header: "Tabel", Width: 40},
+			{Header: "TS", Width: 8},
+			{Header: "Tahun", Width: 8, Align: export.AlignRight},
+			{Header: "Lokal/Wilayah", Width: 12, Align: export.AlignRight},
+			{Header: "Nasional", Width: 10, Align: export.AlignRight},
+			{Header: "Internasional", Width: 12, Align: export.AlignRight},
+			{Header: "Tingkat Tidak Diketahui", Width: 12, Align: export.AlignRight},
+			{Header: "Jumlah", Width: 10, Align: export.AlignRight},
+		}, summary)
+		if err != nil {
+			return err
+		}
+
+		for _, category := range []string{model.AccreditationAcademic, model.AccreditationNonAcademic} {
+			var rows [][]interface{}
+			for _, a := range items {
+				if a.Category != category {
+					continue
+				}
+				mark := func(level string) string {
+					if a.Level == level {
+						return "V"
+					}
+					return ""
+				}
+				rows = append(rows, []interface{}{
+					len(rows) + 1, a.Title, a.Year,
+					mark(model.LevelRegional), mark(model.LevelNational), mark(model.LevelInternational),
+					a.Rank, strings.Join(a.StudentNames, ", "),
+				})
+			}
+			if err := writeTable(w, accreditationSheetTitles[category], accreditationTableColumns, rows); err != nil {
+				return err
+			}
+		}
+
+		return writeTable(w, "Bukti Prestasi", accreditationEvidenceColumns, accreditationEvidenceRows(items))
+	}
+}
@@ -0,0 +1,195 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MockAccreditationRepository struct {
+	achievements []model.AccreditationAchievement
+	lastProgram  string
+	lastSince    time.Time
+}
+
+func (m *MockAccreditationRepository) GetVerifiedAchievements(ctx context.Context, programStudy string, verifiedSince time.Time) ([]model.AccreditationAchievement, error) {
+	m.lastProgram, m.lastSince = programStudy, verifiedSince
+	return m.achievements, nil
+}
+
+func accreditationTestAchievements() []model.AccreditationAchievement {
+	verified := time.Date(2024, 2, 10, 9, 0, 0, 0, time.UTC)
+	return []model.AccreditationAchievement{
+		{
+			AchievementID: "a1", AchievementType: "competition", Title: "Juara 1 Gemastik",
+			StudentIDs: []string{"2101001", "2101002"}, StudentNames: []string{"Ani", "Budi"},
+			Details: map[string]interface{}{"tingkat": "Nasional", "event_date": "2023-10-12", "juara": "Juara 1"},
+			Attachments: []model.AchievementAttachment{
+				{FileName: "sertifikat.pdf"},
+				{Kind: model.AttachmentKindLink, FileURL: "https://gemastik.example.id/hasil", LinkTitle: "Pengumuman Pemenang"},
+				{FileName: "virus.exe", ScanStatus: model.ScanStatusInfected},
+			},
+		},
+		{AchievementID: "a2", AchievementType: "competition", Title: "Best Paper ICoICT", Details: map[string]interface{}{"level": "International", "event_date": "2024-05-01"}, StudentNames: []string{"Ani"}},
+		{AchievementID: "a3", AchievementType: "publication", Title: "Artikel Jurnal Sinta 2", VerifiedAt: &verified, StudentNames: []string{"Citra"}},
+		{AchievementID: "a4", AchievementType: "organization", Title: "Ketua BEM", Details: map[string]interface{}{"tingkat": "Universitas", "event_date": "2022-03-01"}, StudentNames: []string{"Dedi"}},
+		{AchievementID: "a5", AchievementType: "competition", Title: "Lomba Lama", Details: map[string]interface{}{"tingkat": "Nasional", "event_date": "2020-08-17"}},
+	}
+}
+
+func TestAccreditationService_GetReport(t *testing.T) {
+	mockRepo := &MockAccreditationRepository{achievements: accreditationTestAchievements()}
+	service := NewAccreditationService(mockRepo, export.Letterhead{})
+
+	app := fiber.New()
+	app.Get("/reports/accreditation", service.GetReport)
+
+	t.Run("GET - Counts Per Year And Level", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/reports/accreditation?program_study=Informatika&year_to=2024", nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d", resp.StatusCode)
+		}
+		if mockRepo.lastProgram != "Informatika" || mockRepo.lastSince.Year() != 2022 {
+			t.Errorf("Unexpected repository arguments %s %v", mockRepo.lastProgram, mockRepo.lastSince)
+		}
+
+		var body struct {
+			Data model.AccreditationReport `json:"data"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		report := body.Data
+		if report.YearFrom != 2022 || report.Achievements != 4 || report.Unclassified != 1 {
+			t.Errorf("Unexpected report totals %+v", report)
+		}
+
+		academic, nonAcademic := report.Summaries[0], report.Summaries[1]
+		if academic.Category != model.AccreditationAcademic || academic.Total.Unknown != 1 || academic.Years[2].Total != 1 {
+			t.Errorf("Unexpected academic summary %+v", academic)
+		}
+		labels := []string{nonAcademic.Years[0].Label, nonAcademic.Years[1].Label, nonAcademic.Years[2].Label}
+		if strings.Join(labels, ",") != "TS-2,TS-1,TS" {
+			t.Errorf("Unexpected year labels %v", labels)
+		}
+		if y := nonAcademic.Years; y[0].Regional != 1 || y[1].National != 1 || y[2].International != 1 || nonAcademic.Total.Total != 3 {
+			t.Errorf("Unexpected non-academic summary %+v", nonAcademic)
+		}
+	})
+
+	t.Run("GET - XLSX Pack", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/reports/accreditation?program_study=Informatika&year_from=2022&year_to=2024&format=xlsx", nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			t.Fatalf("Not a zip file: %v", err)
+		}
+		files := map[string]string{}
+		for _, f := range zr.File {
+			rc, _ := f.Open()
+			b, _ := io.ReadAll(rc)
+			rc.Close()
+			files[f.Name] = string(b)
+		}
+		for _, want := range []string{`name="Ringkasan"`, `name="8.b.1 Prestasi Akademik"`, `name="8.b.2 Prestasi Non-akademik"`, `name="Bukti Prestasi"`} {
+			if !strings.Contains(files["xl/workbook.xml"], want) {
+				t.Errorf("Workbook is missing sheet %s", want)
+			}
+		}
+		if sheet := files["xl/worksheets/sheet3.xml"]; !strings.Contains(sheet, "Juara 1 Gemastik") || !strings.Contains(sheet, ">Ani, Budi<") {
+			t.Error("Expected the non-academic table to list the team achievement once")
+		}
+		if !strings.Contains(files["xl/worksheets/sheet4.xml"], "https://gemastik.example.id/hasil") {
+			t.Error("Expected evidence links in the evidence sheet")
+		}
+	})
+
+	t.Run("GET - Invalid Parameters", func(t *testing.T) {
+		for _, query := range []string{
+			"year_to=2024",
+			"program_study=Informatika&year_from=2025&year_to=2024",
+			"program_study=Informatika&year_from=2010&year_to=2024",
+			"program_study=Informatika&year_to=duaribu",
+		} {
+			resp, _ := app.Test(httptest.NewRequest("GET", "/reports/accreditation?"+query, nil))
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("Expected 400 for %s, got %d", query, resp.StatusCode)
+			}
+		}
+	})
+}
+
+func TestAccreditationService_GetEvidence(t *testing.T) {
+	mockRepo := &MockAccreditationRepository{achievements: accreditationTestAchievements()}
+	service := NewAccreditationService(mockRepo, export.Letterhead{})
+
+	app := fiber.New()
+	app.Get("/reports/accreditation/evidence", service.GetEvidence)
+
+	t.Run("GET - Drill Down To One Cell", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/reports/accreditation/evidence?program_study=Informatika&year_to=2024&category=non_academic&level=national&year=2023", nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		var body struct {
+			Data []model.AccreditationAchievement `json:"data"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		if len(body.Data) != 1 || body.Data[0].AchievementID != "a1" {
+			t.Fatalf("Expected only the 2023 national achievement, got %+v", body.Data)
+		}
+
+		a := body.Data[0]
+		if a.Rank != "Juara 1" || a.LevelText != "Nasional" {
+			t.Errorf("Unexpected classification %+v", a)
+		}
+		want := []model.AccreditationEvidence{
+			{Kind: "file", Name: "sertifikat.pdf", URL: "/api/v1/achievements/a1/attachments/sertifikat.pdf"},
+			{Kind: model.AttachmentKindLink, Name: "Pengumuman Pemenang", URL: "https://gemastik.example.id/hasil"},
+		}
+		if len(a.Evidence) != len(want) || a.Evidence[0] != want[0] || a.Evidence[1] != want[1] {
+			t.Errorf("Expected infected files to be left out of the evidence, got %+v", a.Evidence)
+		}
+	})
+
+	t.Run("GET - Invalid Drill Down", func(t *testing.T) {
+		for _, query := range []string{"category=sports", "level=galaxy", "year=2019"} {
+			resp, _ := app.Test(httptest.NewRequest("GET", "/reports/accreditation/evidence?program_study=Informatika&year_to=2024&"+query, nil))
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("Expected 400 for %s, got %d", query, resp.StatusCode)
+			}
+		}
+	})
+}
+
+func TestAccreditationLevel(t *testing.T) {
+	for text, want := range map[string]string{
+		"Internasional":       model.LevelInternational,
+		"Nasional":            model.LevelNational,
+		"national":            model.LevelNational,
+		"Provinsi Jawa Timur": model.LevelRegional,
+		"Lokal (universitas)": model.LevelRegional,
+		"Asia Pasifik":        model.LevelInternational,
+		"":                    model.LevelUnknown,
+		"tingkat tidak jelas": model.LevelUnknown,
+	} {
+		if got := accreditationLevel(text); got != want {
+			t.Errorf("accreditationLevel(%q) = %s, want %s", text, got, want)
+		}
+	}
+}
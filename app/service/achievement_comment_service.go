@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const maxCommentAttachments = 3
+
+type IAchievementCommentService interface {
+	List(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	DownloadAttachment(c *fiber.Ctx) error
+}
+
+type AchievementCommentService struct {
+	commentRepo repository.IAchievementCommentRepository
+	achRepo     repository.IAchievementRepository
+	memberRepo  repository.IAchievementMemberRepository
+	studentRepo repository.IStudentRepository
+	lecturerSvc ILecturerService
+	store       storage.Storage
+}
+
+func NewAchievementCommentService(
+	commentRepo repository.IAchievementCommentRepository,
+	achRepo repository.IAchievementRepository,
+	memberRepo repository.IAchievementMemberRepository,
+	studentRepo repository.IStudentRepository,
+	lecturerSvc ILecturerService,
+	store storage.Storage,
+) IAchievementCommentService {
+	return &AchievementCommentService{
+		commentRepo: commentRepo,
+		achRepo:     achRepo,
+		memberRepo:  memberRepo,
+		studentRepo: studentRepo,
+		lecturerSvc: lecturerSvc,
+		store:       store,
+	}
+}
+
+// buildCommentThreads menyusun komentar datar menjadi pohon balasan
+func buildCommentThreads(comments []model.AchievementComment) model.CommentThreads {
+	children := make(map[string][]model.AchievementComment)
+	known := make(map[string]bool, len(comments))
+	for _, cm := range comments {
+		known[cm.ID.Hex()] = true
+	}
+
+	result := model.CommentThreads{Comments: []model.AchievementComment{}}
+	var roots []model.AchievementComment
+	for _, cm := range comments {
+		if cm.Kind == model.CommentKindClarification && !cm.Resolved {
+			result.OpenClarifications++
+		}
+		if cm.ParentID != "" && known[cm.ParentID] {
+			children[cm.ParentID] = append(children[cm.ParentID], cm)
+			continue
+		}
+		roots = append(roots, cm)
+	}
+
+	var attach func(cm model.AchievementComment) model.AchievementComment
+	attach = func(cm model.AchievementComment) model.AchievementComment {
+		for _, child := range children[cm.ID.Hex()] {
+			cm.Replies = append(cm.Replies, attach(child))
+		}
+		return cm
+	}
+	for _, root := range roots {
+		result.Comments = append(result.Comments, attach(root))
+	}
+
+	return result
+}
+
+// List godoc
+// @Summary List achievement comments
+// @Description Get the discussion thread of an achievement (owner, team members, advisor, Admin)
+// @Tags Achievement Comments
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Success 200 {object} helper.Response{data=model.CommentThreads} "Comments retrieved"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Router /achievements/{id}/comments [get]
+func (s *AchievementCommentService) List(c *fiber.Ctx) error {
+	id := c.Params("id")
+	userID := c.Locals("user_id").(string)
+	roleName := c.Locals("role").(string)
+
+	detail, err := getDetailWithMembers(c.Context(), s.achRepo, s.memberRepo, id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if detail == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Prestasi tidak ditemukan"))
+	}
+	if err := authorizeAchievementView(c.Context(), s.studentRepo, s.lecturerSvc, userID, roleName, detail); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	comments, err := s.commentRepo.GetByAchievementID(c.Context(), detail.ID)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	for i := range comments {
+		setCommentAttachmentURLs(&comments[i])
+	}
+
+	return helper.Success(c, "Diskusi prestasi berhasil diambil", buildCommentThreads(comments))
+}
+
+// Create godoc
+// @Summary Post achievement comment
+// @Description Post a comment or reply on an achievement in any status. Advisors and Admin may post a clarification request; a student reply to it marks the request as resolved.
+// @Tags Achievement Comments
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param body formData string true "Comment text"
+// @Param parent_id formData string false "Parent comment ID (reply)"
+// @Param kind formData string false "comment | clarification_request"
+// @Param files formData file false "Optional attachments (PDF/Image, max 5MB each)"
+// @Success 201 {object} helper.Response{data=model.AchievementComment} "Comment posted"
+// @Failure 400 {object} helper.ErrorResponse "Invalid request"
+// @Router /achievements/{id}/comments [post]
+func (s *AchievementCommentService) Create(c *fiber.Ctx) error {
+	id := c.Params("id")
+	userID := c.Locals("user_id").(string)
+	roleName := c.Locals("role").(string)
+	username, _ := c.Locals("username").(string)
+
+	var req model.CreateCommentRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Format request tidak valid", nil)
+	}
+
+	req.Body = strings.TrimSpace(req.Body)
+	if req.Body == "" {
+		return helper.HandleError(c, model.NewValidationError("Isi komentar wajib diisi"))
+	}
+	if req.Kind == "" {
+		req.Kind = model.CommentKindComment
+	}
+	if req.Kind != model.CommentKindComment && req.Kind != model.CommentKindClarification {
+		return helper.HandleError(c, model.NewValidationError("kind harus comment atau clarification_request"))
+	}
+	if req.Kind == model.CommentKindClarification && roleName == "Mahasiswa" {
+		return helper.HandleError(c, model.NewValidationError("Hanya Dosen Wali atau Admin yang dapat meminta klarifikasi"))
+	}
+
+	detail, err := getDetailWithMembers(c.Context(), s.achRepo, s.memberRepo, id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if detail == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Prestasi tidak ditemukan"))
+	}
+	if err := authorizeAchievementView(c.Context(), s.studentRepo, s.lecturerSvc, userID, roleName, detail); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	var parent *model.AchievementComment
+	if req.ParentID != "" {
+		parent, err = s.commentRepo.GetByID(c.Context(), req.ParentID)
+		if err != nil {
+			return helper.HandleError(c, model.ErrDatabaseError)
+		}
+		if parent == nil || parent.AchievementID != detail.ID {
+			return helper.HandleError(c, model.NewNotFoundError("Komentar induk tidak ditemukan"))
+		}
+	}
+
+	comment := &model.AchievementComment{
+		AchievementID: detail.ID,
+		ParentID:      req.ParentID,
+		AuthorID:      userID,
+		AuthorName:    username,
+		AuthorRole:    roleName,
+		Kind:          req.Kind,
+		Body:          req.Body,
+		Attachments:   []model.AchievementAttachment{},
+		CreatedAt:     time.Now(),
+	}
+
+	if form, err := c.MultipartForm(); err == nil {
+		files := form.File["files"]
+		if len(files) > maxCommentAttachments {
+			return helper.HandleError(c, model.NewValidationError(fmt.Sprintf("Maksimal %d lampiran per komentar", maxCommentAttachments)))
+		}
+
+		// Semua file diperiksa dulu agar tidak ada file tersimpan bila salah satunya ditolak
+		sanitized := make([]*helper.SanitizedFile, 0, len(files))
+		for _, fileHeader := range files {
+			file, err := helper.SanitizeUpload(fileHeader, helper.DefaultFilePolicy())
+			if err != nil {
+				return helper.HandleError(c, err)
+			}
+			sanitized = append(sanitized, file)
+		}
+
+		for i, file := range sanitized {
+			filename := fmt.Sprintf("CMT-%s-%d-%d%s", detail.ID, time.Now().Unix(), i, file.Extension)
+			key := model.QuarantinePrefix + "comments/" + filename
+			contentHash, err := helper.SaveSanitizedFile(c.Context(), s.store, key, file)
+			if err != nil {
+				s.deleteCommentFiles(c.Context(), comment.Attachments)
+				return helper.HandleError(c, model.ErrDatabaseError)
+			}
+
+			comment.Attachments = append(comment.Attachments, model.AchievementAttachment{
+				ID:          uuid.New().String(),
+				FileName:    filename,
+				StorageKey:  key,
+				FileType:    file.ContentType,
+				ContentHash: contentHash,
+				Size:        int64(len(file.Data)),
+				ScanStatus:  model.ScanStatusPending,
+				UploadedAt:  time.Now(),
+			})
+		}
+	}
+
+	if err := s.commentRepo.Create(c.Context(), comment); err != nil {
+		s.deleteCommentFiles(c.Context(), comment.Attachments)
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	// Balasan mahasiswa atas permintaan klarifikasi menandai permintaan tersebut selesai
+	if parent != nil && parent.Kind == model.CommentKindClarification && !parent.Resolved && roleName == "Mahasiswa" {
+		if err := s.commentRepo.MarkResolved(c.Context(), parent.ID.Hex()); err != nil {
+			return helper.HandleError(c, model.ErrDatabaseError)
+		}
+	}
+
+	setCommentAttachmentURLs(comment)
+
+	return helper.Created(c, "Komentar berhasil dikirim", comment)
+}
+
+// deleteCommentFiles menghapus file lampiran yang sudah tersimpan ketika komentarnya gagal disimpan
+func (s *AchievementCommentService) deleteCommentFiles(ctx context.Context, attachments []model.AchievementAttachment) {
+	for _, a := range attachments {
+		deleteStoredFile(ctx, s.store, a.StorageKey)
+	}
+}
+
+// DownloadAttachment godoc
+// @Summary Download comment attachment
+// @Description Stream a file attached to a comment (same visibility as the achievement)
+// @Tags Achievement Comments
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param commentId path string true "Comment ID"
+// @Param fileName path string true "Attachment file name"
+// @Success 200 {file} file "Attachment content"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Router /achievements/{id}/comments/{commentId}/attachments/{fileName} [get]
+func (s *AchievementCommentService) DownloadAttachment(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+	roleName := c.Locals("role").(string)
+
+	detail, err := getDetailWithMembers(c.Context(), s.achRepo, s.memberRepo, c.Params("id"))
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if detail == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Prestasi tidak ditemukan"))
+	}
+	if err := authorizeAchievementView(c.Context(), s.studentRepo, s.lecturerSvc, userID, roleName, detail); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	comment, err := s.commentRepo.GetByID(c.Context(), c.Params("commentId"))
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if comment == nil || comment.AchievementID != detail.ID {
+		return helper.HandleError(c, model.NewNotFoundError("Komentar tidak ditemukan"))
+	}
+
+	fileName, err := url.PathUnescape(c.Params("fileName"))
+	if err != nil {
+		return helper.HandleError(c, model.NewNotFoundError("Lampiran tidak ditemukan"))
+	}
+	attachment := findAttachment(comment.Attachments, fileName)
+	if attachment == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Lampiran tidak ditemukan"))
+	}
+	if err := attachmentAccessError(*attachment); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	return serveVerifiedAttachment(c, s.store, *attachment, "comments/")
+}
+
+func setCommentAttachmentURLs(comment *model.AchievementComment) {
+	for i := range comment.Attachments {
+		comment.Attachments[i].FileURL = commentAttachmentURL(comment.AchievementID, comment.ID.Hex(), comment.Attachments[i].FileName)
+		setPreviewURLs(&comment.Attachments[i])
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"image"
+	"image/png"
+	"mime/multipart"
+	"net/http/httptest"
+	"testing"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestAchievementCommentService_Create(t *testing.T) {
+	studentUUID := uuid.New()
+	lecturerID := "dosen-1"
+	studentUserID, lecturerUserID, otherUserID := "user-mhs-1", "user-dosen-1", "user-mhs-2"
+
+	mockAchRepo := &MockAchievementRepository{
+		achRefs: make(map[string]*model.AchievementReference),
+		achDetail: &model.AchievementDetailDTO{
+			ID:      "ach-1",
+			Student: model.StudentListDTO{ID: studentUUID, AdvisorID: &lecturerID},
+			Status:  "submitted",
+		},
+	}
+	mockStudentRepo := &MockStudentRepository{
+		students: map[string]*model.StudentInfo{
+			studentUserID: {ID: studentUUID.String()},
+			otherUserID:   {ID: uuid.New().String()},
+		},
+	}
+	mockCommentRepo := &MockAchievementCommentRepository{}
+	store := storage.NewLocal(t.TempDir())
+	service := NewAchievementCommentService(mockCommentRepo, mockAchRepo, newMockAchievementMemberRepository(),
+		mockStudentRepo, &MockLecturerService{lecturerInfo: &model.LecturerInfo{ID: lecturerID}}, store)
+
+	post := func(userID, role string, reqBody model.CreateCommentRequest) int {
+		app := fiber.New()
+		app.Post("/achievements/:id/comments", func(c *fiber.Ctx) error {
+			c.Locals("user_id", userID)
+			c.Locals("role", role)
+			return service.Create(c)
+		})
+
+		body, _ := json.Marshal(reqBody)
+		req := httptest.NewRequest("POST", "/achievements/ach-1/comments", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	t.Run("POST - Advisor Requests Clarification", func(t *testing.T) {
+		status := post(lecturerUserID, "Dosen Wali", model.CreateCommentRequest{
+			Body: "Mohon lampirkan sertifikat asli", Kind: model.CommentKindClarification,
+		})
+		if status != fiber.StatusCreated {
+			t.Errorf("Expected 201 status, got %d", status)
+		}
+	})
+
+	t.Run("POST - Student Cannot Request Clarification", func(t *testing.T) {
+		status := post(studentUserID, "Mahasiswa", model.CreateCommentRequest{
+			Body: "Tes", Kind: model.CommentKindClarification,
+		})
+		if status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", status)
+		}
+	})
+
+	t.Run("POST - Unrelated Student Forbidden", func(t *testing.T) {
+		status := post(otherUserID, "Mahasiswa", model.CreateCommentRequest{Body: "Halo"})
+		if status == fiber.StatusCreated {
+			t.Errorf("Expected error status, got %d", status)
+		}
+	})
+
+	t.Run("POST - Student Reply Resolves Clarification", func(t *testing.T) {
+		parentID := mockCommentRepo.comments[0].ID.Hex()
+		status := post(studentUserID, "Mahasiswa", model.CreateCommentRequest{
+			Body: "Sertifikat sudah saya unggah", ParentID: parentID,
+		})
+		if status != fiber.StatusCreated {
+			t.Errorf("Expected 201 status, got %d", status)
+		}
+		if !mockCommentRepo.comments[0].Resolved {
+			t.Errorf("Expected clarification request to be resolved")
+		}
+
+		comments, _ := mockCommentRepo.GetByAchievementID(context.Background(), "ach-1")
+		threads := buildCommentThreads(comments)
+		if len(threads.Comments) != 1 || len(threads.Comments[0].Replies) != 1 {
+			t.Errorf("Expected one thread with one reply, got %+v", threads.Comments)
+		}
+		if threads.OpenClarifications != 0 {
+			t.Errorf("Expected no open clarifications, got %d", threads.OpenClarifications)
+		}
+	})
+
+	t.Run("POST - Stored Files Removed When Comment Insert Fails", func(t *testing.T) {
+		mockCommentRepo.createErr = errors.New("mongo tidak tersedia")
+		defer func() { mockCommentRepo.createErr = nil }()
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		writer.WriteField("body", "Berikut sertifikatnya")
+		part, _ := writer.CreateFormFile("files", "sertifikat.png")
+		png.Encode(part, image.NewRGBA(image.Rect(0, 0, 4, 4)))
+		writer.Close()
+
+		app := fiber.New()
+		app.Post("/achievements/:id/comments", func(c *fiber.Ctx) error {
+			c.Locals("user_id", studentUserID)
+			c.Locals("role", "Mahasiswa")
+			return service.Create(c)
+		})
+		req := httptest.NewRequest("POST", "/achievements/ach-1/comments", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusInternalServerError {
+			t.Errorf("Expected 500 status, got %d", resp.StatusCode)
+		}
+
+		var stored []string
+		store.List(context.Background(), model.QuarantinePrefix+"comments/", func(info storage.ObjectInfo) error {
+			stored = append(stored, info.Key)
+			return nil
+		})
+		if len(stored) != 0 {
+			t.Errorf("Expected no orphaned files, got %v", stored)
+		}
+	})
+}
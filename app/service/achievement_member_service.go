@@ -0,0 +1,340 @@
+package service
+
+import (
+	"strings"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type IAchievementMemberService interface {
+	Invite(c *fiber.Ctx) error
+	Remove(c *fiber.Ctx) error
+	Respond(c *fiber.Ctx) error
+	VerifyMember(c *fiber.Ctx) error
+	RejectMember(c *fiber.Ctx) error
+}
+
+type AchievementMemberService struct {
+	achRepo     repository.IAchievementRepository
+	memberRepo  repository.IAchievementMemberRepository
+	studentRepo repository.IStudentRepository
+	lecturerSvc ILecturerService
+}
+
+func NewAchievementMemberService(
+	achRepo repository.IAchievementRepository,
+	memberRepo repository.IAchievementMemberRepository,
+	studentRepo repository.IStudentRepository,
+	lecturerSvc ILecturerService,
+) IAchievementMemberService {
+	return &AchievementMemberService{
+		achRepo:     achRepo,
+		memberRepo:  memberRepo,
+		studentRepo: studentRepo,
+		lecturerSvc: lecturerSvc,
+	}
+}
+
+// Invite godoc
+// @Summary Invite team members
+// @Description Invite co-members (by NIM) to a draft team achievement (Owner only)
+// @Tags Achievement Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param request body model.InviteMembersRequest true "Student numbers (NIM)"
+// @Success 200 {object} helper.Response{data=[]model.AchievementMember} "Members invited"
+// @Failure 400 {object} helper.ErrorResponse "Invalid request"
+// @Router /achievements/{id}/members [post]
+func (s *AchievementMemberService) Invite(c *fiber.Ctx) error {
+	id := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	var req model.InviteMembersRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Format request tidak valid", nil)
+	}
+	if len(req.StudentIDs) == 0 {
+		return helper.HandleError(c, model.NewValidationError("student_ids wajib diisi"))
+	}
+
+	achRef, err := s.achRepo.GetRefByID(c.Context(), id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if achRef == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Prestasi tidak ditemukan"))
+	}
+
+	owner, err := s.studentRepo.GetByUserID(c.Context(), userID)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if owner == nil || achRef.StudentID != owner.ID {
+		return helper.HandleError(c, model.NewValidationError("Hanya pembuat prestasi yang dapat mengundang anggota tim"))
+	}
+
+	if achRef.Status != "draft" {
+		return helper.HandleError(c, model.NewValidationError("Anggota tim hanya dapat diubah saat prestasi berstatus Draft."))
+	}
+
+	var studentIDs []string
+	seen := make(map[string]bool)
+	for _, nim := range req.StudentIDs {
+		nim = strings.TrimSpace(nim)
+		if nim == "" || seen[nim] {
+			continue
+		}
+		seen[nim] = true
+
+		member, err := s.studentRepo.GetByStudentNumber(c.Context(), nim)
+		if err != nil {
+			return helper.HandleError(c, model.ErrDatabaseError)
+		}
+		if member == nil {
+			return helper.HandleError(c, model.NewValidationError("Mahasiswa dengan NIM "+nim+" tidak ditemukan"))
+		}
+		if member.ID == owner.ID {
+			return helper.HandleError(c, model.NewValidationError("Pembuat prestasi sudah otomatis menjadi anggota tim"))
+		}
+		studentIDs = append(studentIDs, member.ID)
+	}
+
+	if err := s.memberRepo.AddMembers(c.Context(), id, studentIDs); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	members, err := s.memberRepo.GetByAchievementID(c.Context(), id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Success(c, "Undangan anggota tim berhasil dikirim", members)
+}
+
+// Remove godoc
+// @Summary Remove team member
+// @Description Remove a co-member from a draft team achievement (Owner only)
+// @Tags Achievement Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param memberId path string true "Member ID"
+// @Success 200 {object} helper.Response "Member removed"
+// @Failure 400 {object} helper.ErrorResponse "Can only change members of draft achievements"
+// @Router /achievements/{id}/members/{memberId} [delete]
+func (s *AchievementMemberService) Remove(c *fiber.Ctx) error {
+	id := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	achRef, err := s.achRepo.GetRefByID(c.Context(), id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if achRef == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Prestasi tidak ditemukan"))
+	}
+
+	owner, err := s.studentRepo.GetByUserID(c.Context(), userID)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if owner == nil || achRef.StudentID != owner.ID {
+		return helper.HandleError(c, model.NewValidationError("Hanya pembuat prestasi yang dapat mengubah anggota tim"))
+	}
+
+	if achRef.Status != "draft" {
+		return helper.HandleError(c, model.NewValidationError("Anggota tim hanya dapat diubah saat prestasi berstatus Draft."))
+	}
+
+	member, err := s.memberRepo.GetByID(c.Context(), c.Params("memberId"))
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if member == nil || member.AchievementID != id {
+		return helper.HandleError(c, model.NewNotFoundError("Anggota tim tidak ditemukan"))
+	}
+
+	if err := s.memberRepo.Remove(c.Context(), member.ID); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Success(c, "Anggota tim berhasil dihapus", nil)
+}
+
+// Respond godoc
+// @Summary Respond to team invitation
+// @Description Confirm or decline participation in a team achievement (Invited student only)
+// @Tags Achievement Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param request body model.RespondInvitationRequest true "Accept or decline"
+// @Success 200 {object} helper.Response "Invitation answered"
+// @Failure 404 {object} helper.ErrorResponse "Invitation not found"
+// @Router /achievements/{id}/members/respond [post]
+func (s *AchievementMemberService) Respond(c *fiber.Ctx) error {
+	id := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	var req model.RespondInvitationRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Format request tidak valid", nil)
+	}
+
+	achRef, err := s.achRepo.GetRefByID(c.Context(), id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if achRef == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Prestasi tidak ditemukan"))
+	}
+
+	studentInfo, err := s.studentRepo.GetByUserID(c.Context(), userID)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if studentInfo == nil {
+		return helper.HandleError(c, model.NewValidationError("Hanya mahasiswa yang dapat menjawab undangan"))
+	}
+
+	members, err := s.memberRepo.GetByAchievementID(c.Context(), id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	var invitation *model.AchievementMember
+	for i := range members {
+		if members[i].StudentID == studentInfo.ID {
+			invitation = &members[i]
+			break
+		}
+	}
+	if invitation == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Undangan anggota tim tidak ditemukan"))
+	}
+
+	if achRef.Status != "draft" {
+		return helper.HandleError(c, model.NewValidationError("Undangan hanya dapat dijawab selama prestasi berstatus Draft."))
+	}
+
+	status := model.InvitationDeclined
+	message := "Undangan anggota tim ditolak"
+	if req.Accept {
+		status = model.InvitationConfirmed
+		message = "Keikutsertaan dalam tim berhasil dikonfirmasi"
+	}
+
+	if err := s.memberRepo.UpdateInvitation(c.Context(), id, studentInfo.ID, status); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Success(c, message, nil)
+}
+
+// loadMemberForAdvisor memastikan anggota terkonfirmasi dan pemanggil adalah dosen wali anggota tersebut
+func (s *AchievementMemberService) loadMemberForAdvisor(c *fiber.Ctx) (*model.AchievementMember, error) {
+	id := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	achRef, err := s.achRepo.GetRefByID(c.Context(), id)
+	if err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	if achRef == nil {
+		return nil, model.NewNotFoundError("Prestasi tidak ditemukan")
+	}
+
+	member, err := s.memberRepo.GetByID(c.Context(), c.Params("memberId"))
+	if err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	if member == nil || member.AchievementID != id {
+		return nil, model.NewNotFoundError("Anggota tim tidak ditemukan")
+	}
+
+	lecturerInfo, err := s.lecturerSvc.GetProfile(c.Context(), userID)
+	if err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	if lecturerInfo == nil {
+		return nil, model.NewValidationError("Akses ditolak. User bukan dosen.")
+	}
+	if member.AdvisorID == nil || *member.AdvisorID != lecturerInfo.ID {
+		return nil, model.NewValidationError("Anda tidak berhak memverifikasi anggota ini (Bukan mahasiswa bimbingan anda)")
+	}
+
+	if achRef.Status != "submitted" && achRef.Status != "verified" {
+		return nil, model.NewValidationError("Partisipasi anggota hanya dapat diverifikasi setelah prestasi disubmit.")
+	}
+	if member.InvitationStatus != model.InvitationConfirmed {
+		return nil, model.NewValidationError("Anggota belum mengonfirmasi keikutsertaan dalam tim.")
+	}
+
+	return member, nil
+}
+
+// VerifyMember godoc
+// @Summary Verify team member participation
+// @Description Verify a co-member's participation (Advisor of that member only)
+// @Tags Achievement Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param memberId path string true "Member ID"
+// @Success 200 {object} helper.Response "Participation verified"
+// @Failure 403 {object} helper.ErrorResponse "Not your advisee"
+// @Router /achievements/{id}/members/{memberId}/verify [post]
+func (s *AchievementMemberService) VerifyMember(c *fiber.Ctx) error {
+	member, err := s.loadMemberForAdvisor(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	err = s.memberRepo.SetVerification(c.Context(), member.ID, model.MemberVerificationVerified, c.Locals("user_id").(string), nil)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Success(c, "Partisipasi anggota tim berhasil diverifikasi", nil)
+}
+
+// RejectMember godoc
+// @Summary Reject team member participation
+// @Description Reject a co-member's participation with a note (Advisor of that member only)
+// @Tags Achievement Members
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param memberId path string true "Member ID"
+// @Param request body model.RejectMemberRequest true "Rejection reason"
+// @Success 200 {object} helper.Response "Participation rejected"
+// @Failure 403 {object} helper.ErrorResponse "Not your advisee"
+// @Router /achievements/{id}/members/{memberId}/reject [post]
+func (s *AchievementMemberService) RejectMember(c *fiber.Ctx) error {
+	var req model.RejectMemberRequest
+	if err := c.BodyParser(&req); err != nil || strings.TrimSpace(req.RejectionNote) == "" {
+		return helper.BadRequest(c, "Format data tidak valid (catatan penolakan diperlukan)", nil)
+	}
+
+	member, err := s.loadMemberForAdvisor(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	err = s.memberRepo.SetVerification(c.Context(), member.ID, model.MemberVerificationRejected, c.Locals("user_id").(string), &req.RejectionNote)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Success(c, "Partisipasi anggota tim ditolak", nil)
+}
@@ -0,0 +1,160 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAchievementMemberService_InviteAndRespond(t *testing.T) {
+	mockAchRepo := &MockAchievementRepository{
+		achRefs: make(map[string]*model.AchievementReference),
+	}
+	mockStudentRepo := &MockStudentRepository{
+		students: make(map[string]*model.StudentInfo),
+	}
+	mockMemberRepo := newMockAchievementMemberRepository()
+	service := NewAchievementMemberService(mockAchRepo, mockMemberRepo, mockStudentRepo, &MockLecturerService{})
+
+	ownerUserID, memberUserID := "user-mhs-1", "user-mhs-2"
+	mockStudentRepo.students[ownerUserID] = &model.StudentInfo{ID: "student-1", StudentID: "NIM001"}
+	mockStudentRepo.students[memberUserID] = &model.StudentInfo{ID: "student-2", StudentID: "NIM002"}
+	mockAchRepo.achRefs["ach-1"] = &model.AchievementReference{ID: "ach-1", StudentID: "student-1", Status: "draft"}
+
+	t.Run("POST - Invite Member Success", func(t *testing.T) {
+		app := fiber.New()
+		app.Post("/achievements/:id/members", func(c *fiber.Ctx) error {
+			c.Locals("user_id", ownerUserID)
+			return service.Invite(c)
+		})
+
+		body, _ := json.Marshal(model.InviteMembersRequest{StudentIDs: []string{"NIM002"}})
+		req := httptest.NewRequest("POST", "/achievements/ach-1/members", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected 200 status, got %d", resp.StatusCode)
+		}
+		if len(mockMemberRepo.members) != 1 {
+			t.Errorf("Expected 1 invited member, got %d", len(mockMemberRepo.members))
+		}
+	})
+
+	t.Run("POST - Invite By Non Owner (Forbidden)", func(t *testing.T) {
+		app := fiber.New()
+		app.Post("/achievements/:id/members", func(c *fiber.Ctx) error {
+			c.Locals("user_id", memberUserID)
+			return service.Invite(c)
+		})
+
+		body, _ := json.Marshal(model.InviteMembersRequest{StudentIDs: []string{"NIM001"}})
+		req := httptest.NewRequest("POST", "/achievements/ach-1/members", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode == fiber.StatusOK {
+			t.Errorf("Expected error status, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("POST - Confirm Invitation", func(t *testing.T) {
+		app := fiber.New()
+		app.Post("/achievements/:id/members/respond", func(c *fiber.Ctx) error {
+			c.Locals("user_id", memberUserID)
+			return service.Respond(c)
+		})
+
+		body, _ := json.Marshal(model.RespondInvitationRequest{Accept: true})
+		req := httptest.NewRequest("POST", "/achievements/ach-1/members/respond", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected 200 status, got %d", resp.StatusCode)
+		}
+		for _, m := range mockMemberRepo.members {
+			if m.InvitationStatus != model.InvitationConfirmed {
+				t.Errorf("Expected invitation to be confirmed, got %s", m.InvitationStatus)
+			}
+		}
+	})
+}
+
+func TestAchievementMemberService_VerifyMember(t *testing.T) {
+	mockAchRepo := &MockAchievementRepository{
+		achRefs: make(map[string]*model.AchievementReference),
+	}
+	mockMemberRepo := newMockAchievementMemberRepository()
+	mockLecturerSvc := &MockLecturerService{lecturerInfo: &model.LecturerInfo{ID: "dosen-2"}}
+	service := NewAchievementMemberService(mockAchRepo, mockMemberRepo, &MockStudentRepository{}, mockLecturerSvc)
+
+	advisorID := "dosen-2"
+	mockAchRepo.achRefs["ach-1"] = &model.AchievementReference{ID: "ach-1", StudentID: "student-1", Status: "submitted"}
+	mockMemberRepo.members["member-1"] = &model.AchievementMember{
+		ID: "member-1", AchievementID: "ach-1", StudentID: "student-2", AdvisorID: &advisorID,
+		InvitationStatus: model.InvitationConfirmed, VerificationStatus: model.MemberVerificationPending,
+	}
+
+	app := fiber.New()
+	app.Post("/achievements/:id/members/:memberId/verify", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-dosen-2")
+		return service.VerifyMember(c)
+	})
+
+	t.Run("POST - Verify Member by Member's Advisor", func(t *testing.T) {
+		req := httptest.NewRequest("POST", "/achievements/ach-1/members/member-1/verify", nil)
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected 200 status, got %d", resp.StatusCode)
+		}
+		if mockMemberRepo.members["member-1"].VerificationStatus != model.MemberVerificationVerified {
+			t.Errorf("Expected member to be verified")
+		}
+	})
+
+	t.Run("POST - Verify Member by Other Advisor (Forbidden)", func(t *testing.T) {
+		mockLecturerSvc.lecturerInfo = &model.LecturerInfo{ID: "dosen-lain"}
+		req := httptest.NewRequest("POST", "/achievements/ach-1/members/member-1/verify", nil)
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode == fiber.StatusOK {
+			t.Errorf("Expected error status, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestMemberPoints(t *testing.T) {
+	if got := model.MemberPoints(90, model.PointsModeSplit, 3, false); got != 30 {
+		t.Errorf("Expected split points 30, got %d", got)
+	}
+	// 100 poin dibagi 3: pembuat menerima sisa pembagian agar totalnya tetap 100
+	owner, member := model.MemberPoints(100, model.PointsModeSplit, 3, true), model.MemberPoints(100, model.PointsModeSplit, 3, false)
+	if owner != 34 || member != 33 || owner+2*member != 100 {
+		t.Errorf("Expected 34 for the owner and 33 per member, got %d and %d", owner, member)
+	}
+	if got := model.MemberPoints(90, model.PointsModePerMember, 3, false); got != 90 {
+		t.Errorf("Expected per-member points 90, got %d", got)
+	}
+}
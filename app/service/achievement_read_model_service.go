@@ -0,0 +1,26 @@
+package service
+
+import (
+	"context"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+)
+
+type IAchievementReadModelService interface {
+	Rebuild(ctx context.Context) (model.ReadModelRebuildResult, error)
+}
+
+type AchievementReadModelService struct {
+	readModelRepo repository.IAchievementReadModelRepository
+}
+
+func NewAchievementReadModelService(readModelRepo repository.IAchievementReadModelRepository) IAchievementReadModelService {
+	return &AchievementReadModelService{readModelRepo: readModelRepo}
+}
+
+// Rebuild mengisi ulang achievement_read_model dari MongoDB. Aman dijalankan berulang kali karena
+// setiap baris ditulis dengan upsert.
+func (s *AchievementReadModelService) Rebuild(ctx context.Context) (model.ReadModelRebuildResult, error) {
+	return s.readModelRepo.Rebuild(ctx)
+}
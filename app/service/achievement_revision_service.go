@@ -0,0 +1,221 @@
+package service
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strconv"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type IAchievementRevisionService interface {
+	List(c *fiber.Ctx) error
+	Diff(c *fiber.Ctx) error
+}
+
+type AchievementRevisionService struct {
+	revisionRepo repository.IAchievementRevisionRepository
+	achRepo      repository.IAchievementRepository
+	memberRepo   repository.IAchievementMemberRepository
+	studentRepo  repository.IStudentRepository
+	lecturerSvc  ILecturerService
+}
+
+func NewAchievementRevisionService(
+	revisionRepo repository.IAchievementRevisionRepository,
+	achRepo repository.IAchievementRepository,
+	memberRepo repository.IAchievementMemberRepository,
+	studentRepo repository.IStudentRepository,
+	lecturerSvc ILecturerService,
+) IAchievementRevisionService {
+	return &AchievementRevisionService{
+		revisionRepo: revisionRepo,
+		achRepo:      achRepo,
+		memberRepo:   memberRepo,
+		studentRepo:  studentRepo,
+		lecturerSvc:  lecturerSvc,
+	}
+}
+
+// diffRevisionSnapshots membandingkan dua revisi per field; details dibandingkan per key dan lampiran per nama file
+func diffRevisionSnapshots(from, to *model.RevisionSnapshot) []model.RevisionFieldChange {
+	changes := []model.RevisionFieldChange{}
+
+	compare := func(field string, oldValue, newValue interface{}) {
+		if !reflect.DeepEqual(oldValue, newValue) {
+			changes = append(changes, model.RevisionFieldChange{Field: field, Change: "changed", Old: oldValue, New: newValue})
+		}
+	}
+
+	compare("achievement_type", from.AchievementType, to.AchievementType)
+	compare("title", from.Title, to.Title)
+	compare("description", from.Description, to.Description)
+	compare("points", from.Points, to.Points)
+	compare("points_mode", from.PointsMode, to.PointsMode)
+
+	fromTags, toTags := from.Tags, to.Tags
+	if len(fromTags) == 0 {
+		fromTags = nil
+	}
+	if len(toTags) == 0 {
+		toTags = nil
+	}
+	compare("tags", fromTags, toTags)
+
+	keys := make(map[string]bool)
+	for k := range from.Details {
+		keys[k] = true
+	}
+	for k := range to.Details {
+		keys[k] = true
+	}
+	sortedKeys := make([]string, 0, len(keys))
+	for k := range keys {
+		sortedKeys = append(sortedKeys, k)
+	}
+	sort.Strings(sortedKeys)
+
+	for _, k := range sortedKeys {
+		oldValue, inOld := from.Details[k]
+		newValue, inNew := to.Details[k]
+		field := "details." + k
+		switch {
+		case !inOld:
+			changes = append(changes, model.RevisionFieldChange{Field: field, Change: "added", New: newValue})
+		case !inNew:
+			changes = append(changes, model.RevisionFieldChange{Field: field, Change: "removed", Old: oldValue})
+		default:
+			compare(field, oldValue, newValue)
+		}
+	}
+
+	oldFiles := make(map[string]model.AchievementAttachment)
+	for _, a := range from.Attachments {
+		oldFiles[a.FileName] = a
+	}
+	newFiles := make(map[string]bool)
+	for _, a := range to.Attachments {
+		newFiles[a.FileName] = true
+		if _, ok := oldFiles[a.FileName]; !ok {
+			changes = append(changes, model.RevisionFieldChange{Field: "attachments", Change: "added", New: a})
+		}
+	}
+	for _, a := range from.Attachments {
+		if !newFiles[a.FileName] {
+			changes = append(changes, model.RevisionFieldChange{Field: "attachments", Change: "removed", Old: a})
+		}
+	}
+
+	return changes
+}
+
+func (s *AchievementRevisionService) authorize(c *fiber.Ctx, id string) error {
+	userID := c.Locals("user_id").(string)
+	roleName := c.Locals("role").(string)
+
+	detail, err := getDetailWithMembers(c.Context(), s.achRepo, s.memberRepo, id)
+	if err != nil {
+		return model.ErrDatabaseError
+	}
+	if detail == nil {
+		return model.NewNotFoundError("Prestasi tidak ditemukan")
+	}
+	return authorizeAchievementView(c.Context(), s.studentRepo, s.lecturerSvc, userID, roleName, detail)
+}
+
+// List godoc
+// @Summary List achievement revisions
+// @Description Get the immutable revision history of an achievement document
+// @Tags Achievement Revisions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Success 200 {object} helper.Response{data=[]model.AchievementRevision} "Revisions retrieved"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Router /achievements/{id}/revisions [get]
+func (s *AchievementRevisionService) List(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if err := s.authorize(c, id); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	revisions, err := s.revisionRepo.GetByAchievementID(c.Context(), id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Success(c, "Riwayat revisi prestasi berhasil diambil", revisions)
+}
+
+// Diff godoc
+// @Summary Diff achievement revisions
+// @Description Field-level diff between two revisions. Defaults to the latest revision against the one before it.
+// @Tags Achievement Revisions
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param from query int false "Base revision version"
+// @Param to query int false "Target revision version"
+// @Success 200 {object} helper.Response{data=model.RevisionDiff} "Diff computed"
+// @Failure 404 {object} helper.ErrorResponse "Revision not found"
+// @Router /achievements/{id}/revisions/diff [get]
+func (s *AchievementRevisionService) Diff(c *fiber.Ctx) error {
+	id := c.Params("id")
+	if err := s.authorize(c, id); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	toVersion, err := strconv.Atoi(c.Query("to", "0"))
+	if err != nil || toVersion < 0 {
+		return helper.HandleError(c, model.NewValidationError("Parameter to tidak valid"))
+	}
+	fromVersion, err := strconv.Atoi(c.Query("from", "0"))
+	if err != nil || fromVersion < 0 {
+		return helper.HandleError(c, model.NewValidationError("Parameter from tidak valid"))
+	}
+
+	if toVersion == 0 {
+		revisions, err := s.revisionRepo.GetByAchievementID(c.Context(), id)
+		if err != nil {
+			return helper.HandleError(c, model.ErrDatabaseError)
+		}
+		if len(revisions) == 0 {
+			return helper.HandleError(c, model.NewNotFoundError("Prestasi ini belum memiliki revisi"))
+		}
+		toVersion = revisions[len(revisions)-1].Version
+	}
+	if fromVersion == 0 {
+		fromVersion = toVersion - 1
+	}
+	if fromVersion < 1 {
+		return helper.HandleError(c, model.NewValidationError("Tidak ada revisi sebelumnya untuk dibandingkan"))
+	}
+
+	from, err := s.revisionRepo.GetByVersion(c.Context(), id, fromVersion)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	to, err := s.revisionRepo.GetByVersion(c.Context(), id, toVersion)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if from == nil || to == nil || from.Snapshot == nil || to.Snapshot == nil {
+		return helper.HandleError(c, model.NewNotFoundError(fmt.Sprintf("Revisi %d atau %d tidak ditemukan", fromVersion, toVersion)))
+	}
+
+	diff := model.RevisionDiff{
+		AchievementID: id,
+		Changes:       diffRevisionSnapshots(from.Snapshot, to.Snapshot),
+	}
+	diff.From, diff.To = *from, *to
+	diff.From.Snapshot, diff.To.Snapshot = nil, nil
+
+	return helper.Success(c, "Perbandingan revisi berhasil dihitung", diff)
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAchievementService_EditRecordsRevisions(t *testing.T) {
+	mockAchRepo := &MockAchievementRepository{
+		achRefs: make(map[string]*model.AchievementReference),
+	}
+	mockStudentRepo := &MockStudentRepository{
+		students: make(map[string]*model.StudentInfo),
+	}
+	mockRevisionRepo := &MockAchievementRevisionRepository{}
+	service := NewAchievementService(mockAchRepo, mockStudentRepo, newMockAchievementTypeRepository("nasional"),
+		newMockAchievementMemberRepository(), mockRevisionRepo, &MockLecturerService{}, storage.NewLocal(t.TempDir()), export.Letterhead{})
+
+	userID := "user-mhs-1"
+	mockStudentRepo.students[userID] = &model.StudentInfo{ID: "student-1"}
+	mockAchRepo.achRefs["ach-1"] = &model.AchievementReference{ID: "ach-1", StudentID: "student-1", Status: "draft"}
+	mockAchRepo.achRefs["ach-2"] = &model.AchievementReference{ID: "ach-2", StudentID: "student-1", Status: "rejected"}
+
+	app := fiber.New()
+	app.Put("/achievements/:id", func(c *fiber.Ctx) error {
+		c.Locals("user_id", userID)
+		return service.Edit(c)
+	})
+
+	edit := func(id string) int {
+		title := "Juara 1 Hackathon (revisi)"
+		body, _ := json.Marshal(model.UpdateAchievementRequest{Title: &title})
+		req := httptest.NewRequest("PUT", "/achievements/"+id, bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	t.Run("PUT - Edit Draft Achievement", func(t *testing.T) {
+		if status := edit("ach-1"); status != fiber.StatusOK {
+			t.Errorf("Expected 200 status, got %d", status)
+		}
+
+		if len(mockRevisionRepo.revisions) != 2 {
+			t.Fatalf("Expected baseline and updated revisions, got %d", len(mockRevisionRepo.revisions))
+		}
+		if mockRevisionRepo.revisions[0].Event != model.RevisionEventBaseline || mockRevisionRepo.revisions[1].Event != model.RevisionEventUpdated {
+			t.Errorf("Unexpected revision events: %+v", mockRevisionRepo.revisions)
+		}
+	})
+
+	t.Run("PUT - Rejected Achievement Is Read-Only", func(t *testing.T) {
+		if status := edit("ach-2"); status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", status)
+		}
+	})
+}
+
+func TestDiffRevisionSnapshots(t *testing.T) {
+	from := &model.RevisionSnapshot{
+		Title:       "Juara 2 Lomba Debat",
+		Details:     map[string]interface{}{"rank": "2", "location": "Jakarta"},
+		Tags:        []string{},
+		Attachments: []model.AchievementAttachment{{FileName: "old.pdf"}},
+	}
+	to := &model.RevisionSnapshot{
+		Title:       "Juara 1 Lomba Debat",
+		Details:     map[string]interface{}{"rank": "1", "organizer": "DIKTI"},
+		Attachments: []model.AchievementAttachment{{FileName: "old.pdf"}, {FileName: "new.pdf"}},
+	}
+
+	changes := diffRevisionSnapshots(from, to)
+
+	got := make(map[string]bool)
+	for _, ch := range changes {
+		got[ch.Field+":"+ch.Change] = true
+	}
+	for _, want := range []string{
+		"title:changed", "details.rank:changed", "details.location:removed",
+		"details.organizer:added", "attachments:added",
+	} {
+		if !got[want] {
+			t.Errorf("Expected change %s, got %+v", want, changes)
+		}
+	}
+	if len(changes) != 5 {
+		t.Errorf("Expected 5 changes, got %d: %+v", len(changes), changes)
+	}
+}
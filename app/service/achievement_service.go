@@ -1,23 +1,35 @@
 package service
 
 import (
+	"context"
 	"fmt"
-	"io"
+	"log"
 	"math"
-	"os"
-	"path/filepath"
+	"mime/multipart"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"sistem-pelaporan-prestasi-mahasiswa/app/model"
 	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
 	"sistem-pelaporan-prestasi-mahasiswa/helper"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+	"sistem-pelaporan-prestasi-mahasiswa/utils"
 
 	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
 )
 
 type IAchievementService interface {
 	Create(c *fiber.Ctx) error
 	UploadAttachment(c *fiber.Ctx) error
+	ReplaceAttachment(c *fiber.Ctx) error
+	DeleteAttachment(c *fiber.Ctx) error
+	DownloadAttachment(c *fiber.Ctx) error
+	GetAttachmentURL(c *fiber.Ctx) error
 	GetAll(c *fiber.Ctx) error
 	GetDetail(c *fiber.Ctx) error
 	Edit(c *fiber.Ctx) error
@@ -29,23 +41,127 @@ type IAchievementService interface {
 }
 
 type AchievementService struct {
-	achRepo     repository.IAchievementRepository
-	studentRepo repository.IStudentRepository
-	lecturerSvc ILecturerService
+	achRepo      repository.IAchievementRepository
+	studentRepo  repository.IStudentRepository
+	typeRepo     repository.IAchievementTypeRepository
+	memberRepo   repository.IAchievementMemberRepository
+	revisionRepo repository.IAchievementRevisionRepository
+	lecturerSvc  ILecturerService
+	store        storage.Storage
+	letterhead   export.Letterhead
 }
 
 func NewAchievementService(
 	achRepo repository.IAchievementRepository,
 	studentRepo repository.IStudentRepository,
+	typeRepo repository.IAchievementTypeRepository,
+	memberRepo repository.IAchievementMemberRepository,
+	revisionRepo repository.IAchievementRevisionRepository,
 	lecturerSvc ILecturerService,
+	store storage.Storage,
+	letterhead export.Letterhead,
 ) IAchievementService {
 	return &AchievementService{
-		achRepo:     achRepo,
-		studentRepo: studentRepo,
-		lecturerSvc: lecturerSvc,
+		achRepo:      achRepo,
+		studentRepo:  studentRepo,
+		typeRepo:     typeRepo,
+		memberRepo:   memberRepo,
+		revisionRepo: revisionRepo,
+		lecturerSvc:  lecturerSvc,
+		store:        store,
+		letterhead:   letterhead,
 	}
 }
 
+// recordRevision menyimpan revisi dokumen; kegagalan hanya dicatat agar tidak membatalkan aksi utama
+func (s *AchievementService) recordRevision(ctx context.Context, achRef *model.AchievementReference, event, status, userID string) {
+	if err := s.revisionRepo.Record(ctx, achRef.ID, achRef.MongoAchievementID, event, status, userID); err != nil {
+		log.Printf("⚠️  Gagal menyimpan revisi prestasi %s (%s): %v", achRef.ID, event, err)
+	}
+}
+
+// getDetailWithMembers mengambil detail prestasi beserta anggota tim
+func getDetailWithMembers(ctx context.Context, achRepo repository.IAchievementRepository, memberRepo repository.IAchievementMemberRepository, id string) (*model.AchievementDetailDTO, error) {
+	detail, err := achRepo.GetDetailByID(ctx, id)
+	if err != nil || detail == nil {
+		return detail, err
+	}
+
+	members, err := memberRepo.GetByAchievementID(ctx, detail.ID)
+	if err != nil {
+		return nil, err
+	}
+	detail.Members = members
+
+	return detail, nil
+}
+
+// authorizeAchievementView menerapkan aturan visibilitas prestasi: pemilik & anggota tim, dosen wali mereka, dan Admin.
+// Prestasi di tempat sampah hanya terlihat oleh Admin.
+func authorizeAchievementView(ctx context.Context, studentRepo repository.IStudentRepository, lecturerSvc ILecturerService, userID, roleName string, detail *model.AchievementDetailDTO) error {
+	if detail.Status == "deleted" && roleName != "Admin" {
+		return model.NewNotFoundError("Prestasi tidak ditemukan")
+	}
+
+	switch roleName {
+	case "Mahasiswa":
+		studentInfo, err := studentRepo.GetByUserID(ctx, userID)
+		if err != nil {
+			return model.ErrDatabaseError
+		}
+		if studentInfo != nil {
+			if studentInfo.ID == detail.Student.ID.String() {
+				return nil
+			}
+			for _, m := range detail.Members {
+				if m.StudentID == studentInfo.ID && m.InvitationStatus != model.InvitationDeclined {
+					return nil
+				}
+			}
+		}
+		return model.NewValidationError("Anda tidak berhak melihat prestasi ini")
+
+	case "Dosen Wali":
+		lecturerInfo, err := lecturerSvc.GetProfile(ctx, userID)
+		if err != nil {
+			return model.ErrDatabaseError
+		}
+		if lecturerInfo != nil {
+			if detail.Student.AdvisorID != nil && *detail.Student.AdvisorID == lecturerInfo.ID {
+				return nil
+			}
+			for _, m := range detail.Members {
+				if m.InvitationStatus == model.InvitationConfirmed && m.AdvisorID != nil && *m.AdvisorID == lecturerInfo.ID {
+					return nil
+				}
+			}
+		}
+		return model.NewValidationError("Mahasiswa ini bukan bimbingan anda")
+
+	case "Admin":
+		return nil
+	}
+
+	return model.NewValidationError("Role tidak dikenali")
+}
+
+// resolveAchievementType memvalidasi achievement_type terhadap katalog dan mengembalikan entri kanonik
+func (s *AchievementService) resolveAchievementType(ctx context.Context, value string) (*model.AchievementType, error) {
+	if value == "" {
+		return nil, model.NewValidationError("achievement_type wajib diisi")
+	}
+
+	t, err := s.typeRepo.Resolve(ctx, value)
+	if err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	if t == nil || !t.IsActive {
+		return nil, model.NewValidationError(fmt.Sprintf("Jenis prestasi '%s' tidak terdaftar di katalog", value))
+	}
+
+	return t, nil
+}
+
 // Create godoc
 // @Summary Create new achievement
 // @Description Create a new achievement in draft status
@@ -74,14 +190,28 @@ func (s *AchievementService) Create(c *fiber.Ctx) error {
 		return helper.HandleError(c, model.NewValidationError("Hanya mahasiswa yang boleh melapor prestasi"))
 	}
 
+	achType, err := s.resolveAchievementType(c.Context(), req.AchievementType)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	pointsMode := req.PointsMode
+	if pointsMode == "" {
+		pointsMode = model.PointsModePerMember
+	}
+	if pointsMode != model.PointsModePerMember && pointsMode != model.PointsModeSplit {
+		return helper.HandleError(c, model.NewValidationError("points_mode harus per_member atau split"))
+	}
+
 	achMongo := &model.AchievementMongo{
 		StudentID:       studentInfo.ID,
-		AchievementType: req.AchievementType,
+		AchievementType: achType.Code,
 		Title:           req.Title,
 		Description:     req.Description,
 		Details:         req.Details,
 		Tags:            req.Tags,
 		Attachments:     []model.AchievementAttachment{},
+		PointsMode:      pointsMode,
 		CreatedAt:       time.Now(),
 		UpdatedAt:       time.Now(),
 	}
@@ -95,12 +225,14 @@ func (s *AchievementService) Create(c *fiber.Ctx) error {
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
 
+	s.recordRevision(c.Context(), achRef, model.RevisionEventCreated, "draft", userID)
+
 	return helper.Created(c, "Prestasi berhasil dibuat (Draft). Silakan upload bukti.", achRef)
 }
 
 // Edit godoc
 // @Summary Edit achievement
-// @Description Edit draft achievement details
+// @Description Edit draft achievement details (each change is stored as a revision)
 // @Tags Achievements
 // @Accept json
 // @Produce json
@@ -139,17 +271,35 @@ func (s *AchievementService) Edit(c *fiber.Ctx) error {
 		return helper.HandleError(c, model.NewValidationError("Hanya prestasi status Draft yang boleh diedit."))
 	}
 
+	if req.AchievementType != nil {
+		achType, err := s.resolveAchievementType(c.Context(), *req.AchievementType)
+		if err != nil {
+			return helper.HandleError(c, err)
+		}
+		req.AchievementType = &achType.Code
+	}
+
+	if req.PointsMode != nil && *req.PointsMode != model.PointsModePerMember && *req.PointsMode != model.PointsModeSplit {
+		return helper.HandleError(c, model.NewValidationError("points_mode harus per_member atau split"))
+	}
+
+	if err := s.revisionRepo.EnsureBaseline(c.Context(), id, achRef.MongoAchievementID, achRef.Status, userID); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
 	err = s.achRepo.Update(c.Context(), id, achRef.MongoAchievementID, &req)
 	if err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
 
+	s.recordRevision(c.Context(), achRef, model.RevisionEventUpdated, achRef.Status, userID)
+
 	return helper.Success(c, "Prestasi berhasil diperbarui", nil)
 }
 
 // UploadAttachment godoc
 // @Summary Upload achievement attachment
-// @Description Upload proof file for achievement (PDF/Image, max 5MB)
+// @Description Upload proof file for a draft achievement. The file type is detected from its content and must match the extension; size and type limits follow the achievement type policy. Image metadata (EXIF/GPS) is stripped.
 // @Tags Achievements
 // @Accept multipart/form-data
 // @Produce json
@@ -168,77 +318,250 @@ func (s *AchievementService) UploadAttachment(c *fiber.Ctx) error {
 		return helper.BadRequest(c, "File tidak ditemukan.", nil)
 	}
 
-	maxSize := int64(5 * 1024 * 1024)
-	allowedTypes := []string{
-		"application/pdf",
-		"image/jpeg",
-		"image/jpg",
-		"image/png",
+	achRef, err := authorizeAttachmentChange(c.Context(), s.achRepo, s.studentRepo, id, userID)
+	if err != nil {
+		return helper.HandleError(c, err)
 	}
 
-	if err := helper.ValidateFile(fileHeader, maxSize, allowedTypes); err != nil {
+	attachmentData, err := s.storeAttachment(c, id, achRef, userID, fileHeader)
+	if err != nil {
 		return helper.HandleError(c, err)
 	}
+	attachmentData.ID = uuid.New().String()
 
-	achRef, err := s.achRepo.GetRefByID(c.Context(), id)
+	err = s.achRepo.AddAttachment(c.Context(), achRef.MongoAchievementID, *attachmentData)
 	if err != nil {
+		deleteStoredFile(c.Context(), s.store, attachmentData.StorageKey)
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
-	if achRef == nil {
-		return helper.HandleError(c, model.NewNotFoundError("Prestasi tidak ditemukan"))
-	}
 
-	studentInfo, err := s.studentRepo.GetByUserID(c.Context(), userID)
+	s.recordRevision(c.Context(), achRef, model.RevisionEventAttachment, achRef.Status, userID)
+
+	return helper.Success(c, "File berhasil diupload", attachmentData)
+}
+
+// ReplaceAttachment godoc
+// @Summary Replace achievement attachment
+// @Description Replace the file of an attachment on a draft achievement. The attachment keeps its ID; the old file is deleted and the new one is scanned again.
+// @Tags Achievements
+// @Accept multipart/form-data
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param attachmentId path string true "Attachment ID (or file name for older attachments)"
+// @Param file formData file true "Attachment file"
+// @Success 200 {object} helper.Response{data=model.AchievementAttachment} "File replaced"
+// @Failure 400 {object} helper.ErrorResponse "Invalid file"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Router /achievements/{id}/attachments/{attachmentId} [put]
+func (s *AchievementService) ReplaceAttachment(c *fiber.Ctx) error {
+	id := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	fileHeader, err := c.FormFile("file")
 	if err != nil {
-		return helper.HandleError(c, model.ErrDatabaseError)
-	}
-	if studentInfo == nil || achRef.StudentID != studentInfo.ID {
-		return helper.HandleError(c, model.NewValidationError("Anda tidak berhak mengedit prestasi ini"))
+		return helper.BadRequest(c, "File tidak ditemukan.", nil)
 	}
 
-	if achRef.Status != "draft" {
-		return helper.HandleError(c, model.NewValidationError("Perubahan data tidak diizinkan. Prestasi ini sedang dalam proses verifikasi atau telah disetujui oleh Dosen Wali."))
+	achRef, err := authorizeAttachmentChange(c.Context(), s.achRepo, s.studentRepo, id, userID)
+	if err != nil {
+		return helper.HandleError(c, err)
 	}
 
-	ext := filepath.Ext(fileHeader.Filename)
-	filename := fmt.Sprintf("ACH-%s-%d%s", id, time.Now().Unix(), ext)
-	uploadDir := "./uploads/achievements"
+	old, err := s.findOwnAttachment(c, id)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	if old.IsLink() {
+		return helper.HandleError(c, model.NewValidationError("Tautan bukti tidak dapat diganti dengan file. Hapus tautan lalu upload file baru."))
+	}
 
-	if _, err := os.Stat(uploadDir); os.IsNotExist(err) {
-		os.MkdirAll(uploadDir, 0755)
+	attachmentData, err := s.storeAttachment(c, id, achRef, userID, fileHeader)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	attachmentData.ID = old.ID
+	if attachmentData.ID == "" {
+		attachmentData.ID = uuid.New().String()
 	}
 
-	savePath := filepath.Join(uploadDir, filename)
-	src, err := fileHeader.Open()
+	err = s.achRepo.ReplaceAttachment(c.Context(), achRef.MongoAchievementID, attachmentRef(*old), *attachmentData)
 	if err != nil {
+		deleteStoredFile(c.Context(), s.store, attachmentData.StorageKey)
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
-	defer src.Close()
+	deleteAttachmentFiles(c.Context(), s.store, *old, "achievements/")
+
+	s.recordRevision(c.Context(), achRef, model.RevisionEventAttachmentReplaced, achRef.Status, userID)
+
+	return helper.Success(c, "File berhasil diganti", attachmentData)
+}
+
+// DeleteAttachment godoc
+// @Summary Delete achievement attachment
+// @Description Remove an attachment from a draft achievement and delete its file
+// @Tags Achievements
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param attachmentId path string true "Attachment ID (or file name for older attachments)"
+// @Success 200 {object} helper.Response "File deleted"
+// @Failure 400 {object} helper.ErrorResponse "Achievement can no longer be changed"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Router /achievements/{id}/attachments/{attachmentId} [delete]
+func (s *AchievementService) DeleteAttachment(c *fiber.Ctx) error {
+	id := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	achRef, err := authorizeAttachmentChange(c.Context(), s.achRepo, s.studentRepo, id, userID)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
 
-	dst, err := os.Create(savePath)
+	attachment, err := s.findOwnAttachment(c, id)
 	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	if err := s.revisionRepo.EnsureBaseline(c.Context(), id, achRef.MongoAchievementID, achRef.Status, userID); err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
-	defer dst.Close()
 
-	if _, err = io.Copy(dst, src); err != nil {
+	if err := s.achRepo.RemoveAttachment(c.Context(), achRef.MongoAchievementID, attachmentRef(*attachment)); err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
+	deleteAttachmentFiles(c.Context(), s.store, *attachment, "achievements/")
 
-	attachmentData := model.AchievementAttachment{
-		FileName:   filename,
-		FileURL:    "/uploads/achievements/" + filename,
-		FileType:   fileHeader.Header.Get("Content-Type"),
-		UploadedAt: time.Now(),
+	s.recordRevision(c.Context(), achRef, model.RevisionEventAttachmentRemoved, achRef.Status, userID)
+
+	return helper.Success(c, "File berhasil dihapus", nil)
+}
+
+// findOwnAttachment mencari lampiran yang masih boleh diubah berdasarkan parameter :attachmentId
+func (s *AchievementService) findOwnAttachment(c *fiber.Ctx, id string) (*model.AchievementAttachment, error) {
+	ref, err := url.PathUnescape(c.Params("attachmentId"))
+	if err != nil {
+		return nil, model.NewNotFoundError("Lampiran tidak ditemukan")
 	}
 
-	err = s.achRepo.AddAttachment(c.Context(), achRef.MongoAchievementID, attachmentData)
+	detail, err := s.achRepo.GetDetailByID(c.Context(), id)
 	if err != nil {
-		os.Remove(savePath)
-		return helper.HandleError(c, model.ErrDatabaseError)
+		return nil, model.ErrDatabaseError
+	}
+	if detail == nil {
+		return nil, model.NewNotFoundError("Prestasi tidak ditemukan")
 	}
 
-	return helper.Success(c, "File berhasil diupload", attachmentData)
+	attachment := findAttachment(detail.Attachments, ref)
+	if attachment == nil {
+		return nil, model.NewNotFoundError("Lampiran tidak ditemukan")
+	}
+	if attachment.FrozenAt != nil {
+		return nil, model.NewValidationError("Lampiran sudah dibekukan sebagai bukti prestasi terverifikasi dan tidak dapat diubah")
+	}
+	found := *attachment
+	return &found, nil
+}
+
+// storeAttachment memeriksa file sesuai kebijakan jenis prestasi lalu menyimpannya ke karantina
+func (s *AchievementService) storeAttachment(c *fiber.Ctx, id string, achRef *model.AchievementReference, userID string, fileHeader *multipart.FileHeader) (*model.AchievementAttachment, error) {
+	policy, err := achievementFilePolicy(c.Context(), s.achRepo, s.typeRepo, id)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := helper.SanitizeUpload(fileHeader, policy)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.revisionRepo.EnsureBaseline(c.Context(), id, achRef.MongoAchievementID, achRef.Status, userID); err != nil {
+		return nil, model.ErrDatabaseError
+	}
+
+	return saveAchievementFile(c.Context(), s.store, id, file)
+}
+
+// findVisibleAttachment memastikan pemanggil boleh melihat prestasi lalu mencari lampirannya
+func (s *AchievementService) findVisibleAttachment(c *fiber.Ctx) (*model.AchievementAttachment, error) {
+	userID := c.Locals("user_id").(string)
+	roleName := c.Locals("role").(string)
+
+	fileName, err := url.PathUnescape(c.Params("fileName"))
+	if err != nil {
+		return nil, model.NewNotFoundError("Lampiran tidak ditemukan")
+	}
+
+	detail, err := getDetailWithMembers(c.Context(), s.achRepo, s.memberRepo, c.Params("id"))
+	if err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	if detail == nil {
+		return nil, model.NewNotFoundError("Prestasi tidak ditemukan")
+	}
+	if err := authorizeAchievementView(c.Context(), s.studentRepo, s.lecturerSvc, userID, roleName, detail); err != nil {
+		return nil, err
+	}
+
+	attachment := findAttachment(detail.Attachments, fileName)
+	if attachment == nil || attachment.IsLink() {
+		return nil, model.NewNotFoundError("Lampiran tidak ditemukan")
+	}
+	if err := attachmentAccessError(*attachment); err != nil {
+		return nil, err
+	}
+	return attachment, nil
+}
+
+// DownloadAttachment godoc
+// @Summary Download achievement attachment
+// @Description Stream an attachment file. Visible to the owner, team members, their advisors and Admin.
+// @Tags Achievements
+// @Produce octet-stream
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param fileName path string true "Attachment file name"
+// @Success 200 {file} file "Attachment content"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Router /achievements/{id}/attachments/{fileName} [get]
+func (s *AchievementService) DownloadAttachment(c *fiber.Ctx) error {
+	attachment, err := s.findVisibleAttachment(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	return serveVerifiedAttachment(c, s.store, *attachment, "achievements/")
+}
+
+// GetAttachmentURL godoc
+// @Summary Get signed attachment URL
+// @Description Create a short-lived HMAC-signed URL for embedding an attachment in reports
+// @Tags Achievements
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param fileName path string true "Attachment file name"
+// @Param ttl query int false "Validity in seconds (default 900, max 3600)"
+// @Success 200 {object} helper.Response{data=model.AttachmentURLResponse} "Signed URL created"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Router /achievements/{id}/attachments/{fileName}/signed-url [get]
+func (s *AchievementService) GetAttachmentURL(c *fiber.Ctx) error {
+	attachment, err := s.findVisibleAttachment(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	ttl := signedURLDefaultTTL
+	if seconds := c.QueryInt("ttl", 0); seconds > 0 {
+		ttl = time.Duration(seconds) * time.Second
+	}
+	if ttl > signedURLMaxTTL {
+		ttl = signedURLMaxTTL
+	}
+
+	signedURL, expiresAt := utils.SignFileURL(attachmentStorageKey(*attachment, "achievements/"), ttl)
+
+	return helper.Success(c, "URL lampiran berhasil dibuat", model.AttachmentURLResponse{URL: signedURL, ExpiresAt: expiresAt})
 }
 
 // Submit godoc
@@ -276,11 +599,55 @@ func (s *AchievementService) Submit(c *fiber.Ctx) error {
 		return helper.HandleError(c, model.NewValidationError("Hanya prestasi berstatus Draft yang dapat disubmit."))
 	}
 
+	detail, err := s.achRepo.GetDetailByID(c.Context(), id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if detail == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Prestasi tidak ditemukan"))
+	}
+	for _, a := range detail.Attachments {
+		if a.IsLink() {
+			continue
+		}
+		switch a.ScanStatus {
+		case model.ScanStatusClean:
+		case model.ScanStatusInfected:
+			return helper.HandleError(c, model.NewValidationError("Lampiran "+a.FileName+" terdeteksi mengandung malware. Hapus dan upload ulang bukti yang bersih."))
+		case model.ScanStatusFailed:
+			return helper.HandleError(c, model.NewValidationError("Lampiran "+a.FileName+" gagal dipindai antivirus. Hapus dan upload ulang bukti tersebut."))
+		default:
+			return helper.HandleError(c, model.NewValidationError("Lampiran masih dalam proses pemindaian antivirus. Silakan submit beberapa saat lagi."))
+		}
+	}
+
+	members, err := s.memberRepo.GetByAchievementID(c.Context(), id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	for _, m := range members {
+		if m.InvitationStatus == model.InvitationInvited {
+			return helper.HandleError(c, model.NewValidationError("Masih ada anggota tim yang belum mengonfirmasi keikutsertaan."))
+		}
+	}
+
 	err = s.achRepo.Submit(c.Context(), id)
 	if err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
 
+	s.recordRevision(c.Context(), achRef, model.RevisionEventSubmitted, "submitted", userID)
+
+	self, candidates, err := s.achRepo.FindDuplicateCandidates(c.Context(), achRef.MongoAchievementID, duplicateCandidateLimit)
+	if err == nil {
+		warnings := detectDuplicates(self, candidates, time.Now())
+		if err := s.achRepo.SaveDuplicateWarnings(c.Context(), achRef.MongoAchievementID, warnings); err != nil {
+			log.Printf("⚠️  Gagal menyimpan hasil deteksi duplikasi prestasi %s: %v", id, err)
+		}
+	} else {
+		log.Printf("⚠️  Gagal menjalankan deteksi duplikasi prestasi %s: %v", id, err)
+	}
+
 	return helper.Success(c, "Prestasi berhasil disubmit ke Dosen Wali", nil)
 }
 
@@ -377,10 +744,36 @@ func (s *AchievementService) Verify(c *fiber.Ctx) error {
 		return helper.HandleError(c, model.NewValidationError("Hanya prestasi yang sudah disubmit yang dapat diverifikasi."))
 	}
 
-	err = s.achRepo.Verify(c.Context(), id, userID, req.Points)
+	achType, err := s.typeRepo.Resolve(c.Context(), achDetail.AchievementType)
 	if err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
+	if achType != nil && achType.MaxPoints > 0 && (req.Points < achType.MinPoints || req.Points > achType.MaxPoints) {
+		return helper.HandleError(c, model.NewValidationError(fmt.Sprintf("Poin untuk jenis %s harus di antara %d dan %d", achType.NameID, achType.MinPoints, achType.MaxPoints)))
+	}
+
+	// Dosen hanya boleh memverifikasi file yang sama persis dengan yang diupload mahasiswa
+	for _, a := range achDetail.Attachments {
+		if a.IsLink() {
+			continue
+		}
+		problem, err := checkAttachmentIntegrity(c.Context(), s.store, a, "achievements/")
+		if err != nil {
+			return helper.HandleError(c, model.ErrDatabaseError)
+		}
+		if problem != "" && problem != model.IntegrityUnverifiable {
+			log.Printf("🚨 Integritas lampiran %s pada prestasi %s tidak valid (%s)", a.FileName, id, problem)
+			return helper.HandleError(c, model.NewValidationError(fmt.Sprintf("Lampiran %s tidak sesuai dengan file yang diupload (%s). Hubungi Admin sebelum memverifikasi.", a.FileName, problem)))
+		}
+	}
+
+	// Lampiran dibekukan dan anggota bimbingan dosen ini diverifikasi dalam transaksi yang sama dengan perubahan status
+	err = s.achRepo.Verify(c.Context(), id, userID, lecturerInfo.ID, req.Points, evidenceDigest(achDetail.Attachments))
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	s.recordRevision(c.Context(), achRef, model.RevisionEventVerified, "verified", userID)
 
 	return helper.Success(c, "Prestasi berhasil diverifikasi dan poin disimpan", nil)
 }
@@ -440,39 +833,59 @@ func (s *AchievementService) Reject(c *fiber.Ctx) error {
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
 
+	s.recordRevision(c.Context(), achRef, model.RevisionEventRejected, "rejected", userID)
+
 	return helper.Success(c, "Prestasi ditolak dan dikembalikan ke mahasiswa", nil)
 }
 
 // GetAll godoc
 // @Summary List achievements
-// @Description Get paginated list of achievements with role-based filtering
+// @Description Get paginated list of achievements with role-based filtering. With format=csv|xlsx|pdf|docx (or a matching Accept header) every achievement matching the filters is exported; page, limit and cursor are ignored.
 // @Tags Achievements
 // @Accept json
 // @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
-// @Param search query string false "Search by title"
+// @Param search query string false "Full-text search over title, description and tags, or student name/NIM"
 // @Param status query string false "Filter by status" Enums(draft, submitted, verified, rejected)
+// @Param type query string false "Filter by achievement type code"
+// @Param tags query string false "Comma-separated tags; achievements must have all of them"
+// @Param date_from query string false "Reported on or after (YYYY-MM-DD)"
+// @Param date_to query string false "Reported on or before (YYYY-MM-DD)"
+// @Param min_points query int false "Minimum points"
+// @Param max_points query int false "Maximum points"
+// @Param program_study query string false "Filter by student program study"
+// @Param academic_year query string false "Filter by student academic year"
+// @Param sort_by query string false "Sort field" Enums(created_at, title, points, student_name)
+// @Param sort_order query string false "Sort order" Enums(asc, desc)
+// @Param cursor query string false "Opaque keyset cursor from next_cursor/prev_cursor; send empty to start cursor pagination (total, page and total_pages are not computed)"
+// @Param format query string false "Output format" Enums(json, csv, xlsx, pdf, docx) default(json)
 // @Success 200 {object} helper.Response{data=model.PaginatedAchievements} "Achievements retrieved"
+// @Failure 400 {object} helper.ErrorResponse "Invalid filter"
 // @Router /achievements [get]
 func (s *AchievementService) GetAll(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(string)
 	roleName := c.Locals("role").(string)
 
-	page := c.QueryInt("page", 1)
-	pageSize := c.QueryInt("limit", 10)
-	search := c.Query("search", "")
-	status := c.Query("status", "")
-
-	if page < 1 {
-		page = 1
+	format, err := exportFormat(c)
+	if err != nil {
+		return helper.HandleError(c, err)
 	}
-	if pageSize < 1 || pageSize > 100 {
-		pageSize = 10
+
+	filter, err := parseAchievementFilter(c)
+	if err != nil {
+		return helper.HandleError(c, err)
 	}
 
-	var studentIDFilter, advisorIDFilter string
+	cursorMode, cursor, err := cursorParam(c, filter.SortBy, filter.SortOrder)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
 
 	switch roleName {
 	case "Mahasiswa":
@@ -483,7 +896,7 @@ func (s *AchievementService) GetAll(c *fiber.Ctx) error {
 		if studentInfo == nil {
 			return helper.HandleError(c, model.NewValidationError("Data mahasiswa tidak ditemukan"))
 		}
-		studentIDFilter = studentInfo.ID
+		filter.StudentID = studentInfo.ID
 
 	case "Dosen Wali":
 		lecturerInfo, err := s.lecturerSvc.GetProfile(c.Context(), userID)
@@ -493,28 +906,190 @@ func (s *AchievementService) GetAll(c *fiber.Ctx) error {
 		if lecturerInfo == nil {
 			return helper.HandleError(c, model.NewValidationError("Data dosen tidak ditemukan"))
 		}
-		advisorIDFilter = lecturerInfo.ID
+		filter.AdvisorID = lecturerInfo.ID
 	default:
 	}
 
-	data, total, err := s.achRepo.GetAll(c.Context(), page, pageSize, search, studentIDFilter, advisorIDFilter, status)
+	if format != export.FormatJSON {
+		return s.exportAchievements(c, format, filter)
+	}
+
+	if cursorMode {
+		data, links, err := s.achRepo.GetAllByCursor(c.Context(), filter, cursor)
+		if err != nil {
+			return helper.HandleError(c, model.ErrDatabaseError)
+		}
+
+		return helper.Success(c, "Daftar prestasi berhasil diambil", &model.PaginatedAchievements{
+			Data:       data,
+			PageSize:   filter.PageSize,
+			NextCursor: helper.EncodeCursor(links.Next),
+			PrevCursor: helper.EncodeCursor(links.Prev),
+		})
+	}
+
+	data, total, err := s.achRepo.GetAll(c.Context(), filter)
 	if err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
 
-	totalPages := int(math.Ceil(float64(total) / float64(pageSize)))
+	totalPages := int(math.Ceil(float64(total) / float64(filter.PageSize)))
 
 	result := &model.PaginatedAchievements{
 		Data:       data,
 		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
 		TotalPages: totalPages,
 	}
 
 	return helper.Success(c, "Daftar prestasi berhasil diambil", result)
 }
 
+var achievementColumns = []export.Column{
+	{Header: "NIM", Width: 14},
+	{Header: "Mahasiswa", Width: 26},
+	{Header: "Judul", Width: 44},
+	{Header: "Jenis", Width: 14},
+	{Header: "Status", Width: 11},
+	{Header: "Poin", Width: 7, Align: export.AlignRight},
+	{Header: "Dilaporkan", Width: 16},
+}
+
+// exportAchievements mengekspor semua prestasi yang cocok dengan filter, dialirkan per halaman keyset
+func (s *AchievementService) exportAchievements(c *fiber.Ctx, format export.Format, filter model.AchievementFilter) error {
+	filter.PageSize = exportPageSize
+	first, links, err := s.achRepo.GetAllByCursor(c.Context(), filter, nil)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	lines := []string{exportPeriod(filter.DateFrom, filter.DateTo)}
+	if filter.Status != "" {
+		lines = append(lines, "Status "+filter.Status)
+	}
+	if filter.AchievementType != "" {
+		lines = append(lines, "Jenis "+filter.AchievementType)
+	}
+	if filter.Search != "" {
+		lines = append(lines, fmt.Sprintf("Pencarian \"%s\"", filter.Search))
+	}
+	doc := export.Document{
+		Title:      "Daftar Prestasi Mahasiswa",
+		Subtitle:   subtitle(lines...),
+		Letterhead: s.letterhead,
+	}
+
+	fetch := func(ctx context.Context, cursor *model.PageCursor) ([]model.AchievementListDTO, model.CursorLinks, error) {
+		return s.achRepo.GetAllByCursor(ctx, filter, cursor)
+	}
+	row := func(a model.AchievementListDTO) []interface{} {
+		return []interface{}{a.StudentID, a.StudentName, a.Title, a.AchievementType, a.Status, a.Points, a.CreatedAt}
+	}
+	return sendExport(c, format, "daftar-prestasi", doc, exportPages("Prestasi", achievementColumns, first, links.Next, fetch, row))
+}
+
+var achievementSortFields = map[string]bool{
+	model.AchievementSortCreatedAt:   true,
+	model.AchievementSortTitle:       true,
+	model.AchievementSortPoints:      true,
+	model.AchievementSortStudentName: true,
+}
+
+// parseAchievementFilter membaca paginasi, filter dan urutan daftar prestasi dari query string
+func parseAchievementFilter(c *fiber.Ctx) (model.AchievementFilter, error) {
+	filter := model.AchievementFilter{
+		Page:            c.QueryInt("page", 1),
+		PageSize:        c.QueryInt("limit", 10),
+		Search:          strings.TrimSpace(c.Query("search")),
+		Status:          c.Query("status"),
+		AchievementType: strings.TrimSpace(c.Query("type")),
+		ProgramStudy:    strings.TrimSpace(c.Query("program_study")),
+		AcademicYear:    strings.TrimSpace(c.Query("academic_year")),
+		SortBy:          c.Query("sort_by", model.AchievementSortCreatedAt),
+		SortOrder:       strings.ToUpper(c.Query("sort_order", "desc")),
+	}
+
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 || filter.PageSize > 100 {
+		filter.PageSize = 10
+	}
+	if !achievementSortFields[filter.SortBy] {
+		filter.SortBy = model.AchievementSortCreatedAt
+	}
+	if filter.SortOrder != "ASC" && filter.SortOrder != "DESC" {
+		filter.SortOrder = "DESC"
+	}
+
+	for _, tag := range strings.Split(c.Query("tags"), ",") {
+		if tag = strings.TrimSpace(tag); tag != "" {
+			filter.Tags = append(filter.Tags, tag)
+		}
+	}
+
+	var err error
+	if filter.MinPoints, err = queryPoints(c, "min_points"); err != nil {
+		return filter, err
+	}
+	if filter.MaxPoints, err = queryPoints(c, "max_points"); err != nil {
+		return filter, err
+	}
+	if filter.MinPoints != nil && filter.MaxPoints != nil && *filter.MinPoints > *filter.MaxPoints {
+		return filter, model.NewValidationError("min_points tidak boleh lebih besar dari max_points")
+	}
+
+	if filter.DateFrom, filter.DateTo, err = queryDateRange(c); err != nil {
+		return filter, err
+	}
+
+	return filter, nil
+}
+
+// queryDateRange membaca date_from dan date_to. date_to inklusif: prestasi yang dilaporkan pada hari
+// tersebut ikut dihitung, sehingga batas atas yang dikembalikan adalah awal hari berikutnya.
+func queryDateRange(c *fiber.Ctx) (from, to *time.Time, err error) {
+	if from, err = queryDate(c, "date_from"); err != nil {
+		return nil, nil, err
+	}
+	if to, err = queryDate(c, "date_to"); err != nil {
+		return nil, nil, err
+	}
+	if to != nil {
+		end := to.AddDate(0, 0, 1)
+		to = &end
+	}
+	if from != nil && to != nil && !from.Before(*to) {
+		return nil, nil, model.NewValidationError("date_from tidak boleh setelah date_to")
+	}
+	return from, to, nil
+}
+
+func queryPoints(c *fiber.Ctx, key string) (*int, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	value, err := strconv.Atoi(raw)
+	if err != nil || value < 0 {
+		return nil, model.NewValidationError(key + " harus berupa bilangan bulat positif")
+	}
+	return &value, nil
+}
+
+func queryDate(c *fiber.Ctx, key string) (*time.Time, error) {
+	raw := c.Query(key)
+	if raw == "" {
+		return nil, nil
+	}
+	date, err := time.ParseInLocation("2006-01-02", raw, time.Local)
+	if err != nil {
+		return nil, model.NewValidationError(key + " harus berformat YYYY-MM-DD")
+	}
+	return &date, nil
+}
+
 // GetDetail godoc
 // @Summary Get achievement detail
 // @Description Get detailed information about specific achievement
@@ -532,7 +1107,7 @@ func (s *AchievementService) GetDetail(c *fiber.Ctx) error {
 	userID := c.Locals("user_id").(string)
 	roleName := c.Locals("role").(string)
 
-	detail, err := s.achRepo.GetDetailByID(c.Context(), id)
+	detail, err := getDetailWithMembers(c.Context(), s.achRepo, s.memberRepo, id)
 	if err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
@@ -540,24 +1115,19 @@ func (s *AchievementService) GetDetail(c *fiber.Ctx) error {
 		return helper.HandleError(c, model.NewNotFoundError("Prestasi tidak ditemukan"))
 	}
 
-	if roleName == "Mahasiswa" {
-		studentInfo, err := s.studentRepo.GetByUserID(c.Context(), userID)
-		if err != nil {
-			return helper.HandleError(c, model.ErrDatabaseError)
-		}
-
-		if studentInfo == nil || studentInfo.ID != detail.Student.ID.String() {
-			return helper.HandleError(c, model.NewValidationError("Anda tidak berhak melihat prestasi ini"))
-		}
-	} else if roleName == "Dosen Wali" {
-		lecturerInfo, err := s.lecturerSvc.GetProfile(c.Context(), userID)
-		if err != nil {
-			return helper.HandleError(c, model.ErrDatabaseError)
-		}
+	if err := authorizeAchievementView(c.Context(), s.studentRepo, s.lecturerSvc, userID, roleName, detail); err != nil {
+		return helper.HandleError(c, err)
+	}
 
-		if detail.Student.AdvisorID != nil && *detail.Student.AdvisorID != lecturerInfo.ID {
-			return helper.HandleError(c, model.NewValidationError("Mahasiswa ini bukan bimbingan anda"))
+	if roleName == "Mahasiswa" {
+		detail.DuplicateWarnings = nil
+	}
+	for i := range detail.Attachments {
+		if detail.Attachments[i].IsLink() {
+			continue
 		}
+		detail.Attachments[i].FileURL = achievementAttachmentURL(detail.ID, detail.Attachments[i].FileName)
+		setPreviewURLs(&detail.Attachments[i])
 	}
 
 	return helper.Success(c, "Detail prestasi berhasil diambil", detail)
@@ -623,7 +1193,7 @@ func (s *AchievementService) GetByStudent(c *fiber.Ctx) error {
 		return helper.HandleError(c, model.NewValidationError("Role tidak dikenali"))
 	}
 
-	data, total, err := s.achRepo.GetAll(c.Context(), page, pageSize, "", targetStudent.ID, "", status)
+	data, total, err := s.achRepo.GetAll(c.Context(), model.AchievementFilter{Page: page, PageSize: pageSize, StudentID: targetStudent.ID, Status: status})
 	if err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
@@ -639,4 +1209,117 @@ func (s *AchievementService) GetByStudent(c *fiber.Ctx) error {
 	}
 
 	return helper.Success(c, "Daftar prestasi mahasiswa berhasil diambil", result)
-}
\ No newline at end of file
+}
+
+const duplicateCandidateLimit = 500
+
+var eventDateKeys = []string{"event_date", "date", "tanggal", "tanggal_kegiatan", "competition_date"}
+
+// eventDate mengambil tanggal kegiatan dari details (format bebas, dinormalisasi ke YYYY-MM-DD bila bisa)
+func eventDate(details map[string]interface{}) string {
+	for _, key := range eventDateKeys {
+		raw, ok := details[key]
+		if !ok || raw == nil {
+			continue
+		}
+		switch v := raw.(type) {
+		case time.Time:
+			return v.Format("2006-01-02")
+		case string:
+			for _, layout := range []string{time.RFC3339, "2006-01-02", "02-01-2006", "02/01/2006"} {
+				if t, err := time.Parse(layout, strings.TrimSpace(v)); err == nil {
+					return t.Format("2006-01-02")
+				}
+			}
+			return utils.NormalizeText(v)
+		default:
+			return fmt.Sprint(v)
+		}
+	}
+	return ""
+}
+
+func detailsText(details map[string]interface{}) string {
+	keys := make([]string, 0, len(details))
+	for k := range details {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		parts = append(parts, fmt.Sprint(details[k]))
+	}
+	return strings.Join(parts, " ")
+}
+
+// detectDuplicates membandingkan prestasi dengan kandidat berdasarkan hash lampiran, kemiripan judul/details, dan tanggal kegiatan
+func detectDuplicates(self *model.AchievementMongo, candidates []model.DuplicateCandidate, now time.Time) []model.DuplicateWarning {
+	if self == nil {
+		return nil
+	}
+
+	hashes := make(map[string]bool)
+	for _, a := range self.Attachments {
+		if a.ContentHash != "" {
+			hashes[a.ContentHash] = true
+		}
+	}
+	selfDate := eventDate(self.Details)
+	selfDetails := detailsText(self.Details)
+
+	var warnings []model.DuplicateWarning
+	for _, cand := range candidates {
+		doc := cand.Document
+		var reasons []string
+		score := 0.0
+
+		for _, a := range doc.Attachments {
+			if a.ContentHash != "" && hashes[a.ContentHash] {
+				reasons = append(reasons, "Lampiran identik (hash isi file sama)")
+				score = 1
+				break
+			}
+		}
+
+		titleSim := utils.DiceSimilarity(self.Title, doc.Title)
+		detailSim := utils.DiceSimilarity(selfDetails, detailsText(doc.Details))
+		sameDate := selfDate != "" && selfDate == eventDate(doc.Details)
+
+		if titleSim >= 0.85 {
+			reasons = append(reasons, fmt.Sprintf("Judul sangat mirip (%.0f%%)", titleSim*100))
+		}
+		if titleSim >= 0.6 && detailSim >= 0.8 {
+			reasons = append(reasons, fmt.Sprintf("Detail kegiatan mirip (%.0f%%)", detailSim*100))
+		}
+		if titleSim >= 0.6 && sameDate {
+			reasons = append(reasons, "Tanggal kegiatan sama ("+selfDate+")")
+		}
+
+		if len(reasons) == 0 {
+			continue
+		}
+
+		weighted := 0.6*titleSim + 0.25*detailSim
+		if sameDate {
+			weighted += 0.15
+		}
+		if weighted > score {
+			score = weighted
+		}
+
+		warnings = append(warnings, model.DuplicateWarning{
+			AchievementID: cand.AchievementID,
+			StudentID:     cand.StudentID,
+			StudentName:   cand.StudentName,
+			Title:         doc.Title,
+			Status:        cand.Status,
+			Score:         math.Round(score*100) / 100,
+			Reasons:       reasons,
+			DetectedAt:    now,
+		})
+	}
+
+	sort.Slice(warnings, func(i, j int) bool { return warnings[i].Score > warnings[j].Score })
+	return warnings
+}
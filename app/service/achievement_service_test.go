@@ -2,11 +2,23 @@ package service
 
 import (
 	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
 	"net/http/httptest"
+	"net/textproto"
+	"strings"
 	"testing"
+	"time"
 
 	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -21,7 +33,7 @@ func TestAchievementService_Create(t *testing.T) {
 	}
 	mockLecturerSvc := &MockLecturerService{}
 
-	service := NewAchievementService(mockAchRepo, mockStudentRepo, mockLecturerSvc)
+	service := NewAchievementService(mockAchRepo, mockStudentRepo, newMockAchievementTypeRepository("nasional"), newMockAchievementMemberRepository(), &MockAchievementRevisionRepository{}, mockLecturerSvc, storage.NewLocal(t.TempDir()), export.Letterhead{})
 
 	userID := "user-mhs-1"
 	studentID := "student-1"
@@ -52,6 +64,26 @@ func TestAchievementService_Create(t *testing.T) {
 			t.Errorf("Expected 201 or 200 status, got %d", resp.StatusCode)
 		}
 	})
+
+	t.Run("POST - Create Achievement Unknown Type", func(t *testing.T) {
+		reqBody := model.CreateAchievementRequest{
+			Title:           "Juara 1 Hackathon",
+			AchievementType: "competition",
+		}
+		body, _ := json.Marshal(reqBody)
+
+		req := httptest.NewRequest("POST", "/achievements", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", resp.StatusCode)
+		}
+	})
 }
 
 func TestAchievementService_Submit(t *testing.T) {
@@ -63,8 +95,9 @@ func TestAchievementService_Submit(t *testing.T) {
 		students: make(map[string]*model.StudentInfo),
 	}
 	mockLecturerSvc := &MockLecturerService{}
+	mockMemberRepo := newMockAchievementMemberRepository()
 
-	service := NewAchievementService(mockAchRepo, mockStudentRepo, mockLecturerSvc)
+	service := NewAchievementService(mockAchRepo, mockStudentRepo, newMockAchievementTypeRepository("nasional"), mockMemberRepo, &MockAchievementRevisionRepository{}, mockLecturerSvc, storage.NewLocal(t.TempDir()), export.Letterhead{})
 
 	userID := "user-mhs-1"
 	studentID := "student-1"
@@ -74,6 +107,9 @@ func TestAchievementService_Submit(t *testing.T) {
 	mockAchRepo.achRefs[achID] = &model.AchievementReference{
 		ID: achID, StudentID: studentID, Status: "draft",
 	}
+	mockAchRepo.achDetail = &model.AchievementDetailDTO{
+		Attachments: []model.AchievementAttachment{{FileName: "ACH-1.pdf", ScanStatus: model.ScanStatusClean}},
+	}
 
 	app.Post("/achievements/:id/submit", func(c *fiber.Ctx) error {
 		c.Locals("user_id", userID)
@@ -92,6 +128,42 @@ func TestAchievementService_Submit(t *testing.T) {
 			t.Errorf("Expected 200 status, got %d", resp.StatusCode)
 		}
 	})
+
+	t.Run("POST - Submit With Pending Team Invitation", func(t *testing.T) {
+		pendingID := "ach-team"
+		mockAchRepo.achRefs[pendingID] = &model.AchievementReference{
+			ID: pendingID, StudentID: studentID, Status: "draft",
+		}
+		mockMemberRepo.AddMembers(context.Background(), pendingID, []string{"student-2"})
+
+		req := httptest.NewRequest("POST", "/achievements/"+pendingID+"/submit", nil)
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("POST - Submit With Unscanned Attachment", func(t *testing.T) {
+		mockAchRepo.achRefs[achID].Status = "draft"
+		mockAchRepo.achDetail.Attachments = append(mockAchRepo.achDetail.Attachments,
+			model.AchievementAttachment{FileName: "ACH-2.pdf", ScanStatus: model.ScanStatusPending})
+
+		req := httptest.NewRequest("POST", "/achievements/"+achID+"/submit", nil)
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", resp.StatusCode)
+		}
+	})
 }
 
 func TestAchievementService_Verify(t *testing.T) {
@@ -104,16 +176,25 @@ func TestAchievementService_Verify(t *testing.T) {
 	}
 	mockLecturerSvc := &MockLecturerService{}
 
-	service := NewAchievementService(mockAchRepo, mockStudentRepo, mockLecturerSvc)
+	store := storage.NewLocal(t.TempDir())
+	service := NewAchievementService(mockAchRepo, mockStudentRepo, newMockAchievementTypeRepository("nasional"), newMockAchievementMemberRepository(), &MockAchievementRevisionRepository{}, mockLecturerSvc, store, export.Letterhead{})
 
 	lecturerUserID := "user-dosen-1"
 	lecturerID := "dosen-1"
 	achID := "ach-1"
 
+	evidence := []byte("%PDF-1.4 sertifikat")
+	sum := sha256.Sum256(evidence)
+	store.Put(context.Background(), "achievements/ACH-1.pdf", bytes.NewReader(evidence), int64(len(evidence)), "application/pdf")
+
 	mockLecturerSvc.lecturerInfo = &model.LecturerInfo{ID: lecturerID}
 	mockAchRepo.achRefs[achID] = &model.AchievementReference{ID: achID, Status: "submitted"}
 	mockAchRepo.achDetail = &model.AchievementDetailDTO{
 		Student: model.StudentListDTO{AdvisorID: &lecturerID},
+		Attachments: []model.AchievementAttachment{{
+			ID: "att-1", FileName: "ACH-1.pdf", StorageKey: "achievements/ACH-1.pdf",
+			ContentHash: hex.EncodeToString(sum[:]), Size: int64(len(evidence)), ScanStatus: model.ScanStatusClean,
+		}},
 	}
 
 	app.Post("/achievements/:id/verify", func(c *fiber.Ctx) error {
@@ -121,10 +202,8 @@ func TestAchievementService_Verify(t *testing.T) {
 		return service.Verify(c)
 	})
 
-	t.Run("POST - Verify Achievement by Advisor", func(t *testing.T) {
-		reqBody := model.VerifyAchievementRequest{Points: 100}
-		body, _ := json.Marshal(reqBody)
-
+	verify := func() int {
+		body, _ := json.Marshal(model.VerifyAchievementRequest{Points: 100})
 		req := httptest.NewRequest("POST", "/achievements/"+achID+"/verify", bytes.NewReader(body))
 		req.Header.Set("Content-Type", "application/json")
 
@@ -132,9 +211,46 @@ func TestAchievementService_Verify(t *testing.T) {
 		if err != nil {
 			t.Fatalf("Failed to send request: %v", err)
 		}
+		return resp.StatusCode
+	}
 
-		if resp.StatusCode != fiber.StatusOK {
-			t.Errorf("Expected 200 status, got %d", resp.StatusCode)
+	t.Run("POST - Modified Attachment Blocks Verification", func(t *testing.T) {
+		tampered := []byte("%PDF-1.4 sertifikaT")
+		store.Put(context.Background(), "achievements/ACH-1.pdf", bytes.NewReader(tampered), int64(len(tampered)), "application/pdf")
+		defer store.Put(context.Background(), "achievements/ACH-1.pdf", bytes.NewReader(evidence), int64(len(evidence)), "application/pdf")
+
+		if status := verify(); status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", status)
+		}
+		if mockAchRepo.evidenceDigest != "" {
+			t.Errorf("Expected attachments not to be frozen")
+		}
+	})
+
+	t.Run("POST - Failed Verify Write Is Reported", func(t *testing.T) {
+		mockAchRepo.verifyErr = fmt.Errorf("koneksi terputus")
+		defer func() { mockAchRepo.verifyErr = nil }()
+
+		if status := verify(); status != fiber.StatusInternalServerError {
+			t.Errorf("Expected 500 status, got %d", status)
+		}
+		if mockAchRepo.evidenceDigest != "" || mockAchRepo.achDetail.Attachments[0].FrozenAt != nil {
+			t.Errorf("Expected attachments not to be frozen")
+		}
+	})
+
+	t.Run("POST - Verify Achievement by Advisor", func(t *testing.T) {
+		if status := verify(); status != fiber.StatusOK {
+			t.Errorf("Expected 200 status, got %d", status)
+		}
+		if mockAchRepo.evidenceDigest != evidenceDigest(mockAchRepo.achDetail.Attachments) {
+			t.Errorf("Expected evidence digest to be recorded")
+		}
+		if mockAchRepo.achDetail.Attachments[0].FrozenAt == nil {
+			t.Errorf("Expected attachments to be frozen")
+		}
+		if mockAchRepo.verifiedAdvisor != lecturerID {
+			t.Errorf("Expected advisor's team members to be verified in the same write, got advisor %q", mockAchRepo.verifiedAdvisor)
 		}
 	})
 }
@@ -149,7 +265,7 @@ func TestAchievementService_Delete(t *testing.T) {
 	}
 	mockLecturerSvc := &MockLecturerService{}
 
-	service := NewAchievementService(mockAchRepo, mockStudentRepo, mockLecturerSvc)
+	service := NewAchievementService(mockAchRepo, mockStudentRepo, newMockAchievementTypeRepository("nasional"), newMockAchievementMemberRepository(), &MockAchievementRevisionRepository{}, mockLecturerSvc, storage.NewLocal(t.TempDir()), export.Letterhead{})
 
 	userID := "user-mhs-1"
 	studentID := "student-1"
@@ -177,4 +293,455 @@ func TestAchievementService_Delete(t *testing.T) {
 			t.Errorf("Expected 200 status, got %d", resp.StatusCode)
 		}
 	})
-}
\ No newline at end of file
+}
+func TestAchievementService_GetAllFilters(t *testing.T) {
+	app := fiber.New()
+	mockAchRepo := &MockAchievementRepository{
+		achRefs: make(map[string]*model.AchievementReference),
+	}
+	mockStudentRepo := &MockStudentRepository{
+		students: make(map[string]*model.StudentInfo),
+	}
+
+	service := NewAchievementService(mockAchRepo, mockStudentRepo, newMockAchievementTypeRepository("kompetisi"), newMockAchievementMemberRepository(), &MockAchievementRevisionRepository{}, &MockLecturerService{}, storage.NewLocal(t.TempDir()), export.Letterhead{})
+
+	mockStudentRepo.students["user-mhs-1"] = &model.StudentInfo{ID: "student-1"}
+
+	role := "Admin"
+	app.Get("/achievements", func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-mhs-1")
+		c.Locals("role", role)
+		return service.GetAll(c)
+	})
+
+	get := func(query string) int {
+		resp, err := app.Test(httptest.NewRequest("GET", "/achievements?"+query, nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	t.Run("GET - All Filters Passed To Repository", func(t *testing.T) {
+		status := get("search=hackathon&type=kompetisi&tags=ai,%20nasional&date_from=2024-01-01&date_to=2024-12-31&min_points=10&max_points=50&program_study=Informatika&academic_year=2021&sort_by=title&sort_order=asc&page=2&limit=20")
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", status)
+		}
+
+		f := mockAchRepo.lastFilter
+		if f.Search != "hackathon" || f.AchievementType != "kompetisi" || f.ProgramStudy != "Informatika" || f.AcademicYear != "2021" {
+			t.Errorf("Unexpected text filters %+v", f)
+		}
+		if len(f.Tags) != 2 || f.Tags[0] != "ai" || f.Tags[1] != "nasional" {
+			t.Errorf("Expected tags [ai nasional], got %v", f.Tags)
+		}
+		if f.MinPoints == nil || *f.MinPoints != 10 || f.MaxPoints == nil || *f.MaxPoints != 50 {
+			t.Errorf("Unexpected points range %v-%v", f.MinPoints, f.MaxPoints)
+		}
+		if f.DateFrom == nil || f.DateFrom.Format("2006-01-02") != "2024-01-01" || f.DateTo == nil || f.DateTo.Format("2006-01-02") != "2025-01-01" {
+			t.Errorf("Expected date_to to include the whole day, got %v - %v", f.DateFrom, f.DateTo)
+		}
+		if f.SortBy != model.AchievementSortTitle || f.SortOrder != "ASC" || f.Page != 2 || f.PageSize != 20 {
+			t.Errorf("Unexpected sort/pagination %+v", f)
+		}
+	})
+
+	t.Run("GET - Unknown Sort Falls Back To Created At", func(t *testing.T) {
+		get("sort_by=password&sort_order=sideways")
+		if f := mockAchRepo.lastFilter; f.SortBy != model.AchievementSortCreatedAt || f.SortOrder != "DESC" || f.Tags != nil {
+			t.Errorf("Unexpected default sort %+v", f)
+		}
+	})
+
+	t.Run("GET - Student Only Sees Own Achievements", func(t *testing.T) {
+		role = "Mahasiswa"
+		defer func() { role = "Admin" }()
+
+		get("status=verified")
+		if f := mockAchRepo.lastFilter; f.StudentID != "student-1" || f.Status != "verified" {
+			t.Errorf("Expected student filter, got %+v", f)
+		}
+	})
+
+	t.Run("GET - Cursor Mode Sorts By Projected Fields", func(t *testing.T) {
+		if status := get("cursor=&sort_by=points"); status != fiber.StatusOK {
+			t.Errorf("Expected 200 for cursor mode sorted by points, got %d", status)
+		}
+		if f := mockAchRepo.lastFilter; f.SortBy != model.AchievementSortPoints {
+			t.Errorf("Expected points sort passed to repository, got %+v", f)
+		}
+		if status := get("cursor=&sort_by=student_name&type=kompetisi"); status != fiber.StatusOK {
+			t.Errorf("Expected 200 for cursor mode sorted by student name, got %d", status)
+		}
+	})
+
+	t.Run("GET - Cursor Next Page Keeps Requested Sort", func(t *testing.T) {
+		mockAchRepo.listPages = [][]model.AchievementListDTO{{{Title: "A"}}, {{Title: "B"}}}
+		defer func() { mockAchRepo.listPages = nil }()
+
+		for _, sortBy := range []string{model.AchievementSortTitle, model.AchievementSortPoints} {
+			resp, err := app.Test(httptest.NewRequest("GET", "/achievements?cursor=&sort_by="+sortBy, nil))
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			var first struct {
+				Data model.PaginatedAchievements `json:"data"`
+			}
+			json.NewDecoder(resp.Body).Decode(&first)
+			if first.Data.NextCursor == "" {
+				t.Fatalf("Expected next_cursor for sort_by=%s", sortBy)
+			}
+
+			if status := get("cursor=" + first.Data.NextCursor + "&sort_by=" + sortBy); status != fiber.StatusOK {
+				t.Errorf("Expected page 2 with sort_by=%s to be accepted, got %d", sortBy, status)
+			}
+			if status := get("cursor=" + first.Data.NextCursor + "&sort_by=created_at"); status != fiber.StatusBadRequest {
+				t.Errorf("Expected cursor from sort_by=%s to be rejected for created_at, got %d", sortBy, status)
+			}
+		}
+	})
+
+	t.Run("GET - Export Streams Every Page", func(t *testing.T) {
+		created := time.Date(2024, 5, 1, 9, 0, 0, 0, time.UTC)
+		mockAchRepo.listPages = [][]model.AchievementListDTO{
+			{{StudentID: "210001", StudentName: "Ani", Title: "Juara 1 Hackathon", AchievementType: "kompetisi", Status: "verified", Points: 40, CreatedAt: created}},
+			{{StudentID: "210002", StudentName: "Budi", Title: "Finalis, \"Lomba\" Robotik", AchievementType: "kompetisi", Status: "submitted", CreatedAt: created}},
+		}
+		mockAchRepo.cursorCalls = 0
+		defer func() { mockAchRepo.listPages = nil }()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/achievements?format=csv&status=verified&page=3&limit=5", nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", resp.StatusCode)
+		}
+		if ct := resp.Header.Get(fiber.HeaderContentType); !strings.HasPrefix(ct, "text/csv") {
+			t.Errorf("Expected CSV content type, got %s", ct)
+		}
+		if cd := resp.Header.Get(fiber.HeaderContentDisposition); !strings.Contains(cd, `filename="daftar-prestasi-`) || !strings.HasSuffix(cd, `.csv"`) {
+			t.Errorf("Unexpected Content-Disposition %s", cd)
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		want := "Prestasi\nNIM,Mahasiswa,Judul,Jenis,Status,Poin,Dilaporkan\n" +
+			"210001,Ani,Juara 1 Hackathon,kompetisi,verified,40,2024-05-01 09:00\n" +
+			"210002,Budi,\"Finalis, \"\"Lomba\"\" Robotik\",kompetisi,submitted,0,2024-05-01 09:00\n"
+		if got := strings.TrimPrefix(string(body), "\xEF\xBB\xBF"); got != want {
+			t.Errorf("Unexpected CSV:\n%s", got)
+		}
+		if mockAchRepo.cursorCalls != 2 {
+			t.Errorf("Expected both pages to be fetched, got %d calls", mockAchRepo.cursorCalls)
+		}
+		if f := mockAchRepo.lastFilter; f.Status != "verified" || f.PageSize != exportPageSize {
+			t.Errorf("Expected filters kept and export page size, got %+v", f)
+		}
+	})
+
+	t.Run("GET - Export Negotiated From Accept Header", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/achievements", nil)
+		req.Header.Set("Accept", "application/pdf")
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if resp.Header.Get(fiber.HeaderContentType) != "application/pdf" || !strings.HasPrefix(string(body), "%PDF-") {
+			t.Errorf("Expected a PDF, got %s", resp.Header.Get(fiber.HeaderContentType))
+		}
+	})
+
+	t.Run("GET - Invalid Filters", func(t *testing.T) {
+		for _, query := range []string{
+			"format=odt",
+			"min_points=abc",
+			"min_points=50&max_points=10",
+			"date_from=01-01-2024",
+			"date_from=2024-12-31&date_to=2024-01-01",
+		} {
+			if status := get(query); status != fiber.StatusBadRequest {
+				t.Errorf("Expected 400 for %s, got %d", query, status)
+			}
+		}
+	})
+}
+
+func TestDetectDuplicates(t *testing.T) {
+	self := &model.AchievementMongo{
+		Title:       "Juara 1 Lomba Hackathon Nasional 2024",
+		Details:     map[string]interface{}{"event_date": "2024-05-12", "penyelenggara": "Kemendikbud"},
+		Attachments: []model.AchievementAttachment{{ContentHash: "abc123"}},
+	}
+
+	candidates := []model.DuplicateCandidate{
+		{AchievementID: "same-file", Document: model.AchievementMongo{
+			Title:       "Sertifikat Peserta",
+			Attachments: []model.AchievementAttachment{{ContentHash: "abc123"}},
+		}},
+		{AchievementID: "similar-title", Document: model.AchievementMongo{
+			Title:   "Juara I Lomba Hackathon Nasional 2024",
+			Details: map[string]interface{}{"tanggal": "12/05/2024"},
+		}},
+		{AchievementID: "unrelated", Document: model.AchievementMongo{
+			Title:   "Ketua Himpunan Mahasiswa",
+			Details: map[string]interface{}{"event_date": "2024-05-12"},
+		}},
+	}
+
+	warnings := detectDuplicates(self, candidates, time.Now())
+
+	found := make(map[string]bool)
+	for _, w := range warnings {
+		found[w.AchievementID] = true
+	}
+
+	if !found["same-file"] {
+		t.Errorf("Expected identical attachment to be flagged")
+	}
+	if !found["similar-title"] {
+		t.Errorf("Expected near-identical title with same event date to be flagged")
+	}
+	if found["unrelated"] {
+		t.Errorf("Same date alone should not be flagged as duplicate")
+	}
+	if len(warnings) > 0 && warnings[0].AchievementID != "same-file" {
+		t.Errorf("Expected identical attachment to rank first, got %s", warnings[0].AchievementID)
+	}
+}
+
+func TestAchievementService_UploadAttachment(t *testing.T) {
+	mockAchRepo := &MockAchievementRepository{
+		achRefs: make(map[string]*model.AchievementReference),
+	}
+	mockStudentRepo := &MockStudentRepository{
+		students: make(map[string]*model.StudentInfo),
+	}
+	store := storage.NewLocal(t.TempDir())
+	service := NewAchievementService(mockAchRepo, mockStudentRepo, newMockAchievementTypeRepository("nasional"),
+		newMockAchievementMemberRepository(), &MockAchievementRevisionRepository{}, &MockLecturerService{}, store, export.Letterhead{})
+
+	userID := "user-mhs-1"
+	mockStudentRepo.students[userID] = &model.StudentInfo{ID: "student-1"}
+	mockAchRepo.achRefs["ach-1"] = &model.AchievementReference{ID: "ach-1", StudentID: "student-1", Status: "draft"}
+
+	app := fiber.New()
+	app.Post("/achievements/:id/attachments", func(c *fiber.Ctx) error {
+		c.Locals("user_id", userID)
+		return service.UploadAttachment(c)
+	})
+
+	upload := func(fileName, contentType string, content []byte) (int, model.AchievementAttachment) {
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		header := textproto.MIMEHeader{}
+		header.Set("Content-Disposition", `form-data; name="file"; filename="`+fileName+`"`)
+		header.Set("Content-Type", contentType)
+		part, _ := writer.CreatePart(header)
+		part.Write(content)
+		writer.Close()
+
+		req := httptest.NewRequest("POST", "/achievements/ach-1/attachments", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+
+		var result struct {
+			Data model.AchievementAttachment `json:"data"`
+		}
+		json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result.Data
+	}
+
+	t.Run("POST - Renamed Executable Rejected", func(t *testing.T) {
+		status, _ := upload("sertifikat.pdf", "application/pdf", []byte("MZ\x90\x00\x03\x00\x00\x00 this program cannot be run in DOS mode"))
+		if status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", status)
+		}
+	})
+
+	t.Run("POST - Extension Mismatch Rejected", func(t *testing.T) {
+		status, _ := upload("foto.pdf", "application/pdf", []byte("\xFF\xD8\xFF\xE0\x00\x04JF\xFF\xD9"))
+		if status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", status)
+		}
+	})
+
+	t.Run("POST - Malformed PDF Rejected", func(t *testing.T) {
+		status, _ := upload("sertifikat.pdf", "application/pdf", []byte("%PDF-1.4 not really a pdf"))
+		if status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", status)
+		}
+	})
+
+	t.Run("POST - JPEG EXIF Stripped", func(t *testing.T) {
+		jpeg := []byte{0xFF, 0xD8}
+		jpeg = append(jpeg, 0xFF, 0xE0, 0x00, 0x06, 'J', 'F', 'I', 'F')
+		exif := append([]byte("Exif\x00\x00"), []byte("GPSLatitude -6.2")...)
+		jpeg = append(jpeg, 0xFF, 0xE1, 0x00, byte(len(exif)+2))
+		jpeg = append(jpeg, exif...)
+		jpeg = append(jpeg, 0xFF, 0xDA, 0x00, 0x02, 0x12, 0x34, 0xFF, 0xD9)
+
+		status, attachment := upload("Foto.JPG", "application/octet-stream", jpeg)
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", status)
+		}
+		if attachment.FileType != "image/jpeg" {
+			t.Errorf("Expected detected type image/jpeg, got %s", attachment.FileType)
+		}
+		if attachment.ScanStatus != model.ScanStatusPending {
+			t.Errorf("Expected upload to wait for antivirus scan, got %q", attachment.ScanStatus)
+		}
+
+		rc, _, err := store.Get(context.Background(), model.QuarantinePrefix+"achievements/"+attachment.FileName)
+		if err != nil {
+			t.Fatalf("Stored file not found: %v", err)
+		}
+		defer rc.Close()
+		stored, _ := io.ReadAll(rc)
+		if bytes.Contains(stored, []byte("GPSLatitude")) || !bytes.Contains(stored, []byte("JFIF")) {
+			t.Errorf("Expected EXIF segment to be stripped and JFIF kept, got %q", stored)
+		}
+	})
+}
+
+func TestAchievementService_DeleteAndReplaceAttachment(t *testing.T) {
+	mockAchRepo := &MockAchievementRepository{
+		achRefs: make(map[string]*model.AchievementReference),
+	}
+	mockStudentRepo := &MockStudentRepository{
+		students: make(map[string]*model.StudentInfo),
+	}
+	store := storage.NewLocal(t.TempDir())
+	service := NewAchievementService(mockAchRepo, mockStudentRepo, newMockAchievementTypeRepository("nasional"),
+		newMockAchievementMemberRepository(), &MockAchievementRevisionRepository{}, &MockLecturerService{}, store, export.Letterhead{})
+
+	ctx := context.Background()
+	mockStudentRepo.students["user-mhs-1"] = &model.StudentInfo{ID: "student-1"}
+	mockStudentRepo.students["user-mhs-2"] = &model.StudentInfo{ID: "student-2"}
+	mockAchRepo.achRefs["ach-1"] = &model.AchievementReference{ID: "ach-1", StudentID: "student-1", Status: "draft"}
+
+	reset := func() {
+		store.Put(ctx, "achievements/new.pdf", bytes.NewReader([]byte("new")), 3, "application/pdf")
+		store.Put(ctx, "achievements/legacy.pdf", bytes.NewReader([]byte("legacy")), 6, "application/pdf")
+		mockAchRepo.achRefs["ach-1"].Status = "draft"
+		mockAchRepo.achDetail = &model.AchievementDetailDTO{
+			ID:              "ach-1",
+			AchievementType: "nasional",
+			Attachments: []model.AchievementAttachment{
+				{ID: "att-1", FileName: "new.pdf", StorageKey: "achievements/new.pdf", ScanStatus: model.ScanStatusClean},
+				{FileName: "legacy.pdf", FileURL: "/uploads/achievements/legacy.pdf"},
+			},
+		}
+	}
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", c.Get("X-User"))
+		return c.Next()
+	})
+	app.Delete("/achievements/:id/attachments/:attachmentId", service.DeleteAttachment)
+	app.Put("/achievements/:id/attachments/:attachmentId", service.ReplaceAttachment)
+
+	send := func(req *http.Request, userID string) int {
+		req.Header.Set("X-User", userID)
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	t.Run("DELETE - Attachment By ID", func(t *testing.T) {
+		reset()
+		status := send(httptest.NewRequest("DELETE", "/achievements/ach-1/attachments/att-1", nil), "user-mhs-1")
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", status)
+		}
+		if len(mockAchRepo.achDetail.Attachments) != 1 || mockAchRepo.achDetail.Attachments[0].FileName != "legacy.pdf" {
+			t.Errorf("Expected only legacy attachment left, got %+v", mockAchRepo.achDetail.Attachments)
+		}
+		if _, err := store.Stat(ctx, "achievements/new.pdf"); err != storage.ErrNotFound {
+			t.Errorf("Expected stored file to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("DELETE - Legacy Attachment By File Name", func(t *testing.T) {
+		reset()
+		status := send(httptest.NewRequest("DELETE", "/achievements/ach-1/attachments/legacy.pdf", nil), "user-mhs-1")
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", status)
+		}
+		if _, err := store.Stat(ctx, "achievements/legacy.pdf"); err != storage.ErrNotFound {
+			t.Errorf("Expected legacy file to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("DELETE - Other Student Forbidden", func(t *testing.T) {
+		reset()
+		status := send(httptest.NewRequest("DELETE", "/achievements/ach-1/attachments/att-1", nil), "user-mhs-2")
+		if status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", status)
+		}
+		if len(mockAchRepo.achDetail.Attachments) != 2 {
+			t.Errorf("Expected attachments untouched, got %+v", mockAchRepo.achDetail.Attachments)
+		}
+	})
+
+	t.Run("DELETE - Submitted Achievement Locked", func(t *testing.T) {
+		reset()
+		mockAchRepo.achRefs["ach-1"].Status = "submitted"
+		status := send(httptest.NewRequest("DELETE", "/achievements/ach-1/attachments/att-1", nil), "user-mhs-1")
+		if status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", status)
+		}
+	})
+
+	t.Run("PUT - Replace Keeps Attachment ID", func(t *testing.T) {
+		reset()
+		png := []byte("\x89PNG\r\n\x1a\n")
+		png = append(png, 0, 0, 0, 0, 'I', 'E', 'N', 'D', 0xAE, 0x42, 0x60, 0x82)
+
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", "poster.png")
+		part.Write(png)
+		writer.Close()
+
+		req := httptest.NewRequest("PUT", "/achievements/ach-1/attachments/att-1", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		status := send(req, "user-mhs-1")
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", status)
+		}
+
+		replaced := mockAchRepo.achDetail.Attachments[0]
+		if replaced.ID != "att-1" || replaced.FileType != "image/png" || replaced.ScanStatus != model.ScanStatusPending {
+			t.Errorf("Unexpected replaced attachment %+v", replaced)
+		}
+		if _, err := store.Stat(ctx, replaced.StorageKey); err != nil {
+			t.Errorf("Expected new file to be stored: %v", err)
+		}
+		if _, err := store.Stat(ctx, "achievements/new.pdf"); err != storage.ErrNotFound {
+			t.Errorf("Expected old file to be deleted, got %v", err)
+		}
+	})
+
+	t.Run("PUT - Unknown Attachment", func(t *testing.T) {
+		reset()
+		body := &bytes.Buffer{}
+		writer := multipart.NewWriter(body)
+		part, _ := writer.CreateFormFile("file", "poster.png")
+		part.Write([]byte("\x89PNG\r\n\x1a\n"))
+		writer.Close()
+
+		req := httptest.NewRequest("PUT", "/achievements/ach-1/attachments/missing", body)
+		req.Header.Set("Content-Type", writer.FormDataContentType())
+		if status := send(req, "user-mhs-1"); status != fiber.StatusNotFound {
+			t.Errorf("Expected 404 status, got %d", status)
+		}
+	})
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"log"
+	"math"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const trashPurgeBatch = 100
+
+type IAchievementTrashService interface {
+	List(c *fiber.Ctx) error
+	Restore(c *fiber.Ctx) error
+	PurgeExpired(ctx context.Context, retention time.Duration, dryRun bool) (model.TrashPurgeSummary, error)
+	Run(ctx context.Context, interval, retention time.Duration)
+}
+
+type AchievementTrashService struct {
+	trashRepo repository.IAchievementTrashRepository
+	achRepo   repository.IAchievementRepository
+	store     storage.Storage
+}
+
+func NewAchievementTrashService(trashRepo repository.IAchievementTrashRepository, achRepo repository.IAchievementRepository, store storage.Storage) IAchievementTrashService {
+	return &AchievementTrashService{
+		trashRepo: trashRepo,
+		achRepo:   achRepo,
+		store:     store,
+	}
+}
+
+// List godoc
+// @Summary List deleted achievements
+// @Description Get paginated list of soft-deleted achievements in the trash (Admin only). Supports the same filters as GET /achievements.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param page query int false "Page number" default(1)
+// @Param limit query int false "Items per page" default(10)
+// @Param search query string false "Full-text search over title, description and tags, or student name/NIM"
+// @Param sort_by query string false "Sort field" Enums(created_at, title, points, student_name)
+// @Param sort_order query string false "Sort order" Enums(asc, desc)
+// @Success 200 {object} helper.Response{data=model.PaginatedAchievements} "Deleted achievements retrieved"
+// @Failure 400 {object} helper.ErrorResponse "Invalid filter"
+// @Router /achievements/trash [get]
+func (s *AchievementTrashService) List(c *fiber.Ctx) error {
+	filter, err := parseAchievementFilter(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	filter.Status = ""
+	filter.Trash = true
+
+	data, total, err := s.achRepo.GetAll(c.Context(), filter)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Success(c, "Daftar prestasi terhapus berhasil diambil", &model.PaginatedAchievements{
+		Data:       data,
+		Total:      total,
+		Page:       filter.Page,
+		PageSize:   filter.PageSize,
+		TotalPages: int(math.Ceil(float64(total) / float64(filter.PageSize))),
+	})
+}
+
+// Restore godoc
+// @Summary Restore deleted achievement
+// @Description Move a soft-deleted achievement out of the trash back to draft (Admin only)
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Success 200 {object} helper.Response "Achievement restored"
+// @Failure 404 {object} helper.ErrorResponse "Achievement not in trash"
+// @Router /achievements/{id}/restore [post]
+func (s *AchievementTrashService) Restore(c *fiber.Ctx) error {
+	restored, err := s.trashRepo.Restore(c.Context(), c.Params("id"))
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if !restored {
+		return helper.HandleError(c, model.NewNotFoundError("Prestasi tidak ada di tempat sampah"))
+	}
+
+	return helper.Success(c, "Prestasi berhasil dipulihkan sebagai draft", nil)
+}
+
+// PurgeExpired menghapus permanen prestasi yang sudah di tempat sampah lebih lama dari retention:
+// file lampiran (termasuk lampiran komentar), dokumen MongoDB, lalu baris PostgreSQL. Semua prestasi
+// kedaluwarsa diproses per batch; prestasi yang gagal dilewati dan dihitung di Failed, lalu dicoba lagi
+// pada putaran berikutnya.
+func (s *AchievementTrashService) PurgeExpired(ctx context.Context, retention time.Duration, dryRun bool) (model.TrashPurgeSummary, error) {
+	var summary model.TrashPurgeSummary
+	deletedBefore := time.Now().Add(-retention)
+
+	var after *model.TrashedAchievement
+	for {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+		expired, err := s.trashRepo.FindExpired(ctx, deletedBefore, after, trashPurgeBatch)
+		if err != nil {
+			return summary, err
+		}
+
+		for _, t := range expired {
+			s.purgeOne(ctx, t, dryRun, &summary)
+		}
+
+		if len(expired) < trashPurgeBatch {
+			return summary, nil
+		}
+		after = &expired[len(expired)-1]
+	}
+}
+
+func (s *AchievementTrashService) purgeOne(ctx context.Context, t model.TrashedAchievement, dryRun bool, summary *model.TrashPurgeSummary) {
+	docs, err := s.trashRepo.FindAttachments(ctx, t.ID, t.MongoID)
+	if err != nil {
+		log.Printf("⚠️  Gagal membaca lampiran prestasi terhapus %s: %v", t.ID, err)
+		summary.Failed++
+		return
+	}
+
+	files := 0
+	for _, doc := range docs {
+		for _, a := range doc.Attachments {
+			if a.IsLink() {
+				continue
+			}
+			files++
+			if !dryRun {
+				deleteAttachmentFiles(ctx, s.store, a, collectionKeyPrefix(doc.Collection))
+			}
+		}
+	}
+
+	if !dryRun {
+		if err := s.trashRepo.Purge(ctx, t.ID, t.MongoID); err != nil {
+			log.Printf("⚠️  Gagal menghapus permanen prestasi %s: %v", t.ID, err)
+			summary.Failed++
+			return
+		}
+	}
+	summary.Purged++
+	summary.Files += files
+}
+
+// Run menghapus permanen isi tempat sampah yang melewati masa retensi secara berkala sampai ctx dibatalkan
+func (s *AchievementTrashService) Run(ctx context.Context, interval, retention time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if summary, err := s.PurgeExpired(ctx, retention, false); err != nil {
+			log.Printf("⚠️  Purge tempat sampah gagal: %v", err)
+		} else if summary.Purged > 0 || summary.Failed > 0 {
+			log.Printf("🗑️  %d prestasi dihapus permanen (%d file), %d gagal", summary.Purged, summary.Files, summary.Failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
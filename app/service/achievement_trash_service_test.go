@@ -0,0 +1,146 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAchievementTrashService_Handlers(t *testing.T) {
+	app := fiber.New()
+	mockAchRepo := &MockAchievementRepository{achRefs: make(map[string]*model.AchievementReference)}
+	trashRepo := &MockAchievementTrashRepository{trashed: map[string]bool{"ach-deleted": true}}
+	svc := NewAchievementTrashService(trashRepo, mockAchRepo, storage.NewLocal(t.TempDir()))
+
+	app.Get("/achievements/trash", svc.List)
+	app.Post("/achievements/:id/restore", svc.Restore)
+
+	t.Run("GET - Trash Lists Only Deleted", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/achievements/trash?status=verified&search=lomba", nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", resp.StatusCode)
+		}
+		if f := mockAchRepo.lastFilter; !f.Trash || f.Status != "" || f.Search != "lomba" {
+			t.Errorf("Expected trash filter without status, got %+v", f)
+		}
+	})
+
+	t.Run("POST - Restore", func(t *testing.T) {
+		resp, _ := app.Test(httptest.NewRequest("POST", "/achievements/ach-deleted/restore", nil))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected 200 status, got %d", resp.StatusCode)
+		}
+
+		resp, _ = app.Test(httptest.NewRequest("POST", "/achievements/ach-deleted/restore", nil))
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("Expected 404 for achievement no longer in trash, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestAchievementTrashService_PurgeExpired(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocal(t.TempDir())
+	for _, key := range []string{"achievements/old.pdf", "achievements/thumbs/old.jpg", "comments/CMT-1.png", "achievements/recent.pdf"} {
+		store.Put(ctx, key, bytes.NewReader([]byte("data")), 4, "application/octet-stream")
+	}
+
+	trashRepo := &MockAchievementTrashRepository{
+		expired: []model.TrashedAchievement{
+			{ID: "ach-old", MongoID: "mongo-old", DeletedAt: time.Now().Add(-40 * 24 * time.Hour)},
+			{ID: "ach-recent", MongoID: "mongo-recent", DeletedAt: time.Now().Add(-time.Hour)},
+		},
+		docs: map[string][]model.AttachmentDocument{
+			"ach-old": {
+				{Collection: "achievements", DocumentID: "mongo-old", Attachments: []model.AchievementAttachment{
+					{FileName: "old.pdf", StorageKey: "achievements/old.pdf", ThumbnailKey: "achievements/thumbs/old.jpg"},
+					{Kind: model.AttachmentKindLink, FileURL: "https://example.com/sertifikat"},
+				}},
+				{Collection: "achievement_comments", DocumentID: "comment-1", Attachments: []model.AchievementAttachment{
+					{FileName: "CMT-1.png"},
+				}},
+			},
+			"ach-recent": {
+				{Collection: "achievements", DocumentID: "mongo-recent", Attachments: []model.AchievementAttachment{
+					{FileName: "recent.pdf", StorageKey: "achievements/recent.pdf"},
+				}},
+			},
+		},
+	}
+	svc := NewAchievementTrashService(trashRepo, &MockAchievementRepository{}, store)
+
+	t.Run("Dry Run Keeps Everything", func(t *testing.T) {
+		summary, err := svc.PurgeExpired(ctx, 30*24*time.Hour, true)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if summary.Purged != 1 || summary.Files != 2 {
+			t.Errorf("Expected 1 achievement with 2 files, got %+v", summary)
+		}
+		if len(trashRepo.purged) != 0 {
+			t.Errorf("Dry run must not purge, got %v", trashRepo.purged)
+		}
+		if _, err := store.Stat(ctx, "achievements/old.pdf"); err != nil {
+			t.Errorf("Dry run must not delete files: %v", err)
+		}
+	})
+
+	t.Run("Expired Achievement Purged With Files", func(t *testing.T) {
+		summary, err := svc.PurgeExpired(ctx, 30*24*time.Hour, false)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if summary.Purged != 1 || summary.Failed != 0 {
+			t.Errorf("Expected 1 purged, got %+v", summary)
+		}
+		if len(trashRepo.purged) != 1 || trashRepo.purged[0] != "ach-old" {
+			t.Errorf("Expected only ach-old purged, got %v", trashRepo.purged)
+		}
+		for _, key := range []string{"achievements/old.pdf", "achievements/thumbs/old.jpg", "comments/CMT-1.png"} {
+			if _, err := store.Stat(ctx, key); err != storage.ErrNotFound {
+				t.Errorf("Expected %s to be deleted, got %v", key, err)
+			}
+		}
+		if _, err := store.Stat(ctx, "achievements/recent.pdf"); err != nil {
+			t.Errorf("Expected file of achievement still within retention to be kept: %v", err)
+		}
+	})
+}
+
+func TestAchievementTrashService_PurgeExpired_SkipsFailures(t *testing.T) {
+	ctx := context.Background()
+	deletedAt := time.Now().Add(-40 * 24 * time.Hour)
+
+	// Batch pertama seluruhnya gagal dihapus; sisanya tetap harus diproses pada putaran yang sama
+	trashRepo := &MockAchievementTrashRepository{purgeErr: map[string]error{}}
+	for i := 0; i < trashPurgeBatch+20; i++ {
+		id := fmt.Sprintf("ach-%03d", i)
+		trashRepo.expired = append(trashRepo.expired, model.TrashedAchievement{ID: id, MongoID: "mongo-" + id, DeletedAt: deletedAt})
+		if i < trashPurgeBatch {
+			trashRepo.purgeErr[id] = fmt.Errorf("storage tidak tersedia")
+		}
+	}
+	svc := NewAchievementTrashService(trashRepo, &MockAchievementRepository{}, storage.NewLocal(t.TempDir()))
+
+	summary, err := svc.PurgeExpired(ctx, 30*24*time.Hour, false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if summary.Failed != trashPurgeBatch || summary.Purged != 20 {
+		t.Errorf("Expected %d failed and 20 purged, got %+v", trashPurgeBatch, summary)
+	}
+	if len(trashRepo.purged) != 20 || trashRepo.purged[0] != fmt.Sprintf("ach-%03d", trashPurgeBatch) {
+		t.Errorf("Expected achievements after the failed batch to be purged, got %v", trashRepo.purged)
+	}
+}
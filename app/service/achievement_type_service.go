@@ -0,0 +1,316 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type IAchievementTypeService interface {
+	MigrateFreeText(ctx context.Context, dryRun bool) ([]model.AchievementTypeMapping, error)
+
+	GetAll(c *fiber.Ctx) error
+	GetByID(c *fiber.Ctx) error
+	Create(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+}
+
+type AchievementTypeService struct {
+	typeRepo repository.IAchievementTypeRepository
+	achRepo  repository.IAchievementRepository
+}
+
+func NewAchievementTypeService(
+	typeRepo repository.IAchievementTypeRepository,
+	achRepo repository.IAchievementRepository,
+) IAchievementTypeService {
+	return &AchievementTypeService{
+		typeRepo: typeRepo,
+		achRepo:  achRepo,
+	}
+}
+
+func normalizeTypeCode(code string) string {
+	code = strings.ToLower(strings.TrimSpace(code))
+	return strings.Join(strings.Fields(code), "_")
+}
+
+func validatePointRange(minPoints, maxPoints int) error {
+	if minPoints < 0 || maxPoints < 0 {
+		return model.NewValidationError("rentang poin tidak boleh negatif")
+	}
+	if maxPoints > 0 && maxPoints < minPoints {
+		return model.NewValidationError("max_points harus lebih besar atau sama dengan min_points")
+	}
+	return nil
+}
+
+// maxAllowedFileSizeMB harus sama dengan CHECK max_file_size_mb di database (migrasi 011)
+const maxAllowedFileSizeMB = 200
+
+// normalizeFilePolicy mengisi default kebijakan upload dan memastikan tipe file yang dipilih dapat diperiksa isinya
+func normalizeFilePolicy(t *model.AchievementType) error {
+	if t.MaxFileSizeMB == 0 {
+		t.MaxFileSizeMB = helper.DefaultMaxFileSizeMB
+	}
+	if t.MaxFileSizeMB < 0 || t.MaxFileSizeMB > maxAllowedFileSizeMB {
+		return model.NewValidationError(fmt.Sprintf("max_file_size_mb harus di antara 1 dan %d", maxAllowedFileSizeMB))
+	}
+
+	if len(t.AllowedFileTypes) == 0 {
+		t.AllowedFileTypes = helper.DefaultFileTypes
+	}
+	for _, ft := range t.AllowedFileTypes {
+		if !helper.IsSupportedFileType(ft) {
+			return model.NewValidationError(fmt.Sprintf("allowed_file_types hanya boleh berisi: %s", strings.Join(helper.SupportedFileTypes, ", ")))
+		}
+	}
+	return nil
+}
+
+// MigrateFreeText memetakan nilai achievement_type bebas di MongoDB ke code katalog
+func (s *AchievementTypeService) MigrateFreeText(ctx context.Context, dryRun bool) ([]model.AchievementTypeMapping, error) {
+	counts, err := s.achRepo.CountByType(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var result []model.AchievementTypeMapping
+	for oldValue, count := range counts {
+		mapping := model.AchievementTypeMapping{OldValue: oldValue, Count: count}
+
+		t, err := s.typeRepo.Resolve(ctx, oldValue)
+		if err != nil {
+			return nil, err
+		}
+		if t != nil {
+			mapping.Code = t.Code
+			mapping.Mapped = true
+
+			if !dryRun && oldValue != t.Code {
+				if _, err := s.achRepo.ReplaceType(ctx, oldValue, t.Code); err != nil {
+					return nil, err
+				}
+			}
+		}
+
+		result = append(result, mapping)
+	}
+
+	return result, nil
+}
+
+// GetAll godoc
+// @Summary List achievement types
+// @Description Get the achievement type catalogue (inactive entries for Admin only)
+// @Tags Achievement Types
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param include_inactive query bool false "Include inactive types (Admin only)"
+// @Success 200 {object} helper.Response{data=[]model.AchievementType} "Achievement types retrieved"
+// @Router /achievement-types [get]
+func (s *AchievementTypeService) GetAll(c *fiber.Ctx) error {
+	roleName, _ := c.Locals("role").(string)
+	includeInactive := c.QueryBool("include_inactive", false) && roleName == "Admin"
+
+	types, err := s.typeRepo.GetAll(c.Context(), includeInactive)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if types == nil {
+		types = []model.AchievementType{}
+	}
+
+	return helper.Success(c, "Daftar jenis prestasi berhasil diambil", types)
+}
+
+// GetByID godoc
+// @Summary Get achievement type
+// @Description Get a single achievement type from the catalogue
+// @Tags Achievement Types
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement Type ID"
+// @Success 200 {object} helper.Response{data=model.AchievementType} "Achievement type retrieved"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Router /achievement-types/{id} [get]
+func (s *AchievementTypeService) GetByID(c *fiber.Ctx) error {
+	t, err := s.typeRepo.GetByID(c.Context(), c.Params("id"))
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if t == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Jenis prestasi tidak ditemukan"))
+	}
+
+	return helper.Success(c, "Detail jenis prestasi berhasil diambil", t)
+}
+
+// Create godoc
+// @Summary Create achievement type
+// @Description Add a new entry to the achievement type catalogue (Admin only)
+// @Tags Achievement Types
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param request body model.CreateAchievementTypeRequest true "Achievement type data"
+// @Success 201 {object} helper.Response{data=model.AchievementType} "Achievement type created"
+// @Failure 400 {object} helper.ErrorResponse "Invalid request"
+// @Router /achievement-types [post]
+func (s *AchievementTypeService) Create(c *fiber.Ctx) error {
+	var req model.CreateAchievementTypeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Format request tidak valid", nil)
+	}
+
+	code := normalizeTypeCode(req.Code)
+	if code == "" || strings.TrimSpace(req.NameID) == "" || strings.TrimSpace(req.NameEN) == "" {
+		return helper.HandleError(c, model.NewValidationError("code, name_id, dan name_en wajib diisi"))
+	}
+	if err := validatePointRange(req.MinPoints, req.MaxPoints); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	exists, err := s.typeRepo.CheckCodeExists(c.Context(), code, nil)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if exists {
+		return helper.HandleError(c, model.NewValidationError("code jenis prestasi sudah digunakan"))
+	}
+
+	t := &model.AchievementType{
+		Code:                code,
+		NameID:              strings.TrimSpace(req.NameID),
+		NameEN:              strings.TrimSpace(req.NameEN),
+		Description:         req.Description,
+		RequiredAttachments: req.RequiredAttachments,
+		MinPoints:           req.MinPoints,
+		MaxPoints:           req.MaxPoints,
+		Aliases:             req.Aliases,
+		MaxFileSizeMB:       req.MaxFileSizeMB,
+		AllowedFileTypes:    req.AllowedFileTypes,
+		IsActive:            true,
+	}
+	if err := normalizeFilePolicy(t); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	if err := s.typeRepo.Create(c.Context(), t); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Created(c, "Jenis prestasi berhasil dibuat", t)
+}
+
+// Update godoc
+// @Summary Update achievement type
+// @Description Update an achievement type in the catalogue (Admin only)
+// @Tags Achievement Types
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement Type ID"
+// @Param request body model.UpdateAchievementTypeRequest true "Updated data"
+// @Success 200 {object} helper.Response{data=model.AchievementType} "Achievement type updated"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Router /achievement-types/{id} [put]
+func (s *AchievementTypeService) Update(c *fiber.Ctx) error {
+	var req model.UpdateAchievementTypeRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Format request tidak valid", nil)
+	}
+
+	t, err := s.typeRepo.GetByID(c.Context(), c.Params("id"))
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if t == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Jenis prestasi tidak ditemukan"))
+	}
+
+	if req.NameID != nil {
+		t.NameID = strings.TrimSpace(*req.NameID)
+	}
+	if req.NameEN != nil {
+		t.NameEN = strings.TrimSpace(*req.NameEN)
+	}
+	if req.Description != nil {
+		t.Description = *req.Description
+	}
+	if req.RequiredAttachments != nil {
+		t.RequiredAttachments = req.RequiredAttachments
+	}
+	if req.MinPoints != nil {
+		t.MinPoints = *req.MinPoints
+	}
+	if req.MaxPoints != nil {
+		t.MaxPoints = *req.MaxPoints
+	}
+	if req.Aliases != nil {
+		t.Aliases = req.Aliases
+	}
+	if req.MaxFileSizeMB != nil {
+		t.MaxFileSizeMB = *req.MaxFileSizeMB
+	}
+	if req.AllowedFileTypes != nil {
+		t.AllowedFileTypes = req.AllowedFileTypes
+	}
+	if req.IsActive != nil {
+		t.IsActive = *req.IsActive
+	}
+
+	if t.NameID == "" || t.NameEN == "" {
+		return helper.HandleError(c, model.NewValidationError("name_id dan name_en tidak boleh kosong"))
+	}
+	if err := validatePointRange(t.MinPoints, t.MaxPoints); err != nil {
+		return helper.HandleError(c, err)
+	}
+	if err := normalizeFilePolicy(t); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	if err := s.typeRepo.Update(c.Context(), t); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Success(c, "Jenis prestasi berhasil diperbarui", t)
+}
+
+// Delete godoc
+// @Summary Deactivate achievement type
+// @Description Deactivate an achievement type so it can no longer be used for new achievements (Admin only)
+// @Tags Achievement Types
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement Type ID"
+// @Success 200 {object} helper.Response "Achievement type deactivated"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Router /achievement-types/{id} [delete]
+func (s *AchievementTypeService) Delete(c *fiber.Ctx) error {
+	id := c.Params("id")
+
+	t, err := s.typeRepo.GetByID(c.Context(), id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if t == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Jenis prestasi tidak ditemukan"))
+	}
+
+	if err := s.typeRepo.Deactivate(c.Context(), id); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Success(c, "Jenis prestasi berhasil dinonaktifkan", nil)
+}
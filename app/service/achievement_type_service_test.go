@@ -0,0 +1,85 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestAchievementTypeService_Create(t *testing.T) {
+	app := fiber.New()
+	mockTypeRepo := newMockAchievementTypeRepository("kompetisi")
+	service := NewAchievementTypeService(mockTypeRepo, &MockAchievementRepository{})
+
+	app.Post("/achievement-types", service.Create)
+
+	t.Run("POST - Create Achievement Type Success", func(t *testing.T) {
+		body, _ := json.Marshal(model.CreateAchievementTypeRequest{
+			Code: "Publikasi Ilmiah", NameID: "Publikasi Ilmiah", NameEN: "Scientific Publication",
+			MinPoints: 10, MaxPoints: 50,
+		})
+		req := httptest.NewRequest("POST", "/achievement-types", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Errorf("Expected 201 status, got %d", resp.StatusCode)
+		}
+		if _, ok := mockTypeRepo.types["publikasi_ilmiah"]; !ok {
+			t.Errorf("Expected code to be normalized to publikasi_ilmiah")
+		}
+	})
+
+	t.Run("POST - Create Achievement Type Duplicate Code", func(t *testing.T) {
+		body, _ := json.Marshal(model.CreateAchievementTypeRequest{
+			Code: "KOMPETISI", NameID: "Kompetisi", NameEN: "Competition",
+		})
+		req := httptest.NewRequest("POST", "/achievement-types", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", resp.StatusCode)
+		}
+	})
+}
+
+func TestAchievementTypeService_MigrateFreeText(t *testing.T) {
+	mockTypeRepo := newMockAchievementTypeRepository("kompetisi")
+	mockTypeRepo.types["kompetisi"].Aliases = []string{"competition", "lomba"}
+	mockAchRepo := &MockAchievementRepository{
+		typeCounts: map[string]int64{"Kompetisi": 3, "competition": 2, "kompetisi": 4, "Lainnya": 1},
+		replaced:   make(map[string]string),
+	}
+	service := NewAchievementTypeService(mockTypeRepo, mockAchRepo)
+
+	mappings, err := service.MigrateFreeText(context.Background(), false)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(mappings) != 4 {
+		t.Fatalf("Expected 4 mappings, got %d", len(mappings))
+	}
+
+	if mockAchRepo.replaced["Kompetisi"] != "kompetisi" || mockAchRepo.replaced["competition"] != "kompetisi" {
+		t.Errorf("Expected free-text values to be mapped to kompetisi, got %v", mockAchRepo.replaced)
+	}
+	if _, ok := mockAchRepo.replaced["kompetisi"]; ok {
+		t.Errorf("Canonical value should not be rewritten")
+	}
+	if _, ok := mockAchRepo.replaced["Lainnya"]; ok {
+		t.Errorf("Unknown value should be left untouched")
+	}
+}
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+	"sistem-pelaporan-prestasi-mahasiswa/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	signedURLDefaultTTL = 15 * time.Minute
+	signedURLMaxTTL     = time.Hour
+)
+
+// attachmentStorageKey mengembalikan key storage lampiran; lampiran lama hanya menyimpan file_url /uploads/<key>
+func attachmentStorageKey(a model.AchievementAttachment, prefix string) string {
+	if a.StorageKey != "" {
+		return a.StorageKey
+	}
+	if strings.HasPrefix(a.FileURL, "/uploads/") {
+		return strings.TrimPrefix(a.FileURL, "/uploads/")
+	}
+	return prefix + a.FileName
+}
+
+// findAttachment mencari lampiran berdasarkan id atau file_name (lampiran lama belum punya id)
+func findAttachment(attachments []model.AchievementAttachment, ref string) *model.AchievementAttachment {
+	for i := range attachments {
+		if (attachments[i].ID != "" && attachments[i].ID == ref) || attachments[i].FileName == ref {
+			return &attachments[i]
+		}
+	}
+	return nil
+}
+
+// attachmentRef adalah penanda lampiran yang dipakai repository untuk mencocokkan elemen array
+func attachmentRef(a model.AchievementAttachment) string {
+	if a.ID != "" {
+		return a.ID
+	}
+	return a.FileName
+}
+
+func achievementAttachmentURL(achievementID, fileName string) string {
+	return "/api/v1/achievements/" + achievementID + "/attachments/" + url.PathEscape(fileName)
+}
+
+func commentAttachmentURL(achievementID, commentID, fileName string) string {
+	return "/api/v1/achievements/" + achievementID + "/comments/" + commentID + "/attachments/" + url.PathEscape(fileName)
+}
+
+// setPreviewURLs mengisi URL bertanda tangan untuk thumbnail dan pratinjau, sehingga bisa dipakai langsung di <img>
+func setPreviewURLs(a *model.AchievementAttachment) {
+	if a.ThumbnailKey != "" {
+		a.ThumbnailURL, _ = utils.SignFileURL(a.ThumbnailKey, signedURLDefaultTTL)
+	}
+	if a.PreviewKey != "" {
+		a.PreviewURL, _ = utils.SignFileURL(a.PreviewKey, signedURLDefaultTTL)
+	}
+}
+
+// filePolicyFor mengambil kebijakan upload dari katalog jenis prestasi (default bila jenis tidak dikenal)
+func filePolicyFor(t *model.AchievementType) helper.FilePolicy {
+	policy := helper.DefaultFilePolicy()
+	if t == nil {
+		return policy
+	}
+	if t.MaxFileSizeMB > 0 {
+		policy.MaxBytes = int64(t.MaxFileSizeMB) * 1024 * 1024
+	}
+	if len(t.AllowedFileTypes) > 0 {
+		policy.AllowedTypes = t.AllowedFileTypes
+	}
+	return policy
+}
+
+// authorizeAttachmentChange: hanya pemilik prestasi berstatus draft yang boleh menambah, mengganti atau menghapus lampiran
+func authorizeAttachmentChange(ctx context.Context, achRepo repository.IAchievementRepository, studentRepo repository.IStudentRepository, id, userID string) (*model.AchievementReference, error) {
+	achRef, err := achRepo.GetRefByID(ctx, id)
+	if err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	if achRef == nil {
+		return nil, model.NewNotFoundError("Prestasi tidak ditemukan")
+	}
+
+	studentInfo, err := studentRepo.GetByUserID(ctx, userID)
+	if err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	if studentInfo == nil || achRef.StudentID != studentInfo.ID {
+		return nil, model.NewValidationError("Anda tidak berhak mengedit prestasi ini")
+	}
+
+	if achRef.Status != "draft" {
+		return nil, model.NewValidationError("Perubahan data tidak diizinkan. Prestasi ini sedang dalam proses verifikasi atau telah disetujui oleh Dosen Wali.")
+	}
+	return achRef, nil
+}
+
+// achievementFilePolicy mengambil kebijakan upload dari jenis prestasi milik achievement id
+func achievementFilePolicy(ctx context.Context, achRepo repository.IAchievementRepository, typeRepo repository.IAchievementTypeRepository, id string) (helper.FilePolicy, error) {
+	var achType *model.AchievementType
+	detail, err := achRepo.GetDetailByID(ctx, id)
+	if err != nil {
+		return helper.FilePolicy{}, model.ErrDatabaseError
+	}
+	if detail != nil {
+		achType, err = typeRepo.Resolve(ctx, detail.AchievementType)
+		if err != nil {
+			return helper.FilePolicy{}, model.ErrDatabaseError
+		}
+	}
+	return filePolicyFor(achType), nil
+}
+
+// saveAchievementFile menyimpan file yang sudah disanitasi ke karantina dan menyiapkan data lampirannya
+func saveAchievementFile(ctx context.Context, store storage.Storage, id string, file *helper.SanitizedFile) (*model.AchievementAttachment, error) {
+	attachment := newAchievementAttachment(id, file.Extension, file.ContentType)
+
+	contentHash, err := helper.SaveSanitizedFile(ctx, store, attachment.StorageKey, file)
+	if err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	attachment.ContentHash = contentHash
+	attachment.Size = int64(len(file.Data))
+	return attachment, nil
+}
+
+// newAchievementAttachment menyiapkan data lampiran baru beserta key karantinanya; hash dan ukuran diisi
+// pemanggil setelah file tersimpan
+func newAchievementAttachment(id, extension, contentType string) *model.AchievementAttachment {
+	filename := fmt.Sprintf("ACH-%s-%d%s", id, time.Now().UnixNano(), extension)
+	return &model.AchievementAttachment{
+		FileName:   filename,
+		FileURL:    achievementAttachmentURL(id, filename),
+		FileType:   contentType,
+		StorageKey: model.QuarantinePrefix + "achievements/" + filename,
+		ScanStatus: model.ScanStatusPending,
+		UploadedAt: time.Now(),
+	}
+}
+
+// deleteStoredFile menghapus file fisik; file yang gagal dihapus akan dibersihkan oleh gc-attachments
+func deleteStoredFile(ctx context.Context, store storage.Storage, key string) {
+	if err := store.Delete(ctx, key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+		log.Printf("⚠️  Gagal menghapus file %s: %v", key, err)
+	}
+}
+
+// deleteAttachmentFiles menghapus file lampiran beserta thumbnail dan pratinjaunya
+func deleteAttachmentFiles(ctx context.Context, store storage.Storage, a model.AchievementAttachment, prefix string) {
+	if a.IsLink() {
+		return
+	}
+	deleteStoredFile(ctx, store, attachmentStorageKey(a, prefix))
+	for _, key := range []string{a.ThumbnailKey, a.PreviewKey} {
+		if key != "" {
+			deleteStoredFile(ctx, store, key)
+		}
+	}
+}
+
+// evidenceDigest menghitung digest gabungan lampiran (id, hash, ukuran) yang dibekukan saat verifikasi.
+// Tautan bukti tidak punya hash, sehingga URL-nya yang ikut dibekukan.
+func evidenceDigest(attachments []model.AchievementAttachment) string {
+	lines := make([]string, 0, len(attachments))
+	for _, a := range attachments {
+		if a.IsLink() {
+			lines = append(lines, fmt.Sprintf("%s\tlink\t%s", attachmentRef(a), a.FileURL))
+			continue
+		}
+		lines = append(lines, fmt.Sprintf("%s\t%s\t%d", attachmentRef(a), a.ContentHash, a.Size))
+	}
+	sort.Strings(lines)
+	sum := sha256.Sum256([]byte(strings.Join(lines, "\n")))
+	return hex.EncodeToString(sum[:])
+}
+
+// checkAttachmentIntegrity membandingkan file di storage dengan ukuran dan SHA-256 yang dicatat saat upload.
+// Mengembalikan string kosong bila file utuh, atau salah satu konstanta model.Integrity*.
+func checkAttachmentIntegrity(ctx context.Context, store storage.Storage, a model.AchievementAttachment, prefix string) (string, error) {
+	key := attachmentStorageKey(a, prefix)
+
+	info, err := store.Stat(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return model.IntegrityMissing, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if a.Size > 0 && info.Size != a.Size {
+		return model.IntegritySizeMismatch, nil
+	}
+	if a.ContentHash == "" {
+		return model.IntegrityUnverifiable, nil
+	}
+
+	rc, _, err := store.Get(ctx, key)
+	if errors.Is(err, storage.ErrNotFound) {
+		return model.IntegrityMissing, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	defer rc.Close()
+
+	h := sha256.New()
+	if _, err := io.Copy(h, rc); err != nil {
+		return "", err
+	}
+	if hex.EncodeToString(h.Sum(nil)) != a.ContentHash {
+		return model.IntegrityModified, nil
+	}
+	return "", nil
+}
+
+// serveVerifiedAttachment hanya mengirim file bila ukuran dan hash-nya masih sama dengan saat upload
+func serveVerifiedAttachment(c *fiber.Ctx, store storage.Storage, a model.AchievementAttachment, prefix string) error {
+	problem, err := checkAttachmentIntegrity(c.Context(), store, a, prefix)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	switch problem {
+	case model.IntegrityMissing:
+		return helper.HandleError(c, model.NewNotFoundError("File tidak ditemukan"))
+	case model.IntegritySizeMismatch, model.IntegrityModified:
+		log.Printf("🚨 Integritas lampiran %s tidak valid (%s)", attachmentStorageKey(a, prefix), problem)
+		return helper.Conflict(c, "File lampiran tidak sesuai dengan catatan integritas saat upload. Hubungi Admin.", nil)
+	}
+
+	return helper.StreamFile(c, store, attachmentStorageKey(a, prefix), a.FileName)
+}
+
+// attachmentAccessError menolak akses ke lampiran yang masih dikarantina atau terinfeksi
+func attachmentAccessError(a model.AchievementAttachment) error {
+	switch a.ScanStatus {
+	case model.ScanStatusPending:
+		return model.NewValidationError("Lampiran sedang dipindai antivirus. Silakan coba beberapa saat lagi.")
+	case model.ScanStatusInfected:
+		return model.NewValidationError("Lampiran terdeteksi mengandung malware dan telah dihapus")
+	case model.ScanStatusFailed:
+		return model.NewValidationError("Lampiran gagal dipindai antivirus dan tidak dapat diunduh")
+	}
+	return nil
+}
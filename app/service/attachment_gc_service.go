@@ -0,0 +1,82 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"log"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+)
+
+// Prefix storage yang hanya berisi file lampiran
+var attachmentKeyPrefixes = []string{"achievements/", "comments/", model.QuarantinePrefix, model.PreviewPrefix}
+
+type IAttachmentGCService interface {
+	CollectGarbage(ctx context.Context, minAge time.Duration, dryRun bool) (model.AttachmentGCResult, error)
+}
+
+type AttachmentGCService struct {
+	attachmentRepo repository.IAttachmentRepository
+	store          storage.Storage
+}
+
+func NewAttachmentGCService(attachmentRepo repository.IAttachmentRepository, store storage.Storage) IAttachmentGCService {
+	return &AttachmentGCService{
+		attachmentRepo: attachmentRepo,
+		store:          store,
+	}
+}
+
+// CollectGarbage menghapus file lampiran yang tidak dirujuk dokumen mana pun, misalnya sisa upload yang gagal
+// atau file lama yang gagal dihapus saat lampiran diganti. File yang lebih muda dari minAge dilewati agar
+// upload yang sedang berjalan tidak ikut terhapus.
+func (s *AttachmentGCService) CollectGarbage(ctx context.Context, minAge time.Duration, dryRun bool) (model.AttachmentGCResult, error) {
+	var result model.AttachmentGCResult
+
+	referenced := make(map[string]bool)
+	err := s.attachmentRepo.ForEach(ctx, func(doc model.AttachmentDocument) error {
+		for _, a := range doc.Attachments {
+			referenced[attachmentStorageKey(a, collectionKeyPrefix(doc.Collection))] = true
+			referenced[a.ThumbnailKey] = true
+			referenced[a.PreviewKey] = true
+		}
+		return nil
+	})
+	if err != nil {
+		return result, err
+	}
+
+	cutoff := time.Now().Add(-minAge)
+	var orphans []storage.ObjectInfo
+	for _, prefix := range attachmentKeyPrefixes {
+		err := s.store.List(ctx, prefix, func(info storage.ObjectInfo) error {
+			result.Checked++
+			if !referenced[info.Key] && info.ModTime.Before(cutoff) {
+				orphans = append(orphans, info)
+			}
+			return nil
+		})
+		if err != nil {
+			return result, err
+		}
+	}
+
+	for _, info := range orphans {
+		result.Orphaned++
+		result.Bytes += info.Size
+		if dryRun {
+			continue
+		}
+		if err := s.store.Delete(ctx, info.Key); err != nil && !errors.Is(err, storage.ErrNotFound) {
+			log.Printf("⚠️  Gagal menghapus file yatim %s: %v", info.Key, err)
+			result.Failed++
+			continue
+		}
+		result.Deleted++
+	}
+
+	return result, nil
+}
@@ -0,0 +1,79 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+)
+
+func TestAttachmentGCService_CollectGarbage(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocal(t.TempDir())
+	for _, key := range []string{
+		"achievements/kept.pdf",
+		"achievements/legacy.pdf",
+		"comments/CMT-1.png",
+		"achievements/orphan.pdf",
+		model.QuarantinePrefix + "achievements/failed-upload.pdf",
+	} {
+		store.Put(ctx, key, bytes.NewReader([]byte("data")), 4, "application/pdf")
+	}
+
+	repo := &MockAttachmentRepository{docs: []model.AttachmentDocument{
+		{Collection: "achievements", DocumentID: "doc-1", Attachments: []model.AchievementAttachment{
+			{FileName: "kept.pdf", StorageKey: "achievements/kept.pdf"},
+			{FileName: "legacy.pdf", FileURL: "/uploads/achievements/legacy.pdf"},
+		}},
+		{Collection: "achievement_comments", DocumentID: "doc-2", Attachments: []model.AchievementAttachment{
+			{FileName: "CMT-1.png"},
+		}},
+	}}
+	svc := NewAttachmentGCService(repo, store)
+
+	t.Run("Recent Files Skipped", func(t *testing.T) {
+		result, err := svc.CollectGarbage(ctx, time.Hour, false)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Checked != 5 || result.Orphaned != 0 {
+			t.Errorf("Expected 5 checked and no orphans, got %+v", result)
+		}
+	})
+
+	t.Run("Dry Run Keeps Files", func(t *testing.T) {
+		result, err := svc.CollectGarbage(ctx, 0, true)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Orphaned != 2 || result.Deleted != 0 || result.Bytes != 8 {
+			t.Errorf("Expected 2 orphans and nothing deleted, got %+v", result)
+		}
+		if _, err := store.Stat(ctx, "achievements/orphan.pdf"); err != nil {
+			t.Errorf("Dry run must not delete files: %v", err)
+		}
+	})
+
+	t.Run("Orphans Deleted", func(t *testing.T) {
+		result, err := svc.CollectGarbage(ctx, 0, false)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if result.Deleted != 2 {
+			t.Errorf("Expected 2 deleted files, got %+v", result)
+		}
+		for _, key := range []string{"achievements/orphan.pdf", model.QuarantinePrefix + "achievements/failed-upload.pdf"} {
+			if _, err := store.Stat(ctx, key); err != storage.ErrNotFound {
+				t.Errorf("Expected %s to be deleted, got %v", key, err)
+			}
+		}
+		for _, key := range []string{"achievements/kept.pdf", "achievements/legacy.pdf", "comments/CMT-1.png"} {
+			if _, err := store.Stat(ctx, key); err != nil {
+				t.Errorf("Expected %s to be kept: %v", key, err)
+			}
+		}
+	})
+}
@@ -0,0 +1,74 @@
+package service
+
+import (
+	"context"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+)
+
+type IAttachmentIntegrityService interface {
+	Audit(ctx context.Context) (model.IntegrityReport, error)
+}
+
+type AttachmentIntegrityService struct {
+	attachmentRepo repository.IAttachmentRepository
+	store          storage.Storage
+}
+
+func NewAttachmentIntegrityService(attachmentRepo repository.IAttachmentRepository, store storage.Storage) IAttachmentIntegrityService {
+	return &AttachmentIntegrityService{
+		attachmentRepo: attachmentRepo,
+		store:          store,
+	}
+}
+
+// Audit memeriksa setiap lampiran terhadap ukuran dan hash yang dicatat saat upload, serta digest bukti
+// prestasi yang sudah diverifikasi. Lampiran terinfeksi dilewati karena filenya memang sudah dihapus,
+// begitu pula tautan bukti yang tidak punya file.
+func (s *AttachmentIntegrityService) Audit(ctx context.Context) (model.IntegrityReport, error) {
+	report := model.IntegrityReport{Issues: []model.IntegrityIssue{}}
+
+	err := s.attachmentRepo.ForEach(ctx, func(doc model.AttachmentDocument) error {
+		prefix := collectionKeyPrefix(doc.Collection)
+
+		for _, a := range doc.Attachments {
+			if a.IsLink() || a.ScanStatus == model.ScanStatusInfected {
+				continue
+			}
+			report.Checked++
+
+			problem, err := checkAttachmentIntegrity(ctx, s.store, a, prefix)
+			if err != nil {
+				return err
+			}
+			if problem == model.IntegrityUnverifiable {
+				report.Unverifiable++
+				continue
+			}
+			if problem != "" {
+				report.Issues = append(report.Issues, model.IntegrityIssue{
+					Collection: doc.Collection,
+					DocumentID: doc.DocumentID,
+					FileName:   a.FileName,
+					Key:        attachmentStorageKey(a, prefix),
+					Problem:    problem,
+					Frozen:     a.FrozenAt != nil,
+				})
+			}
+		}
+
+		if doc.EvidenceDigest != "" && evidenceDigest(doc.Attachments) != doc.EvidenceDigest {
+			report.Issues = append(report.Issues, model.IntegrityIssue{
+				Collection: doc.Collection,
+				DocumentID: doc.DocumentID,
+				Problem:    model.IntegrityDigestBroken,
+				Frozen:     true,
+			})
+		}
+		return nil
+	})
+
+	return report, err
+}
@@ -0,0 +1,209 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"log"
+	"strings"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/preview"
+	"sistem-pelaporan-prestasi-mahasiswa/scanner"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+)
+
+const (
+	scanBatchSize   = 50
+	maxScanAttempts = 5
+	scanRetryDelay  = time.Minute
+)
+
+type IAttachmentScanService interface {
+	ScanPending(ctx context.Context, limit int) (model.ScanSummary, error)
+	BackfillStatus(ctx context.Context) (int64, error)
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type AttachmentScanService struct {
+	scanRepo repository.IAttachmentScanRepository
+	store    storage.Storage
+	scanner  scanner.Scanner
+}
+
+func NewAttachmentScanService(
+	scanRepo repository.IAttachmentScanRepository,
+	store storage.Storage,
+	scn scanner.Scanner,
+) IAttachmentScanService {
+	return &AttachmentScanService{
+		scanRepo: scanRepo,
+		store:    store,
+		scanner:  scn,
+	}
+}
+
+func collectionKeyPrefix(collection string) string {
+	if collection == "achievement_comments" {
+		return "comments/"
+	}
+	return "achievements/"
+}
+
+// ScanPending memindai lampiran yang masih dikarantina. File bersih dipindah keluar dari karantina,
+// file terinfeksi dihapus dan ditandai infected.
+func (s *AttachmentScanService) ScanPending(ctx context.Context, limit int) (model.ScanSummary, error) {
+	var summary model.ScanSummary
+
+	pending, err := s.scanRepo.FindPending(ctx, limit)
+	if err != nil {
+		return summary, err
+	}
+
+	for _, p := range pending {
+		status, err := s.scanOne(ctx, p)
+		if err != nil {
+			log.Printf("⚠️  Gagal memindai lampiran %s/%s: %v", p.DocumentID, p.Attachment.FileName, err)
+			summary.Failed++
+			if s.recordFailure(ctx, p, err) == model.ScanStatusFailed {
+				summary.GaveUp++
+			}
+			continue
+		}
+
+		summary.Scanned++
+		if status == model.ScanStatusClean {
+			summary.Clean++
+		} else {
+			summary.Infected++
+		}
+	}
+
+	return summary, nil
+}
+
+// recordFailure menjadwalkan ulang lampiran yang gagal dipindai dengan jeda yang berlipat ganda
+// (1, 2, 4, 8 menit). Setelah maxScanAttempts percobaan lampiran ditandai failed agar tidak terus
+// memenuhi antrean, misalnya file lama yang sudah tidak ada di storage.
+func (s *AttachmentScanService) recordFailure(ctx context.Context, p model.PendingScan, scanErr error) string {
+	attempts := p.Attachment.ScanAttempts + 1
+	status := model.ScanStatusPending
+	if attempts >= maxScanAttempts {
+		status = model.ScanStatusFailed
+		log.Printf("🚫 Lampiran %s/%s gagal dipindai %d kali, ditandai failed", p.DocumentID, p.Attachment.FileName, attempts)
+	}
+	nextScanAt := time.Now().Add(scanRetryDelay << (attempts - 1))
+
+	if err := s.scanRepo.RecordFailure(ctx, p.Collection, p.DocumentID, p.Attachment.FileName, status, attempts, nextScanAt, scanErr.Error()); err != nil {
+		log.Printf("⚠️  Gagal mencatat kegagalan pemindaian %s/%s: %v", p.DocumentID, p.Attachment.FileName, err)
+	}
+	return status
+}
+
+// BackfillStatus menandai lampiran lama tanpa scan_status sebagai pending
+func (s *AttachmentScanService) BackfillStatus(ctx context.Context) (int64, error) {
+	return s.scanRepo.BackfillStatus(ctx)
+}
+
+func (s *AttachmentScanService) scanOne(ctx context.Context, p model.PendingScan) (string, error) {
+	key := attachmentStorageKey(p.Attachment, collectionKeyPrefix(p.Collection))
+
+	rc, _, err := s.store.Get(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	data, err := io.ReadAll(rc)
+	rc.Close()
+	if err != nil {
+		return "", err
+	}
+
+	result, err := s.scanner.Scan(ctx, bytes.NewReader(data))
+	if err != nil {
+		return "", err
+	}
+
+	if !result.Clean {
+		log.Printf("🚫 Lampiran %s terinfeksi (%s), file dihapus", key, result.Signature)
+		if err := s.store.Delete(ctx, key); err != nil {
+			return "", err
+		}
+		return model.ScanStatusInfected, s.scanRepo.SetResult(ctx, p.Collection, p.DocumentID, p.Attachment.FileName, model.ScanStatusInfected, result.Signature, key)
+	}
+
+	finalKey := strings.TrimPrefix(key, model.QuarantinePrefix)
+	if finalKey != key {
+		if err := s.store.Put(ctx, finalKey, bytes.NewReader(data), int64(len(data)), p.Attachment.FileType); err != nil {
+			return "", err
+		}
+	}
+
+	if err := s.scanRepo.SetResult(ctx, p.Collection, p.DocumentID, p.Attachment.FileName, model.ScanStatusClean, "", finalKey); err != nil {
+		return "", err
+	}
+	if finalKey != key {
+		if err := s.store.Delete(ctx, key); err != nil {
+			log.Printf("⚠️  Gagal menghapus file karantina %s: %v", key, err)
+		}
+	}
+
+	s.generatePreview(ctx, p, finalKey, data)
+
+	return model.ScanStatusClean, nil
+}
+
+// generatePreview membuat thumbnail dan pratinjau untuk file yang sudah dinyatakan bersih.
+// Kegagalan hanya dicatat; lampiran tetap bisa diunduh tanpa pratinjau.
+func (s *AttachmentScanService) generatePreview(ctx context.Context, p model.PendingScan, key string, data []byte) {
+	result, err := preview.Generate(data, p.Attachment.FileType)
+	if errors.Is(err, preview.ErrUnsupported) || errors.Is(err, preview.ErrNoImage) {
+		return
+	}
+	if err != nil {
+		log.Printf("⚠️  Gagal membuat pratinjau %s: %v", key, err)
+		return
+	}
+
+	thumbnailKey := model.PreviewPrefix + key + ".thumb.jpg"
+	previewKey := model.PreviewPrefix + key + ".preview.jpg"
+	for k, img := range map[string][]byte{thumbnailKey: result.Thumbnail, previewKey: result.Preview} {
+		if err := s.store.Put(ctx, k, bytes.NewReader(img), int64(len(img)), "image/jpeg"); err != nil {
+			log.Printf("⚠️  Gagal menyimpan pratinjau %s: %v", k, err)
+			return
+		}
+	}
+
+	if err := s.scanRepo.SetPreview(ctx, p.Collection, p.DocumentID, p.Attachment.FileName, thumbnailKey, previewKey); err != nil {
+		log.Printf("⚠️  Gagal menyimpan pratinjau %s: %v", key, err)
+	}
+}
+
+// Run memindai antrean karantina secara berkala sampai ctx dibatalkan
+func (s *AttachmentScanService) Run(ctx context.Context, interval time.Duration) {
+	if n, err := s.BackfillStatus(ctx); err != nil {
+		log.Printf("⚠️  Gagal menandai lampiran lama untuk dipindai: %v", err)
+	} else if n > 0 {
+		log.Printf("🛡️  %d dokumen dengan lampiran lama masuk antrean pemindaian", n)
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		summary, err := s.ScanPending(ctx, scanBatchSize)
+		if err != nil {
+			log.Printf("⚠️  Pemindaian lampiran gagal: %v", err)
+		} else if summary.Scanned > 0 || summary.Failed > 0 {
+			log.Printf("🛡️  Pemindaian lampiran: %d bersih, %d terinfeksi, %d gagal (%d dihentikan)", summary.Clean, summary.Infected, summary.Failed, summary.GaveUp)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/png"
+	"strings"
+	"testing"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/scanner"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+)
+
+func TestAttachmentScanService_ScanPending(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocal(t.TempDir())
+	store.Put(ctx, "quarantine/achievements/clean.pdf", strings.NewReader("%PDF-1.4 bersih"), 15, "application/pdf")
+	store.Put(ctx, "quarantine/comments/virus.pdf", strings.NewReader("%PDF-1.4 "+scanner.EICAR), 77, "application/pdf")
+
+	mockScanRepo := &MockAttachmentScanRepository{
+		pending: []model.PendingScan{
+			{Collection: "achievements", DocumentID: "doc-1", Attachment: model.AchievementAttachment{
+				FileName: "clean.pdf", StorageKey: "quarantine/achievements/clean.pdf", ScanStatus: model.ScanStatusPending,
+			}},
+			{Collection: "achievement_comments", DocumentID: "doc-2", Attachment: model.AchievementAttachment{
+				FileName: "virus.pdf", StorageKey: "quarantine/comments/virus.pdf", ScanStatus: model.ScanStatusPending,
+			}},
+		},
+		results: make(map[string]string),
+	}
+	service := NewAttachmentScanService(mockScanRepo, store, scanner.NewFake())
+
+	summary, err := service.ScanPending(ctx, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if summary.Clean != 1 || summary.Infected != 1 {
+		t.Errorf("Expected 1 clean and 1 infected, got %+v", summary)
+	}
+
+	if mockScanRepo.results["clean.pdf"] != "clean:achievements/clean.pdf" {
+		t.Errorf("Expected clean file to leave quarantine, got %q", mockScanRepo.results["clean.pdf"])
+	}
+	if _, err := store.Stat(ctx, "achievements/clean.pdf"); err != nil {
+		t.Errorf("Expected clean file at final key: %v", err)
+	}
+	if _, err := store.Stat(ctx, "quarantine/achievements/clean.pdf"); err != storage.ErrNotFound {
+		t.Errorf("Expected quarantine copy to be removed, got %v", err)
+	}
+
+	if !strings.HasPrefix(mockScanRepo.results["virus.pdf"], "infected:") {
+		t.Errorf("Expected infected status, got %q", mockScanRepo.results["virus.pdf"])
+	}
+	if _, err := store.Stat(ctx, "quarantine/comments/virus.pdf"); err != storage.ErrNotFound {
+		t.Errorf("Expected infected file to be deleted, got %v", err)
+	}
+}
+
+func TestAttachmentScanService_GeneratesPreview(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocal(t.TempDir())
+
+	var img bytes.Buffer
+	png.Encode(&img, image.NewRGBA(image.Rect(0, 0, 640, 480)))
+	store.Put(ctx, "quarantine/achievements/poster.png", bytes.NewReader(img.Bytes()), int64(img.Len()), "image/png")
+
+	mockScanRepo := &MockAttachmentScanRepository{
+		pending: []model.PendingScan{
+			{Collection: "achievements", DocumentID: "doc-1", Attachment: model.AchievementAttachment{
+				FileName: "poster.png", FileType: "image/png", StorageKey: "quarantine/achievements/poster.png", ScanStatus: model.ScanStatusPending,
+			}},
+		},
+		results: make(map[string]string),
+	}
+	service := NewAttachmentScanService(mockScanRepo, store, scanner.NewFake())
+
+	if _, err := service.ScanPending(ctx, 10); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	keys, ok := mockScanRepo.previews["poster.png"]
+	if !ok {
+		t.Fatalf("Expected preview keys to be recorded")
+	}
+	for _, key := range keys {
+		if !strings.HasPrefix(key, model.PreviewPrefix+"achievements/") {
+			t.Errorf("Unexpected preview key %q", key)
+		}
+		if _, err := store.Stat(ctx, key); err != nil {
+			t.Errorf("Expected preview %s to be stored: %v", key, err)
+		}
+	}
+}
+
+func TestAttachmentScanService_MissingFileBacksOff(t *testing.T) {
+	ctx := context.Background()
+	store := storage.NewLocal(t.TempDir())
+
+	mockScanRepo := &MockAttachmentScanRepository{
+		pending: []model.PendingScan{
+			{Collection: "achievements", DocumentID: "doc-1", Attachment: model.AchievementAttachment{
+				FileName: "lama.pdf", ScanStatus: model.ScanStatusPending,
+			}},
+			{Collection: "achievements", DocumentID: "doc-2", Attachment: model.AchievementAttachment{
+				FileName: "hilang.pdf", ScanStatus: model.ScanStatusPending, ScanAttempts: maxScanAttempts - 1,
+			}},
+		},
+		results: make(map[string]string),
+	}
+	service := NewAttachmentScanService(mockScanRepo, store, scanner.NewFake())
+
+	summary, err := service.ScanPending(ctx, 10)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if summary.Failed != 2 || summary.GaveUp != 1 {
+		t.Errorf("Expected 2 failures with 1 given up, got %+v", summary)
+	}
+	if got := mockScanRepo.results["lama.pdf"]; got != "pending:1" {
+		t.Errorf("Expected a first failure to stay pending for retry, got %q", got)
+	}
+	if got := mockScanRepo.results["hilang.pdf"]; got != fmt.Sprintf("failed:%d", maxScanAttempts) {
+		t.Errorf("Expected the last attempt to mark the attachment failed, got %q", got)
+	}
+}
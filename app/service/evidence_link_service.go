@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"log"
+	"strings"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+	"sistem-pelaporan-prestasi-mahasiswa/linkcheck"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	linkRecheckAfter = 24 * time.Hour
+	linkCheckBatch   = 50
+	maxLinkTitleLen  = 200
+)
+
+type IEvidenceLinkService interface {
+	AddLink(c *fiber.Ctx) error
+	CheckDue(ctx context.Context) (model.LinkCheckSummary, error)
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type EvidenceLinkService struct {
+	linkRepo     repository.IEvidenceLinkRepository
+	achRepo      repository.IAchievementRepository
+	studentRepo  repository.IStudentRepository
+	revisionRepo repository.IAchievementRevisionRepository
+	checker      linkcheck.Checker
+	policy       helper.LinkPolicy
+}
+
+func NewEvidenceLinkService(
+	linkRepo repository.IEvidenceLinkRepository,
+	achRepo repository.IAchievementRepository,
+	studentRepo repository.IStudentRepository,
+	revisionRepo repository.IAchievementRevisionRepository,
+	checker linkcheck.Checker,
+	policy helper.LinkPolicy,
+) IEvidenceLinkService {
+	return &EvidenceLinkService{
+		linkRepo:     linkRepo,
+		achRepo:      achRepo,
+		studentRepo:  studentRepo,
+		revisionRepo: revisionRepo,
+		checker:      checker,
+		policy:       policy,
+	}
+}
+
+// AddLink godoc
+// @Summary Add evidence link
+// @Description Attach a URL (news article, competition results page or DOI) as evidence to a draft achievement. The domain must pass the allow/deny policy; the page title and HTTP status are checked in the background.
+// @Tags Achievements
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param body body model.AddEvidenceLinkRequest true "Evidence link"
+// @Success 201 {object} helper.Response{data=model.AchievementAttachment} "Link added"
+// @Failure 400 {object} helper.ErrorResponse "Invalid URL or domain not allowed"
+// @Router /achievements/{id}/links [post]
+func (s *EvidenceLinkService) AddLink(c *fiber.Ctx) error {
+	id := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	var req model.AddEvidenceLinkRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Format request tidak valid", nil)
+	}
+
+	linkURL, err := helper.NormalizeEvidenceURL(req.URL, s.policy)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	title := strings.TrimSpace(req.Title)
+	if len([]rune(title)) > maxLinkTitleLen {
+		return helper.HandleError(c, model.NewValidationError("Judul tautan maksimal 200 karakter"))
+	}
+	if title == "" {
+		title = linkURL
+	}
+
+	achRef, err := authorizeAttachmentChange(c.Context(), s.achRepo, s.studentRepo, id, userID)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	detail, err := s.achRepo.GetDetailByID(c.Context(), id)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if detail != nil {
+		for _, a := range detail.Attachments {
+			if a.IsLink() && a.FileURL == linkURL {
+				return helper.HandleError(c, model.NewValidationError("Tautan ini sudah ditambahkan sebagai bukti"))
+			}
+		}
+	}
+
+	if err := s.revisionRepo.EnsureBaseline(c.Context(), id, achRef.MongoAchievementID, achRef.Status, userID); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	link := model.AchievementAttachment{
+		ID:         uuid.New().String(),
+		Kind:       model.AttachmentKindLink,
+		FileName:   title,
+		FileURL:    linkURL,
+		FileType:   "text/uri-list",
+		UploadedAt: time.Now(),
+	}
+	if err := s.achRepo.AddAttachment(c.Context(), achRef.MongoAchievementID, link); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	if err := s.revisionRepo.Record(c.Context(), achRef.ID, achRef.MongoAchievementID, model.RevisionEventAttachment, achRef.Status, userID); err != nil {
+		log.Printf("⚠️  Gagal menyimpan revisi prestasi %s (%s): %v", achRef.ID, model.RevisionEventAttachment, err)
+	}
+
+	return helper.Created(c, "Tautan bukti berhasil ditambahkan", link)
+}
+
+// CheckDue mengecek tautan yang belum pernah dicek atau terakhir dicek lebih dari 24 jam lalu
+func (s *EvidenceLinkService) CheckDue(ctx context.Context) (model.LinkCheckSummary, error) {
+	var summary model.LinkCheckSummary
+	if s.checker == nil {
+		return summary, nil
+	}
+
+	due, err := s.linkRepo.FindDue(ctx, time.Now().Add(-linkRecheckAfter), linkCheckBatch)
+	if err != nil {
+		return summary, err
+	}
+
+	for _, l := range due {
+		result := s.checker.Check(ctx, l.Attachment.FileURL)
+		if err := s.linkRepo.SetResult(ctx, l.DocumentID, l.Attachment.ID, result.StatusCode, result.Title, result.Error, time.Now()); err != nil {
+			log.Printf("⚠️  Gagal menyimpan hasil cek tautan %s: %v", l.Attachment.FileURL, err)
+			summary.Failed++
+			continue
+		}
+
+		summary.Checked++
+		if result.Alive() {
+			summary.Alive++
+		} else {
+			summary.Dead++
+		}
+	}
+	return summary, nil
+}
+
+// Run mengecek tautan bukti secara berkala sampai ctx dibatalkan
+func (s *EvidenceLinkService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if summary, err := s.CheckDue(ctx); err != nil {
+			log.Printf("⚠️  Pengecekan tautan bukti gagal: %v", err)
+		} else if summary.Dead > 0 {
+			log.Printf("🔗 %d dari %d tautan bukti tidak bisa dibuka", summary.Dead, summary.Checked)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+const (
+	// exportPageSize adalah jumlah baris per query saat ekspor daftar dialirkan halaman demi halaman
+	exportPageSize = 500
+	// exportTimeout membatasi lama satu ekspor setelah respons mulai dikirim
+	exportTimeout = 10 * time.Minute
+)
+
+// exportFormat membaca format= atau header Accept; JSON berarti respons biasa
+func exportFormat(c *fiber.Ctx) (export.Format, error) {
+	f, err := export.Negotiate(c.Query("format"), c.Get(fiber.HeaderAccept))
+	if err != nil {
+		return "", model.NewValidationError("format harus salah satu dari json, csv, xlsx, pdf, docx")
+	}
+	return f, nil
+}
+
+// sendExport mengirim laporan sebagai file unduhan yang dialirkan ke klien. fill dijalankan setelah
+// handler selesai sehingga tidak boleh memakai *fiber.Ctx; semua data request harus sudah dibaca.
+// Error di tengah aliran hanya bisa dicatat: status 200 sudah terkirim dan file dibiarkan terpotong.
+func sendExport(c *fiber.Ctx, f export.Format, name string, doc export.Document, fill func(ctx context.Context, w export.Writer) error) error {
+	if doc.GeneratedAt.IsZero() {
+		doc.GeneratedAt = time.Now()
+	}
+	filename := f.Filename(name, doc.GeneratedAt)
+
+	c.Set(fiber.HeaderContentType, f.ContentType())
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(bw *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+
+		w, err := export.New(f, bw, doc)
+		if err == nil {
+			err = fill(ctx, w)
+		}
+		if err == nil {
+			err = w.Close()
+		}
+		if err == nil {
+			err = bw.Flush()
+		}
+		if err != nil {
+			log.Printf("⚠️  Ekspor %s terhenti: %v", filename, err)
+		}
+	})
+	return nil
+}
+
+// exportTable adalah fill untuk laporan yang datanya sudah lengkap di memori
+func exportTable(title string, columns []export.Column, rows [][]interface{}) func(ctx context.Context, w export.Writer) error {
+	return func(ctx context.Context, w export.Writer) error {
+		return writeTable(w, title, columns, rows)
+	}
+}
+
+func writeTable(w export.Writer, title string, columns []export.Column, rows [][]interface{}) error {
+	if err := w.Table(title, columns); err != nil {
+		return err
+	}
+	for _, row := range rows {
+		if err := w.Row(row...); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// exportPages mengalirkan seluruh hasil paginasi keyset ke Writer. Halaman pertama diambil oleh pemanggil
+// sebelum respons dikirim sehingga error database masih bisa dilaporkan sebagai JSON.
+func exportPages[T any](
+	title string,
+	columns []export.Column,
+	first []T,
+	next *model.PageCursor,
+	fetch func(ctx context.Context, cursor *model.PageCursor) ([]T, model.CursorLinks, error),
+	row func(item T) []interface{},
+) func(ctx context.Context, w export.Writer) error {
+	return func(ctx context.Context, w export.Writer) error {
+		if err := w.Table(title, columns); err != nil {
+			return err
+		}
+		page := first
+		for {
+			for _, item := range page {
+				if err := w.Row(row(item)...); err != nil {
+					return err
+				}
+			}
+			if next == nil {
+				return nil
+			}
+			var links model.CursorLinks
+			var err error
+			if page, links, err = fetch(ctx, next); err != nil {
+				return err
+			}
+			next = links.Next
+		}
+	}
+}
+
+// exportPeriod meringkas rentang tanggal filter untuk subjudul laporan. to eksklusif.
+func exportPeriod(from, to *time.Time) string {
+	switch {
+	case from != nil && to != nil:
+		return fmt.Sprintf("Periode %s s.d. %s", from.Format("02-01-2006"), to.AddDate(0, 0, -1).Format("02-01-2006"))
+	case from != nil:
+		return "Sejak " + from.Format("02-01-2006")
+	case to != nil:
+		return "Sampai " + to.AddDate(0, 0, -1).Format("02-01-2006")
+	}
+	return ""
+}
+
+// subtitle membuang baris kosong dari daftar subjudul
+func subtitle(lines ...string) []string {
+	var out []string
+	for _, line := range lines {
+		if line != "" {
+			out = append(out, line)
+		}
+	}
+	return out
+}
+
+func facultyLine(faculty string) string {
+	if faculty == "" {
+		return ""
+	}
+	return "Fakultas " + faculty
+}
@@ -0,0 +1,61 @@
+package service
+
+import (
+	"path"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+	"sistem-pelaporan-prestasi-mahasiswa/utils"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type IFileService interface {
+	ServeSigned(c *fiber.Ctx) error
+}
+
+type FileService struct {
+	store          storage.Storage
+	attachmentRepo repository.IAttachmentRepository
+}
+
+func NewFileService(store storage.Storage, attachmentRepo repository.IAttachmentRepository) IFileService {
+	return &FileService{store: store, attachmentRepo: attachmentRepo}
+}
+
+// ServeSigned godoc
+// @Summary Download file via signed URL
+// @Description Stream a file using a short-lived signed URL (no login required). Attachments are only served while their size and SHA-256 still match the upload.
+// @Tags Files
+// @Produce octet-stream
+// @Param key path string true "Storage key"
+// @Param expires query int true "Expiry (unix seconds)"
+// @Param signature query string true "HMAC signature"
+// @Success 200 {file} file "File content"
+// @Failure 403 {object} helper.ErrorResponse "Invalid or expired signature"
+// @Failure 409 {object} helper.ErrorResponse "Attachment no longer matches its upload"
+// @Router /files/{key} [get]
+func (s *FileService) ServeSigned(c *fiber.Ctx) error {
+	key, err := storage.CleanKey(c.Params("*"))
+	if err != nil {
+		return helper.HandleError(c, model.NewNotFoundError("File tidak ditemukan"))
+	}
+
+	if !utils.VerifyFileSignature(key, c.Query("expires"), c.Query("signature"), time.Now()) {
+		return helper.Forbidden(c, "Tautan unduhan tidak valid atau sudah kedaluwarsa")
+	}
+
+	// Lampiran diperiksa integritasnya seperti unduhan biasa; file turunan (thumbnail, pratinjau) tidak punya hash
+	attachment, err := s.attachmentRepo.FindByStorageKey(c.Context(), key)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if attachment != nil {
+		return serveVerifiedAttachment(c, s.store, *attachment, path.Dir(key)+"/")
+	}
+
+	return helper.StreamFile(c, s.store, key, path.Base(key))
+}
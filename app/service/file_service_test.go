@@ -0,0 +1,175 @@
+package service
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+	"sistem-pelaporan-prestasi-mahasiswa/utils"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+func TestFileService_ServeSigned(t *testing.T) {
+	store := storage.NewLocal(t.TempDir())
+	store.Put(context.Background(), "achievements/ACH-1.pdf", strings.NewReader("%PDF-1.4"), 8, "application/pdf")
+
+	store.Put(context.Background(), "achievements/thumbs/ACH-1.jpg", strings.NewReader("jpeg"), 4, "image/jpeg")
+
+	sum := sha256.Sum256([]byte("%PDF-1.4"))
+	attachmentRepo := &MockAttachmentRepository{docs: []model.AttachmentDocument{{
+		Collection: "achievements",
+		Attachments: []model.AchievementAttachment{{
+			FileName: "ACH-1.pdf", StorageKey: "achievements/ACH-1.pdf", ContentHash: hex.EncodeToString(sum[:]), Size: 8,
+		}},
+	}}}
+
+	app := fiber.New()
+	service := NewFileService(store, attachmentRepo)
+	app.Get("/api/v1/files/*", service.ServeSigned)
+
+	t.Run("GET - Valid Signature", func(t *testing.T) {
+		signedURL, _ := utils.SignFileURL("achievements/ACH-1.pdf", time.Minute)
+
+		resp, err := app.Test(httptest.NewRequest("GET", signedURL, nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", resp.StatusCode)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if string(body) != "%PDF-1.4" {
+			t.Errorf("Unexpected body %q", body)
+		}
+	})
+
+	t.Run("GET - Signature For Another File", func(t *testing.T) {
+		signedURL, _ := utils.SignFileURL("achievements/ACH-2.pdf", time.Minute)
+		signedURL = strings.Replace(signedURL, "ACH-2.pdf", "ACH-1.pdf", 1)
+
+		resp, err := app.Test(httptest.NewRequest("GET", signedURL, nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusForbidden {
+			t.Errorf("Expected 403 status, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("GET - Expired Signature", func(t *testing.T) {
+		signedURL, _ := utils.SignFileURL("achievements/ACH-1.pdf", -time.Minute)
+
+		resp, err := app.Test(httptest.NewRequest("GET", signedURL, nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusForbidden {
+			t.Errorf("Expected 403 status, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("GET - Derived File Without Hash", func(t *testing.T) {
+		signedURL, _ := utils.SignFileURL("achievements/thumbs/ACH-1.jpg", time.Minute)
+
+		resp, err := app.Test(httptest.NewRequest("GET", signedURL, nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected 200 status, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("GET - Tampered Attachment Refused", func(t *testing.T) {
+		store.Put(context.Background(), "achievements/ACH-1.pdf", strings.NewReader("%PDF-1.5"), 8, "application/pdf")
+		signedURL, _ := utils.SignFileURL("achievements/ACH-1.pdf", time.Minute)
+
+		resp, err := app.Test(httptest.NewRequest("GET", signedURL, nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("Expected 409 status, got %d", resp.StatusCode)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		if strings.Contains(string(body), "%PDF-1.5") {
+			t.Error("Expected the tampered bytes not to be served")
+		}
+	})
+}
+
+func TestAchievementService_DownloadAttachment(t *testing.T) {
+	store := storage.NewLocal(t.TempDir())
+	store.Put(context.Background(), "achievements/ACH-1.pdf", strings.NewReader("%PDF-1.4"), 8, "application/pdf")
+
+	ownerUUID := uuid.New()
+	mockAchRepo := &MockAchievementRepository{
+		achRefs: make(map[string]*model.AchievementReference),
+		achDetail: &model.AchievementDetailDTO{
+			ID:      "ach-1",
+			Student: model.StudentListDTO{ID: ownerUUID},
+			Attachments: []model.AchievementAttachment{
+				{FileName: "ACH-1.pdf", FileURL: "/uploads/achievements/ACH-1.pdf"},
+			},
+		},
+	}
+	mockStudentRepo := &MockStudentRepository{
+		students: map[string]*model.StudentInfo{
+			"user-owner": {ID: ownerUUID.String()},
+			"user-other": {ID: uuid.New().String()},
+		},
+	}
+	service := NewAchievementService(mockAchRepo, mockStudentRepo, newMockAchievementTypeRepository("nasional"),
+		newMockAchievementMemberRepository(), &MockAchievementRevisionRepository{}, &MockLecturerService{}, store, export.Letterhead{})
+
+	download := func(userID string) int {
+		app := fiber.New()
+		app.Get("/achievements/:id/attachments/:fileName", func(c *fiber.Ctx) error {
+			c.Locals("user_id", userID)
+			c.Locals("role", "Mahasiswa")
+			return service.DownloadAttachment(c)
+		})
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/achievements/ach-1/attachments/ACH-1.pdf", nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		return resp.StatusCode
+	}
+
+	t.Run("GET - Owner Downloads Legacy Attachment", func(t *testing.T) {
+		if status := download("user-owner"); status != fiber.StatusOK {
+			t.Errorf("Expected 200 status, got %d", status)
+		}
+	})
+
+	t.Run("GET - Other Student Forbidden", func(t *testing.T) {
+		if status := download("user-other"); status == fiber.StatusOK {
+			t.Errorf("Expected error status, got %d", status)
+		}
+	})
+
+	t.Run("GET - Tampered File Refused", func(t *testing.T) {
+		sum := sha256.Sum256([]byte("%PDF-1.4"))
+		mockAchRepo.achDetail.Attachments[0].ContentHash = hex.EncodeToString(sum[:])
+		mockAchRepo.achDetail.Attachments[0].Size = 8
+		if status := download("user-owner"); status != fiber.StatusOK {
+			t.Fatalf("Expected intact file to download, got %d", status)
+		}
+
+		store.Put(context.Background(), "achievements/ACH-1.pdf", strings.NewReader("%PDF-1.5"), 8, "application/pdf")
+		if status := download("user-owner"); status != fiber.StatusConflict {
+			t.Errorf("Expected 409 status for modified file, got %d", status)
+		}
+	})
+}
@@ -159,6 +159,7 @@ func (s *LecturerService) GetAll(c *fiber.Ctx) error {
 // @Param id path string true "Lecturer ID"
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
+// @Param cursor query string false "Opaque keyset cursor from next_cursor/prev_cursor; send empty to start cursor pagination (total, page and total_pages are not computed)"
 // @Success 200 {object} helper.Response{data=model.PaginatedStudents} "Advisees retrieved"
 // @Failure 401 {object} helper.ErrorResponse "Unauthorized"
 // @Failure 403 {object} helper.ErrorResponse "Forbidden - Not your advisees"
@@ -199,6 +200,24 @@ func (s *LecturerService) GetAdvisees(c *fiber.Ctx) error {
 		}
 	}
 
+	cursorMode, cursor, err := cursorParam(c, "full_name", "ASC")
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	if cursorMode {
+		data, links, err := s.lecturerRepo.GetAdviseesByCursor(c.Context(), lecturerID, cursor, pageSize)
+		if err != nil {
+			return helper.HandleError(c, model.ErrDatabaseError)
+		}
+
+		return helper.Success(c, "Daftar mahasiswa bimbingan berhasil diambil", &model.PaginatedStudents{
+			Data:       data,
+			PageSize:   pageSize,
+			NextCursor: helper.EncodeCursor(links.Next),
+			PrevCursor: helper.EncodeCursor(links.Prev),
+		})
+	}
+
 	data, total, err := s.lecturerRepo.GetAdvisees(c.Context(), lecturerID, page, pageSize)
 	if err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
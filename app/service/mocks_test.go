@@ -3,11 +3,18 @@ package service
 import (
 	"context"
 	"database/sql"
+	"errors"
+	"fmt"
 	"mime/multipart"
 	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/google/uuid"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 // --- MOCK USER REPOSITORY ---
@@ -22,6 +29,9 @@ type MockUserRepository struct {
 func (m *MockUserRepository) GetAll(ctx context.Context, p, ps int, s, sb, so string) ([]model.User, int64, error) {
 	return nil, 0, nil
 }
+func (m *MockUserRepository) GetAllByCursor(ctx context.Context, s, sb, so string, cur *model.PageCursor, limit int) ([]model.User, model.CursorLinks, error) {
+	return nil, model.CursorLinks{}, nil
+}
 func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*model.User, error) {
 	if u, ok := m.users[id]; ok {
 		return u, nil
@@ -153,12 +163,17 @@ func (m *MockLecturerRepository) GetAll(ctx context.Context, p, ps int, s, sb, s
 func (m *MockLecturerRepository) GetAdvisees(ctx context.Context, id string, p, ps int) ([]model.StudentListDTO, int64, error) {
 	return nil, 0, nil
 }
+func (m *MockLecturerRepository) GetAdviseesByCursor(ctx context.Context, id string, cur *model.PageCursor, limit int) ([]model.StudentListDTO, model.CursorLinks, error) {
+	return nil, model.CursorLinks{}, nil
+}
 
 // --- MOCK STUDENT REPOSITORY ---
 type MockStudentRepository struct {
 	students map[string]*model.StudentInfo
 	idExists bool
 	detailID string
+
+	lastCursor *model.PageCursor
 }
 
 func (m *MockStudentRepository) Create(ctx context.Context, tx *sql.Tx, uID, sID, ps, ay string, advID *string) error {
@@ -173,6 +188,14 @@ func (m *MockStudentRepository) GetByUserID(ctx context.Context, uID string) (*m
 	}
 	return nil, nil
 }
+func (m *MockStudentRepository) GetByStudentNumber(ctx context.Context, nim string) (*model.StudentInfo, error) {
+	for _, s := range m.students {
+		if s.StudentID == nim {
+			return s, nil
+		}
+	}
+	return nil, nil
+}
 func (m *MockStudentRepository) Delete(ctx context.Context, tx *sql.Tx, uID string) error { return nil }
 func (m *MockStudentRepository) CheckStudentIDExists(ctx context.Context, id string, ex *string) (bool, error) {
 	return m.idExists, nil
@@ -180,6 +203,17 @@ func (m *MockStudentRepository) CheckStudentIDExists(ctx context.Context, id str
 func (m *MockStudentRepository) GetAll(ctx context.Context, p, ps int, s, sb, so string) ([]model.StudentListDTO, int64, error) {
 	return nil, 0, nil
 }
+
+// GetAllByCursor selalu mengembalikan satu mahasiswa dan cursor ke halaman berikutnya
+func (m *MockStudentRepository) GetAllByCursor(ctx context.Context, s, sb, so string, cur *model.PageCursor, limit int) ([]model.StudentListDTO, model.CursorLinks, error) {
+	m.lastCursor = cur
+	next := &model.PageCursor{SortBy: sb, Order: so, Value: "Budi", ID: "11111111-1111-1111-1111-111111111111"}
+	links := model.CursorLinks{Next: next}
+	if cur != nil {
+		links.Prev = &model.PageCursor{SortBy: sb, Order: so, Value: "Ani", ID: "22222222-2222-2222-2222-222222222222", Backward: true}
+	}
+	return []model.StudentListDTO{{FullName: "Budi"}}, links, nil
+}
 func (m *MockStudentRepository) GetDetailByID(ctx context.Context, id string) (*model.StudentDetailDTO, error) {
 	if id == "valid-student" || id == m.detailID {
 		return &model.StudentDetailDTO{
@@ -198,8 +232,17 @@ func (m *MockStudentRepository) UpdateAdvisor(ctx context.Context, sID string, a
 
 // --- MOCK ACHIEVEMENT REPOSITORY ---
 type MockAchievementRepository struct {
-	achRefs   map[string]*model.AchievementReference
-	achDetail *model.AchievementDetailDTO
+	achRefs    map[string]*model.AchievementReference
+	achDetail  *model.AchievementDetailDTO
+	typeCounts map[string]int64
+	replaced   map[string]string
+
+	evidenceDigest  string
+	verifiedAdvisor string
+	verifyErr       error
+	lastFilter      model.AchievementFilter
+	listPages       [][]model.AchievementListDTO
+	cursorCalls     int
 }
 
 func (m *MockAchievementRepository) Create(ctx context.Context, r *model.AchievementReference, mo *model.AchievementMongo) error {
@@ -216,11 +259,65 @@ func (m *MockAchievementRepository) GetRefByID(ctx context.Context, id string) (
 }
 
 func (m *MockAchievementRepository) AddAttachment(ctx context.Context, mID string, a model.AchievementAttachment) error {
+	if m.achDetail != nil {
+		m.achDetail.Attachments = append(m.achDetail.Attachments, a)
+	}
 	return nil
 }
-func (m *MockAchievementRepository) GetAll(ctx context.Context, p, ps int, s, sf, af, stf string) ([]model.AchievementListDTO, int64, error) {
+func (m *MockAchievementRepository) Verify(ctx context.Context, id, lID, advID string, pts int, digest string) error {
+	if m.verifyErr != nil {
+		return m.verifyErr
+	}
+	m.evidenceDigest = digest
+	m.verifiedAdvisor = advID
+	if m.achDetail != nil {
+		now := time.Now()
+		for i := range m.achDetail.Attachments {
+			m.achDetail.Attachments[i].FrozenAt = &now
+		}
+	}
+	return nil
+}
+func (m *MockAchievementRepository) RemoveAttachment(ctx context.Context, mID, ref string) error {
+	var kept []model.AchievementAttachment
+	for _, a := range m.achDetail.Attachments {
+		if a.ID != ref && a.FileName != ref {
+			kept = append(kept, a)
+		}
+	}
+	m.achDetail.Attachments = kept
+	return nil
+}
+func (m *MockAchievementRepository) ReplaceAttachment(ctx context.Context, mID, ref string, attachment model.AchievementAttachment) error {
+	for i, a := range m.achDetail.Attachments {
+		if a.ID == ref || a.FileName == ref {
+			m.achDetail.Attachments[i] = attachment
+		}
+	}
+	return nil
+}
+func (m *MockAchievementRepository) GetAll(ctx context.Context, f model.AchievementFilter) ([]model.AchievementListDTO, int64, error) {
+	m.lastFilter = f
 	return nil, 0, nil
 }
+// GetAllByCursor mengembalikan listPages satu per satu; Value cursor adalah indeks halaman berikutnya dan
+// urutannya mengikuti filter seperti repository asli
+func (m *MockAchievementRepository) GetAllByCursor(ctx context.Context, f model.AchievementFilter, cur *model.PageCursor) ([]model.AchievementListDTO, model.CursorLinks, error) {
+	m.lastFilter = f
+	m.cursorCalls++
+	page := 0
+	if cur != nil {
+		page, _ = strconv.Atoi(cur.Value)
+	}
+	if page >= len(m.listPages) {
+		return nil, model.CursorLinks{}, nil
+	}
+	var links model.CursorLinks
+	if page+1 < len(m.listPages) {
+		links.Next = &model.PageCursor{SortBy: f.SortBy, Order: f.SortOrder, Value: strconv.Itoa(page + 1), ID: "ach-" + strconv.Itoa(page)}
+	}
+	return m.listPages[page], links, nil
+}
 
 func (m *MockAchievementRepository) GetDetailByID(ctx context.Context, id string) (*model.AchievementDetailDTO, error) {
 	return m.achDetail, nil
@@ -230,16 +327,365 @@ func (m *MockAchievementRepository) Update(ctx context.Context, id, mID string,
 }
 func (m *MockAchievementRepository) Submit(ctx context.Context, id string) error     { return nil }
 func (m *MockAchievementRepository) SoftDelete(ctx context.Context, id string) error { return nil }
-func (m *MockAchievementRepository) Verify(ctx context.Context, id, lID string, pts int) error {
+func (m *MockAchievementRepository) Reject(ctx context.Context, id, lID string, note string) error {
 	return nil
 }
-func (m *MockAchievementRepository) Reject(ctx context.Context, id, lID string, note string) error {
+func (m *MockAchievementRepository) FindDuplicateCandidates(ctx context.Context, mID string, limit int) (*model.AchievementMongo, []model.DuplicateCandidate, error) {
+	return &model.AchievementMongo{}, nil, nil
+}
+func (m *MockAchievementRepository) SaveDuplicateWarnings(ctx context.Context, mID string, w []model.DuplicateWarning) error {
 	return nil
 }
+func (m *MockAchievementRepository) CountByType(ctx context.Context) (map[string]int64, error) {
+	return m.typeCounts, nil
+}
+func (m *MockAchievementRepository) ReplaceType(ctx context.Context, old, code string) (int64, error) {
+	m.replaced[old] = code
+	return m.typeCounts[old], nil
+}
 func (m *MockAchievementRepository) UploadAttachment(ctx context.Context, id, uID string, fh *multipart.FileHeader) (*model.AchievementAttachment, error) {
 	return nil, nil
 }
 
+// --- MOCK UPLOAD SESSION REPOSITORY ---
+type MockUploadSessionRepository struct {
+	sessions    map[string]*model.UploadSession
+	completeErr error
+}
+
+func (m *MockUploadSessionRepository) Create(ctx context.Context, s *model.UploadSession) error {
+	m.sessions[s.ID] = s
+	return nil
+}
+func (m *MockUploadSessionRepository) GetByID(ctx context.Context, id string) (*model.UploadSession, error) {
+	if s, ok := m.sessions[id]; ok {
+		copied := *s
+		return &copied, nil
+	}
+	return nil, nil
+}
+func (m *MockUploadSessionRepository) AdvanceOffset(ctx context.Context, id string, from, to int64, exp time.Time) (bool, error) {
+	s, ok := m.sessions[id]
+	if !ok || s.Offset != from || s.Status != model.UploadSessionActive {
+		return false, nil
+	}
+	s.Offset = to
+	s.ExpiresAt = exp
+	return true, nil
+}
+func (m *MockUploadSessionRepository) ClaimCompletion(ctx context.Context, id string, staleBefore time.Time) (bool, error) {
+	s, ok := m.sessions[id]
+	if !ok || !(s.Status == model.UploadSessionActive || s.Status == model.UploadSessionCompleting && s.UpdatedAt.Before(staleBefore)) {
+		return false, nil
+	}
+	s.Status = model.UploadSessionCompleting
+	s.UpdatedAt = time.Now()
+	return true, nil
+}
+func (m *MockUploadSessionRepository) ReleaseCompletion(ctx context.Context, id string) error {
+	if s, ok := m.sessions[id]; ok && s.Status == model.UploadSessionCompleting {
+		s.Status = model.UploadSessionActive
+	}
+	return nil
+}
+func (m *MockUploadSessionRepository) Complete(ctx context.Context, id string, a model.AchievementAttachment) error {
+	if m.completeErr != nil {
+		return m.completeErr
+	}
+	if s, ok := m.sessions[id]; ok {
+		s.Status = model.UploadSessionCompleted
+		s.Attachment = &a
+	}
+	return nil
+}
+func (m *MockUploadSessionRepository) Delete(ctx context.Context, id string) error {
+	delete(m.sessions, id)
+	return nil
+}
+func (m *MockUploadSessionRepository) FindExpired(ctx context.Context, now time.Time, limit int) ([]model.UploadSession, error) {
+	var result []model.UploadSession
+	for _, s := range m.sessions {
+		if s.ExpiresAt.Before(now) {
+			result = append(result, *s)
+		}
+	}
+	return result, nil
+}
+
+// --- MOCK EVIDENCE LINK REPOSITORY ---
+type MockEvidenceLinkRepository struct {
+	achDetail *model.AchievementDetailDTO
+}
+
+func (m *MockEvidenceLinkRepository) FindDue(ctx context.Context, before time.Time, limit int) ([]model.LinkToCheck, error) {
+	var due []model.LinkToCheck
+	for _, a := range m.achDetail.Attachments {
+		if a.IsLink() && (a.LinkCheckedAt == nil || a.LinkCheckedAt.Before(before)) {
+			due = append(due, model.LinkToCheck{DocumentID: "mongo-1", Attachment: a})
+		}
+	}
+	return due, nil
+}
+func (m *MockEvidenceLinkRepository) SetResult(ctx context.Context, docID, linkID string, status int, title, errMsg string, at time.Time) error {
+	for i := range m.achDetail.Attachments {
+		if a := &m.achDetail.Attachments[i]; a.ID == linkID {
+			a.LinkStatus, a.LinkTitle, a.LinkError, a.LinkCheckedAt = status, title, errMsg, &at
+		}
+	}
+	return nil
+}
+
+// --- MOCK ATTACHMENT REPOSITORY ---
+type MockAttachmentRepository struct {
+	docs []model.AttachmentDocument
+}
+
+func (m *MockAttachmentRepository) FindByStorageKey(ctx context.Context, key string) (*model.AchievementAttachment, error) {
+	for _, doc := range m.docs {
+		for i := range doc.Attachments {
+			if doc.Attachments[i].StorageKey == key {
+				return &doc.Attachments[i], nil
+			}
+		}
+	}
+	return nil, nil
+}
+func (m *MockAttachmentRepository) ForEach(ctx context.Context, fn func(doc model.AttachmentDocument) error) error {
+	for _, doc := range m.docs {
+		if err := fn(doc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// --- MOCK ACHIEVEMENT TYPE REPOSITORY ---
+type MockAchievementTypeRepository struct {
+	types map[string]*model.AchievementType
+}
+
+func newMockAchievementTypeRepository(codes ...string) *MockAchievementTypeRepository {
+	m := &MockAchievementTypeRepository{types: make(map[string]*model.AchievementType)}
+	for _, code := range codes {
+		m.types[strings.ToLower(code)] = &model.AchievementType{ID: uuid.New().String(), Code: strings.ToLower(code), IsActive: true}
+	}
+	return m
+}
+
+func (m *MockAchievementTypeRepository) GetAll(ctx context.Context, inactive bool) ([]model.AchievementType, error) {
+	var result []model.AchievementType
+	for _, t := range m.types {
+		if t.IsActive || inactive {
+			result = append(result, *t)
+		}
+	}
+	return result, nil
+}
+func (m *MockAchievementTypeRepository) GetByID(ctx context.Context, id string) (*model.AchievementType, error) {
+	for _, t := range m.types {
+		if t.ID == id {
+			return t, nil
+		}
+	}
+	return nil, nil
+}
+func (m *MockAchievementTypeRepository) Resolve(ctx context.Context, value string) (*model.AchievementType, error) {
+	value = strings.ToLower(strings.TrimSpace(value))
+	if t, ok := m.types[value]; ok {
+		return t, nil
+	}
+	for _, t := range m.types {
+		for _, alias := range t.Aliases {
+			if strings.ToLower(alias) == value {
+				return t, nil
+			}
+		}
+	}
+	return nil, nil
+}
+func (m *MockAchievementTypeRepository) CheckCodeExists(ctx context.Context, code string, ex *string) (bool, error) {
+	_, ok := m.types[strings.ToLower(code)]
+	return ok, nil
+}
+func (m *MockAchievementTypeRepository) Create(ctx context.Context, t *model.AchievementType) error {
+	t.ID = uuid.New().String()
+	m.types[t.Code] = t
+	return nil
+}
+func (m *MockAchievementTypeRepository) Update(ctx context.Context, t *model.AchievementType) error {
+	return nil
+}
+func (m *MockAchievementTypeRepository) Deactivate(ctx context.Context, id string) error {
+	for _, t := range m.types {
+		if t.ID == id {
+			t.IsActive = false
+		}
+	}
+	return nil
+}
+
+// --- MOCK ACHIEVEMENT MEMBER REPOSITORY ---
+type MockAchievementMemberRepository struct {
+	members map[string]*model.AchievementMember
+}
+
+func newMockAchievementMemberRepository() *MockAchievementMemberRepository {
+	return &MockAchievementMemberRepository{members: make(map[string]*model.AchievementMember)}
+}
+
+func (m *MockAchievementMemberRepository) AddMembers(ctx context.Context, achID string, studentIDs []string) error {
+	for _, sID := range studentIDs {
+		id := uuid.New().String()
+		m.members[id] = &model.AchievementMember{
+			ID: id, AchievementID: achID, StudentID: sID,
+			InvitationStatus: model.InvitationInvited, VerificationStatus: model.MemberVerificationPending,
+		}
+	}
+	return nil
+}
+func (m *MockAchievementMemberRepository) GetByAchievementID(ctx context.Context, achID string) ([]model.AchievementMember, error) {
+	result := []model.AchievementMember{}
+	for _, mem := range m.members {
+		if mem.AchievementID == achID {
+			result = append(result, *mem)
+		}
+	}
+	return result, nil
+}
+func (m *MockAchievementMemberRepository) GetByID(ctx context.Context, id string) (*model.AchievementMember, error) {
+	if mem, ok := m.members[id]; ok {
+		return mem, nil
+	}
+	return nil, nil
+}
+func (m *MockAchievementMemberRepository) Remove(ctx context.Context, id string) error {
+	delete(m.members, id)
+	return nil
+}
+func (m *MockAchievementMemberRepository) UpdateInvitation(ctx context.Context, achID, sID, status string) error {
+	for _, mem := range m.members {
+		if mem.AchievementID == achID && mem.StudentID == sID {
+			mem.InvitationStatus = status
+		}
+	}
+	return nil
+}
+func (m *MockAchievementMemberRepository) SetVerification(ctx context.Context, id, status, by string, note *string) error {
+	if mem, ok := m.members[id]; ok {
+		mem.VerificationStatus = status
+		mem.RejectionNote = note
+	}
+	return nil
+}
+
+// --- MOCK ACHIEVEMENT COMMENT REPOSITORY ---
+type MockAchievementCommentRepository struct {
+	comments  []*model.AchievementComment
+	createErr error
+}
+
+func (m *MockAchievementCommentRepository) Create(ctx context.Context, cm *model.AchievementComment) error {
+	if m.createErr != nil {
+		return m.createErr
+	}
+	cm.ID = primitive.NewObjectID()
+	m.comments = append(m.comments, cm)
+	return nil
+}
+func (m *MockAchievementCommentRepository) GetByAchievementID(ctx context.Context, achID string) ([]model.AchievementComment, error) {
+	result := []model.AchievementComment{}
+	for _, cm := range m.comments {
+		if cm.AchievementID == achID {
+			result = append(result, *cm)
+		}
+	}
+	return result, nil
+}
+func (m *MockAchievementCommentRepository) GetByID(ctx context.Context, id string) (*model.AchievementComment, error) {
+	for _, cm := range m.comments {
+		if cm.ID.Hex() == id {
+			return cm, nil
+		}
+	}
+	return nil, nil
+}
+func (m *MockAchievementCommentRepository) MarkResolved(ctx context.Context, id string) error {
+	for _, cm := range m.comments {
+		if cm.ID.Hex() == id {
+			cm.Resolved = true
+		}
+	}
+	return nil
+}
+
+// --- MOCK ACHIEVEMENT REVISION REPOSITORY ---
+type MockAchievementRevisionRepository struct {
+	revisions []model.AchievementRevision
+}
+
+func (m *MockAchievementRevisionRepository) Record(ctx context.Context, achID, mID, event, status, by string) error {
+	rev := model.AchievementRevision{
+		AchievementID: achID, Version: len(m.revisions) + 1, Event: event, Status: status, ChangedBy: by,
+		Snapshot: &model.RevisionSnapshot{},
+	}
+	m.revisions = append(m.revisions, rev)
+	return nil
+}
+func (m *MockAchievementRevisionRepository) EnsureBaseline(ctx context.Context, achID, mID, status, by string) error {
+	for _, rev := range m.revisions {
+		if rev.AchievementID == achID {
+			return nil
+		}
+	}
+	return m.Record(ctx, achID, mID, model.RevisionEventBaseline, status, by)
+}
+func (m *MockAchievementRevisionRepository) GetByAchievementID(ctx context.Context, achID string) ([]model.AchievementRevision, error) {
+	result := []model.AchievementRevision{}
+	for _, rev := range m.revisions {
+		if rev.AchievementID == achID {
+			result = append(result, rev)
+		}
+	}
+	return result, nil
+}
+func (m *MockAchievementRevisionRepository) GetByVersion(ctx context.Context, achID string, version int) (*model.AchievementRevision, error) {
+	for i := range m.revisions {
+		if m.revisions[i].AchievementID == achID && m.revisions[i].Version == version {
+			return &m.revisions[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// --- MOCK ATTACHMENT SCAN REPOSITORY ---
+type MockAttachmentScanRepository struct {
+	pending  []model.PendingScan
+	results  map[string]string
+	previews map[string][2]string
+}
+
+func (m *MockAttachmentScanRepository) FindPending(ctx context.Context, limit int) ([]model.PendingScan, error) {
+	return m.pending, nil
+}
+func (m *MockAttachmentScanRepository) SetResult(ctx context.Context, col, docID, fileName, status, sig, key string) error {
+	m.results[fileName] = status + ":" + key
+	return nil
+}
+func (m *MockAttachmentScanRepository) RecordFailure(ctx context.Context, col, docID, fileName, status string, attempts int, next time.Time, reason string) error {
+	m.results[fileName] = fmt.Sprintf("%s:%d", status, attempts)
+	return nil
+}
+func (m *MockAttachmentScanRepository) BackfillStatus(ctx context.Context) (int64, error) {
+	return 0, nil
+}
+func (m *MockAttachmentScanRepository) SetPreview(ctx context.Context, col, docID, fileName, thumbKey, previewKey string) error {
+	if m.previews == nil {
+		m.previews = make(map[string][2]string)
+	}
+	m.previews[fileName] = [2]string{thumbKey, previewKey}
+	return nil
+}
+
 // --- MOCK AUTH REPOSITORY ---
 type MockAuthRepository struct {
 	users map[string]*model.User
@@ -259,4 +705,139 @@ func (m *MockAuthRepository) GetUserByID(ctx context.Context, id string) (*model
 		return u, nil
 	}
 	return nil, nil
-}
\ No newline at end of file
+}
+
+// --- MOCK OUTBOX REPOSITORY ---
+type MockOutboxRepository struct {
+	events     []model.OutboxEvent
+	failing    map[int64]bool
+	applied    []int64
+	dispatched []int64
+	retryAt    map[int64]time.Time
+}
+
+func (m *MockOutboxRepository) FindDue(ctx context.Context, limit int) ([]model.OutboxEvent, error) {
+	return m.events, nil
+}
+func (m *MockOutboxRepository) Apply(ctx context.Context, e model.OutboxEvent) error {
+	m.applied = append(m.applied, e.ID)
+	if m.failing[e.ID] {
+		return errors.New("mongo unavailable")
+	}
+	return nil
+}
+func (m *MockOutboxRepository) MarkDispatched(ctx context.Context, id int64) error {
+	m.dispatched = append(m.dispatched, id)
+	return nil
+}
+func (m *MockOutboxRepository) MarkFailed(ctx context.Context, id int64, errMsg string, nextAttemptAt time.Time) error {
+	if m.retryAt == nil {
+		m.retryAt = make(map[int64]time.Time)
+	}
+	m.retryAt[id] = nextAttemptAt
+	return nil
+}
+
+// --- MOCK RECONCILE REPOSITORY ---
+type MockReconcileRepository struct {
+	refs     []model.ReconcileReference
+	docs     []model.AchievementMongo
+	stuck    []model.OutboxEvent
+	restored []string
+	deleted  []string
+	points   map[string]int
+	project  []string
+	pending  []string
+	// created berisi dokumen yang referensinya dibuat setelah ListReferences
+	created map[string]bool
+}
+
+func (m *MockReconcileRepository) ListReferences(ctx context.Context) ([]model.ReconcileReference, error) {
+	return m.refs, nil
+}
+func (m *MockReconcileRepository) ForEachDocument(ctx context.Context, fn func(doc model.AchievementMongo) error) error {
+	for _, d := range m.docs {
+		if err := fn(d); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+func (m *MockReconcileRepository) FindStuckOutbox(ctx context.Context, minAttempts int) ([]model.OutboxEvent, error) {
+	return m.stuck, nil
+}
+func (m *MockReconcileRepository) HasReference(ctx context.Context, mongoID string) (bool, error) {
+	return m.created[mongoID], nil
+}
+func (m *MockReconcileRepository) RestoreReference(ctx context.Context, doc model.AchievementMongo) error {
+	m.restored = append(m.restored, doc.ID.Hex())
+	return nil
+}
+func (m *MockReconcileRepository) SoftDeleteReference(ctx context.Context, id string) error {
+	m.deleted = append(m.deleted, id)
+	return nil
+}
+func (m *MockReconcileRepository) SetDocumentPoints(ctx context.Context, mongoID string, points int) error {
+	if m.points == nil {
+		m.points = make(map[string]int)
+	}
+	m.points[mongoID] = points
+	return nil
+}
+func (m *MockReconcileRepository) Project(ctx context.Context, id string, doc model.AchievementMongo) error {
+	m.project = append(m.project, id)
+	return nil
+}
+func (m *MockReconcileRepository) DispatchPending(ctx context.Context, achievementID string) error {
+	m.pending = append(m.pending, achievementID)
+	return nil
+}
+
+// --- MOCK ACHIEVEMENT TRASH REPOSITORY ---
+type MockAchievementTrashRepository struct {
+	trashed  map[string]bool
+	expired  []model.TrashedAchievement
+	docs     map[string][]model.AttachmentDocument
+	purged   []string
+	purgeErr map[string]error
+}
+
+func (m *MockAchievementTrashRepository) Restore(ctx context.Context, id string) (bool, error) {
+	if !m.trashed[id] {
+		return false, nil
+	}
+	delete(m.trashed, id)
+	return true, nil
+}
+func (m *MockAchievementTrashRepository) FindExpired(ctx context.Context, deletedBefore time.Time, after *model.TrashedAchievement, limit int) ([]model.TrashedAchievement, error) {
+	var items []model.TrashedAchievement
+	for _, t := range m.expired {
+		if !t.DeletedAt.Before(deletedBefore) {
+			continue
+		}
+		if after != nil && (t.DeletedAt.Before(after.DeletedAt) || t.DeletedAt.Equal(after.DeletedAt) && t.ID <= after.ID) {
+			continue
+		}
+		items = append(items, t)
+	}
+	sort.Slice(items, func(i, j int) bool {
+		if !items[i].DeletedAt.Equal(items[j].DeletedAt) {
+			return items[i].DeletedAt.Before(items[j].DeletedAt)
+		}
+		return items[i].ID < items[j].ID
+	})
+	if len(items) > limit {
+		items = items[:limit]
+	}
+	return items, nil
+}
+func (m *MockAchievementTrashRepository) FindAttachments(ctx context.Context, id string, mongoID string) ([]model.AttachmentDocument, error) {
+	return m.docs[id], nil
+}
+func (m *MockAchievementTrashRepository) Purge(ctx context.Context, id string, mongoID string) error {
+	if err := m.purgeErr[id]; err != nil {
+		return err
+	}
+	m.purged = append(m.purged, id)
+	return nil
+}
@@ -0,0 +1,93 @@
+package service
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+)
+
+const (
+	outboxBatch      = 100
+	outboxMaxBackoff = time.Hour
+)
+
+type IOutboxService interface {
+	Dispatch(ctx context.Context) (model.OutboxDispatchSummary, error)
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type OutboxService struct {
+	outboxRepo repository.IOutboxRepository
+}
+
+func NewOutboxService(outboxRepo repository.IOutboxRepository) IOutboxService {
+	return &OutboxService{outboxRepo: outboxRepo}
+}
+
+// outboxBackoff: 2^attempts detik, dibatasi satu jam
+func outboxBackoff(attempts int) time.Duration {
+	if attempts >= 12 {
+		return outboxMaxBackoff
+	}
+	backoff := time.Duration(1<<attempts) * time.Second
+	if backoff > outboxMaxBackoff {
+		return outboxMaxBackoff
+	}
+	return backoff
+}
+
+// Dispatch menerapkan event outbox yang tertunda ke MongoDB. Event yang gagal dijadwalkan ulang dengan
+// backoff eksponensial; event lain untuk prestasi yang sama ditahan agar urutannya tetap terjaga.
+func (s *OutboxService) Dispatch(ctx context.Context) (model.OutboxDispatchSummary, error) {
+	var summary model.OutboxDispatchSummary
+
+	events, err := s.outboxRepo.FindDue(ctx, outboxBatch)
+	if err != nil {
+		return summary, err
+	}
+
+	blocked := make(map[string]bool)
+	for _, e := range events {
+		if blocked[e.AchievementID] {
+			continue
+		}
+
+		if err := s.outboxRepo.Apply(ctx, e); err != nil {
+			blocked[e.AchievementID] = true
+			summary.Failed++
+			if err := s.outboxRepo.MarkFailed(ctx, e.ID, err.Error(), time.Now().Add(outboxBackoff(e.Attempts))); err != nil {
+				return summary, err
+			}
+			continue
+		}
+
+		if err := s.outboxRepo.MarkDispatched(ctx, e.ID); err != nil {
+			return summary, err
+		}
+		summary.Dispatched++
+	}
+	return summary, nil
+}
+
+// Run menjalankan dispatcher secara berkala sampai ctx dibatalkan
+func (s *OutboxService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if summary, err := s.Dispatch(ctx); err != nil {
+			log.Printf("⚠️  Dispatcher outbox gagal: %v", err)
+		} else if summary.Failed > 0 {
+			log.Printf("⚠️  %d event outbox gagal diterapkan ke MongoDB dan akan dicoba ulang", summary.Failed)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
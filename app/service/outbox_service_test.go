@@ -0,0 +1,52 @@
+package service
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+)
+
+func TestOutboxService_Dispatch(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("Failed Event Retried With Backoff And Blocks Later Events", func(t *testing.T) {
+		repo := &MockOutboxRepository{
+			events: []model.OutboxEvent{
+				{ID: 1, AchievementID: "ach-1", EventType: model.OutboxInsertAchievement, Attempts: 3},
+				{ID: 2, AchievementID: "ach-2", EventType: model.OutboxInsertAchievement},
+				{ID: 3, AchievementID: "ach-1", EventType: model.OutboxSetPoints},
+			},
+			failing: map[int64]bool{1: true},
+		}
+		svc := NewOutboxService(repo)
+
+		before := time.Now()
+		summary, err := svc.Dispatch(ctx)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if summary.Dispatched != 1 || summary.Failed != 1 {
+			t.Errorf("Expected 1 dispatched and 1 failed, got %+v", summary)
+		}
+		if len(repo.applied) != 2 || repo.applied[0] != 1 || repo.applied[1] != 2 {
+			t.Errorf("Expected set_points for ach-1 to wait for its insert, applied %v", repo.applied)
+		}
+		if len(repo.dispatched) != 1 || repo.dispatched[0] != 2 {
+			t.Errorf("Expected only event 2 dispatched, got %v", repo.dispatched)
+		}
+		if retry := repo.retryAt[1]; retry.Before(before.Add(8 * time.Second)) {
+			t.Errorf("Expected retry after 2^3 seconds, got %v", retry.Sub(before))
+		}
+	})
+
+	t.Run("Backoff Capped", func(t *testing.T) {
+		if got := outboxBackoff(40); got != outboxMaxBackoff {
+			t.Errorf("Expected backoff capped at %v, got %v", outboxMaxBackoff, got)
+		}
+		if got := outboxBackoff(0); got != time.Second {
+			t.Errorf("Expected first retry after 1s, got %v", got)
+		}
+	})
+}
@@ -0,0 +1,30 @@
+package service
+
+import (
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// cursorParam membaca parameter cursor. Mode cursor (keyset) aktif bila parameter dikirim;
+// nilai kosong berarti halaman pertama. Cursor hanya berlaku untuk urutan yang sama saat dibuat.
+func cursorParam(c *fiber.Ctx, sortBy, sortOrder string) (bool, *model.PageCursor, error) {
+	if !c.Context().QueryArgs().Has("cursor") {
+		return false, nil, nil
+	}
+
+	raw := c.Query("cursor")
+	if raw == "" {
+		return true, nil, nil
+	}
+
+	cursor, err := helper.DecodeCursor(raw)
+	if err != nil {
+		return true, nil, err
+	}
+	if cursor.SortBy != sortBy || cursor.Order != sortOrder {
+		return true, nil, model.NewValidationError("Cursor tidak berlaku untuk urutan ini. Mulai ulang dari halaman pertama.")
+	}
+	return true, cursor, nil
+}
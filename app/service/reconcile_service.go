@@ -0,0 +1,178 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+)
+
+// Event outbox yang gagal sebanyak ini dilaporkan sebagai macet
+const outboxStuckAttempts = 5
+
+type IReconcileService interface {
+	Reconcile(ctx context.Context, fix bool) (model.ReconcileReport, error)
+}
+
+type ReconcileService struct {
+	reconcileRepo repository.IReconcileRepository
+}
+
+func NewReconcileService(reconcileRepo repository.IReconcileRepository) IReconcileService {
+	return &ReconcileService{reconcileRepo: reconcileRepo}
+}
+
+// Reconcile membandingkan achievement_references dengan koleksi achievements dan melaporkan setiap
+// inkonsistensi. Dengan fix=true hanya perbaikan yang tidak menghapus data yang dijalankan:
+//   - dokumen yatim dibuatkan ulang referensi draft milik mahasiswanya
+//   - referensi tanpa dokumen diperbaiki dari outbox bila ada event tertunda, atau di-soft delete bila masih draft
+//   - poin Mongo disamakan dengan poin hasil verifikasi di achievement_read_model
+//   - proyeksi yang hilang atau usang ditulis ulang dari dokumen Mongo
+//
+// Prestasi yang masih punya event outbox belum terkirim tidak dibandingkan dan hanya dihitung sebagai
+// Pending. Event outbox yang macet hanya dilaporkan.
+func (s *ReconcileService) Reconcile(ctx context.Context, fix bool) (model.ReconcileReport, error) {
+	report := model.ReconcileReport{Counts: map[string]int{}, Issues: []model.ReconcileIssue{}}
+
+	refs, err := s.reconcileRepo.ListReferences(ctx)
+	if err != nil {
+		return report, err
+	}
+	report.References = len(refs)
+
+	byMongoID := make(map[string]*model.ReconcileReference, len(refs))
+	for i := range refs {
+		byMongoID[refs[i].MongoID] = &refs[i]
+	}
+	seen := make(map[string]bool, len(refs))
+
+	add := func(issue model.ReconcileIssue, repair func() error) {
+		if fix && repair != nil {
+			if err := repair(); err != nil {
+				issue.FixError = err.Error()
+			} else {
+				issue.Fixed = true
+				report.Fixed++
+			}
+		}
+		report.Counts[issue.Category]++
+		report.Issues = append(report.Issues, issue)
+	}
+
+	err = s.reconcileRepo.ForEachDocument(ctx, func(doc model.AchievementMongo) error {
+		report.Documents++
+		mongoID := doc.ID.Hex()
+
+		ref, ok := byMongoID[mongoID]
+		if !ok {
+			// Daftar referensi dimuat sebelum pemindaian; dokumen yang baru dibuat selama pemindaian bukan yatim
+			exists, err := s.reconcileRepo.HasReference(ctx, mongoID)
+			if err != nil {
+				return err
+			}
+			if exists {
+				return nil
+			}
+			add(model.ReconcileIssue{
+				Category: model.ReconcileOrphanDocument,
+				MongoID:  mongoID,
+				Detail:   fmt.Sprintf("dokumen %q milik mahasiswa %s tidak punya referensi", doc.Title, doc.StudentID),
+			}, func() error { return s.reconcileRepo.RestoreReference(ctx, doc) })
+			return nil
+		}
+		seen[mongoID] = true
+
+		// Event outbox yang belum terkirim berarti proyeksi lebih baru daripada dokumen Mongo; perbandingan
+		// baru bermakna setelah dispatcher menerapkannya
+		if ref.PendingEvents > 0 {
+			report.Pending++
+			return nil
+		}
+
+		issue := model.ReconcileIssue{AchievementID: ref.ID, MongoID: mongoID, Status: ref.Status}
+
+		// Sejak outbox, poin hasil verifikasi ditulis ke proyeksi dalam transaksi yang sama dengan statusnya.
+		// Poin 0 sah untuk jenis prestasi tanpa rentang poin.
+		if ref.Status == "verified" && ref.ReadModel != nil && doc.Points != ref.ReadModel.Points {
+			verifiedPoints := ref.ReadModel.Points
+			issue.Category = model.ReconcilePointsMismatch
+			issue.Detail = fmt.Sprintf("poin Mongo %d, poin verifikasi %d", doc.Points, verifiedPoints)
+			add(issue, func() error { return s.reconcileRepo.SetDocumentPoints(ctx, mongoID, verifiedPoints) })
+			doc.Points = verifiedPoints
+		}
+
+		if detail := projectionDiff(ref.ReadModel, doc); detail != "" {
+			issue.Category = model.ReconcileReadModelStale
+			issue.Detail = detail
+			add(issue, func() error { return s.reconcileRepo.Project(ctx, ref.ID, doc) })
+		}
+		return nil
+	})
+	if err != nil {
+		return report, err
+	}
+
+	for _, ref := range refs {
+		if seen[ref.MongoID] || ref.Status == "deleted" {
+			continue
+		}
+		ref := ref
+		issue := model.ReconcileIssue{
+			Category:      model.ReconcileDanglingReference,
+			AchievementID: ref.ID,
+			MongoID:       ref.MongoID,
+			Status:        ref.Status,
+			Detail:        "dokumen Mongo tidak ditemukan",
+		}
+		var repair func() error
+		switch {
+		case ref.PendingEvents > 0:
+			issue.Detail += fmt.Sprintf(", %d event outbox tertunda", ref.PendingEvents)
+			repair = func() error { return s.reconcileRepo.DispatchPending(ctx, ref.ID) }
+		case ref.Status == "draft":
+			repair = func() error { return s.reconcileRepo.SoftDeleteReference(ctx, ref.ID) }
+		default:
+			issue.Detail += ", isi prestasi tidak bisa dipulihkan"
+		}
+		add(issue, repair)
+	}
+
+	stuck, err := s.reconcileRepo.FindStuckOutbox(ctx, outboxStuckAttempts)
+	if err != nil {
+		return report, err
+	}
+	for _, e := range stuck {
+		add(model.ReconcileIssue{
+			Category:      model.ReconcileOutboxStuck,
+			AchievementID: e.AchievementID,
+			MongoID:       e.MongoID,
+			Detail:        fmt.Sprintf("event %d (%s) gagal %d kali: %s", e.ID, e.EventType, e.Attempts, e.LastError),
+		}, nil)
+	}
+
+	return report, nil
+}
+
+// projectionDiff mengembalikan deskripsi perbedaan proyeksi terhadap dokumen Mongo, kosong jika sama
+func projectionDiff(p *model.AchievementProjection, doc model.AchievementMongo) string {
+	if p == nil {
+		return "baris achievement_read_model belum ada"
+	}
+	switch {
+	case p.Title != doc.Title:
+		return "judul berbeda"
+	case p.AchievementType != doc.AchievementType:
+		return fmt.Sprintf("jenis %q, dokumen %q", p.AchievementType, doc.AchievementType)
+	case p.Description != doc.Description:
+		return "deskripsi berbeda"
+	case !slices.Equal(p.Tags, doc.Tags):
+		return "tag berbeda"
+	case p.Points != doc.Points:
+		return fmt.Sprintf("poin %d, dokumen %d", p.Points, doc.Points)
+	case p.PointsMode != doc.PointsMode:
+		return "mode poin berbeda"
+	}
+	return ""
+}
@@ -0,0 +1,120 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestReconcileService_Reconcile(t *testing.T) {
+	ctx := context.Background()
+
+	orphanID := primitive.NewObjectID()
+	okID := primitive.NewObjectID()
+	pointsID := primitive.NewObjectID()
+	staleID := primitive.NewObjectID()
+	zeroID := primitive.NewObjectID()
+	queuedID := primitive.NewObjectID()
+	newID := primitive.NewObjectID()
+
+	projection := func(title string, points int) *model.AchievementProjection {
+		return &model.AchievementProjection{AchievementType: "kompetisi", Title: title, Tags: []string{"ai"}, Points: points}
+	}
+	doc := func(id primitive.ObjectID, title string, points int) model.AchievementMongo {
+		return model.AchievementMongo{ID: id, StudentID: "student-1", AchievementType: "kompetisi", Title: title, Tags: []string{"ai"}, Points: points}
+	}
+
+	newRepo := func() *MockReconcileRepository {
+		return &MockReconcileRepository{
+			refs: []model.ReconcileReference{
+				{ID: "ref-ok", MongoID: okID.Hex(), Status: "verified", ReadModel: projection("Juara 1", 50)},
+				{ID: "ref-points", MongoID: pointsID.Hex(), Status: "verified", ReadModel: projection("Juara 2", 40)},
+				{ID: "ref-stale", MongoID: staleID.Hex(), Status: "draft", ReadModel: projection("Judul Lama", 0)},
+				{ID: "ref-zero", MongoID: zeroID.Hex(), Status: "verified", ReadModel: projection("Juara 3", 0)},
+				{ID: "ref-queued", MongoID: queuedID.Hex(), Status: "verified", PendingEvents: 2, ReadModel: projection("Judul Baru", 30)},
+				{ID: "ref-draft", MongoID: primitive.NewObjectID().Hex(), Status: "draft"},
+				{ID: "ref-submitted", MongoID: primitive.NewObjectID().Hex(), Status: "submitted"},
+				{ID: "ref-pending", MongoID: primitive.NewObjectID().Hex(), Status: "draft", PendingEvents: 1},
+				{ID: "ref-deleted", MongoID: primitive.NewObjectID().Hex(), Status: "deleted"},
+			},
+			docs: []model.AchievementMongo{
+				doc(orphanID, "Tanpa Referensi", 0),
+				doc(okID, "Juara 1", 50),
+				doc(pointsID, "Juara 2", 0),
+				doc(staleID, "Judul Baru", 0),
+				doc(zeroID, "Juara 3", 0),
+				doc(queuedID, "Judul Lama", 0),
+				doc(newID, "Dibuat Saat Pemeriksaan", 0),
+			},
+			created: map[string]bool{newID.Hex(): true},
+			stuck:   []model.OutboxEvent{{ID: 9, AchievementID: "ref-x", EventType: model.OutboxSetPoints, Attempts: 7, LastError: "timeout"}},
+		}
+	}
+
+	t.Run("Report Only", func(t *testing.T) {
+		repo := newRepo()
+		report, err := NewReconcileService(repo).Reconcile(ctx, false)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		expected := map[string]int{
+			model.ReconcileOrphanDocument:    1,
+			model.ReconcilePointsMismatch:    1,
+			model.ReconcileReadModelStale:    1,
+			model.ReconcileDanglingReference: 3,
+			model.ReconcileOutboxStuck:       1,
+		}
+		for category, count := range expected {
+			if report.Counts[category] != count {
+				t.Errorf("Expected %d %s, got %d", count, category, report.Counts[category])
+			}
+		}
+		if report.References != 9 || report.Documents != 7 || report.Pending != 1 || report.Fixed != 0 {
+			t.Errorf("Unexpected totals %+v", report)
+		}
+		if len(repo.restored)+len(repo.deleted)+len(repo.points)+len(repo.project)+len(repo.pending) != 0 {
+			t.Error("Expected no repairs without --fix")
+		}
+	})
+
+	t.Run("Fix Runs Safe Repairs", func(t *testing.T) {
+		repo := newRepo()
+		report, err := NewReconcileService(repo).Reconcile(ctx, true)
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+
+		if len(repo.restored) != 1 || repo.restored[0] != orphanID.Hex() {
+			t.Errorf("Expected orphan document restored as draft, got %v", repo.restored)
+		}
+		if repo.points[pointsID.Hex()] != 40 {
+			t.Errorf("Expected Mongo points set to verified points, got %v", repo.points)
+		}
+		if len(repo.project) != 1 || repo.project[0] != "ref-stale" {
+			t.Errorf("Expected stale projection rewritten, got %v", repo.project)
+		}
+		if len(repo.deleted) != 1 || repo.deleted[0] != "ref-draft" {
+			t.Errorf("Expected only dangling draft soft deleted, got %v", repo.deleted)
+		}
+		if len(repo.pending) != 1 || repo.pending[0] != "ref-pending" {
+			t.Errorf("Expected pending outbox dispatched, got %v", repo.pending)
+		}
+		// Proyeksi yang lebih baru dari Mongo karena event outbox tertunda tidak boleh ditimpa
+		for _, id := range repo.project {
+			if id == "ref-queued" {
+				t.Error("Expected projection with pending outbox events to be left alone")
+			}
+		}
+		if _, ok := repo.points[queuedID.Hex()]; ok {
+			t.Error("Expected Mongo points with pending outbox events to be left alone")
+		}
+		// Referensi submitted tanpa dokumen dan outbox macet butuh penanganan manual
+		if report.Fixed != 5 || len(report.Issues) != 7 {
+			t.Errorf("Expected 5 of 7 issues fixed, got %d of %d", report.Fixed, len(report.Issues))
+		}
+	})
+}
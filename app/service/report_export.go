@@ -0,0 +1,148 @@
+package service
+
+import (
+	"context"
+	"sort"
+	"strings"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
+)
+
+var breakdownColumns = []export.Column{
+	{Header: "Nilai", Width: 30},
+	{Header: "Prestasi", Width: 10, Align: export.AlignRight},
+	{Header: "Terverifikasi", Width: 12, Align: export.AlignRight},
+	{Header: "Poin", Width: 10, Align: export.AlignRight},
+}
+
+func breakdownRows(items []model.StatBreakdown) [][]interface{} {
+	rows := make([][]interface{}, len(items))
+	for i, b := range items {
+		rows[i] = []interface{}{b.Key, b.Achievements, b.Verified, b.Points}
+	}
+	return rows
+}
+
+// dashboardExport menulis statistik dashboard sebagai beberapa tabel (satu sheet per tabel di XLSX)
+func dashboardExport(stats *model.DashboardStatistics) func(ctx context.Context, w export.Writer) error {
+	return func(ctx context.Context, w export.Writer) error {
+		t := stats.VerificationTurnaround
+		summary := [][]interface{}{
+			{"Total mahasiswa", stats.TotalStudents},
+			{"Total dosen", stats.TotalLecturers},
+			{"Total prestasi", stats.TotalAchievements},
+			{"Prestasi terverifikasi", t.Verified},
+			{"Rata-rata waktu verifikasi (jam)", t.AverageHours},
+			{"Median waktu verifikasi (jam)", t.MedianHours},
+			{"P90 waktu verifikasi (jam)", t.P90Hours},
+		}
+		if err := writeTable(w, "Ringkasan", []export.Column{{Header: "Metrik", Width: 36}, {Header: "Nilai", Width: 14, Align: export.AlignRight}}, summary); err != nil {
+			return err
+		}
+
+		statuses := make([]string, 0, len(stats.AchievementsByStatus))
+		for status := range stats.AchievementsByStatus {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		byStatus := make([][]interface{}, len(statuses))
+		for i, status := range statuses {
+			byStatus[i] = []interface{}{status, stats.AchievementsByStatus[status]}
+		}
+		if err := writeTable(w, "Per Status", []export.Column{{Header: "Status", Width: 20}, {Header: "Jumlah", Width: 10, Align: export.AlignRight}}, byStatus); err != nil {
+			return err
+		}
+
+		top := make([][]interface{}, len(stats.TopStudents))
+		for i, s := range stats.TopStudents {
+			top[i] = []interface{}{i + 1, s.StudentID, s.Name, s.ProgramStudy, s.Achievements, s.TotalPoints}
+		}
+		err := writeTable(w, "Mahasiswa Teratas", []export.Column{
+			{Header: "No", Width: 5, Align: export.AlignRight},
+			{Header: "NIM", Width: 14},
+			{Header: "Nama", Width: 30},
+			{Header: "Program Studi", Width: 24},
+			{Header: "Prestasi", Width: 10, Align: export.AlignRight},
+			{Header: "Total Poin", Width: 10, Align: export.AlignRight},
+		}, top)
+		if err != nil {
+			return err
+		}
+
+		if err := writeTable(w, "Per Jenis Prestasi", breakdownColumns, breakdownRows(stats.ByType)); err != nil {
+			return err
+		}
+		if err := writeTable(w, "Per Program Studi", breakdownColumns, breakdownRows(stats.ByProgramStudy)); err != nil {
+			return err
+		}
+		return writeTable(w, "Per Angkatan", breakdownColumns, breakdownRows(stats.ByAcademicYear))
+	}
+}
+
+func studentReportExport(report *model.StudentReportDTO) func(ctx context.Context, w export.Writer) error {
+	return func(ctx context.Context, w export.Writer) error {
+		p := report.StudentProfile
+		advisor := ""
+		if p.AdvisorName != nil {
+			advisor = *p.AdvisorName
+		}
+		profile := [][]interface{}{
+			{"NIM", p.StudentID},
+			{"Nama", p.FullName},
+			{"Program studi", p.ProgramStudy},
+			{"Angkatan", p.AcademicYear},
+			{"Dosen wali", advisor},
+			{"Total prestasi", report.TotalAchievements},
+			{"Total poin", report.TotalPoints},
+		}
+		if err := writeTable(w, "Profil", []export.Column{{Header: "Data", Width: 20}, {Header: "Nilai", Width: 40}}, profile); err != nil {
+			return err
+		}
+
+		types := make([]string, 0, len(report.PointsByType))
+		for t := range report.PointsByType {
+			types = append(types, t)
+		}
+		sort.Strings(types)
+		byType := make([][]interface{}, len(types))
+		for i, t := range types {
+			byType[i] = []interface{}{t, report.PointsByType[t]}
+		}
+		if err := writeTable(w, "Poin per Jenis", []export.Column{{Header: "Jenis", Width: 24}, {Header: "Poin", Width: 10, Align: export.AlignRight}}, byType); err != nil {
+			return err
+		}
+
+		recent := make([][]interface{}, len(report.RecentAchievements))
+		for i, a := range report.RecentAchievements {
+			recent[i] = []interface{}{a.Title, a.AchievementType, a.Status, a.Points, a.CreatedAt}
+		}
+		return writeTable(w, "Prestasi Terbaru", achievementColumns[2:], recent)
+	}
+}
+
+// timeseriesExport menulis deret waktu dalam format panjang: satu baris per bucket per kombinasi grup
+func timeseriesExport(result *model.TimeseriesResult) func(ctx context.Context, w export.Writer) error {
+	columns := []export.Column{{Header: "Periode", Width: 16}, {Header: "Mulai", Width: 12}}
+	for _, g := range result.GroupBy {
+		columns = append(columns, export.Column{Header: strings.ReplaceAll(g, "_", " "), Width: 20})
+	}
+	for _, m := range result.Metrics {
+		columns = append(columns, export.Column{Header: m, Width: 10, Align: export.AlignRight})
+	}
+
+	var rows [][]interface{}
+	for _, series := range result.Series {
+		for _, p := range series.Points {
+			row := []interface{}{p.Label, p.Bucket}
+			for _, g := range result.GroupBy {
+				row = append(row, series.Group[g])
+			}
+			for _, m := range result.Metrics {
+				row = append(row, p.Values[m])
+			}
+			rows = append(rows, row)
+		}
+	}
+	return exportTable("Deret Waktu", columns, rows)
+}
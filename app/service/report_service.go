@@ -1,8 +1,14 @@
 package service
 
 import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
 	"sistem-pelaporan-prestasi-mahasiswa/app/model"
 	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
 	"sistem-pelaporan-prestasi-mahasiswa/helper"
 
 	"github.com/gofiber/fiber/v2"
@@ -11,42 +17,86 @@ import (
 type IReportService interface {
 	GetDashboardStats(c *fiber.Ctx) error
 	GetStudentReport(c *fiber.Ctx) error
+	GetTimeseries(c *fiber.Ctx) error
 }
 
 type ReportService struct {
 	reportRepo  repository.IReportRepository
 	studentRepo repository.IStudentRepository
 	lecturerSvc ILecturerService
+	letterhead  export.Letterhead
 }
 
 func NewReportService(
 	reportRepo repository.IReportRepository,
 	studentRepo repository.IStudentRepository,
 	lecturerSvc ILecturerService,
+	letterhead export.Letterhead,
 ) IReportService {
 	return &ReportService{
 		reportRepo:  reportRepo,
 		studentRepo: studentRepo,
 		lecturerSvc: lecturerSvc,
+		letterhead:  letterhead,
 	}
 }
 
 // GetDashboardStats godoc
 // @Summary Get dashboard statistics
-// @Description Get global statistics for the dashboard (Admin only)
+// @Description Get global statistics for the dashboard (Admin only): totals, status breakdown, top students by verified points, breakdowns by achievement type, program study and academic year, and verification turnaround. Deleted achievements are excluded.
 // @Tags Reports
 // @Accept json
 // @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
 // @Security BearerAuth
+// @Param date_from query string false "Reported on or after (YYYY-MM-DD)"
+// @Param date_to query string false "Reported on or before (YYYY-MM-DD)"
+// @Param faculty query string false "Only students whose program study belongs to this faculty (must be mapped via /study-programs)"
+// @Param top query int false "Number of top students" default(10)
+// @Param format query string false "Output format; alternatively send an Accept header (text/csv, application/pdf or the XLSX/DOCX media type)" Enums(json, csv, xlsx, pdf, docx) default(json)
 // @Success 200 {object} helper.Response{data=model.DashboardStatistics} "Statistics retrieved"
+// @Failure 400 {object} helper.ErrorResponse "Invalid filter"
 // @Failure 401 {object} helper.ErrorResponse "Unauthorized"
 // @Router /reports/statistics [get]
 func (s *ReportService) GetDashboardStats(c *fiber.Ctx) error {
-	result, err := s.reportRepo.GetGlobalStats(c.Context())
+	format, err := exportFormat(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	filter := model.StatisticsFilter{
+		Faculty: strings.TrimSpace(c.Query("faculty")),
+		TopN:    c.QueryInt("top", 10),
+	}
+	if filter.TopN < 0 || filter.TopN > 100 {
+		return helper.HandleError(c, model.NewValidationError("top harus di antara 0 dan 100"))
+	}
+
+	if filter.DateFrom, filter.DateTo, err = queryDateRange(c); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	if err := s.checkFaculty(c.Context(), filter.Faculty); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	result, err := s.reportRepo.GetGlobalStats(c.Context(), filter)
 	if err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
 	}
 
+	if format != export.FormatJSON {
+		doc := export.Document{
+			Title:      "Statistik Prestasi Mahasiswa",
+			Subtitle:   subtitle(exportPeriod(filter.DateFrom, filter.DateTo), facultyLine(filter.Faculty)),
+			Letterhead: s.letterhead,
+		}
+		return sendExport(c, format, "statistik-prestasi", doc, dashboardExport(result))
+	}
+
 	return helper.Success(c, "Statistik dashboard berhasil diambil", result)
 }
 
@@ -56,8 +106,13 @@ func (s *ReportService) GetDashboardStats(c *fiber.Ctx) error {
 // @Tags Reports
 // @Accept json
 // @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
 // @Security BearerAuth
 // @Param id path string true "Student User ID"
+// @Param format query string false "Output format; alternatively send an Accept header (text/csv, application/pdf or the XLSX/DOCX media type)" Enums(json, csv, xlsx, pdf, docx) default(json)
 // @Success 200 {object} helper.Response{data=model.StudentReportDTO} "Student report retrieved"
 // @Failure 403 {object} helper.ErrorResponse "Forbidden - Not authorized to view this report"
 // @Failure 404 {object} helper.ErrorResponse "Student not found"
@@ -67,6 +122,11 @@ func (s *ReportService) GetStudentReport(c *fiber.Ctx) error {
 	viewerUserID := c.Locals("user_id").(string)
 	viewerRole := c.Locals("role").(string)
 
+	format, err := exportFormat(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
 	targetStudent, err := s.studentRepo.GetByUserID(c.Context(), targetUserID)
 	if err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
@@ -115,5 +175,156 @@ func (s *ReportService) GetStudentReport(c *fiber.Ctx) error {
 		IsActive:     studentDetail.IsActive,
 	}
 
+	if format != export.FormatJSON {
+		doc := export.Document{
+			Title:      "Laporan Prestasi Mahasiswa",
+			Subtitle:   subtitle(fmt.Sprintf("%s (%s)", studentDetail.FullName, studentDetail.StudentID), studentDetail.ProgramStudy),
+			Letterhead: s.letterhead,
+		}
+		return sendExport(c, format, "laporan-prestasi-"+studentDetail.StudentID, doc, studentReportExport(report))
+	}
+
 	return helper.Success(c, "Laporan prestasi mahasiswa berhasil diambil", report)
 }
+
+// GetTimeseries godoc
+// @Summary Get achievement time series
+// @Description Get achievement trends per time bucket (Admin only). Each metric is counted at its own timestamp: created (reported), submitted, verified/rejected (decision time) and points (awarded at verification). Empty buckets are returned with zero values.
+// @Tags Reports
+// @Accept json
+// @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
+// @Security BearerAuth
+// @Param bucket query string false "Bucket size; semesters run August-January (Ganjil) and February-July (Genap)" Enums(day, week, month, semester) default(month)
+// @Param metrics query string false "Comma-separated metrics: created, submitted, verified, rejected, points" default(submitted,verified)
+// @Param group_by query string false "Comma-separated dimensions (max 2): achievement_type, program_study, academic_year, faculty"
+// @Param date_from query string false "Start date (YYYY-MM-DD), default 12 months before date_to"
+// @Param date_to query string false "End date inclusive (YYYY-MM-DD), default today"
+// @Param faculty query string false "Only students whose program study belongs to this faculty (must be mapped via /study-programs)"
+// @Param format query string false "Output format; alternatively send an Accept header (text/csv, application/pdf or the XLSX/DOCX media type)" Enums(json, csv, xlsx, pdf, docx) default(json)
+// @Success 200 {object} helper.Response{data=model.TimeseriesResult} "Time series retrieved"
+// @Failure 400 {object} helper.ErrorResponse "Invalid parameter"
+// @Router /reports/timeseries [get]
+func (s *ReportService) GetTimeseries(c *fiber.Ctx) error {
+	format, err := exportFormat(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	filter, err := parseTimeseriesFilter(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	if err := s.checkFaculty(c.Context(), filter.Faculty); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	rows, err := s.reportRepo.GetTimeseries(c.Context(), filter)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	result := &model.TimeseriesResult{
+		Bucket:   filter.Bucket,
+		Metrics:  filter.Metrics,
+		GroupBy:  filter.GroupBy,
+		DateFrom: filter.DateFrom,
+		DateTo:   filter.DateTo,
+		Series:   buildTimeseries(filter, rows),
+	}
+
+	if format != export.FormatJSON {
+		doc := export.Document{
+			Title:      "Tren Prestasi Mahasiswa",
+			Subtitle:   subtitle(exportPeriod(&filter.DateFrom, &filter.DateTo), "Bucket "+filter.Bucket, facultyLine(filter.Faculty)),
+			Letterhead: s.letterhead,
+		}
+		return sendExport(c, format, "tren-prestasi", doc, timeseriesExport(result))
+	}
+
+	return helper.Success(c, "Deret waktu prestasi berhasil diambil", result)
+}
+
+// checkFaculty menolak filter fakultas yang belum dipetakan ke program studi mana pun, agar laporan
+// tidak diam-diam kosong karena pemetaan study_programs belum diisi
+func (s *ReportService) checkFaculty(ctx context.Context, faculty string) error {
+	if faculty == "" {
+		return nil
+	}
+	ok, err := s.reportRepo.HasFaculty(ctx, faculty)
+	if err != nil {
+		return model.ErrDatabaseError
+	}
+	if !ok {
+		return model.NewValidationError(fmt.Sprintf("Fakultas '%s' belum dipetakan ke program studi mana pun; atur pemetaan lewat /study-programs", faculty))
+	}
+	return nil
+}
+
+func parseTimeseriesFilter(c *fiber.Ctx) (model.TimeseriesFilter, error) {
+	filter := model.TimeseriesFilter{
+		Bucket:  c.Query("bucket", model.BucketMonth),
+		Faculty: strings.TrimSpace(c.Query("faculty")),
+		GroupBy: []string{},
+	}
+	if !timeseriesBucketSizes[filter.Bucket] {
+		return filter, model.NewValidationError("bucket harus salah satu dari day, week, month, semester")
+	}
+
+	seen := map[string]bool{}
+	for _, m := range strings.Split(c.Query("metrics", model.MetricSubmitted+","+model.MetricVerified), ",") {
+		if m = strings.TrimSpace(m); m == "" || seen[m] {
+			continue
+		}
+		if !timeseriesMetrics[m] {
+			return filter, model.NewValidationError(fmt.Sprintf("Metrik '%s' tidak dikenal", m))
+		}
+		seen[m] = true
+		filter.Metrics = append(filter.Metrics, m)
+	}
+	if len(filter.Metrics) == 0 {
+		return filter, model.NewValidationError("Minimal satu metrik diperlukan")
+	}
+
+	for _, g := range strings.Split(c.Query("group_by"), ",") {
+		if g = strings.TrimSpace(g); g == "" || seen[g] {
+			continue
+		}
+		if !timeseriesGroups[g] {
+			return filter, model.NewValidationError(fmt.Sprintf("Dimensi group_by '%s' tidak dikenal", g))
+		}
+		seen[g] = true
+		filter.GroupBy = append(filter.GroupBy, g)
+	}
+	if len(filter.GroupBy) > 2 {
+		return filter, model.NewValidationError("group_by maksimal 2 dimensi")
+	}
+
+	from, to, err := queryDateRange(c)
+	if err != nil {
+		return filter, err
+	}
+	if to == nil {
+		y, m, d := time.Now().Date()
+		end := time.Date(y, m, d, 0, 0, 0, 0, time.Local).AddDate(0, 0, 1)
+		to = &end
+	}
+	if from == nil {
+		start := to.AddDate(-1, 0, 0)
+		from = &start
+	}
+	if !from.Before(*to) {
+		return filter, model.NewValidationError("date_from tidak boleh setelah date_to")
+	}
+	filter.DateFrom, filter.DateTo = *from, *to
+
+	if len(timeseriesBuckets(filter.DateFrom, filter.DateTo, filter.Bucket)) > maxTimeseriesBuckets {
+		return filter, model.NewValidationError(fmt.Sprintf("Rentang tanggal terlalu panjang untuk bucket %s (maksimal %d bucket)", filter.Bucket, maxTimeseriesBuckets))
+	}
+
+	return filter, nil
+}
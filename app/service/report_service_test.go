@@ -1,11 +1,19 @@
 package service
 
 import (
+	"archive/zip"
+	"bytes"
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
 	"net/http/httptest"
+	"strings"
 	"testing"
+	"time"
 
 	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -13,9 +21,15 @@ import (
 type MockReportRepository struct {
 	globalStats   *model.DashboardStatistics
 	studentReport *model.StudentReportDTO
+	lastFilter    model.StatisticsFilter
+	timeseries    []model.TimeseriesRow
+	lastSeries    model.TimeseriesFilter
+	// unmappedFaculties berisi fakultas yang dianggap belum dipetakan ke program studi
+	unmappedFaculties []string
 }
 
-func (m *MockReportRepository) GetGlobalStats(ctx context.Context) (*model.DashboardStatistics, error) {
+func (m *MockReportRepository) GetGlobalStats(ctx context.Context, f model.StatisticsFilter) (*model.DashboardStatistics, error) {
+	m.lastFilter = f
 	return m.globalStats, nil
 }
 
@@ -26,6 +40,20 @@ func (m *MockReportRepository) GetStudentReport(ctx context.Context, studentID s
 	return nil, nil
 }
 
+func (m *MockReportRepository) GetTimeseries(ctx context.Context, f model.TimeseriesFilter) ([]model.TimeseriesRow, error) {
+	m.lastSeries = f
+	return m.timeseries, nil
+}
+
+func (m *MockReportRepository) HasFaculty(ctx context.Context, faculty string) (bool, error) {
+	for _, f := range m.unmappedFaculties {
+		if strings.EqualFold(f, faculty) {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
 func TestReportService_GetDashboardStats(t *testing.T) {
 	app := fiber.New()
 	mockReportRepo := &MockReportRepository{
@@ -35,7 +63,7 @@ func TestReportService_GetDashboardStats(t *testing.T) {
 			TotalAchievements: 5,
 		},
 	}
-	service := NewReportService(mockReportRepo, &MockStudentRepository{}, &MockLecturerService{})
+	service := NewReportService(mockReportRepo, &MockStudentRepository{}, &MockLecturerService{}, export.Letterhead{})
 
 	app.Get("/reports/statistics", service.GetDashboardStats)
 
@@ -50,6 +78,188 @@ func TestReportService_GetDashboardStats(t *testing.T) {
 		if resp.StatusCode != fiber.StatusOK {
 			t.Errorf("Expected 200 status, got %d", resp.StatusCode)
 		}
+		if f := mockReportRepo.lastFilter; f.TopN != 10 || f.DateFrom != nil || f.Faculty != "" {
+			t.Errorf("Expected default filter with top 10, got %+v", f)
+		}
+	})
+
+	t.Run("GET - Filters Passed To Repository", func(t *testing.T) {
+		req := httptest.NewRequest("GET", "/reports/statistics?date_from=2024-01-01&date_to=2024-06-30&faculty=%20Teknik%20&top=5", nil)
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", resp.StatusCode)
+		}
+
+		f := mockReportRepo.lastFilter
+		if f.Faculty != "Teknik" || f.TopN != 5 {
+			t.Errorf("Unexpected filter %+v", f)
+		}
+		if f.DateFrom == nil || f.DateFrom.Format("2006-01-02") != "2024-01-01" || f.DateTo == nil || f.DateTo.Format("2006-01-02") != "2024-07-01" {
+			t.Errorf("Expected date_to to include the whole day, got %v - %v", f.DateFrom, f.DateTo)
+		}
+	})
+
+	t.Run("GET - Unmapped Faculty Rejected", func(t *testing.T) {
+		mockReportRepo.unmappedFaculties = []string{"Kedokteran"}
+		defer func() { mockReportRepo.unmappedFaculties = nil }()
+
+		resp, err := app.Test(httptest.NewRequest("GET", "/reports/statistics?faculty=kedokteran", nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 for an unmapped faculty, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("GET - Export XLSX", func(t *testing.T) {
+		mockReportRepo.globalStats.TopStudents = []model.TopStudent{{StudentID: "210001", Name: "Ani", Achievements: 3, TotalPoints: 90}}
+		resp, err := app.Test(httptest.NewRequest("GET", "/reports/statistics?format=xlsx&date_from=2024-01-01", nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK || resp.Header.Get(fiber.HeaderContentType) != export.FormatXLSX.ContentType() {
+			t.Fatalf("Expected an XLSX download, got %d %s", resp.StatusCode, resp.Header.Get(fiber.HeaderContentType))
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			t.Fatalf("Expected a zip archive: %v", err)
+		}
+		sheets := 0
+		for _, f := range zr.File {
+			if strings.HasPrefix(f.Name, "xl/worksheets/") {
+				sheets++
+			}
+		}
+		if sheets != 6 {
+			t.Errorf("Expected one sheet per table (6), got %d", sheets)
+		}
+	})
+
+	t.Run("GET - Invalid Filters", func(t *testing.T) {
+		for _, query := range []string{"format=html", "top=500", "date_from=2024/01/01", "date_from=2024-12-31&date_to=2024-01-01"} {
+			resp, err := app.Test(httptest.NewRequest("GET", "/reports/statistics?"+query, nil))
+			if err != nil {
+				t.Fatalf("Failed to send request: %v", err)
+			}
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("Expected 400 for %s, got %d", query, resp.StatusCode)
+			}
+		}
+	})
+}
+
+func TestReportService_GetTimeseries(t *testing.T) {
+	app := fiber.New()
+	mockReportRepo := &MockReportRepository{unmappedFaculties: []string{"Kedokteran"}}
+	service := NewReportService(mockReportRepo, &MockStudentRepository{}, &MockLecturerService{}, export.Letterhead{})
+
+	app.Get("/reports/timeseries", service.GetTimeseries)
+
+	get := func(t *testing.T, query string) (*http.Response, model.TimeseriesResult) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/reports/timeseries?"+query, nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		var body struct {
+			Data model.TimeseriesResult `json:"data"`
+		}
+		json.NewDecoder(resp.Body).Decode(&body)
+		return resp, body.Data
+	}
+	day := func(s string) time.Time {
+		d, _ := time.Parse("2006-01-02", s)
+		return d
+	}
+
+	t.Run("GET - Defaults", func(t *testing.T) {
+		mockReportRepo.timeseries = nil
+		resp, data := get(t, "")
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", resp.StatusCode)
+		}
+
+		f := mockReportRepo.lastSeries
+		if f.Bucket != model.BucketMonth || len(f.Metrics) != 2 || len(f.GroupBy) != 0 {
+			t.Errorf("Unexpected default filter %+v", f)
+		}
+		if !f.DateFrom.Equal(f.DateTo.AddDate(-1, 0, 0)) {
+			t.Errorf("Expected a 12 month default range, got %v - %v", f.DateFrom, f.DateTo)
+		}
+		if len(data.Series) != 1 || len(data.Series[0].Points) < 12 {
+			t.Errorf("Expected one gap-filled series, got %+v", data.Series)
+		}
+	})
+
+	t.Run("GET - Gap Filling And Totals", func(t *testing.T) {
+		mockReportRepo.timeseries = []model.TimeseriesRow{
+			{Bucket: day("2024-01-01"), Metric: model.MetricVerified, Value: 2},
+			{Bucket: day("2024-03-01"), Metric: model.MetricVerified, Value: 3},
+			{Bucket: day("2024-03-01"), Metric: model.MetricPoints, Value: 40},
+		}
+		resp, data := get(t, "date_from=2024-01-15&date_to=2024-04-10&metrics=verified,points")
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", resp.StatusCode)
+		}
+
+		points := data.Series[0].Points
+		if len(points) != 4 || points[0].Label != "2024-01" || points[3].Label != "2024-04" {
+			t.Fatalf("Expected buckets 2024-01..2024-04, got %+v", points)
+		}
+		if points[1].Values[model.MetricVerified] != 0 || points[2].Values[model.MetricPoints] != 40 {
+			t.Errorf("Unexpected bucket values %+v", points)
+		}
+		if totals := data.Series[0].Totals; totals[model.MetricVerified] != 5 || totals[model.MetricPoints] != 40 {
+			t.Errorf("Unexpected totals %+v", totals)
+		}
+	})
+
+	t.Run("GET - Semester Buckets Grouped", func(t *testing.T) {
+		mockReportRepo.timeseries = []model.TimeseriesRow{
+			{Bucket: day("2023-08-01"), Metric: model.MetricSubmitted, Group: []string{"competition"}, Value: 1},
+			{Bucket: day("2024-02-01"), Metric: model.MetricSubmitted, Group: []string{"academic"}, Value: 4},
+		}
+		resp, data := get(t, "bucket=semester&metrics=submitted&group_by=achievement_type&date_from=2023-09-01&date_to=2024-12-31")
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", resp.StatusCode)
+		}
+
+		if len(data.Series) != 2 || data.Series[0].Group[model.GroupAchievementType] != "academic" {
+			t.Fatalf("Expected two series sorted by group, got %+v", data.Series)
+		}
+		labels := []string{}
+		for _, p := range data.Series[0].Points {
+			labels = append(labels, p.Label)
+		}
+		if strings.Join(labels, ",") != "2023/2024 Ganjil,2023/2024 Genap,2024/2025 Ganjil" {
+			t.Errorf("Unexpected semester labels %v", labels)
+		}
+		if data.Series[0].Points[1].Values[model.MetricSubmitted] != 4 {
+			t.Errorf("Expected 4 submissions in 2023/2024 Genap, got %+v", data.Series[0].Points)
+		}
+	})
+
+	t.Run("GET - Invalid Parameters", func(t *testing.T) {
+		for _, query := range []string{
+			"bucket=year",
+			"metrics=views",
+			"group_by=city",
+			"group_by=faculty,program_study,academic_year",
+			"date_from=2024-12-31&date_to=2024-01-01",
+			"bucket=day&date_from=2015-01-01&date_to=2024-12-31",
+			"faculty=Kedokteran",
+		} {
+			resp, _ := get(t, query)
+			if resp.StatusCode != fiber.StatusBadRequest {
+				t.Errorf("Expected 400 for %s, got %d", query, resp.StatusCode)
+			}
+		}
 	})
 }
 
@@ -59,7 +269,7 @@ func TestReportService_GetStudentReport_RBAC(t *testing.T) {
 		students: make(map[string]*model.StudentInfo),
 	}
 	mockLecturerSvc := &MockLecturerService{}
-	service := NewReportService(mockReportRepo, mockStudentRepo, mockLecturerSvc)
+	service := NewReportService(mockReportRepo, mockStudentRepo, mockLecturerSvc, export.Letterhead{})
 
 	targetUserID := "user-mhs-uuid"
 	studentProfileID := "student-internal-id"
@@ -0,0 +1,533 @@
+package service
+
+import (
+	"archive/zip"
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type ISKPIService interface {
+	GenerateStudent(c *fiber.Ctx) error
+	GenerateBatch(c *fiber.Ctx) error
+	Cohort(ctx context.Context, f model.SKPICohortFilter) ([]model.SKPIStudent, error)
+	Batch(ctx context.Context, students []model.SKPIStudent, f export.Format, template string, write func(name string, fill func(w io.Writer) error) error) (model.SKPIBatchSummary, error)
+}
+
+type SKPIService struct {
+	skpiRepo    repository.ISKPIRepository
+	studentRepo repository.IStudentRepository
+	config      model.SKPIConfig
+	letterhead  export.Letterhead
+}
+
+func NewSKPIService(
+	skpiRepo repository.ISKPIRepository,
+	studentRepo repository.IStudentRepository,
+	config model.SKPIConfig,
+	letterhead export.Letterhead,
+) ISKPIService {
+	return &SKPIService{
+		skpiRepo:    skpiRepo,
+		studentRepo: studentRepo,
+		config:      config,
+		letterhead:  letterhead,
+	}
+}
+
+// Kunci details yang dibaca untuk judul berbahasa Inggris dan tingkat kegiatan
+var (
+	skpiTitleENKeys = []string{"title_en", "judul_en", "english_title"}
+	skpiLevelKeys   = []string{"level", "tingkat", "competition_level"}
+)
+
+var bulanIndonesia = [...]string{"Januari", "Februari", "Maret", "April", "Mei", "Juni", "Juli", "Agustus", "September", "Oktober", "November", "Desember"}
+
+// GenerateStudent godoc
+// @Summary Generate a student's SKPI
+// @Description Generate the bilingual SKPI (Surat Keterangan Pendamping Ijazah / Diploma Supplement) of a student from their verified non-academic achievements, grouped by the template's category mapping (Admin only). format=json returns the document content for preview.
+// @Tags SKPI
+// @Accept json
+// @Produce application/pdf
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Student User ID"
+// @Param format query string false "Output format" Enums(pdf, docx, json) default(pdf)
+// @Param template query string false "Template name from SKPI_CONFIG; defaults to the configured default template"
+// @Success 200 {object} helper.Response{data=model.SKPIDocument} "SKPI content (format=json)"
+// @Failure 400 {object} helper.ErrorResponse "Invalid format or template"
+// @Failure 404 {object} helper.ErrorResponse "Student not found"
+// @Router /skpi/students/{id} [get]
+func (s *SKPIService) GenerateStudent(c *fiber.Ctx) error {
+	format, err := skpiFormat(c, true)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	name, tpl, err := s.template(c.Query("template"))
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	userID := c.Params("id")
+	info, err := s.studentRepo.GetByUserID(c.Context(), userID)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if info == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Mahasiswa tidak ditemukan"))
+	}
+	detail, err := s.studentRepo.GetDetailByID(c.Context(), info.ID)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if detail == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Detail mahasiswa tidak ditemukan"))
+	}
+
+	student := model.SKPIStudent{
+		ID:           info.ID,
+		UserID:       userID,
+		StudentID:    detail.StudentID,
+		FullName:     detail.FullName,
+		ProgramStudy: detail.ProgramStudy,
+		AcademicYear: detail.AcademicYear,
+	}
+	doc, err := s.build(c.Context(), student, name, tpl, time.Now())
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	if format == export.FormatJSON {
+		return helper.Success(c, "SKPI mahasiswa berhasil disusun", doc)
+	}
+	return sendExport(c, format, "skpi-"+safeFilename(student.StudentID), s.exportDocument(doc, tpl), skpiContent(doc, tpl))
+}
+
+// GenerateBatch godoc
+// @Summary Generate SKPI for a graduating cohort
+// @Description Generate the SKPI of every student in a cohort (academic year, optionally one program study) as a ZIP archive with one file per student plus daftar-skpi.csv listing each file (Admin only). The archive is streamed; students whose SKPI fails are marked in daftar-skpi.csv.
+// @Tags SKPI
+// @Accept json
+// @Produce application/zip
+// @Security BearerAuth
+// @Param academic_year query string true "Academic year (angkatan), e.g. 2021"
+// @Param program_study query string false "Only students of this program study"
+// @Param format query string false "Document format" Enums(pdf, docx) default(pdf)
+// @Param template query string false "Template name from SKPI_CONFIG"
+// @Success 200 {file} file "ZIP archive"
+// @Failure 400 {object} helper.ErrorResponse "Invalid parameter"
+// @Failure 404 {object} helper.ErrorResponse "No students in the cohort"
+// @Router /skpi/batch [get]
+func (s *SKPIService) GenerateBatch(c *fiber.Ctx) error {
+	format, err := skpiFormat(c, false)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	name, _, err := s.template(c.Query("template"))
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	filter := model.SKPICohortFilter{
+		AcademicYear: strings.TrimSpace(c.Query("academic_year")),
+		ProgramStudy: strings.TrimSpace(c.Query("program_study")),
+	}
+	if filter.AcademicYear == "" {
+		return helper.HandleError(c, model.NewValidationError("academic_year wajib diisi"))
+	}
+
+	students, err := s.Cohort(c.Context(), filter)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if len(students) == 0 {
+		return helper.HandleError(c, model.NewNotFoundError("Tidak ada mahasiswa pada angkatan tersebut"))
+	}
+
+	base := "skpi-" + safeFilename(filter.AcademicYear)
+	if filter.ProgramStudy != "" {
+		base += "-" + safeFilename(filter.ProgramStudy)
+	}
+	filename := fmt.Sprintf("%s-%s.zip", base, time.Now().Format("20060102"))
+
+	c.Set(fiber.HeaderContentType, "application/zip")
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf(`attachment; filename="%s"`, filename))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+	c.Status(fiber.StatusOK).Context().SetBodyStreamWriter(func(bw *bufio.Writer) {
+		ctx, cancel := context.WithTimeout(context.Background(), exportTimeout)
+		defer cancel()
+
+		zw := zip.NewWriter(bw)
+		summary, err := s.Batch(ctx, students, format, name, func(name string, fill func(w io.Writer) error) error {
+			w, err := zw.Create(name)
+			if err != nil {
+				return err
+			}
+			return fill(w)
+		})
+		if err == nil {
+			err = zw.Close()
+		}
+		if err == nil {
+			err = bw.Flush()
+		}
+		if err != nil {
+			log.Printf("⚠️  Ekspor %s terhenti: %v", filename, err)
+			return
+		}
+		log.Printf("✅ SKPI %s: %d dari %d mahasiswa, %d gagal", filename, summary.Generated, summary.Students, summary.Failed)
+	})
+	return nil
+}
+
+func (s *SKPIService) Cohort(ctx context.Context, f model.SKPICohortFilter) ([]model.SKPIStudent, error) {
+	return s.skpiRepo.ListCohort(ctx, f)
+}
+
+// Batch membuat SKPI setiap mahasiswa lewat write, satu file per mahasiswa, lalu daftar-skpi.csv.
+// Kegagalan satu mahasiswa dicatat di daftar dan tidak menghentikan yang lain; error dari write
+// (misalnya klien memutus unduhan atau disk penuh) menghentikan seluruh batch.
+func (s *SKPIService) Batch(ctx context.Context, students []model.SKPIStudent, f export.Format, template string, write func(name string, fill func(w io.Writer) error) error) (model.SKPIBatchSummary, error) {
+	summary := model.SKPIBatchSummary{Students: len(students)}
+	if f != export.FormatPDF && f != export.FormatDOCX {
+		return summary, model.NewValidationError("format SKPI harus pdf atau docx")
+	}
+	name, tpl, err := s.template(template)
+	if err != nil {
+		return summary, err
+	}
+
+	issuedAt := time.Now()
+	var rows [][]interface{}
+	for _, student := range students {
+		if err := ctx.Err(); err != nil {
+			return summary, err
+		}
+
+		filename := fmt.Sprintf("skpi-%s.%s", safeFilename(student.StudentID), f)
+		doc, err := s.build(ctx, student, name, tpl, issuedAt)
+		if err != nil {
+			log.Printf("⚠️  SKPI %s gagal disusun: %v", student.StudentID, err)
+			summary.Failed++
+			rows = append(rows, []interface{}{student.StudentID, student.FullName, student.ProgramStudy, nil, "", "Gagal: " + err.Error()})
+			continue
+		}
+
+		err = write(filename, func(w io.Writer) error {
+			ew, err := export.New(f, w, s.exportDocument(doc, tpl))
+			if err != nil {
+				return err
+			}
+			if err := skpiContent(doc, tpl)(ctx, ew); err != nil {
+				return err
+			}
+			return ew.Close()
+		})
+		if err != nil {
+			return summary, err
+		}
+		summary.Generated++
+		rows = append(rows, []interface{}{student.StudentID, student.FullName, student.ProgramStudy, skpiItemCount(doc), filename, "OK"})
+	}
+
+	err = write("daftar-skpi.csv", func(w io.Writer) error {
+		cw, err := export.New(export.FormatCSV, w, export.Document{})
+		if err != nil {
+			return err
+		}
+		columns := []export.Column{{Header: "NIM"}, {Header: "Nama"}, {Header: "Program Studi"}, {Header: "Jumlah Prestasi"}, {Header: "File"}, {Header: "Status"}}
+		if err := writeTable(cw, "", columns, rows); err != nil {
+			return err
+		}
+		return cw.Close()
+	})
+	return summary, err
+}
+
+// template memilih template SKPI; nama kosong berarti template bawaan konfigurasi
+func (s *SKPIService) template(name string) (string, model.SKPITemplate, error) {
+	if name = strings.TrimSpace(name); name == "" {
+		name = s.config.DefaultTemplate
+	}
+	tpl, ok := s.config.Templates[name]
+	if !ok {
+		return "", tpl, model.NewValidationError(fmt.Sprintf("Template SKPI '%s' tidak dikenal", name))
+	}
+	return name, tpl, nil
+}
+
+// build menyusun isi SKPI: prestasi verified dikelompokkan ke kategori template
+func (s *SKPIService) build(ctx context.Context, student model.SKPIStudent, name string, tpl model.SKPITemplate, issuedAt time.Time) (*model.SKPIDocument, error) {
+	items, err := s.skpiRepo.GetVerifiedAchievements(ctx, student.ID)
+	if err != nil {
+		return nil, err
+	}
+	for i := range items {
+		items[i].TitleEN = detailString(items[i].Details, skpiTitleENKeys)
+		items[i].Level = detailString(items[i].Details, skpiLevelKeys)
+		items[i].EventDate = eventDate(items[i].Details)
+	}
+
+	return &model.SKPIDocument{
+		Number:   skpiNumber(tpl.NumberFormat, student, issuedAt),
+		Template: name,
+		Student:  student,
+		Sections: skpiSections(tpl, items),
+		IssuedAt: issuedAt,
+	}, nil
+}
+
+func (s *SKPIService) exportDocument(doc *model.SKPIDocument, tpl model.SKPITemplate) export.Document {
+	return export.Document{
+		Title:       strings.ToUpper(tpl.Title.ID),
+		Subtitle:    subtitle(tpl.Title.EN, "Nomor / Number: "+doc.Number),
+		Letterhead:  s.letterhead,
+		GeneratedAt: doc.IssuedAt,
+		Portrait:    true,
+	}
+}
+
+// skpiSections mengelompokkan prestasi sesuai urutan kategori template. Kategori dengan "*" menampung
+// jenis yang tidak dipetakan; jenis di ExcludeTypes dan jenis tanpa kategori tidak dicantumkan.
+// Kategori tanpa prestasi tidak ditampilkan.
+func skpiSections(tpl model.SKPITemplate, items []model.SKPIAchievement) []model.SKPISection {
+	excluded := map[string]bool{}
+	for _, t := range tpl.ExcludeTypes {
+		excluded[t] = true
+	}
+	category := map[string]int{}
+	wildcard := -1
+	for i, cat := range tpl.Categories {
+		for _, t := range cat.Types {
+			if t == "*" {
+				if wildcard < 0 {
+					wildcard = i
+				}
+			} else if _, ok := category[t]; !ok {
+				category[t] = i
+			}
+		}
+	}
+
+	grouped := make([][]model.SKPIAchievement, len(tpl.Categories))
+	for _, item := range items {
+		if excluded[item.AchievementType] {
+			continue
+		}
+		i, ok := category[item.AchievementType]
+		if !ok {
+			if wildcard < 0 {
+				continue
+			}
+			i = wildcard
+		}
+		grouped[i] = append(grouped[i], item)
+	}
+
+	sections := []model.SKPISection{}
+	for i, cat := range tpl.Categories {
+		if len(grouped[i]) == 0 {
+			continue
+		}
+		sort.SliceStable(grouped[i], func(a, b int) bool {
+			return skpiSortDate(grouped[i][a]) < skpiSortDate(grouped[i][b])
+		})
+		sections = append(sections, model.SKPISection{Category: cat, Items: grouped[i]})
+	}
+	return sections
+}
+
+// skpiSortDate mengurutkan prestasi menurut tanggal kegiatan, atau tanggal verifikasi bila kosong
+func skpiSortDate(a model.SKPIAchievement) string {
+	if a.EventDate != "" {
+		return a.EventDate
+	}
+	if a.VerifiedAt != nil {
+		return a.VerifiedAt.Format("2006-01-02")
+	}
+	return ""
+}
+
+func skpiItemCount(doc *model.SKPIDocument) int {
+	n := 0
+	for _, sec := range doc.Sections {
+		n += len(sec.Items)
+	}
+	return n
+}
+
+// skpiNumber mengisi {nim}, {year} dan {program} pada format nomor SKPI
+func skpiNumber(format string, student model.SKPIStudent, issuedAt time.Time) string {
+	if format == "" {
+		format = "SKPI/{year}/{nim}"
+	}
+	return strings.NewReplacer(
+		"{nim}", student.StudentID,
+		"{year}", issuedAt.Format("2006"),
+		"{program}", student.ProgramStudy,
+	).Replace(format)
+}
+
+// skpiContent menulis isi SKPI: identitas pemegang, prestasi per kategori dan blok tanda tangan. Setiap
+// teks ditulis dalam Bahasa Indonesia lalu terjemahan Inggrisnya dicetak miring.
+func skpiContent(doc *model.SKPIDocument, tpl model.SKPITemplate) func(ctx context.Context, w export.Writer) error {
+	return func(ctx context.Context, w export.Writer) error {
+		bilingual := func(style export.TextStyle, text model.BilingualText) error {
+			if text.ID != "" {
+				if err := w.Text(style, text.ID); err != nil {
+					return err
+				}
+			}
+			if text.EN != "" {
+				style.Italic = true
+				return w.Text(style, text.EN)
+			}
+			return nil
+		}
+
+		if err := bilingual(export.TextStyle{}, tpl.Intro); err != nil {
+			return err
+		}
+		w.Text(export.TextStyle{}, "")
+
+		st := doc.Student
+		holder := [][]interface{}{
+			{"Nama Lengkap / Full Name", st.FullName},
+			{"Nomor Induk Mahasiswa / Student Number", st.StudentID},
+			{"Program Studi / Study Program", st.ProgramStudy},
+			{"Angkatan / Year of Entry", st.AcademicYear},
+		}
+		columns := []export.Column{{Header: "Keterangan / Item", Width: 24}, {Header: "Isi / Value", Width: 40}}
+		if err := writeTable(w, "1. Informasi Pemegang SKPI / Holder Information", columns, holder); err != nil {
+			return err
+		}
+
+		w.Text(export.TextStyle{}, "")
+		if err := w.Text(export.TextStyle{Size: 10, Bold: true}, "2. Prestasi dan Penghargaan / Achievements and Awards"); err != nil {
+			return err
+		}
+		if len(doc.Sections) == 0 {
+			err := bilingual(export.TextStyle{}, model.BilingualText{
+				ID: "Tidak ada prestasi non-akademik yang terverifikasi.",
+				EN: "No verified non-academic achievements.",
+			})
+			if err != nil {
+				return err
+			}
+		}
+		columns = []export.Column{
+			{Header: "No", Width: 4, Align: export.AlignRight},
+			{Header: "Kegiatan / Activity", Width: 40},
+			{Header: "Tingkat / Level", Width: 14},
+			{Header: "Tanggal / Date", Width: 12},
+		}
+		for i, sec := range doc.Sections {
+			rows := make([][]interface{}, len(sec.Items))
+			for j, item := range sec.Items {
+				title := item.Title
+				if item.TitleEN != "" && item.TitleEN != item.Title {
+					title += "\n" + item.TitleEN
+				}
+				date := item.EventDate
+				if date == "" && item.VerifiedAt != nil {
+					date = item.VerifiedAt.Format("2006-01-02")
+				}
+				rows[j] = []interface{}{j + 1, title, item.Level, date}
+			}
+			title := fmt.Sprintf("%c. %s / %s", 'A'+i, sec.Category.Name.ID, sec.Category.Name.EN)
+			if err := writeTable(w, title, columns, rows); err != nil {
+				return err
+			}
+		}
+
+		w.Text(export.TextStyle{}, "")
+		if err := bilingual(export.TextStyle{}, tpl.Closing); err != nil {
+			return err
+		}
+		w.Text(export.TextStyle{}, "")
+
+		right := export.TextStyle{Align: export.AlignRight}
+		issued := model.BilingualText{
+			ID: tanggalIndonesia(doc.IssuedAt),
+			EN: doc.IssuedAt.Format("2 January 2006"),
+		}
+		if tpl.Place != "" {
+			issued.ID = tpl.Place + ", " + issued.ID
+			issued.EN = tpl.Place + ", " + issued.EN
+		}
+		if err := bilingual(right, issued); err != nil {
+			return err
+		}
+		if err := bilingual(right, tpl.SignatoryTitle); err != nil {
+			return err
+		}
+		for i := 0; i < 3; i++ {
+			w.Text(right, "")
+		}
+		name := tpl.SignatoryName
+		if name == "" {
+			name = "(....................................)"
+		}
+		if err := w.Text(export.TextStyle{Bold: true, Align: export.AlignRight}, name); err != nil {
+			return err
+		}
+		if tpl.SignatoryID != "" {
+			return w.Text(right, "NIP. "+tpl.SignatoryID)
+		}
+		return nil
+	}
+}
+
+// skpiFormat membaca format= untuk SKPI; bawaannya PDF. JSON hanya untuk pratinjau satu mahasiswa.
+func skpiFormat(c *fiber.Ctx, allowJSON bool) (export.Format, error) {
+	f, err := export.Negotiate(c.Query("format", string(export.FormatPDF)), "")
+	switch {
+	case err == nil && (f == export.FormatPDF || f == export.FormatDOCX):
+		return f, nil
+	case err == nil && f == export.FormatJSON && allowJSON:
+		return f, nil
+	case allowJSON:
+		return "", model.NewValidationError("format SKPI harus salah satu dari pdf, docx, json")
+	}
+	return "", model.NewValidationError("format SKPI harus pdf atau docx")
+}
+
+// detailString mengambil nilai teks pertama yang ada dari details
+func detailString(details map[string]interface{}, keys []string) string {
+	for _, key := range keys {
+		if v, ok := details[key]; ok && v != nil {
+			if s := strings.TrimSpace(fmt.Sprint(v)); s != "" {
+				return s
+			}
+		}
+	}
+	return ""
+}
+
+func tanggalIndonesia(t time.Time) string {
+	return fmt.Sprintf("%d %s %d", t.Day(), bulanIndonesia[t.Month()-1], t.Year())
+}
+
+// safeFilename mengganti karakter selain huruf, angka, titik dan tanda hubung agar aman dipakai
+// sebagai nama file di dalam ZIP atau di disk
+func safeFilename(s string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r >= 'a' && r <= 'z', r >= 'A' && r <= 'Z', r >= '0' && r <= '9', r == '-', r == '.':
+			return r
+		}
+		return '_'
+	}, strings.TrimSpace(s))
+}
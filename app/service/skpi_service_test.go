@@ -0,0 +1,244 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MockSKPIRepository struct {
+	achievements map[string][]model.SKPIAchievement
+	failFor      string
+	cohort       []model.SKPIStudent
+	lastCohort   model.SKPICohortFilter
+}
+
+func (m *MockSKPIRepository) GetVerifiedAchievements(ctx context.Context, studentID string) ([]model.SKPIAchievement, error) {
+	if studentID == m.failFor {
+		return nil, errors.New("koneksi terputus")
+	}
+	// Salinan agar pengisian TitleEN/Level oleh service tidak mengubah data mock
+	return append([]model.SKPIAchievement(nil), m.achievements[studentID]...), nil
+}
+
+func (m *MockSKPIRepository) ListCohort(ctx context.Context, f model.SKPICohortFilter) ([]model.SKPIStudent, error) {
+	m.lastCohort = f
+	return m.cohort, nil
+}
+
+func skpiTestAchievements() []model.SKPIAchievement {
+	verified := time.Date(2024, 5, 2, 9, 0, 0, 0, time.UTC)
+	return []model.SKPIAchievement{
+		{AchievementID: "a1", AchievementType: "organization", Title: "Ketua Himpunan Mahasiswa", VerifiedAt: &verified},
+		{AchievementID: "a2", AchievementType: "competition", Title: "Juara 2 Hackathon Nasional", Details: map[string]interface{}{"title_en": "2nd Place, National Hackathon", "tingkat": "Nasional", "event_date": "2023-11-20"}},
+		{AchievementID: "a3", AchievementType: "academic", Title: "Mahasiswa Berprestasi"},
+		{AchievementID: "a4", AchievementType: "competition", Title: "Juara 1 Lomba Debat", Details: map[string]interface{}{"event_date": "2023-03-01"}},
+		{AchievementID: "a5", AchievementType: "community_service", Title: "Relawan Bencana"},
+	}
+}
+
+func TestSKPIService_GenerateStudent(t *testing.T) {
+	mockStudentRepo := &MockStudentRepository{
+		students: map[string]*model.StudentInfo{"user-mhs": {ID: "valid-student"}},
+	}
+	mockSKPIRepo := &MockSKPIRepository{
+		achievements: map[string][]model.SKPIAchievement{"valid-student": skpiTestAchievements()},
+	}
+	service := NewSKPIService(mockSKPIRepo, mockStudentRepo, model.DefaultSKPIConfig(), export.Letterhead{Institution: "Universitas Contoh"})
+
+	app := fiber.New()
+	app.Get("/skpi/students/:id", service.GenerateStudent)
+
+	get := func(url string) (int, string, []byte) {
+		resp, err := app.Test(httptest.NewRequest("GET", url, nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		body, _ := io.ReadAll(resp.Body)
+		return resp.StatusCode, resp.Header.Get(fiber.HeaderContentType), body
+	}
+
+	t.Run("GET - JSON Preview Groups Achievements", func(t *testing.T) {
+		status, _, body := get("/skpi/students/user-mhs?format=json")
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200, got %d: %s", status, body)
+		}
+		var resp struct {
+			Data model.SKPIDocument `json:"data"`
+		}
+		json.Unmarshal(body, &resp)
+		doc := resp.Data
+
+		if doc.Template != "default" || doc.Number != "SKPI/"+time.Now().Format("2006")+"/S12345" {
+			t.Errorf("Unexpected template or number: %s %s", doc.Template, doc.Number)
+		}
+		var keys []string
+		for _, sec := range doc.Sections {
+			keys = append(keys, sec.Category.Key)
+		}
+		// Prestasi akademik dikecualikan; jenis tanpa kategori masuk ke kategori "*"
+		if strings.Join(keys, ",") != "competition,organization,other" {
+			t.Fatalf("Unexpected sections %v", keys)
+		}
+		competition := doc.Sections[0].Items
+		if competition[0].AchievementID != "a4" || competition[1].AchievementID != "a2" {
+			t.Errorf("Expected achievements sorted by event date, got %s, %s", competition[0].AchievementID, competition[1].AchievementID)
+		}
+		if competition[1].TitleEN != "2nd Place, National Hackathon" || competition[1].Level != "Nasional" || competition[1].EventDate != "2023-11-20" {
+			t.Errorf("Expected details to be read, got %+v", competition[1])
+		}
+	})
+
+	t.Run("GET - PDF By Default", func(t *testing.T) {
+		status, contentType, body := get("/skpi/students/user-mhs")
+		if status != fiber.StatusOK || contentType != "application/pdf" || !bytes.HasPrefix(body, []byte("%PDF-")) {
+			t.Errorf("Expected a PDF, got %d %s", status, contentType)
+		}
+		if !bytes.Contains(body, []byte("/MediaBox [0 0 595.28 841.89]")) {
+			t.Error("Expected a portrait document")
+		}
+	})
+
+	t.Run("GET - DOCX Is Bilingual", func(t *testing.T) {
+		status, contentType, body := get("/skpi/students/user-mhs?format=docx")
+		if status != fiber.StatusOK || contentType != export.FormatDOCX.ContentType() {
+			t.Fatalf("Expected a DOCX, got %d %s", status, contentType)
+		}
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			t.Fatalf("Not a zip file: %v", err)
+		}
+		var document string
+		for _, f := range zr.File {
+			if f.Name == "word/document.xml" {
+				rc, _ := f.Open()
+				b, _ := io.ReadAll(rc)
+				rc.Close()
+				document = string(b)
+			}
+		}
+		for _, want := range []string{
+			"SURAT KETERANGAN PENDAMPING IJAZAH",
+			"Diploma Supplement",
+			"A. Prestasi Kompetisi / Competition Achievements",
+			"Juara 2 Hackathon Nasional</w:t><w:br/><w:t xml:space=\"preserve\">2nd Place, National Hackathon",
+			"Wakil Rektor Bidang Kemahasiswaan",
+		} {
+			if !strings.Contains(document, want) {
+				t.Errorf("Document is missing %q", want)
+			}
+		}
+		if strings.Contains(document, "Mahasiswa Berprestasi") {
+			t.Error("Academic achievements should not be listed")
+		}
+	})
+
+	t.Run("GET - Invalid Requests", func(t *testing.T) {
+		for url, want := range map[string]int{
+			"/skpi/students/user-mhs?format=csv":        fiber.StatusBadRequest,
+			"/skpi/students/user-mhs?template=fakultas": fiber.StatusBadRequest,
+			"/skpi/students/unknown-user":               fiber.StatusNotFound,
+		} {
+			if status, _, _ := get(url); status != want {
+				t.Errorf("Expected %d for %s, got %d", want, url, status)
+			}
+		}
+	})
+}
+
+func TestSKPIService_GenerateBatch(t *testing.T) {
+	mockSKPIRepo := &MockSKPIRepository{
+		achievements: map[string][]model.SKPIAchievement{"s1": skpiTestAchievements()},
+		failFor:      "s2",
+	}
+	service := NewSKPIService(mockSKPIRepo, &MockStudentRepository{}, model.DefaultSKPIConfig(), export.Letterhead{})
+
+	app := fiber.New()
+	app.Get("/skpi/batch", service.GenerateBatch)
+
+	t.Run("GET - Missing Academic Year", func(t *testing.T) {
+		resp, _ := app.Test(httptest.NewRequest("GET", "/skpi/batch", nil))
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("GET - Empty Cohort", func(t *testing.T) {
+		resp, _ := app.Test(httptest.NewRequest("GET", "/skpi/batch?academic_year=2010", nil))
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("Expected 404, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("GET - Cohort Archive", func(t *testing.T) {
+		mockSKPIRepo.cohort = []model.SKPIStudent{
+			{ID: "s1", StudentID: "2101001", FullName: "Ani", ProgramStudy: "Informatika", AcademicYear: "2021"},
+			{ID: "s2", StudentID: "2101002", FullName: "Budi", ProgramStudy: "Informatika", AcademicYear: "2021"},
+			{ID: "s3", StudentID: "2101/003", FullName: "Citra", ProgramStudy: "Informatika", AcademicYear: "2021"},
+		}
+		resp, err := app.Test(httptest.NewRequest("GET", "/skpi/batch?academic_year=2021&program_study=Informatika&format=docx", nil), -1)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK || resp.Header.Get(fiber.HeaderContentType) != "application/zip" {
+			t.Fatalf("Expected a zip archive, got %d %s", resp.StatusCode, resp.Header.Get(fiber.HeaderContentType))
+		}
+		if f := mockSKPIRepo.lastCohort; f.AcademicYear != "2021" || f.ProgramStudy != "Informatika" {
+			t.Errorf("Unexpected cohort filter %+v", f)
+		}
+
+		body, _ := io.ReadAll(resp.Body)
+		zr, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+		if err != nil {
+			t.Fatalf("Not a zip file: %v", err)
+		}
+		var names []string
+		var list string
+		for _, f := range zr.File {
+			names = append(names, f.Name)
+			if f.Name == "daftar-skpi.csv" {
+				rc, _ := f.Open()
+				b, _ := io.ReadAll(rc)
+				rc.Close()
+				list = string(b)
+			}
+		}
+		if strings.Join(names, ",") != "skpi-2101001.docx,skpi-2101_003.docx,daftar-skpi.csv" {
+			t.Errorf("Unexpected archive entries %v", names)
+		}
+		for _, want := range []string{"2101001,Ani,Informatika,4,skpi-2101001.docx,OK", "2101002,Budi,Informatika,,,Gagal: koneksi terputus", "2101/003,Citra,Informatika,0,skpi-2101_003.docx,OK"} {
+			if !strings.Contains(list, want) {
+				t.Errorf("daftar-skpi.csv is missing %q:\n%s", want, list)
+			}
+		}
+	})
+}
+
+func TestSKPISections(t *testing.T) {
+	tpl := model.SKPITemplate{
+		Categories: []model.SKPICategory{
+			{Key: "kegiatan", Types: []string{"competition", "organization"}},
+			{Key: "publikasi", Types: []string{"publication"}},
+		},
+	}
+	sections := skpiSections(tpl, skpiTestAchievements())
+	if len(sections) != 1 || sections[0].Category.Key != "kegiatan" || len(sections[0].Items) != 3 {
+		t.Fatalf("Expected one merged section without unmapped types, got %+v", sections)
+	}
+	// Tanpa tanggal kegiatan, tanggal verifikasi dipakai untuk pengurutan
+	if got := sections[0].Items[2].AchievementID; got != "a1" {
+		t.Errorf("Expected the organization achievement last, got %s", got)
+	}
+}
@@ -3,11 +3,13 @@ package service
 import (
 	"context"
 	"database/sql"
+	"fmt"
 	"math"
 	"strings"
 
 	"sistem-pelaporan-prestasi-mahasiswa/app/model"
 	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
 	"sistem-pelaporan-prestasi-mahasiswa/helper"
 
 	"github.com/gofiber/fiber/v2"
@@ -28,15 +30,18 @@ type IStudentService interface {
 type StudentService struct {
 	studentRepo repository.IStudentRepository
 	lecturerSvc ILecturerService
+	letterhead  export.Letterhead
 }
 
 func NewStudentService(
 	studentRepo repository.IStudentRepository,
 	lecturerSvc ILecturerService,
+	letterhead export.Letterhead,
 ) IStudentService {
 	return &StudentService{
 		studentRepo: studentRepo,
 		lecturerSvc: lecturerSvc,
+		letterhead:  letterhead,
 	}
 }
 
@@ -79,16 +84,22 @@ func (s *StudentService) ValidateStudentID(ctx context.Context, studentID string
 
 // GetAll godoc
 // @Summary List all students
-// @Description Get paginated list of students with optional filtering and sorting
+// @Description Get paginated list of students with optional filtering and sorting. With format=csv|xlsx|pdf|docx (or a matching Accept header) every matching student is exported; page, limit and cursor are ignored.
 // @Tags Students
 // @Accept json
 // @Produce json
+// @Produce text/csv
+// @Produce application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Produce application/pdf
+// @Produce application/vnd.openxmlformats-officedocument.wordprocessingml.document
 // @Security BearerAuth
 // @Param page query int false "Page number" default(1)
 // @Param limit query int false "Items per page" default(10)
 // @Param search query string false "Search by name or student ID"
 // @Param sort_by query string false "Sort field"
 // @Param sort_order query string false "Sort order" Enums(asc, desc)
+// @Param cursor query string false "Opaque keyset cursor from next_cursor/prev_cursor; send empty to start cursor pagination (total, page and total_pages are not computed)"
+// @Param format query string false "Output format" Enums(json, csv, xlsx, pdf, docx) default(json)
 // @Success 200 {object} helper.Response{data=model.PaginatedStudents} "Students retrieved"
 // @Failure 401 {object} helper.ErrorResponse "Unauthorized"
 // @Router /students [get]
@@ -123,6 +134,32 @@ func (s *StudentService) GetAll(c *fiber.Ctx) error {
 		sortOrder = "DESC"
 	}
 
+	format, err := exportFormat(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	if format != export.FormatJSON {
+		return s.exportStudents(c, format, search, sortBy, sortOrder)
+	}
+
+	cursorMode, cursor, err := cursorParam(c, sortBy, sortOrder)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	if cursorMode {
+		students, links, err := s.studentRepo.GetAllByCursor(c.Context(), search, sortBy, sortOrder, cursor, pageSize)
+		if err != nil {
+			return helper.HandleError(c, model.ErrDatabaseError)
+		}
+
+		return helper.Success(c, "Daftar mahasiswa berhasil diambil", &model.PaginatedStudents{
+			Data:       students,
+			PageSize:   pageSize,
+			NextCursor: helper.EncodeCursor(links.Next),
+			PrevCursor: helper.EncodeCursor(links.Prev),
+		})
+	}
+
 	students, total, err := s.studentRepo.GetAll(c.Context(), page, pageSize, search, sortBy, sortOrder)
 	if err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
@@ -141,6 +178,48 @@ func (s *StudentService) GetAll(c *fiber.Ctx) error {
 	return helper.Success(c, "Daftar mahasiswa berhasil diambil", result)
 }
 
+// exportStudents mengekspor semua mahasiswa yang cocok dengan pencarian, dialirkan per halaman keyset
+func (s *StudentService) exportStudents(c *fiber.Ctx, format export.Format, search, sortBy, sortOrder string) error {
+	first, links, err := s.studentRepo.GetAllByCursor(c.Context(), search, sortBy, sortOrder, nil, exportPageSize)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	var lines []string
+	if search != "" {
+		lines = append(lines, fmt.Sprintf("Pencarian \"%s\"", search))
+	}
+	doc := export.Document{
+		Title:      "Daftar Mahasiswa",
+		Subtitle:   lines,
+		Letterhead: s.letterhead,
+	}
+
+	columns := []export.Column{
+		{Header: "NIM", Width: 14},
+		{Header: "Nama", Width: 28},
+		{Header: "Email", Width: 28},
+		{Header: "Program Studi", Width: 24},
+		{Header: "Angkatan", Width: 9},
+		{Header: "Dosen Wali", Width: 26},
+		{Header: "Aktif", Width: 6},
+	}
+	fetch := func(ctx context.Context, cursor *model.PageCursor) ([]model.StudentListDTO, model.CursorLinks, error) {
+		return s.studentRepo.GetAllByCursor(ctx, search, sortBy, sortOrder, cursor, exportPageSize)
+	}
+	row := func(st model.StudentListDTO) []interface{} {
+		advisor, active := "", "Tidak"
+		if st.AdvisorName != nil {
+			advisor = *st.AdvisorName
+		}
+		if st.IsActive {
+			active = "Ya"
+		}
+		return []interface{}{st.StudentID, st.FullName, st.Email, st.ProgramStudy, st.AcademicYear, advisor, active}
+	}
+	return sendExport(c, format, "daftar-mahasiswa", doc, exportPages("Mahasiswa", columns, first, links.Next, fetch, row))
+}
+
 // GetByID godoc
 // @Summary Get student by ID
 // @Description Get detailed information about a specific student
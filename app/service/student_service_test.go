@@ -7,6 +7,7 @@ import (
 	"testing"
 
 	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
 
 	"github.com/gofiber/fiber/v2"
 )
@@ -17,7 +18,7 @@ func TestStudentService_GetAll(t *testing.T) {
 		students: make(map[string]*model.StudentInfo),
 	}
 	mockLecturerSvc := &MockLecturerService{}
-	service := NewStudentService(mockRepo, mockLecturerSvc)
+	service := NewStudentService(mockRepo, mockLecturerSvc, export.Letterhead{})
 
 	app.Get("/students", service.GetAll)
 
@@ -33,6 +34,59 @@ func TestStudentService_GetAll(t *testing.T) {
 			t.Errorf("Expected 200 status, got %d", resp.StatusCode)
 		}
 	})
+
+	list := func(query string) (int, model.PaginatedStudents) {
+		resp, err := app.Test(httptest.NewRequest("GET", "/students?"+query, nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		var result struct {
+			Data model.PaginatedStudents `json:"data"`
+		}
+		json.NewDecoder(resp.Body).Decode(&result)
+		return resp.StatusCode, result.Data
+	}
+
+	var next string
+
+	t.Run("GET - Cursor Mode First Page", func(t *testing.T) {
+		status, page := list("cursor=&limit=1&sortBy=u.full_name&order=asc")
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", status)
+		}
+		if page.NextCursor == "" || page.PrevCursor != "" || page.Total != 0 {
+			t.Errorf("Expected only next_cursor on first page, got %+v", page)
+		}
+		if mockRepo.lastCursor != nil {
+			t.Errorf("Expected no cursor on first page, got %+v", mockRepo.lastCursor)
+		}
+		next = page.NextCursor
+	})
+
+	t.Run("GET - Cursor Mode Next Page", func(t *testing.T) {
+		status, page := list("cursor=" + next + "&limit=1&sortBy=u.full_name&order=asc")
+		if status != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", status)
+		}
+		if c := mockRepo.lastCursor; c == nil || c.Value != "Budi" || c.SortBy != "u.full_name" || c.Order != "ASC" {
+			t.Errorf("Expected decoded cursor to reach repository, got %+v", c)
+		}
+		if page.PrevCursor == "" {
+			t.Error("Expected prev_cursor on second page")
+		}
+	})
+
+	t.Run("GET - Cursor From Other Sort Rejected", func(t *testing.T) {
+		if status, _ := list("cursor=" + next + "&sortBy=u.created_at"); status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", status)
+		}
+	})
+
+	t.Run("GET - Malformed Cursor Rejected", func(t *testing.T) {
+		if status, _ := list("cursor=not-a-cursor"); status != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", status)
+		}
+	})
 }
 
 func TestStudentService_GetByID(t *testing.T) {
@@ -41,7 +95,7 @@ func TestStudentService_GetByID(t *testing.T) {
 		students: make(map[string]*model.StudentInfo),
 	}
 	mockLecturerSvc := &MockLecturerService{}
-	service := NewStudentService(mockRepo, mockLecturerSvc)
+	service := NewStudentService(mockRepo, mockLecturerSvc, export.Letterhead{})
 
 	mockRepo.detailID = "student-1"
 
@@ -80,7 +134,7 @@ func TestStudentService_UpdateAdvisor(t *testing.T) {
 		students: make(map[string]*model.StudentInfo),
 	}
 	mockLecturerSvc := &MockLecturerService{}
-	service := NewStudentService(mockRepo, mockLecturerSvc)
+	service := NewStudentService(mockRepo, mockLecturerSvc, export.Letterhead{})
 
 	mockRepo.detailID = "student-1"
 	mockLecturerSvc.exists = true
@@ -0,0 +1,130 @@
+package service
+
+import (
+	"net/url"
+	"strings"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type IStudyProgramService interface {
+	GetAll(c *fiber.Ctx) error
+	Update(c *fiber.Ctx) error
+	Delete(c *fiber.Ctx) error
+}
+
+type StudyProgramService struct {
+	programRepo repository.IStudyProgramRepository
+}
+
+func NewStudyProgramService(programRepo repository.IStudyProgramRepository) IStudyProgramService {
+	return &StudyProgramService{programRepo: programRepo}
+}
+
+func studyProgramName(c *fiber.Ctx) (string, error) {
+	name, err := url.PathUnescape(c.Params("name"))
+	if err != nil || strings.TrimSpace(name) == "" {
+		return "", model.NewValidationError("Nama program studi tidak valid")
+	}
+	return strings.TrimSpace(name), nil
+}
+
+// GetAll godoc
+// @Summary List study programs
+// @Description Get every study program with its faculty mapping and number of students (Admin only). Program studies of new or updated students are added automatically with an empty faculty.
+// @Tags Study Programs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Success 200 {object} helper.Response{data=[]model.StudyProgram} "Study programs retrieved"
+// @Router /study-programs [get]
+func (s *StudyProgramService) GetAll(c *fiber.Ctx) error {
+	programs, err := s.programRepo.GetAll(c.Context())
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if programs == nil {
+		programs = []model.StudyProgram{}
+	}
+
+	return helper.Success(c, "Daftar program studi berhasil diambil", programs)
+}
+
+// Update godoc
+// @Summary Map study program to faculty
+// @Description Set the faculty of a study program, adding the program if it does not exist yet (Admin only). Used by the faculty filter and grouping in reports.
+// @Tags Study Programs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Study program name"
+// @Param request body model.UpdateStudyProgramRequest true "Faculty mapping"
+// @Success 200 {object} helper.Response{data=model.StudyProgram} "Study program updated"
+// @Failure 400 {object} helper.ErrorResponse "Invalid request"
+// @Router /study-programs/{name} [put]
+func (s *StudyProgramService) Update(c *fiber.Ctx) error {
+	name, err := studyProgramName(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	var req model.UpdateStudyProgramRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Format request tidak valid", nil)
+	}
+	faculty := strings.TrimSpace(req.Faculty)
+	if len(name) > 100 || len(faculty) > 100 {
+		return helper.HandleError(c, model.NewValidationError("Nama program studi dan fakultas maksimal 100 karakter"))
+	}
+
+	if err := s.programRepo.Upsert(c.Context(), name, faculty); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	program, err := s.programRepo.GetByName(c.Context(), name)
+	if err != nil || program == nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Success(c, "Fakultas program studi berhasil diperbarui", program)
+}
+
+// Delete godoc
+// @Summary Delete study program
+// @Description Remove a study program that no student uses anymore (Admin only)
+// @Tags Study Programs
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param name path string true "Study program name"
+// @Success 200 {object} helper.Response "Study program deleted"
+// @Failure 400 {object} helper.ErrorResponse "Study program still used by students"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Router /study-programs/{name} [delete]
+func (s *StudyProgramService) Delete(c *fiber.Ctx) error {
+	name, err := studyProgramName(c)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	program, err := s.programRepo.GetByName(c.Context(), name)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if program == nil {
+		return helper.HandleError(c, model.NewNotFoundError("Program studi tidak ditemukan"))
+	}
+	if program.Students > 0 {
+		return helper.HandleError(c, model.NewValidationError("Program studi masih dipakai mahasiswa dan tidak dapat dihapus"))
+	}
+
+	if err := s.programRepo.Delete(c.Context(), name); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	return helper.Success(c, "Program studi berhasil dihapus", nil)
+}
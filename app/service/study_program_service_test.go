@@ -0,0 +1,102 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"testing"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+type MockStudyProgramRepository struct {
+	programs map[string]*model.StudyProgram
+}
+
+func (m *MockStudyProgramRepository) GetAll(ctx context.Context) ([]model.StudyProgram, error) {
+	var programs []model.StudyProgram
+	for _, p := range m.programs {
+		programs = append(programs, *p)
+	}
+	return programs, nil
+}
+
+func (m *MockStudyProgramRepository) GetByName(ctx context.Context, name string) (*model.StudyProgram, error) {
+	if p, ok := m.programs[name]; ok {
+		copied := *p
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (m *MockStudyProgramRepository) Upsert(ctx context.Context, name, faculty string) error {
+	if p, ok := m.programs[name]; ok {
+		p.Faculty = faculty
+		return nil
+	}
+	m.programs[name] = &model.StudyProgram{Name: name, Faculty: faculty}
+	return nil
+}
+
+func (m *MockStudyProgramRepository) Delete(ctx context.Context, name string) error {
+	delete(m.programs, name)
+	return nil
+}
+
+func TestStudyProgramService(t *testing.T) {
+	app := fiber.New()
+	mockRepo := &MockStudyProgramRepository{programs: map[string]*model.StudyProgram{
+		"Teknik Informatika": {Name: "Teknik Informatika", Students: 12},
+		"Sastra Jawa":        {Name: "Sastra Jawa", Faculty: "Ilmu Budaya"},
+	}}
+	service := NewStudyProgramService(mockRepo)
+
+	app.Put("/study-programs/:name", service.Update)
+	app.Delete("/study-programs/:name", service.Delete)
+
+	t.Run("PUT - Map Faculty", func(t *testing.T) {
+		body, _ := json.Marshal(model.UpdateStudyProgramRequest{Faculty: " Teknik "})
+		req := httptest.NewRequest("PUT", "/study-programs/Teknik%20Informatika", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", resp.StatusCode)
+		}
+		if f := mockRepo.programs["Teknik Informatika"].Faculty; f != "Teknik" {
+			t.Errorf("Expected faculty Teknik, got %q", f)
+		}
+	})
+
+	t.Run("DELETE - Program Still Used", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/study-programs/Teknik%20Informatika", nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", resp.StatusCode)
+		}
+		if _, ok := mockRepo.programs["Teknik Informatika"]; !ok {
+			t.Errorf("Expected study program to be kept")
+		}
+	})
+
+	t.Run("DELETE - Unused Program", func(t *testing.T) {
+		resp, err := app.Test(httptest.NewRequest("DELETE", "/study-programs/Sastra%20Jawa", nil))
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		if resp.StatusCode != fiber.StatusOK {
+			t.Errorf("Expected 200 status, got %d", resp.StatusCode)
+		}
+		if _, ok := mockRepo.programs["Sastra Jawa"]; ok {
+			t.Errorf("Expected study program to be deleted")
+		}
+	})
+}
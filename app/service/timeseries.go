@@ -0,0 +1,160 @@
+package service
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+)
+
+// Batas jumlah bucket per permintaan, misalnya tiga tahun harian
+const maxTimeseriesBuckets = 1100
+
+var timeseriesBucketSizes = map[string]bool{
+	model.BucketDay:      true,
+	model.BucketWeek:     true,
+	model.BucketMonth:    true,
+	model.BucketSemester: true,
+}
+
+var timeseriesMetrics = map[string]bool{
+	model.MetricCreated:   true,
+	model.MetricSubmitted: true,
+	model.MetricVerified:  true,
+	model.MetricRejected:  true,
+	model.MetricPoints:    true,
+}
+
+var timeseriesGroups = map[string]bool{
+	model.GroupAchievementType: true,
+	model.GroupProgramStudy:    true,
+	model.GroupAcademicYear:    true,
+	model.GroupFaculty:         true,
+}
+
+// bucketStart mengembalikan tanggal awal bucket yang memuat t (tanpa jam, UTC)
+func bucketStart(t time.Time, bucket string) time.Time {
+	y, m, d := t.Date()
+	switch bucket {
+	case model.BucketWeek:
+		day := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+		return day.AddDate(0, 0, -((int(day.Weekday()) + 6) % 7))
+	case model.BucketMonth:
+		return time.Date(y, m, 1, 0, 0, 0, 0, time.UTC)
+	case model.BucketSemester:
+		switch {
+		case m >= time.August:
+			return time.Date(y, time.August, 1, 0, 0, 0, 0, time.UTC)
+		case m == time.January:
+			return time.Date(y-1, time.August, 1, 0, 0, 0, 0, time.UTC)
+		default:
+			return time.Date(y, time.February, 1, 0, 0, 0, 0, time.UTC)
+		}
+	}
+	return time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+}
+
+func nextBucket(start time.Time, bucket string) time.Time {
+	switch bucket {
+	case model.BucketWeek:
+		return start.AddDate(0, 0, 7)
+	case model.BucketMonth:
+		return start.AddDate(0, 1, 0)
+	case model.BucketSemester:
+		return start.AddDate(0, 6, 0)
+	}
+	return start.AddDate(0, 0, 1)
+}
+
+func bucketLabel(start time.Time, bucket string) string {
+	switch bucket {
+	case model.BucketWeek:
+		y, w := start.ISOWeek()
+		return fmt.Sprintf("%d-W%02d", y, w)
+	case model.BucketMonth:
+		return start.Format("2006-01")
+	case model.BucketSemester:
+		if start.Month() == time.August {
+			return fmt.Sprintf("%d/%d Ganjil", start.Year(), start.Year()+1)
+		}
+		return fmt.Sprintf("%d/%d Genap", start.Year()-1, start.Year())
+	}
+	return start.Format("2006-01-02")
+}
+
+// timeseriesBuckets mengembalikan awal setiap bucket yang beririsan dengan [from, to)
+func timeseriesBuckets(from, to time.Time, bucket string) []time.Time {
+	var buckets []time.Time
+	end := time.Date(to.Year(), to.Month(), to.Day(), 0, 0, 0, 0, time.UTC)
+	for b := bucketStart(from, bucket); b.Before(end); b = nextBucket(b, bucket) {
+		buckets = append(buckets, b)
+		if len(buckets) > maxTimeseriesBuckets {
+			break
+		}
+	}
+	return buckets
+}
+
+// buildTimeseries menyusun baris agregasi menjadi satu deret per kombinasi grup. Bucket tanpa data
+// diisi 0 agar grafik tetap kontinu.
+func buildTimeseries(f model.TimeseriesFilter, rows []model.TimeseriesRow) []model.TimeseriesSeries {
+	buckets := timeseriesBuckets(f.DateFrom, f.DateTo, f.Bucket)
+	index := make(map[string]int, len(buckets))
+	for i, b := range buckets {
+		index[b.Format("2006-01-02")] = i
+	}
+
+	newSeries := func(group []string) *model.TimeseriesSeries {
+		s := &model.TimeseriesSeries{Totals: map[string]int{}, Points: make([]model.TimeseriesPoint, len(buckets))}
+		if len(f.GroupBy) > 0 {
+			s.Group = make(map[string]string, len(f.GroupBy))
+			for i, g := range f.GroupBy {
+				s.Group[g] = group[i]
+			}
+		}
+		for _, m := range f.Metrics {
+			s.Totals[m] = 0
+		}
+		for i, b := range buckets {
+			values := make(map[string]int, len(f.Metrics))
+			for _, m := range f.Metrics {
+				values[m] = 0
+			}
+			s.Points[i] = model.TimeseriesPoint{Bucket: b, Label: bucketLabel(b, f.Bucket), Values: values}
+		}
+		return s
+	}
+
+	series := map[string]*model.TimeseriesSeries{}
+	if len(f.GroupBy) == 0 {
+		series[""] = newSeries(nil)
+	}
+	for _, row := range rows {
+		i, ok := index[row.Bucket.Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		key := strings.Join(row.Group, "\x00")
+		s, ok := series[key]
+		if !ok {
+			s = newSeries(row.Group)
+			series[key] = s
+		}
+		s.Points[i].Values[row.Metric] += row.Value
+		s.Totals[row.Metric] += row.Value
+	}
+
+	keys := make([]string, 0, len(series))
+	for key := range series {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	result := make([]model.TimeseriesSeries, 0, len(keys))
+	for _, key := range keys {
+		result = append(result, *series[key])
+	}
+	return result
+}
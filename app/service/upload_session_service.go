@@ -0,0 +1,616 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+
+	"github.com/gofiber/fiber/v2"
+	"github.com/google/uuid"
+)
+
+const (
+	uploadChunkSize     = 2 * 1024 * 1024
+	maxUploadChunkBytes = 4 * 1024 * 1024
+	uploadSessionTTL    = 24 * time.Hour
+	uploadExpireBatch   = 100
+
+	// Klaim penyelesaian yang tidak selesai dalam waktu ini dianggap terhenti (misalnya server mati)
+	uploadCompleteTimeout = 10 * time.Minute
+)
+
+type IUploadSessionService interface {
+	Create(c *fiber.Ctx) error
+	Status(c *fiber.Ctx) error
+	UploadChunk(c *fiber.Ctx) error
+	Cancel(c *fiber.Ctx) error
+	ExpireSessions(ctx context.Context) (int, error)
+	Run(ctx context.Context, interval time.Duration)
+}
+
+type UploadSessionService struct {
+	sessionRepo  repository.IUploadSessionRepository
+	achRepo      repository.IAchievementRepository
+	studentRepo  repository.IStudentRepository
+	typeRepo     repository.IAchievementTypeRepository
+	revisionRepo repository.IAchievementRevisionRepository
+	store        storage.Storage
+}
+
+func NewUploadSessionService(
+	sessionRepo repository.IUploadSessionRepository,
+	achRepo repository.IAchievementRepository,
+	studentRepo repository.IStudentRepository,
+	typeRepo repository.IAchievementTypeRepository,
+	revisionRepo repository.IAchievementRevisionRepository,
+	store storage.Storage,
+) IUploadSessionService {
+	return &UploadSessionService{
+		sessionRepo:  sessionRepo,
+		achRepo:      achRepo,
+		studentRepo:  studentRepo,
+		typeRepo:     typeRepo,
+		revisionRepo: revisionRepo,
+		store:        store,
+	}
+}
+
+func uploadChunkPrefix(sessionID string) string {
+	return model.UploadChunkPrefix + sessionID + "/"
+}
+
+// Offset ditulis dengan lebar tetap agar urutan key sama dengan urutan potongan
+func uploadChunkKey(sessionID string, offset int64) string {
+	return fmt.Sprintf("%s%020d", uploadChunkPrefix(sessionID), offset)
+}
+
+// parseUploadChecksum membaca header Upload-Checksum format tus: "sha256 <base64>"
+func parseUploadChecksum(header string) ([]byte, error) {
+	algo, value, ok := strings.Cut(strings.TrimSpace(header), " ")
+	if !ok || !strings.EqualFold(algo, "sha256") {
+		return nil, model.NewValidationError("Header Upload-Checksum wajib diisi dengan format: sha256 <base64>")
+	}
+	sum, err := base64.StdEncoding.DecodeString(strings.TrimSpace(value))
+	if err != nil || len(sum) != sha256.Size {
+		return nil, model.NewValidationError("Nilai Upload-Checksum tidak valid")
+	}
+	return sum, nil
+}
+
+func setUploadHeaders(c *fiber.Ctx, session *model.UploadSession) {
+	c.Set("Upload-Offset", strconv.FormatInt(session.Offset, 10))
+	c.Set("Upload-Length", strconv.FormatInt(session.Size, 10))
+	c.Set("Upload-Expires", session.ExpiresAt.UTC().Format(time.RFC1123))
+	c.Set(fiber.HeaderCacheControl, "no-store")
+}
+
+// getOwnSession memastikan sesi milik pemanggil, untuk prestasi yang sama, dan belum kedaluwarsa
+func (s *UploadSessionService) getOwnSession(c *fiber.Ctx, userID string) (*model.UploadSession, error) {
+	session, err := s.sessionRepo.GetByID(c.Context(), c.Params("uploadId"))
+	if err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	if session == nil || session.UserID != userID || session.AchievementID != c.Params("id") {
+		return nil, model.NewNotFoundError("Sesi upload tidak ditemukan")
+	}
+	if session.Status == model.UploadSessionActive && time.Now().After(session.ExpiresAt) {
+		return nil, model.NewNotFoundError("Sesi upload sudah kedaluwarsa. Silakan mulai upload dari awal.")
+	}
+	return session, nil
+}
+
+// Create godoc
+// @Summary Start resumable upload
+// @Description Start a chunked upload for a large attachment on a draft achievement. Size and type limits follow the achievement type policy. Send the file with PATCH requests carrying Upload-Offset and Upload-Checksum headers; unfinished sessions expire after 24 hours of inactivity.
+// @Tags Achievement Uploads
+// @Accept json
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param body body model.CreateUploadSessionRequest true "File name, total size in bytes and optional SHA-256 (hex) of the whole file"
+// @Success 201 {object} helper.Response{data=model.UploadSession} "Upload session created"
+// @Failure 400 {object} helper.ErrorResponse "Invalid request"
+// @Router /achievements/{id}/uploads [post]
+func (s *UploadSessionService) Create(c *fiber.Ctx) error {
+	id := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	var req model.CreateUploadSessionRequest
+	if err := c.BodyParser(&req); err != nil {
+		return helper.BadRequest(c, "Format request tidak valid", nil)
+	}
+	req.FileName = strings.TrimSpace(req.FileName)
+	req.Checksum = strings.ToLower(strings.TrimSpace(req.Checksum))
+	if req.FileName == "" || req.Size <= 0 {
+		return helper.HandleError(c, model.NewValidationError("file_name dan size wajib diisi"))
+	}
+	if req.Checksum != "" {
+		if sum, err := hex.DecodeString(req.Checksum); err != nil || len(sum) != sha256.Size {
+			return helper.HandleError(c, model.NewValidationError("checksum harus berupa SHA-256 dalam format hex"))
+		}
+	}
+
+	if _, err := authorizeAttachmentChange(c.Context(), s.achRepo, s.studentRepo, id, userID); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	policy, err := achievementFilePolicy(c.Context(), s.achRepo, s.typeRepo, id)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	if req.Size > policy.MaxBytes {
+		return helper.HandleError(c, model.NewValidationError(fmt.Sprintf("Ukuran file terlalu besar. Maksimal %dMB", policy.MaxBytes/(1024*1024))))
+	}
+	if err := helper.CheckFileName(req.FileName, policy); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	now := time.Now()
+	session := &model.UploadSession{
+		ID:            uuid.New().String(),
+		AchievementID: id,
+		UserID:        userID,
+		FileName:      req.FileName,
+		Size:          req.Size,
+		ChunkSize:     uploadChunkSize,
+		Checksum:      req.Checksum,
+		Status:        model.UploadSessionActive,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+		ExpiresAt:     now.Add(uploadSessionTTL),
+	}
+	if err := s.sessionRepo.Create(c.Context(), session); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	setUploadHeaders(c, session)
+	c.Location(fmt.Sprintf("/api/v1/achievements/%s/uploads/%s", id, session.ID))
+	return helper.Created(c, "Sesi upload berhasil dibuat", session)
+}
+
+// Status godoc
+// @Summary Get resumable upload status
+// @Description Return the number of bytes received so far (also in the Upload-Offset header) so an interrupted upload can resume
+// @Tags Achievement Uploads
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param uploadId path string true "Upload session ID"
+// @Success 200 {object} helper.Response{data=model.UploadSession} "Upload status"
+// @Failure 404 {object} helper.ErrorResponse "Not found or expired"
+// @Router /achievements/{id}/uploads/{uploadId} [get]
+func (s *UploadSessionService) Status(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	session, err := s.getOwnSession(c, userID)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	setUploadHeaders(c, session)
+	return helper.Success(c, "Status upload berhasil diambil", session)
+}
+
+// UploadChunk godoc
+// @Summary Upload a chunk
+// @Description Append a chunk at Upload-Offset. The Upload-Checksum header ("sha256 <base64>") must match the chunk. When the last chunk arrives the file is checked against the achievement type policy and attached to the achievement.
+// @Tags Achievement Uploads
+// @Accept octet-stream
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param uploadId path string true "Upload session ID"
+// @Param Upload-Offset header int true "Byte offset of this chunk"
+// @Param Upload-Checksum header string true "sha256 <base64 digest of the chunk>"
+// @Success 200 {object} helper.Response{data=model.UploadSession} "Chunk accepted"
+// @Failure 400 {object} helper.ErrorResponse "Checksum mismatch or invalid file"
+// @Failure 409 {object} helper.ErrorResponse "Offset mismatch or upload is being completed"
+// @Router /achievements/{id}/uploads/{uploadId} [patch]
+func (s *UploadSessionService) UploadChunk(c *fiber.Ctx) error {
+	id := c.Params("id")
+	userID := c.Locals("user_id").(string)
+
+	session, err := s.getOwnSession(c, userID)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	if session.Status == model.UploadSessionCompleted {
+		setUploadHeaders(c, session)
+		return helper.Success(c, "Upload sudah selesai", session)
+	}
+
+	// Semua potongan sudah diterima tetapi penyelesaian sebelumnya gagal: ulangi tanpa mengirim data lagi
+	if session.Offset == session.Size {
+		return s.complete(c, session)
+	}
+
+	offset, err := strconv.ParseInt(c.Get("Upload-Offset"), 10, 64)
+	if err != nil || offset < 0 {
+		return helper.HandleError(c, model.NewValidationError("Header Upload-Offset wajib diisi"))
+	}
+	if offset != session.Offset {
+		setUploadHeaders(c, session)
+		return helper.Conflict(c, "Upload-Offset tidak sesuai dengan data yang sudah diterima", session)
+	}
+
+	expected, err := parseUploadChecksum(c.Get("Upload-Checksum"))
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	chunk := c.Body()
+	if len(chunk) == 0 || len(chunk) > maxUploadChunkBytes {
+		return helper.HandleError(c, model.NewValidationError(fmt.Sprintf("Ukuran potongan harus di antara 1 byte dan %dMB", maxUploadChunkBytes/(1024*1024))))
+	}
+	if offset+int64(len(chunk)) > session.Size {
+		return helper.HandleError(c, model.NewValidationError("Potongan melebihi ukuran file yang didaftarkan"))
+	}
+	if sum := sha256.Sum256(chunk); !bytes.Equal(sum[:], expected) {
+		return helper.HandleError(c, model.NewValidationError("Checksum potongan tidak cocok, kirim ulang potongan ini"))
+	}
+
+	if _, err := authorizeAttachmentChange(c.Context(), s.achRepo, s.studentRepo, id, userID); err != nil {
+		return helper.HandleError(c, err)
+	}
+
+	if err := s.store.Put(c.Context(), uploadChunkKey(session.ID, offset), bytes.NewReader(chunk), int64(len(chunk)), "application/octet-stream"); err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+
+	newOffset := offset + int64(len(chunk))
+	expiresAt := time.Now().Add(uploadSessionTTL)
+	advanced, err := s.sessionRepo.AdvanceOffset(c.Context(), session.ID, offset, newOffset, expiresAt)
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if !advanced {
+		if latest, err := s.sessionRepo.GetByID(c.Context(), session.ID); err == nil && latest != nil {
+			session = latest
+		}
+		setUploadHeaders(c, session)
+		return helper.Conflict(c, "Upload-Offset tidak sesuai dengan data yang sudah diterima", session)
+	}
+	session.Offset = newOffset
+	session.ExpiresAt = expiresAt
+
+	if session.Offset < session.Size {
+		setUploadHeaders(c, session)
+		return helper.Success(c, "Potongan berhasil diterima", session)
+	}
+
+	return s.complete(c, session)
+}
+
+// complete mengklaim sesi sebelum menggabungkan potongan sehingga dua request yang sama-sama mencapai
+// akhir file tidak menambahkan lampiran ganda
+func (s *UploadSessionService) complete(c *fiber.Ctx, session *model.UploadSession) error {
+	claimed, err := s.sessionRepo.ClaimCompletion(c.Context(), session.ID, time.Now().Add(-uploadCompleteTimeout))
+	if err != nil {
+		return helper.HandleError(c, model.ErrDatabaseError)
+	}
+	if !claimed {
+		if latest, err := s.sessionRepo.GetByID(c.Context(), session.ID); err == nil && latest != nil {
+			session = latest
+		}
+		setUploadHeaders(c, session)
+		if session.Status == model.UploadSessionCompleted {
+			return helper.Success(c, "Upload sudah selesai", session)
+		}
+		return helper.Conflict(c, "Upload sedang diselesaikan. Periksa status upload beberapa saat lagi.", session)
+	}
+
+	attachment, err := s.finish(c, session)
+	if err != nil {
+		// Sesi yang ditolak sudah dihapus; selain itu kembalikan ke active agar penyelesaian bisa diulang
+		if err := s.sessionRepo.ReleaseCompletion(c.Context(), session.ID); err != nil {
+			log.Printf("⚠️  Gagal membuka kembali sesi upload %s: %v", session.ID, err)
+		}
+		return helper.HandleError(c, err)
+	}
+	session.Status = model.UploadSessionCompleted
+	session.Attachment = attachment
+
+	setUploadHeaders(c, session)
+	return helper.Success(c, "File berhasil diupload", session)
+}
+
+// finish memeriksa file hasil gabungan potongan seperti upload biasa, lalu menambahkannya sebagai lampiran.
+// Potongan dibaca langsung dari storage dua kali (pemeriksaan, lalu penyimpanan) sehingga file sebesar
+// apa pun tidak pernah dimuat utuh ke memori. File yang ditolak menghapus sesi beserta potongannya karena
+// tidak mungkin dilanjutkan.
+func (s *UploadSessionService) finish(c *fiber.Ctx, session *model.UploadSession) (*model.AchievementAttachment, error) {
+	ctx := c.Context()
+
+	// Penyelesaian sebelumnya sudah menambahkan lampiran tetapi gagal menandai sesi selesai
+	existing, err := s.sessionAttachment(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+	if existing != nil {
+		if err := s.sessionRepo.Complete(ctx, session.ID, *existing); err != nil {
+			return nil, model.ErrDatabaseError
+		}
+		s.deleteChunks(ctx, session.ID)
+		return existing, nil
+	}
+
+	keys, err := s.chunkKeys(ctx, session)
+	if err != nil {
+		return nil, err
+	}
+
+	achRef, err := authorizeAttachmentChange(ctx, s.achRepo, s.studentRepo, session.AchievementID, session.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	policy, err := achievementFilePolicy(ctx, s.achRepo, s.typeRepo, session.AchievementID)
+	if err != nil {
+		return nil, err
+	}
+	if session.Size > policy.MaxBytes {
+		s.discard(ctx, session)
+		return nil, model.NewValidationError(fmt.Sprintf("Ukuran file terlalu besar. Maksimal %dMB", policy.MaxBytes/(1024*1024)))
+	}
+
+	// Pemeriksaan pertama hanya menghitung checksum, ukuran dan hash hasil sanitasi tanpa menyimpan apa pun
+	rawHash, cleanHash := sha256.New(), sha256.New()
+	var cleanSize byteCounter
+	chunks := s.openChunks(ctx, keys)
+	src := io.TeeReader(chunks, rawHash)
+	contentType, ext, sanitizeErr := helper.SanitizeStream(io.MultiWriter(cleanHash, &cleanSize), src, session.FileName, policy)
+	// Sanitasi PNG/JPEG bisa berhenti sebelum akhir file; sisanya tetap dibaca agar checksum mencakup seluruh file
+	_, drainErr := io.Copy(io.Discard, src)
+	chunks.Close()
+
+	var invalid *model.ValidationError
+	if sanitizeErr != nil && !errors.As(sanitizeErr, &invalid) || drainErr != nil {
+		return nil, model.ErrDatabaseError
+	}
+	if session.Checksum != "" && hex.EncodeToString(rawHash.Sum(nil)) != session.Checksum {
+		s.discard(ctx, session)
+		return nil, model.NewValidationError("Checksum file tidak cocok. Silakan upload ulang file.")
+	}
+	if sanitizeErr != nil {
+		s.discard(ctx, session)
+		return nil, sanitizeErr
+	}
+
+	if err := s.revisionRepo.EnsureBaseline(ctx, achRef.ID, achRef.MongoAchievementID, achRef.Status, session.UserID); err != nil {
+		return nil, model.ErrDatabaseError
+	}
+
+	// Pemeriksaan kedua menyalurkan hasil sanitasi yang sama langsung ke storage
+	attachment := newAchievementAttachment(session.AchievementID, ext, contentType)
+	attachment.ID = session.ID
+	attachment.ContentHash = hex.EncodeToString(cleanHash.Sum(nil))
+	attachment.Size = int64(cleanSize)
+
+	pr, pw := io.Pipe()
+	go func() {
+		chunks := s.openChunks(ctx, keys)
+		_, _, err := helper.SanitizeStream(pw, chunks, session.FileName, policy)
+		chunks.Close()
+		pw.CloseWithError(err)
+	}()
+	err = s.store.Put(ctx, attachment.StorageKey, pr, attachment.Size, contentType)
+	pr.CloseWithError(io.ErrClosedPipe)
+	if err != nil {
+		deleteStoredFile(ctx, s.store, attachment.StorageKey)
+		return nil, model.ErrDatabaseError
+	}
+
+	if err := s.achRepo.AddAttachment(ctx, achRef.MongoAchievementID, *attachment); err != nil {
+		deleteStoredFile(ctx, s.store, attachment.StorageKey)
+		return nil, model.ErrDatabaseError
+	}
+	if err := s.revisionRepo.Record(ctx, achRef.ID, achRef.MongoAchievementID, model.RevisionEventAttachment, achRef.Status, session.UserID); err != nil {
+		log.Printf("⚠️  Gagal menyimpan revisi prestasi %s: %v", achRef.ID, err)
+	}
+
+	// Potongan baru dihapus setelah sesi tercatat selesai; bila gagal, penyelesaian ulang menemukan lampiran
+	// lewat id sesi (lihat sessionAttachment) tanpa perlu membaca potongan lagi
+	if err := s.sessionRepo.Complete(ctx, session.ID, *attachment); err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	s.deleteChunks(ctx, session.ID)
+
+	return attachment, nil
+}
+
+// sessionAttachment mengembalikan lampiran yang sudah ditambahkan oleh penyelesaian sesi sebelumnya.
+// Lampiran hasil upload bertahap memakai id sesinya sebagai id lampiran.
+func (s *UploadSessionService) sessionAttachment(ctx context.Context, session *model.UploadSession) (*model.AchievementAttachment, error) {
+	detail, err := s.achRepo.GetDetailByID(ctx, session.AchievementID)
+	if err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	if detail == nil {
+		return nil, nil
+	}
+	for _, a := range detail.Attachments {
+		if a.ID == session.ID {
+			return &a, nil
+		}
+	}
+	return nil, nil
+}
+
+// chunkKeys mengembalikan key potongan sesuai urutan offset dan memastikan tidak ada celah
+func (s *UploadSessionService) chunkKeys(ctx context.Context, session *model.UploadSession) ([]string, error) {
+	var objects []storage.ObjectInfo
+	err := s.store.List(ctx, uploadChunkPrefix(session.ID), func(info storage.ObjectInfo) error {
+		objects = append(objects, info)
+		return nil
+	})
+	if err != nil {
+		return nil, model.ErrDatabaseError
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+
+	var keys []string
+	var size int64
+	for _, info := range objects {
+		if info.Key != uploadChunkKey(session.ID, size) {
+			continue
+		}
+		keys = append(keys, info.Key)
+		size += info.Size
+	}
+
+	if size != session.Size {
+		s.discard(ctx, session)
+		return nil, model.NewValidationError("Potongan file tidak lengkap. Silakan upload ulang file.")
+	}
+	return keys, nil
+}
+
+// openChunks membaca potongan berurutan sebagai satu aliran; setiap potongan baru dibuka saat dibutuhkan
+func (s *UploadSessionService) openChunks(ctx context.Context, keys []string) *chunkReader {
+	return &chunkReader{ctx: ctx, store: s.store, keys: keys}
+}
+
+type chunkReader struct {
+	ctx     context.Context
+	store   storage.Storage
+	keys    []string
+	current io.ReadCloser
+}
+
+func (r *chunkReader) Read(p []byte) (int, error) {
+	for {
+		if r.current == nil {
+			if len(r.keys) == 0 {
+				return 0, io.EOF
+			}
+			rc, _, err := r.store.Get(r.ctx, r.keys[0])
+			if err != nil {
+				return 0, err
+			}
+			r.current, r.keys = rc, r.keys[1:]
+		}
+		n, err := r.current.Read(p)
+		if err == io.EOF {
+			r.current.Close()
+			r.current = nil
+			if n == 0 {
+				continue
+			}
+			err = nil
+		}
+		return n, err
+	}
+}
+
+func (r *chunkReader) Close() error {
+	if r.current == nil {
+		return nil
+	}
+	err := r.current.Close()
+	r.current = nil
+	return err
+}
+
+type byteCounter int64
+
+func (n *byteCounter) Write(p []byte) (int, error) {
+	*n += byteCounter(len(p))
+	return len(p), nil
+}
+
+func (s *UploadSessionService) deleteChunks(ctx context.Context, sessionID string) {
+	var keys []string
+	err := s.store.List(ctx, uploadChunkPrefix(sessionID), func(info storage.ObjectInfo) error {
+		keys = append(keys, info.Key)
+		return nil
+	})
+	if err != nil {
+		log.Printf("⚠️  Gagal membaca potongan upload %s: %v", sessionID, err)
+		return
+	}
+	for _, key := range keys {
+		deleteStoredFile(ctx, s.store, key)
+	}
+}
+
+func (s *UploadSessionService) discard(ctx context.Context, session *model.UploadSession) {
+	s.deleteChunks(ctx, session.ID)
+	if err := s.sessionRepo.Delete(ctx, session.ID); err != nil {
+		log.Printf("⚠️  Gagal menghapus sesi upload %s: %v", session.ID, err)
+	}
+}
+
+// Cancel godoc
+// @Summary Cancel resumable upload
+// @Description Abort an upload session and delete the chunks received so far
+// @Tags Achievement Uploads
+// @Produce json
+// @Security BearerAuth
+// @Param id path string true "Achievement ID"
+// @Param uploadId path string true "Upload session ID"
+// @Success 200 {object} helper.Response "Upload cancelled"
+// @Failure 404 {object} helper.ErrorResponse "Not found"
+// @Failure 409 {object} helper.ErrorResponse "Upload is being completed"
+// @Router /achievements/{id}/uploads/{uploadId} [delete]
+func (s *UploadSessionService) Cancel(c *fiber.Ctx) error {
+	userID := c.Locals("user_id").(string)
+
+	session, err := s.getOwnSession(c, userID)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	if session.Status == model.UploadSessionCompleting {
+		return helper.Conflict(c, "Upload sedang diselesaikan dan tidak bisa dibatalkan", nil)
+	}
+
+	s.discard(c.Context(), session)
+
+	return helper.Success(c, "Upload dibatalkan", nil)
+}
+
+// ExpireSessions menghapus sesi yang kedaluwarsa beserta potongan yang tersisa
+func (s *UploadSessionService) ExpireSessions(ctx context.Context) (int, error) {
+	sessions, err := s.sessionRepo.FindExpired(ctx, time.Now(), uploadExpireBatch)
+	if err != nil {
+		return 0, err
+	}
+	for i := range sessions {
+		s.discard(ctx, &sessions[i])
+	}
+	return len(sessions), nil
+}
+
+// Run membersihkan sesi upload kedaluwarsa secara berkala sampai ctx dibatalkan
+func (s *UploadSessionService) Run(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		if n, err := s.ExpireSessions(ctx); err != nil {
+			log.Printf("⚠️  Pembersihan sesi upload gagal: %v", err)
+		} else if n > 0 {
+			log.Printf("🧹 %d sesi upload kedaluwarsa dihapus", n)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
@@ -0,0 +1,261 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func chunkChecksum(chunk []byte) string {
+	sum := sha256.Sum256(chunk)
+	return "sha256 " + base64.StdEncoding.EncodeToString(sum[:])
+}
+
+func TestUploadSessionService(t *testing.T) {
+	ctx := context.Background()
+	mockAchRepo := &MockAchievementRepository{
+		achRefs: make(map[string]*model.AchievementReference),
+		achDetail: &model.AchievementDetailDTO{
+			ID:              "ach-1",
+			AchievementType: "kompetisi",
+		},
+	}
+	mockStudentRepo := &MockStudentRepository{
+		students: make(map[string]*model.StudentInfo),
+	}
+	typeRepo := newMockAchievementTypeRepository("kompetisi")
+	typeRepo.types["kompetisi"].MaxFileSizeMB = 100
+	typeRepo.types["kompetisi"].AllowedFileTypes = []string{helper.FileTypePDF, helper.FileTypeMP4}
+	sessionRepo := &MockUploadSessionRepository{sessions: make(map[string]*model.UploadSession)}
+	store := storage.NewLocal(t.TempDir())
+
+	svc := NewUploadSessionService(sessionRepo, mockAchRepo, mockStudentRepo, typeRepo, &MockAchievementRevisionRepository{}, store)
+
+	mockStudentRepo.students["user-mhs-1"] = &model.StudentInfo{ID: "student-1"}
+	mockAchRepo.achRefs["ach-1"] = &model.AchievementReference{ID: "ach-1", StudentID: "student-1", Status: "draft"}
+
+	app := fiber.New()
+	app.Use(func(c *fiber.Ctx) error {
+		c.Locals("user_id", "user-mhs-1")
+		return c.Next()
+	})
+	app.Post("/achievements/:id/uploads", svc.Create)
+	app.Get("/achievements/:id/uploads/:uploadId", svc.Status)
+	app.Patch("/achievements/:id/uploads/:uploadId", svc.UploadChunk)
+
+	send := func(req *http.Request) (*http.Response, model.UploadSession) {
+		resp, err := app.Test(req)
+		if err != nil {
+			t.Fatalf("Failed to send request: %v", err)
+		}
+		var result struct {
+			Data model.UploadSession `json:"data"`
+		}
+		json.NewDecoder(resp.Body).Decode(&result)
+		return resp, result.Data
+	}
+
+	create := func(fileName string, size int, checksum string) (*http.Response, model.UploadSession) {
+		body, _ := json.Marshal(model.CreateUploadSessionRequest{FileName: fileName, Size: int64(size), Checksum: checksum})
+		req := httptest.NewRequest("POST", "/achievements/ach-1/uploads", bytes.NewReader(body))
+		req.Header.Set("Content-Type", "application/json")
+		return send(req)
+	}
+
+	patch := func(sessionID string, offset int, chunk []byte, checksum string) (*http.Response, model.UploadSession) {
+		req := httptest.NewRequest("PATCH", "/achievements/ach-1/uploads/"+sessionID, bytes.NewReader(chunk))
+		req.Header.Set("Content-Type", "application/offset+octet-stream")
+		req.Header.Set("Upload-Offset", strconv.Itoa(offset))
+		req.Header.Set("Upload-Checksum", checksum)
+		return send(req)
+	}
+
+	// ftyp + mdat + moov
+	video := []byte("\x00\x00\x00\x10ftypisom\x00\x00\x02\x00")
+	video = append(video, []byte("\x00\x00\x00\x18mdat0123456789abcdef")...)
+	video = append(video, []byte("\x00\x00\x00\x08moov")...)
+	videoSum := sha256.Sum256(video)
+
+	t.Run("POST - Size Above Type Limit", func(t *testing.T) {
+		resp, _ := create("final.mp4", 101*1024*1024, "")
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("POST - Type Not Allowed", func(t *testing.T) {
+		resp, _ := create("poster.png", 1024, "")
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("PATCH - Resume After Interrupted Upload", func(t *testing.T) {
+		resp, session := create("final.mp4", len(video), hex.EncodeToString(videoSum[:]))
+		if resp.StatusCode != fiber.StatusCreated {
+			t.Fatalf("Expected 201 status, got %d", resp.StatusCode)
+		}
+
+		first, rest := video[:20], video[20:]
+
+		resp, _ = patch(session.ID, 0, first, chunkChecksum([]byte("corrupted in transit")))
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected checksum mismatch to be rejected, got %d", resp.StatusCode)
+		}
+
+		resp, _ = patch(session.ID, 0, first, chunkChecksum(first))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", resp.StatusCode)
+		}
+
+		// Koneksi terputus: klien menanyakan offset lalu melanjutkan
+		resp, status := send(httptest.NewRequest("GET", "/achievements/ach-1/uploads/"+session.ID, nil))
+		if status.Offset != 20 || resp.Header.Get("Upload-Offset") != "20" {
+			t.Errorf("Expected offset 20, got %d (header %q)", status.Offset, resp.Header.Get("Upload-Offset"))
+		}
+
+		resp, _ = patch(session.ID, 0, first, chunkChecksum(first))
+		if resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("Expected stale offset to conflict, got %d", resp.StatusCode)
+		}
+
+		resp, done := patch(session.ID, 20, rest, chunkChecksum(rest))
+		if resp.StatusCode != fiber.StatusOK {
+			t.Fatalf("Expected 200 status, got %d", resp.StatusCode)
+		}
+		if done.Status != model.UploadSessionCompleted || done.Attachment == nil {
+			t.Fatalf("Expected completed session with attachment, got %+v", done)
+		}
+		if done.Attachment.FileType != helper.FileTypeMP4 || done.Attachment.ScanStatus != model.ScanStatusPending {
+			t.Errorf("Unexpected attachment %+v", done.Attachment)
+		}
+
+		added := mockAchRepo.achDetail.Attachments[len(mockAchRepo.achDetail.Attachments)-1]
+		rc, _, err := store.Get(ctx, added.StorageKey)
+		if err != nil {
+			t.Fatalf("Assembled file not stored: %v", err)
+		}
+		defer rc.Close()
+		var stored bytes.Buffer
+		stored.ReadFrom(rc)
+		if !bytes.Equal(stored.Bytes(), video) {
+			t.Errorf("Assembled file differs from uploaded content")
+		}
+
+		var leftover int
+		store.List(ctx, model.UploadChunkPrefix, func(storage.ObjectInfo) error {
+			leftover++
+			return nil
+		})
+		if leftover != 0 {
+			t.Errorf("Expected chunks to be removed, %d left", leftover)
+		}
+	})
+
+	t.Run("PATCH - Completion Claimed Once", func(t *testing.T) {
+		_, session := create("final.mp4", len(video), "")
+		patch(session.ID, 0, video[:10], chunkChecksum(video[:10]))
+		store.Put(ctx, uploadChunkKey(session.ID, 10), bytes.NewReader(video[10:]), int64(len(video)-10), "application/octet-stream")
+
+		// Request lain sudah mengklaim penyelesaian setelah menerima potongan terakhir
+		s := sessionRepo.sessions[session.ID]
+		s.Offset, s.Status, s.UpdatedAt = int64(len(video)), model.UploadSessionCompleting, time.Now()
+		attachments := len(mockAchRepo.achDetail.Attachments)
+
+		resp, _ := patch(session.ID, len(video), nil, "")
+		if resp.StatusCode != fiber.StatusConflict {
+			t.Errorf("Expected 409 while another request completes the upload, got %d", resp.StatusCode)
+		}
+		if len(mockAchRepo.achDetail.Attachments) != attachments {
+			t.Errorf("Expected no attachment to be added by the second request")
+		}
+
+		// Klaim yang terhenti boleh diambil alih
+		s.UpdatedAt = time.Now().Add(-uploadCompleteTimeout - time.Minute)
+		resp, done := patch(session.ID, len(video), nil, "")
+		if resp.StatusCode != fiber.StatusOK || done.Status != model.UploadSessionCompleted {
+			t.Fatalf("Expected stale claim to be taken over, got %d %+v", resp.StatusCode, done)
+		}
+		if len(mockAchRepo.achDetail.Attachments) != attachments+1 {
+			t.Errorf("Expected exactly one attachment, got %d", len(mockAchRepo.achDetail.Attachments)-attachments)
+		}
+
+		resp, _ = patch(session.ID, len(video), nil, "")
+		if resp.StatusCode != fiber.StatusOK || len(mockAchRepo.achDetail.Attachments) != attachments+1 {
+			t.Errorf("Expected a repeated request to report the finished upload, got %d", resp.StatusCode)
+		}
+	})
+
+	t.Run("PATCH - Retry After Failed Completion Mark", func(t *testing.T) {
+		_, session := create("final.mp4", len(video), "")
+		attachments := len(mockAchRepo.achDetail.Attachments)
+
+		sessionRepo.completeErr = fmt.Errorf("koneksi terputus")
+		resp, _ := patch(session.ID, 0, video, chunkChecksum(video))
+		sessionRepo.completeErr = nil
+		if resp.StatusCode != fiber.StatusInternalServerError {
+			t.Fatalf("Expected 500 when the session cannot be marked completed, got %d", resp.StatusCode)
+		}
+		if len(mockAchRepo.achDetail.Attachments) != attachments+1 {
+			t.Fatalf("Expected the attachment to be added once")
+		}
+
+		// Penyelesaian diulang setelah klaim dilepas: lampiran yang sudah ada dipakai, bukan dianggap tidak lengkap
+		resp, done := patch(session.ID, len(video), nil, "")
+		if resp.StatusCode != fiber.StatusOK || done.Status != model.UploadSessionCompleted {
+			t.Fatalf("Expected retry to finish the upload, got %d %+v", resp.StatusCode, done)
+		}
+		if done.Attachment == nil || done.Attachment.ID != session.ID {
+			t.Errorf("Expected the existing attachment to be reported, got %+v", done.Attachment)
+		}
+		if len(mockAchRepo.achDetail.Attachments) != attachments+1 {
+			t.Errorf("Expected no duplicate attachment, got %d", len(mockAchRepo.achDetail.Attachments)-attachments)
+		}
+	})
+
+	t.Run("PATCH - Whole File Checksum Mismatch", func(t *testing.T) {
+		_, session := create("final.mp4", len(video), strings.Repeat("0", 64))
+		resp, _ := patch(session.ID, 0, video, chunkChecksum(video))
+		if resp.StatusCode != fiber.StatusBadRequest {
+			t.Errorf("Expected 400 status, got %d", resp.StatusCode)
+		}
+		if _, ok := sessionRepo.sessions[session.ID]; ok {
+			t.Errorf("Expected rejected session to be discarded")
+		}
+	})
+
+	t.Run("Expired Sessions Cleaned Up", func(t *testing.T) {
+		_, session := create("final.mp4", len(video), "")
+		patch(session.ID, 0, video[:10], chunkChecksum(video[:10]))
+		sessionRepo.sessions[session.ID].ExpiresAt = time.Now().Add(-time.Minute)
+
+		resp, _ := patch(session.ID, 10, video[10:], chunkChecksum(video[10:]))
+		if resp.StatusCode != fiber.StatusNotFound {
+			t.Errorf("Expected expired session to be rejected, got %d", resp.StatusCode)
+		}
+
+		n, err := svc.ExpireSessions(ctx)
+		if err != nil || n == 0 {
+			t.Fatalf("Expected expired session to be removed, got %d (%v)", n, err)
+		}
+		if _, err := store.Stat(ctx, uploadChunkKey(session.ID, 0)); err != storage.ErrNotFound {
+			t.Errorf("Expected chunk to be deleted, got %v", err)
+		}
+	})
+}
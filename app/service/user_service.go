@@ -56,6 +56,7 @@ func NewUserService(
 // @Param search query string false "Search by name, email, or username"
 // @Param sort_by query string false "Sort by field" Enums(created_at, full_name, email)
 // @Param sort_order query string false "Sort order" Enums(asc, desc)
+// @Param cursor query string false "Opaque keyset cursor from next_cursor/prev_cursor; send empty to start cursor pagination (total, page and total_pages are not computed)"
 // @Success 200 {object} helper.Response{data=model.PaginatedUsers} "Users retrieved"
 // @Failure 401 {object} helper.ErrorResponse "Unauthorized"
 // @Router /users [get]
@@ -89,6 +90,29 @@ func (s *UserService) GetAll(c *fiber.Ctx) error {
 		sortOrder = "DESC"
 	}
 
+	cursorMode, cursor, err := cursorParam(c, sortBy, sortOrder)
+	if err != nil {
+		return helper.HandleError(c, err)
+	}
+	if cursorMode {
+		users, links, err := s.userRepo.GetAllByCursor(c.Context(), search, sortBy, sortOrder, cursor, pageSize)
+		if err != nil {
+			return helper.HandleError(c, model.ErrDatabaseError)
+		}
+
+		userDTOs := make([]model.UserListDTO, len(users))
+		for i, user := range users {
+			userDTOs[i] = user.ToListDTO()
+		}
+
+		return helper.Success(c, "Daftar user berhasil diambil", &model.PaginatedUsers{
+			Data:       userDTOs,
+			PageSize:   pageSize,
+			NextCursor: helper.EncodeCursor(links.Next),
+			PrevCursor: helper.EncodeCursor(links.Prev),
+		})
+	}
+
 	users, total, err := s.userRepo.GetAll(c.Context(), page, pageSize, search, sortBy, sortOrder)
 	if err != nil {
 		return helper.HandleError(c, model.ErrDatabaseError)
@@ -0,0 +1,42 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"log"
+	"sort"
+)
+
+func migrateAchievementTypes(ctx context.Context, deps *Deps, args []string) error {
+	fs := flag.NewFlagSet("migrate-achievement-types", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "tampilkan pemetaan tanpa mengubah data")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	mappings, err := deps.AchievementTypeSvc.MigrateFreeText(ctx, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	sort.Slice(mappings, func(i, j int) bool { return mappings[i].OldValue < mappings[j].OldValue })
+
+	var unmapped int64
+	for _, m := range mappings {
+		if m.Mapped {
+			log.Printf("✅ %-30q -> %-20s (%d dokumen)", m.OldValue, m.Code, m.Count)
+		} else {
+			log.Printf("⚠️  %-30q -> (tidak ada di katalog) (%d dokumen)", m.OldValue, m.Count)
+			unmapped += m.Count
+		}
+	}
+
+	if *dryRun {
+		log.Println("ℹ️  Dry run: tidak ada data yang diubah")
+	}
+	if unmapped > 0 {
+		log.Printf("⚠️  %d dokumen belum terpetakan. Tambahkan code atau alias di katalog lalu jalankan ulang.", unmapped)
+	}
+
+	return nil
+}
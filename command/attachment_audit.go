@@ -0,0 +1,46 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+)
+
+func auditAttachments(ctx context.Context, deps *Deps, args []string) error {
+	fs := flag.NewFlagSet("audit-attachments", flag.ContinueOnError)
+	asJSON := fs.Bool("json", false, "tulis laporan lengkap sebagai JSON ke stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := deps.AttachmentIntegritySvc.Audit(ctx)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		for _, issue := range report.Issues {
+			frozen := ""
+			if issue.Frozen {
+				frozen = " [bukti terverifikasi]"
+			}
+			log.Printf("🚨 %s %s/%s %s: %s%s", issue.Problem, issue.Collection, issue.DocumentID, issue.FileName, issue.Key, frozen)
+		}
+	}
+
+	log.Printf("✅ %d lampiran diperiksa, %d bermasalah, %d tanpa hash (upload lama)", report.Checked, len(report.Issues), report.Unverifiable)
+	if len(report.Issues) > 0 {
+		return fmt.Errorf("%d lampiran hilang atau berubah", len(report.Issues))
+	}
+
+	return nil
+}
@@ -0,0 +1,33 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"time"
+)
+
+func gcAttachments(ctx context.Context, deps *Deps, args []string) error {
+	fs := flag.NewFlagSet("gc-attachments", flag.ContinueOnError)
+	minAge := fs.Duration("min-age", 24*time.Hour, "lewati file yang lebih baru dari durasi ini")
+	dryRun := fs.Bool("dry-run", false, "tampilkan file yatim tanpa menghapus")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := deps.AttachmentGCSvc.CollectGarbage(ctx, *minAge, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ %d file diperiksa, %d file yatim (%d byte), %d dihapus, %d gagal", result.Checked, result.Orphaned, result.Bytes, result.Deleted, result.Failed)
+	if *dryRun {
+		log.Println("ℹ️  Dry run: tidak ada file yang dihapus")
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%d file gagal dihapus, jalankan ulang perintah setelah memeriksa log", result.Failed)
+	}
+
+	return nil
+}
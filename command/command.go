@@ -0,0 +1,66 @@
+package command
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/service"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// Deps berisi koneksi dan service yang dibutuhkan oleh subcommand
+type Deps struct {
+	PgDB                    *sql.DB
+	MongoDB                 *mongo.Database
+	AchievementTypeSvc      service.IAchievementTypeService
+	AttachmentScanSvc       service.IAttachmentScanService
+	AttachmentGCSvc         service.IAttachmentGCService
+	AttachmentIntegritySvc  service.IAttachmentIntegrityService
+	AchievementReadModelSvc service.IAchievementReadModelService
+	ReconcileSvc            service.IReconcileService
+	AchievementTrashSvc     service.IAchievementTrashService
+	SKPISvc                 service.ISKPIService
+	TrashRetention          time.Duration
+}
+
+type handler func(ctx context.Context, deps *Deps, args []string) error
+
+var commands = map[string]handler{
+	"migrate-achievement-types": migrateAchievementTypes,
+	"storage-migrate":           storageMigrate,
+	"scan-attachments":          scanAttachments,
+	"gc-attachments":            gcAttachments,
+	"audit-attachments":         auditAttachments,
+	"rebuild-read-model":        rebuildReadModel,
+	"reconcile":                 reconcile,
+	"purge-trash":               purgeTrash,
+	"generate-skpi":             generateSKPI,
+}
+
+// Run menjalankan subcommand sesuai argumen pertama, misalnya: ./server migrate-achievement-types --dry-run
+func Run(ctx context.Context, deps *Deps, args []string) error {
+	if len(args) == 0 {
+		return fmt.Errorf("subcommand tidak diberikan. Tersedia: %s", available())
+	}
+
+	cmd, ok := commands[args[0]]
+	if !ok {
+		return fmt.Errorf("subcommand '%s' tidak dikenal. Tersedia: %s", args[0], available())
+	}
+
+	return cmd(ctx, deps, args[1:])
+}
+
+func available() string {
+	names := make([]string, 0, len(commands))
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
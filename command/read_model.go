@@ -0,0 +1,31 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"log"
+	"strings"
+)
+
+func rebuildReadModel(ctx context.Context, deps *Deps, args []string) error {
+	fs := flag.NewFlagSet("rebuild-read-model", flag.ContinueOnError)
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := deps.AchievementReadModelSvc.Rebuild(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ %d prestasi diproyeksikan ke achievement_read_model", result.Projected)
+	if result.Missing > 0 {
+		log.Printf("⚠️  %d referensi prestasi tidak punya dokumen MongoDB dan tidak akan muncul di daftar", result.Missing)
+	}
+	if len(result.Pending) > 0 {
+		log.Printf("⚠️  %d prestasi dilewati karena event outbox-nya belum terkirim; jalankan ulang setelah dispatcher selesai: %s",
+			len(result.Pending), strings.Join(result.Pending, ", "))
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"sort"
+)
+
+func reconcile(ctx context.Context, deps *Deps, args []string) error {
+	fs := flag.NewFlagSet("reconcile", flag.ContinueOnError)
+	fix := fs.Bool("fix", false, "jalankan perbaikan yang aman (tanpa menghapus data)")
+	asJSON := fs.Bool("json", false, "tulis laporan lengkap sebagai JSON ke stdout")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	report, err := deps.ReconcileSvc.Reconcile(ctx, *fix)
+	if err != nil {
+		return err
+	}
+
+	if *asJSON {
+		enc := json.NewEncoder(os.Stdout)
+		enc.SetIndent("", "  ")
+		if err := enc.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		for _, issue := range report.Issues {
+			status := ""
+			switch {
+			case issue.Fixed:
+				status = " [diperbaiki]"
+			case issue.FixError != "":
+				status = " [gagal diperbaiki: " + issue.FixError + "]"
+			}
+			log.Printf("🚨 %s ref=%s mongo=%s: %s%s", issue.Category, issue.AchievementID, issue.MongoID, issue.Detail, status)
+		}
+	}
+
+	categories := make([]string, 0, len(report.Counts))
+	for category := range report.Counts {
+		categories = append(categories, category)
+	}
+	sort.Strings(categories)
+	for _, category := range categories {
+		log.Printf("   %-20s %d", category, report.Counts[category])
+	}
+	log.Printf("✅ %d referensi dan %d dokumen diperiksa, %d inkonsistensi, %d diperbaiki", report.References, report.Documents, len(report.Issues), report.Fixed)
+	if report.Pending > 0 {
+		log.Printf("ℹ️  %d prestasi dilewati karena event outbox-nya belum terkirim", report.Pending)
+	}
+
+	if remaining := len(report.Issues) - report.Fixed; remaining > 0 {
+		if !*fix {
+			log.Println("ℹ️  Jalankan dengan --fix untuk memperbaiki yang aman diperbaiki otomatis")
+		}
+		return fmt.Errorf("%d inkonsistensi belum diperbaiki", remaining)
+	}
+	return nil
+}
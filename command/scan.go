@@ -0,0 +1,32 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"log"
+)
+
+// scanAttachments menjalankan satu putaran pemindaian antrean karantina tanpa menunggu worker
+func scanAttachments(ctx context.Context, deps *Deps, args []string) error {
+	fs := flag.NewFlagSet("scan-attachments", flag.ContinueOnError)
+	limit := fs.Int("limit", 500, "jumlah maksimum lampiran yang dipindai")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	backfilled, err := deps.AttachmentScanSvc.BackfillStatus(ctx)
+	if err != nil {
+		return err
+	}
+	if backfilled > 0 {
+		log.Printf("🛡️  %d dokumen dengan lampiran lama masuk antrean pemindaian", backfilled)
+	}
+
+	summary, err := deps.AttachmentScanSvc.ScanPending(ctx, *limit)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ %d lampiran dipindai: %d bersih, %d terinfeksi, %d gagal (%d ditandai failed)", summary.Scanned, summary.Clean, summary.Infected, summary.Failed, summary.GaveUp)
+	return nil
+}
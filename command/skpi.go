@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
+)
+
+func generateSKPI(ctx context.Context, deps *Deps, args []string) error {
+	fs := flag.NewFlagSet("generate-skpi", flag.ContinueOnError)
+	academicYear := fs.String("academic-year", "", "angkatan yang dibuatkan SKPI (wajib)")
+	programStudy := fs.String("program-study", "", "batasi ke satu program studi")
+	format := fs.String("format", "pdf", "format dokumen: pdf atau docx")
+	template := fs.String("template", "", "nama template di SKPI_CONFIG (default: template bawaan)")
+	out := fs.String("out", "skpi", "direktori tujuan; file yang sudah ada ditimpa")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if strings.TrimSpace(*academicYear) == "" {
+		return fmt.Errorf("--academic-year wajib diisi")
+	}
+	f := export.Format(strings.ToLower(*format))
+	if f != export.FormatPDF && f != export.FormatDOCX {
+		return fmt.Errorf("--format harus pdf atau docx")
+	}
+
+	students, err := deps.SKPISvc.Cohort(ctx, model.SKPICohortFilter{
+		AcademicYear: strings.TrimSpace(*academicYear),
+		ProgramStudy: strings.TrimSpace(*programStudy),
+	})
+	if err != nil {
+		return err
+	}
+	if len(students) == 0 {
+		return fmt.Errorf("tidak ada mahasiswa angkatan %s", *academicYear)
+	}
+	if err := os.MkdirAll(*out, 0o755); err != nil {
+		return err
+	}
+
+	summary, err := deps.SKPISvc.Batch(ctx, students, f, *template, func(name string, fill func(w io.Writer) error) error {
+		file, err := os.Create(filepath.Join(*out, name))
+		if err != nil {
+			return err
+		}
+		if err := fill(file); err != nil {
+			file.Close()
+			return err
+		}
+		return file.Close()
+	})
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ %d dari %d SKPI ditulis ke %s, %d gagal (lihat daftar-skpi.csv)", summary.Generated, summary.Students, *out, summary.Failed)
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d SKPI gagal dibuat", summary.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+)
+
+func storageMigrate(ctx context.Context, deps *Deps, args []string) error {
+	fs := flag.NewFlagSet("storage-migrate", flag.ContinueOnError)
+	from := fs.String("from", "local", "backend sumber (local, s3)")
+	to := fs.String("to", "s3", "backend tujuan (local, s3)")
+	prefix := fs.String("prefix", "", "hanya salin key dengan prefix ini, misalnya achievements/")
+	dryRun := fs.Bool("dry-run", false, "tampilkan jumlah file tanpa menyalin")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	if *from == *to {
+		return fmt.Errorf("backend sumber dan tujuan tidak boleh sama")
+	}
+
+	src, err := storage.New(*from)
+	if err != nil {
+		return err
+	}
+	dst, err := storage.New(*to)
+	if err != nil {
+		return err
+	}
+
+	result, err := storage.Copy(ctx, src, dst, *prefix, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ %d file disalin (%d byte), %d sudah ada, %d gagal", result.Copied, result.Bytes, result.Skipped, result.Failed)
+	if *dryRun {
+		log.Println("ℹ️  Dry run: tidak ada file yang disalin")
+	}
+	if result.Failed > 0 {
+		return fmt.Errorf("%d file gagal disalin, jalankan ulang perintah setelah memeriksa log", result.Failed)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+)
+
+func purgeTrash(ctx context.Context, deps *Deps, args []string) error {
+	fs := flag.NewFlagSet("purge-trash", flag.ContinueOnError)
+	retention := fs.Duration("retention", deps.TrashRetention, "hapus permanen prestasi yang sudah di tempat sampah lebih lama dari durasi ini")
+	dryRun := fs.Bool("dry-run", false, "tampilkan jumlah prestasi tanpa menghapus")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+
+	summary, err := deps.AchievementTrashSvc.PurgeExpired(ctx, *retention, *dryRun)
+	if err != nil {
+		return err
+	}
+
+	log.Printf("✅ %d prestasi dihapus permanen (%d file lampiran), %d gagal", summary.Purged, summary.Files, summary.Failed)
+	if *dryRun {
+		log.Println("ℹ️  Dry run: tidak ada data yang dihapus")
+	}
+	if summary.Failed > 0 {
+		return fmt.Errorf("%d prestasi gagal dihapus, jalankan ulang perintah setelah memeriksa log", summary.Failed)
+	}
+	return nil
+}
@@ -0,0 +1,34 @@
+-- Katalog jenis prestasi (menggantikan achievement_type teks bebas)
+CREATE TABLE IF NOT EXISTS achievement_types (
+    id                   UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    code                 VARCHAR(50)  NOT NULL UNIQUE,
+    name_id              VARCHAR(100) NOT NULL,
+    name_en              VARCHAR(100) NOT NULL,
+    description          TEXT         NOT NULL DEFAULT '',
+    required_attachments TEXT[]       NOT NULL DEFAULT '{}',
+    min_points           INTEGER      NOT NULL DEFAULT 0,
+    max_points           INTEGER      NOT NULL DEFAULT 0,
+    aliases              TEXT[]       NOT NULL DEFAULT '{}',
+    is_active            BOOLEAN      NOT NULL DEFAULT true,
+    created_at           TIMESTAMPTZ  NOT NULL DEFAULT NOW(),
+    updated_at           TIMESTAMPTZ  NOT NULL DEFAULT NOW()
+);
+
+INSERT INTO achievement_types (code, name_id, name_en, description, required_attachments, min_points, max_points, aliases)
+VALUES
+    ('competition',   'Kompetisi',         'Competition',            'Lomba atau kompetisi akademik maupun non-akademik', '{sertifikat}',          10, 100, '{kompetisi,lomba}'),
+    ('publication',   'Publikasi',         'Publication',            'Artikel jurnal, prosiding, atau buku',              '{naskah,bukti terbit}', 20, 150, '{publikasi,jurnal,paper}'),
+    ('organization',  'Organisasi',        'Organization',           'Kepengurusan organisasi kemahasiswaan',            '{surat keputusan}',     5,  50,  '{organisasi}'),
+    ('certification', 'Sertifikasi',       'Certification',          'Sertifikasi kompetensi atau profesi',               '{sertifikat}',          10, 75,  '{sertifikasi,sertifikat}'),
+    ('academic',      'Prestasi Akademik', 'Academic Achievement',   'Beasiswa, mahasiswa berprestasi, dan sejenisnya',   '{surat keterangan}',    10, 100, '{akademik}'),
+    ('other',         'Lainnya',           'Other',                  'Prestasi yang tidak termasuk kategori lain',        '{}',                    0,  50,  '{lainnya,lain-lain}')
+ON CONFLICT (code) DO NOTHING;
+
+INSERT INTO permissions (name, resource, action, description)
+VALUES ('achievement_type:manage', 'achievement_type', 'manage', 'Kelola katalog jenis prestasi')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'Admin' AND p.name = 'achievement_type:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,19 @@
+-- Anggota tim pada prestasi beregu. Pembuat prestasi (achievement_references.student_id)
+-- tidak disimpan di sini; tabel ini hanya berisi anggota yang diundang.
+CREATE TABLE IF NOT EXISTS achievement_members (
+    id                  UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+    achievement_id      UUID NOT NULL REFERENCES achievement_references(id) ON DELETE CASCADE,
+    student_id          UUID NOT NULL REFERENCES students(id) ON DELETE CASCADE,
+    invitation_status   VARCHAR(20) NOT NULL DEFAULT 'invited'
+                        CHECK (invitation_status IN ('invited', 'confirmed', 'declined')),
+    verification_status VARCHAR(20) NOT NULL DEFAULT 'pending'
+                        CHECK (verification_status IN ('pending', 'verified', 'rejected')),
+    rejection_note      TEXT,
+    verified_by         UUID REFERENCES users(id),
+    verified_at         TIMESTAMPTZ,
+    created_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at          TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    UNIQUE (achievement_id, student_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_members_student ON achievement_members(student_id);
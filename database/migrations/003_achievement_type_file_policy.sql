@@ -0,0 +1,13 @@
+-- Kebijakan upload lampiran per jenis prestasi. Tipe file diperiksa dari isi (magic bytes),
+-- sehingga hanya tipe yang dikenali aplikasi yang boleh dipakai.
+ALTER TABLE achievement_types
+    ADD COLUMN IF NOT EXISTS max_file_size_mb INT NOT NULL DEFAULT 5
+        CHECK (max_file_size_mb BETWEEN 1 AND 50),
+    ADD COLUMN IF NOT EXISTS allowed_file_types TEXT[] NOT NULL
+        DEFAULT ARRAY['application/pdf', 'image/jpeg', 'image/png']
+        CHECK (allowed_file_types <@ ARRAY['application/pdf', 'image/jpeg', 'image/png']);
+
+-- Publikasi cukup PDF dengan batas lebih besar untuk naskah lengkap
+UPDATE achievement_types
+SET max_file_size_mb = 20, allowed_file_types = ARRAY['application/pdf']
+WHERE code = 'publication';
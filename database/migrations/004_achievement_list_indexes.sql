@@ -0,0 +1,13 @@
+-- Index untuk filter dan urutan daftar prestasi (status, tanggal, program studi, angkatan).
+-- Filter jenis, tag, poin dan pencarian teks dibaca dari achievement_read_model (lihat migrasi 006).
+CREATE INDEX IF NOT EXISTS idx_achievement_references_status_created
+    ON achievement_references (status, created_at DESC, id);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_created
+    ON achievement_references (created_at DESC, id);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_mongo_id
+    ON achievement_references (mongo_achievement_id);
+
+CREATE INDEX IF NOT EXISTS idx_students_program_study_academic_year
+    ON students (program_study, academic_year);
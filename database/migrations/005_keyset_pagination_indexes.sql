@@ -0,0 +1,12 @@
+-- Index komposit (kolom urutan, id) untuk paginasi keyset (parameter cursor) pada daftar
+-- user, mahasiswa, mahasiswa bimbingan dan prestasi.
+CREATE INDEX IF NOT EXISTS idx_users_active_created_id ON users (created_at, id) WHERE is_active = true;
+CREATE INDEX IF NOT EXISTS idx_users_active_full_name_id ON users (full_name, id) WHERE is_active = true;
+CREATE INDEX IF NOT EXISTS idx_users_active_username_id ON users (username, id) WHERE is_active = true;
+CREATE INDEX IF NOT EXISTS idx_users_active_email_id ON users (email, id) WHERE is_active = true;
+
+CREATE INDEX IF NOT EXISTS idx_students_advisor_user ON students (advisor_id, user_id);
+CREATE INDEX IF NOT EXISTS idx_students_student_id_user ON students (student_id, user_id);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_student_created_id
+    ON achievement_references (student_id, created_at, id);
@@ -0,0 +1,22 @@
+-- Proyeksi field prestasi dari MongoDB (judul, jenis, tag, poin) agar daftar prestasi, pencarian dan
+-- laporan bisa difilter, diurutkan dan dihitung dengan satu query SQL tanpa join ke Mongo di Go.
+-- Diperbarui setiap kali dokumen prestasi ditulis; jalankan `rebuild-read-model` setelah migrasi ini
+-- untuk mengisi data lama.
+CREATE TABLE IF NOT EXISTS achievement_read_model (
+    achievement_id       UUID PRIMARY KEY REFERENCES achievement_references(id) ON DELETE CASCADE,
+    mongo_achievement_id VARCHAR(24) NOT NULL,
+    achievement_type     TEXT NOT NULL DEFAULT '',
+    title                TEXT NOT NULL DEFAULT '',
+    description          TEXT NOT NULL DEFAULT '',
+    tags                 TEXT[] NOT NULL DEFAULT '{}',
+    points               INT NOT NULL DEFAULT 0,
+    points_mode          TEXT NOT NULL DEFAULT '',
+    search_vector        TSVECTOR NOT NULL DEFAULT ''::tsvector,
+    projected_at         TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_read_model_search ON achievement_read_model USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_achievement_read_model_tags ON achievement_read_model USING GIN (tags);
+CREATE INDEX IF NOT EXISTS idx_achievement_read_model_type_points ON achievement_read_model (achievement_type, points);
+CREATE INDEX IF NOT EXISTS idx_achievement_read_model_title ON achievement_read_model (title, achievement_id);
+CREATE INDEX IF NOT EXISTS idx_achievement_read_model_points ON achievement_read_model (points, achievement_id);
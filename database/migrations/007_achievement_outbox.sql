@@ -0,0 +1,18 @@
+-- Outbox untuk penulisan lintas PostgreSQL -> MongoDB. Perubahan di PostgreSQL dan event-nya ditulis
+-- dalam satu transaksi; dispatcher lalu menerapkan event ke MongoDB dan mengulanginya sampai berhasil.
+-- Setiap event idempoten sehingga aman diterapkan lebih dari sekali.
+CREATE TABLE IF NOT EXISTS achievement_outbox (
+    id                   BIGSERIAL PRIMARY KEY,
+    achievement_id       UUID NOT NULL,
+    mongo_achievement_id VARCHAR(24) NOT NULL,
+    event_type           TEXT NOT NULL,
+    payload              BYTEA NOT NULL,
+    attempts             INT NOT NULL DEFAULT 0,
+    last_error           TEXT NOT NULL DEFAULT '',
+    next_attempt_at      TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    created_at           TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    dispatched_at        TIMESTAMPTZ
+);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_outbox_pending
+    ON achievement_outbox (next_attempt_at, id) WHERE dispatched_at IS NULL;
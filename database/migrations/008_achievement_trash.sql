@@ -0,0 +1,18 @@
+-- Tempat sampah prestasi: waktu soft delete dicatat agar prestasi bisa dipulihkan Admin dan dihapus
+-- permanen (baris PostgreSQL, dokumen MongoDB dan file lampiran) setelah masa retensi lewat.
+ALTER TABLE achievement_references ADD COLUMN IF NOT EXISTS deleted_at TIMESTAMPTZ;
+
+UPDATE achievement_references SET deleted_at = updated_at
+WHERE status = 'deleted' AND deleted_at IS NULL;
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_deleted_at
+    ON achievement_references (deleted_at, id) WHERE status = 'deleted';
+
+INSERT INTO permissions (name, resource, action, description)
+VALUES ('achievement:trash', 'achievement', 'trash', 'Lihat, pulihkan dan hapus permanen prestasi di tempat sampah')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'Admin' AND p.name = 'achievement:trash'
+ON CONFLICT DO NOTHING;
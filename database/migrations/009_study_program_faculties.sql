@@ -0,0 +1,16 @@
+-- Pemetaan program studi ke fakultas untuk filter fakultas pada statistik dashboard. Program studi
+-- mahasiswa yang sudah ada dimasukkan dengan fakultas kosong; isi kolom faculty secara manual.
+-- Program studi yang belum dipetakan tidak ikut terhitung saat statistik difilter per fakultas.
+CREATE TABLE IF NOT EXISTS study_programs (
+    name    VARCHAR(100) PRIMARY KEY,
+    faculty VARCHAR(100) NOT NULL DEFAULT ''
+);
+
+INSERT INTO study_programs (name)
+SELECT DISTINCT program_study FROM students WHERE program_study IS NOT NULL AND program_study != ''
+ON CONFLICT (name) DO NOTHING;
+
+CREATE INDEX IF NOT EXISTS idx_study_programs_faculty ON study_programs (faculty);
+
+CREATE INDEX IF NOT EXISTS idx_achievement_references_verified_turnaround
+    ON achievement_references (verified_at) WHERE status = 'verified';
@@ -0,0 +1,10 @@
+-- Izin membuat SKPI (Surat Keterangan Pendamping Ijazah) per mahasiswa maupun satu angkatan.
+-- Template dan pemetaan kategori tidak disimpan di database; lihat SKPI_CONFIG.
+INSERT INTO permissions (name, resource, action, description)
+VALUES ('skpi:generate', 'skpi', 'generate', 'Buat SKPI mahasiswa dari prestasi terverifikasi')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'Admin' AND p.name = 'skpi:generate'
+ON CONFLICT DO NOTHING;
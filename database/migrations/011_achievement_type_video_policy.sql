@@ -0,0 +1,12 @@
+-- Batas kebijakan upload di 003 (maksimal 50MB, hanya PDF/JPEG/PNG) lebih sempit daripada yang diterima
+-- aplikasi sejak upload bertahap dan video MP4 didukung. CHECK lama diganti agar sama dengan validasi
+-- AchievementTypeService (maxAllowedFileSizeMB dan helper.SupportedFileTypes).
+ALTER TABLE achievement_types
+    DROP CONSTRAINT IF EXISTS achievement_types_max_file_size_mb_check,
+    DROP CONSTRAINT IF EXISTS achievement_types_allowed_file_types_check;
+
+ALTER TABLE achievement_types
+    ADD CONSTRAINT achievement_types_max_file_size_mb_check
+        CHECK (max_file_size_mb BETWEEN 1 AND 200),
+    ADD CONSTRAINT achievement_types_allowed_file_types_check
+        CHECK (allowed_file_types <@ ARRAY['application/pdf', 'image/jpeg', 'image/png', 'video/mp4']);
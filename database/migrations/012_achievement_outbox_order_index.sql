@@ -0,0 +1,4 @@
+-- Dispatcher hanya mengambil event tertua yang belum terkirim untuk setiap prestasi (lihat
+-- outboxRepository.FindDue dan dispatchNow); indeks ini dipakai untuk mencari event lebih lama tersebut.
+CREATE INDEX IF NOT EXISTS idx_achievement_outbox_pending_achievement
+    ON achievement_outbox (achievement_id, id) WHERE dispatched_at IS NULL;
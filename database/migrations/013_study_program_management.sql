@@ -0,0 +1,16 @@
+-- Pemetaan program studi ke fakultas dikelola Admin lewat /study-programs. Program studi baru
+-- didaftarkan otomatis saat data mahasiswa dibuat/diubah; baris di bawah menyusulkan program studi
+-- yang muncul setelah migrasi 009 dijalankan.
+INSERT INTO study_programs (name)
+SELECT DISTINCT program_study FROM students
+WHERE program_study IS NOT NULL AND program_study <> ''
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO permissions (name, resource, action, description)
+VALUES ('study_program:manage', 'study_program', 'manage', 'Kelola pemetaan program studi ke fakultas')
+ON CONFLICT (name) DO NOTHING;
+
+INSERT INTO role_permissions (role_id, permission_id)
+SELECT r.id, p.id FROM roles r, permissions p
+WHERE r.name = 'Admin' AND p.name = 'study_program:manage'
+ON CONFLICT DO NOTHING;
@@ -0,0 +1,5 @@
+-- Daftar prestasi me-LEFT JOIN achievement_read_model dan mengurutkan/memfilter dengan
+-- COALESCE(rm.title, '') dan COALESCE(rm.points, 0), sehingga index kolom polos dari migrasi 006 tidak
+-- pernah dipakai dan hanya menambah beban tulis; urutan judul dan poin diurutkan setelah join.
+DROP INDEX IF EXISTS idx_achievement_read_model_title;
+DROP INDEX IF EXISTS idx_achievement_read_model_points;
@@ -0,0 +1,8 @@
+-- Setiap dokumen Mongo hanya boleh punya satu achievement_references, agar `reconcile --fix` tidak bisa
+-- membuat referensi kedua untuk dokumen yang referensinya dibuat selama pemeriksaan berjalan.
+-- Migrasi gagal bila duplikat sudah ada; temukan dengan:
+--   SELECT mongo_achievement_id FROM achievement_references GROUP BY 1 HAVING COUNT(*) > 1;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_achievement_references_mongo_id_unique
+    ON achievement_references (mongo_achievement_id);
+
+DROP INDEX IF EXISTS idx_achievement_references_mongo_id;
@@ -7,6 +7,7 @@ import (
 	"os"
 	"time"
 
+	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/mongo"
 	"go.mongodb.org/mongo-driver/mongo/options"
 )
@@ -35,4 +36,27 @@ func ConnectMongo() (*mongo.Database, error) {
 
 	log.Println("✅ Berhasil terhubung ke MongoDB")
 	return client.Database(dbName), nil
-}
\ No newline at end of file
+}
+// EnsureMongoIndexes membuat index yang dibutuhkan query aplikasi; aman dipanggil berulang kali
+func EnsureMongoIndexes(ctx context.Context, db *mongo.Database) error {
+	achievements := db.Collection("achievements").Indexes()
+	// Filter, urutan dan pencarian daftar prestasi dibaca dari achievement_read_model di PostgreSQL;
+	// index di sini hanya untuk deteksi duplikat dan migrasi katalog jenis prestasi.
+	_, err := achievements.CreateMany(ctx, []mongo.IndexModel{
+		{Keys: bson.D{{Key: "achievement_type", Value: 1}}},
+		{Keys: bson.D{{Key: "attachments.content_hash", Value: 1}}},
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat index achievements: %v", err)
+	}
+
+	// Nomor versi revisi unik per prestasi; penyimpanan revisi bersamaan diulang bila bentrok
+	_, err = db.Collection("achievement_revisions").Indexes().CreateOne(ctx, mongo.IndexModel{
+		Keys:    bson.D{{Key: "achievement_id", Value: 1}, {Key: "version", Value: 1}},
+		Options: options.Index().SetUnique(true),
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat index achievement_revisions: %v", err)
+	}
+	return nil
+}
@@ -23,14 +23,9 @@ const docTemplate = `{
     "host": "{{.Host}}",
     "basePath": "{{.BasePath}}",
     "paths": {
-        "/achievements": {
+        "/achievement-types": {
             "get": {
-                "security": [
-                    {
-                        "BearerAuth": []
-                    }
-                ],
-                "description": "Get paginated list of achievements with role-based filtering",
+                "description": "Get the achievement type catalogue (inactive entries for Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -38,46 +33,20 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "List achievements",
+                "summary": "List achievement types",
                 "parameters": [
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by title",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "draft",
-                            "submitted",
-                            "verified",
-                            "rejected"
-                        ],
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
+                        "type": "boolean",
+                        "description": "Include inactive types (Admin only)",
+                        "name": "include_inactive",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievements retrieved",
+                        "description": "Achievement types retrieved",
                         "schema": {
                             "allOf": [
                                 {
@@ -87,22 +56,25 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.PaginatedAchievements"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.AchievementType"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a new achievement in draft status",
+                ]
+            },
+            "post": {
+                "description": "Add a new entry to the achievement type catalogue (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -110,23 +82,23 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Create new achievement",
+                "summary": "Create achievement type",
                 "parameters": [
                     {
-                        "description": "Achievement data",
+                        "description": "Achievement type data",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateAchievementRequest"
+                            "$ref": "#/definitions/model.CreateAchievementTypeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "201": {
-                        "description": "Achievement created",
+                        "description": "Achievement type created",
                         "schema": {
                             "allOf": [
                                 {
@@ -136,7 +108,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.AchievementReference"
+                                            "$ref": "#/definitions/model.AchievementType"
                                         }
                                     }
                                 }
@@ -148,24 +120,18 @@ const docTemplate = `{
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
-                    },
-                    "401": {
-                        "description": "unauthorized - student only",
-                        "schema": {
-                            "$ref": "#/definitions/helper.ErrorResponse"
-                        }
                     }
-                }
-            }
-        },
-        "/achievements/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get detailed information about specific achievement",
+                ]
+            }
+        },
+        "/achievement-types/{id}": {
+            "get": {
+                "description": "Get a single achievement type from the catalogue",
                 "consumes": [
                     "application/json"
                 ],
@@ -173,13 +139,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Get achievement detail",
+                "summary": "Get achievement type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement ID",
+                        "description": "Achievement Type ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -187,7 +153,7 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement details",
+                        "description": "Achievement type retrieved",
                         "schema": {
                             "allOf": [
                                 {
@@ -197,34 +163,28 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.AchievementDetailDTO"
+                                            "$ref": "#/definitions/model.AchievementType"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
-                        "schema": {
-                            "$ref": "#/definitions/helper.ErrorResponse"
-                        }
-                    },
                     "404": {
                         "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Edit draft achievement details",
+                ]
+            },
+            "put": {
+                "description": "Update an achievement type in the catalogue (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -232,13 +192,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Edit achievement",
+                "summary": "Update achievement type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement ID",
+                        "description": "Achievement Type ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -249,32 +209,44 @@ const docTemplate = `{
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateAchievementRequest"
+                            "$ref": "#/definitions/model.UpdateAchievementTypeRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement updated",
+                        "description": "Achievement type updated",
                         "schema": {
-                            "$ref": "#/definitions/helper.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.AchievementType"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
-                    "400": {
-                        "description": "Can only edit draft achievements",
+                    "404": {
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Soft delete draft achievement",
+                ]
+            },
+            "delete": {
+                "description": "Deactivate an achievement type so it can no longer be used for new achievements (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -282,13 +254,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Types"
                 ],
-                "summary": "Delete achievement",
+                "summary": "Deactivate achievement type",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Achievement ID",
+                        "description": "Achievement Type ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -296,57 +268,169 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement deleted",
+                        "description": "Achievement type deactivated",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
-                    "400": {
-                        "description": "Can only delete draft achievements",
+                    "404": {
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/achievements/{id}/attachments": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Upload proof file for achievement (PDF/Image, max 5MB)",
+                ]
+            }
+        },
+        "/achievements": {
+            "get": {
+                "description": "Get paginated list of achievements with role-based filtering. With format=csv|xlsx|pdf|docx (or a matching Accept header) every achievement matching the filters is exported; page, limit and cursor are ignored.",
                 "consumes": [
-                    "multipart/form-data"
+                    "application/json"
                 ],
                 "produces": [
-                    "application/json"
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf",
+                    "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
                 ],
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Upload achievement attachment",
+                "summary": "List achievements",
                 "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
                     {
                         "type": "string",
-                        "description": "Achievement ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Full-text search over title, description and tags, or student name/NIM",
+                        "name": "search",
+                        "in": "query"
                     },
                     {
-                        "type": "file",
-                        "description": "Attachment file",
-                        "name": "file",
-                        "in": "formData",
-                        "required": true
+                        "enum": [
+                            "draft",
+                            "submitted",
+                            "verified",
+                            "rejected"
+                        ],
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by achievement type code",
+                        "name": "type",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated tags; achievements must have all of them",
+                        "name": "tags",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reported on or after (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reported on or before (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Minimum points",
+                        "name": "min_points",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Maximum points",
+                        "name": "max_points",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student program study",
+                        "name": "program_study",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by student academic year",
+                        "name": "academic_year",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "title",
+                            "points",
+                            "student_name"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque keyset cursor from next_cursor/prev_cursor; send empty to start cursor pagination (total, page and total_pages are not computed)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx",
+                            "pdf",
+                            "docx"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "File uploaded",
+                        "description": "Achievements retrieved",
                         "schema": {
                             "allOf": [
                                 {
@@ -356,7 +440,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.AchievementAttachment"
+                                            "$ref": "#/definitions/model.PaginatedAchievements"
                                         }
                                     }
                                 }
@@ -364,22 +448,20 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid file",
+                        "description": "Invalid filter",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/achievements/{id}/history": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get all achievements for a specific student",
+                ]
+            },
+            "post": {
+                "description": "Create a new achievement in draft status",
                 "consumes": [
                     "application/json"
                 ],
@@ -389,15 +471,71 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Get student achievement history",
+                "summary": "Create new achievement",
                 "parameters": [
                     {
-                        "type": "string",
-                        "description": "Student User ID",
-                        "name": "id",
-                        "in": "path",
-                        "required": true
+                        "description": "Achievement data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateAchievementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Achievement created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.AchievementReference"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
                     },
+                    "401": {
+                        "description": "unauthorized - student only",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/achievements/trash": {
+            "get": {
+                "description": "Get paginated list of soft-deleted achievements in the trash (Admin only). Supports the same filters as GET /achievements.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "List deleted achievements",
+                "parameters": [
                     {
                         "type": "integer",
                         "default": 1,
@@ -414,14 +552,36 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
+                        "description": "Full-text search over title, description and tags, or student name/NIM",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "title",
+                            "points",
+                            "student_name"
+                        ],
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort_order",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Student achievements",
+                        "description": "Deleted achievements retrieved",
                         "schema": {
                             "allOf": [
                                 {
@@ -438,23 +598,23 @@ const docTemplate = `{
                             ]
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Invalid filter",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/achievements/{id}/reject": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Reject achievement with reason (Advisor only)",
+                ]
+            }
+        },
+        "/achievements/{id}": {
+            "get": {
+                "description": "Get detailed information about specific achievement",
                 "consumes": [
                     "application/json"
                 ],
@@ -464,7 +624,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Reject achievement",
+                "summary": "Get achievement detail",
                 "parameters": [
                     {
                         "type": "string",
@@ -472,41 +632,48 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Rejection reason",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.RejectAchievementRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement rejected",
+                        "description": "Achievement details",
                         "schema": {
-                            "$ref": "#/definitions/helper.Response"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.AchievementDetailDTO"
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "403": {
-                        "description": "Not your advisee",
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/achievements/{id}/submit": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Submit achievement for advisor verification",
+                ]
+            },
+            "put": {
+                "description": "Edit draft achievement details (each change is stored as a revision)",
                 "consumes": [
                     "application/json"
                 ],
@@ -516,7 +683,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Submit achievement",
+                "summary": "Edit achievement",
                 "parameters": [
                     {
                         "type": "string",
@@ -524,32 +691,39 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Updated data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateAchievementRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement submitted",
+                        "description": "Achievement updated",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "400": {
-                        "description": "Can only submit draft achievements",
+                        "description": "Can only edit draft achievements",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/achievements/{id}/verify": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Verify and approve achievement with points (Advisor only)",
+                ]
+            },
+            "delete": {
+                "description": "Soft delete draft achievement",
                 "consumes": [
                     "application/json"
                 ],
@@ -559,7 +733,7 @@ const docTemplate = `{
                 "tags": [
                     "Achievements"
                 ],
-                "summary": "Verify achievement",
+                "summary": "Delete achievement",
                 "parameters": [
                     {
                         "type": "string",
@@ -567,60 +741,61 @@ const docTemplate = `{
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "Points to award",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.VerifyAchievementRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Achievement verified",
+                        "description": "Achievement deleted",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
-                    "403": {
-                        "description": "Not your advisee",
+                    "400": {
+                        "description": "Can only delete draft achievements",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
         },
-        "/auth/login": {
+        "/achievements/{id}/attachments": {
             "post": {
-                "description": "Authenticate user with username and password",
+                "description": "Upload proof file for achievement. The file type is detected from its content and must match the extension; size and type limits follow the achievement type policy. Image metadata (EXIF/GPS) is stripped.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Achievements"
                 ],
-                "summary": "Login user",
+                "summary": "Upload achievement attachment",
                 "parameters": [
                     {
-                        "description": "Login credentials",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.LoginRequest"
-                        }
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Attachment file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Login successful",
+                        "description": "File uploaded",
                         "schema": {
                             "allOf": [
                                 {
@@ -630,7 +805,7 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.LoginResponse"
+                                            "$ref": "#/definitions/model.AchievementAttachment"
                                         }
                                     }
                                 }
@@ -638,69 +813,58 @@ const docTemplate = `{
                         }
                     },
                     "400": {
-                        "description": "Invalid request format",
-                        "schema": {
-                            "$ref": "#/definitions/helper.ErrorResponse"
-                        }
-                    },
-                    "401": {
-                        "description": "Invalid credentials",
+                        "description": "Invalid file",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/auth/logout": {
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Logout current user (placeholder for token invalidation)",
+                ]
+            }
+        },
+        "/achievements/{id}/attachments/{attachmentId}": {
+            "put": {
+                "description": "Replace the file of an attachment on a draft achievement. The attachment keeps its ID; the old file is deleted and the new one is scanned again.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Achievements"
                 ],
-                "summary": "Logout user",
-                "responses": {
-                    "200": {
-                        "description": "Logout successful",
-                        "schema": {
-                            "$ref": "#/definitions/helper.Response"
-                        }
-                    }
-                }
-            }
-        },
-        "/auth/profile": {
-            "get": {
-                "security": [
+                "summary": "Replace achievement attachment",
+                "parameters": [
                     {
-                        "BearerAuth": []
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID (or file name for older attachments)",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "file",
+                        "description": "Attachment file",
+                        "name": "file",
+                        "in": "formData",
+                        "required": true
                     }
                 ],
-                "description": "Get current authenticated user's profile information",
-                "consumes": [
-                    "application/json"
-                ],
-                "produces": [
-                    "application/json"
-                ],
-                "tags": [
-                    "Auth"
-                ],
-                "summary": "Get user profile",
                 "responses": {
                     "200": {
-                        "description": "Profile retrieved",
+                        "description": "File replaced",
                         "schema": {
                             "allOf": [
                                 {
@@ -710,220 +874,166 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.UserProfileDTO"
+                                            "$ref": "#/definitions/model.AchievementAttachment"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Invalid file",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     },
                     "404": {
-                        "description": "User not found",
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/auth/refresh": {
-            "post": {
-                "description": "Generate new access token using refresh token",
-                "consumes": [
-                    "application/json"
-                ],
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Remove an attachment from a draft achievement and delete its file",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Auth"
+                    "Achievements"
                 ],
-                "summary": "Refresh access token",
+                "summary": "Delete achievement attachment",
                 "parameters": [
                     {
-                        "description": "Refresh token",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.RefreshTokenRequest"
-                        }
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment ID (or file name for older attachments)",
+                        "name": "attachmentId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Token refreshed",
+                        "description": "File deleted",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helper.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/model.LoginResponse"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helper.Response"
                         }
                     },
                     "400": {
-                        "description": "Invalid request",
+                        "description": "Achievement can no longer be changed",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     },
-                    "401": {
-                        "description": "Invalid or expired refresh token",
+                    "404": {
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/lecturers": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get paginated list of lecturers (Admin only)",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/achievements/{id}/attachments/{fileName}": {
+            "get": {
+                "description": "Stream an attachment file. Visible to the owner, team members, their advisors and Admin.",
                 "produces": [
-                    "application/json"
+                    "application/octet-stream"
                 ],
                 "tags": [
-                    "Lecturers"
+                    "Achievements"
                 ],
-                "summary": "List all lecturers",
+                "summary": "Download achievement attachment",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Search by name or lecturer ID",
-                        "name": "search",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Sort field",
-                        "name": "sort_by",
-                        "in": "query"
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
                         "type": "string",
-                        "description": "Sort order",
-                        "name": "sort_order",
-                        "in": "query"
+                        "description": "Attachment file name",
+                        "name": "fileName",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Lecturers retrieved",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helper.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/model.PaginatedLecturers"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "401": {
-                        "description": "Unauthorized",
+                        "description": "Attachment content",
                         "schema": {
-                            "$ref": "#/definitions/helper.ErrorResponse"
+                            "type": "file"
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Admin only",
+                    "404": {
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/lecturers/{id}/advisees": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get paginated list of students under a lecturer's guidance",
-                "consumes": [
-                    "application/json"
-                ],
+                ]
+            }
+        },
+        "/achievements/{id}/attachments/{fileName}/signed-url": {
+            "get": {
+                "description": "Create a short-lived HMAC-signed URL for embedding an attachment in reports",
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Lecturers"
+                    "Achievements"
                 ],
-                "summary": "Get lecturer's advisees",
+                "summary": "Get signed attachment URL",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Lecturer ID",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
+                        "type": "string",
+                        "description": "Attachment file name",
+                        "name": "fileName",
+                        "in": "path",
+                        "required": true
                     },
                     {
                         "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
+                        "description": "Validity in seconds (default 900, max 3600)",
+                        "name": "ttl",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Advisees retrieved",
+                        "description": "Signed URL created",
                         "schema": {
                             "allOf": [
                                 {
@@ -933,36 +1043,30 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.PaginatedStudents"
+                                            "$ref": "#/definitions/model.AttachmentURLResponse"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
-                        "schema": {
-                            "$ref": "#/definitions/helper.ErrorResponse"
-                        }
-                    },
-                    "403": {
-                        "description": "Forbidden - Not your advisees",
+                    "404": {
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/reports/statistics": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get global statistics for the dashboard (Admin only)",
+                ]
+            }
+        },
+        "/achievements/{id}/comments": {
+            "get": {
+                "description": "Get the discussion thread of an achievement (owner, team members, advisor, Admin)",
                 "consumes": [
                     "application/json"
                 ],
@@ -970,12 +1074,21 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Achievement Comments"
+                ],
+                "summary": "List achievement comments",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
                 ],
-                "summary": "Get dashboard statistics",
                 "responses": {
                     "200": {
-                        "description": "Statistics retrieved",
+                        "description": "Comments retrieved",
                         "schema": {
                             "allOf": [
                                 {
@@ -985,52 +1098,75 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.DashboardStatistics"
+                                            "$ref": "#/definitions/model.CommentThreads"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "404": {
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/reports/student/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get achievement report for a specific student with RBAC",
+                ]
+            },
+            "post": {
+                "description": "Post a comment or reply on an achievement in any status. Advisors and Admin may post a clarification request; a student reply to it marks the request as resolved.",
                 "consumes": [
-                    "application/json"
+                    "multipart/form-data"
                 ],
                 "produces": [
                     "application/json"
                 ],
                 "tags": [
-                    "Reports"
+                    "Achievement Comments"
                 ],
-                "summary": "Get student report",
+                "summary": "Post achievement comment",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student User ID",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment text",
+                        "name": "body",
+                        "in": "formData",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Parent comment ID (reply)",
+                        "name": "parent_id",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "string",
+                        "description": "comment | clarification_request",
+                        "name": "kind",
+                        "in": "formData"
+                    },
+                    {
+                        "type": "file",
+                        "description": "Optional attachments (PDF/Image, max 5MB each)",
+                        "name": "files",
+                        "in": "formData"
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Student report retrieved",
+                    "201": {
+                        "description": "Comment posted",
                         "schema": {
                             "allOf": [
                                 {
@@ -1040,36 +1176,84 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.StudentReportDTO"
+                                            "$ref": "#/definitions/model.AchievementComment"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "403": {
-                        "description": "Forbidden - Not authorized to view this report",
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/achievements/{id}/comments/{commentId}/attachments/{fileName}": {
+            "get": {
+                "description": "Stream a file attached to a comment (same visibility as the achievement)",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Achievement Comments"
+                ],
+                "summary": "Download comment attachment",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comment ID",
+                        "name": "commentId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Attachment file name",
+                        "name": "fileName",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Attachment content",
+                        "schema": {
+                            "type": "file"
+                        }
                     },
                     "404": {
-                        "description": "Student not found",
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/students": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get paginated list of students with optional filtering and sorting",
+                ]
+            }
+        },
+        "/achievements/{id}/history": {
+            "get": {
+                "description": "Get all achievements for a specific student",
                 "consumes": [
                     "application/json"
                 ],
@@ -1077,10 +1261,17 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Achievements"
                 ],
-                "summary": "List all students",
+                "summary": "Get student achievement history",
                 "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
                     {
                         "type": "integer",
                         "default": 1,
@@ -1097,30 +1288,14 @@ const docTemplate = `{
                     },
                     {
                         "type": "string",
-                        "description": "Search by name or student ID",
-                        "name": "search",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Sort field",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
-                        "type": "string",
-                        "description": "Sort order",
-                        "name": "sort_order",
+                        "description": "Filter by status",
+                        "name": "status",
                         "in": "query"
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Students retrieved",
+                        "description": "Student achievements",
                         "schema": {
                             "allOf": [
                                 {
@@ -1130,30 +1305,30 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.PaginatedStudents"
+                                            "$ref": "#/definitions/model.PaginatedAchievements"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "403": {
+                        "description": "Forbidden",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/students/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get detailed information about a specific student",
+                ]
+            }
+        },
+        "/achievements/{id}/links": {
+            "post": {
+                "description": "Attach a URL (news article, competition results page or DOI) as evidence to a draft achievement. The domain must pass the allow/deny policy; the page title and HTTP status are checked in the background.",
                 "consumes": [
                     "application/json"
                 ],
@@ -1161,21 +1336,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Achievements"
                 ],
-                "summary": "Get student by ID",
+                "summary": "Add evidence link",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "description": "Evidence link",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.AddEvidenceLinkRequest"
+                        }
                     }
                 ],
                 "responses": {
-                    "200": {
-                        "description": "Student details retrieved",
+                    "201": {
+                        "description": "Link added",
                         "schema": {
                             "allOf": [
                                 {
@@ -1185,30 +1369,30 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.StudentDetailDTO"
+                                            "$ref": "#/definitions/model.AchievementAttachment"
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "404": {
-                        "description": "Student not found",
+                    "400": {
+                        "description": "Invalid URL or domain not allowed",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/students/{id}/achievements": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get all achievements for a specific student",
+                ]
+            }
+        },
+        "/achievements/{id}/members": {
+            "post": {
+                "description": "Invite co-members (by NIM) to a draft team achievement (Owner only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1216,41 +1400,30 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Achievements"
+                    "Achievement Members"
                 ],
-                "summary": "Get student achievement history",
+                "summary": "Invite team members",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student User ID",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
-                    {
-                        "type": "string",
-                        "description": "Filter by status",
-                        "name": "status",
-                        "in": "query"
+                        "description": "Student numbers (NIM)",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.InviteMembersRequest"
+                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Student achievements",
+                        "description": "Members invited",
                         "schema": {
                             "allOf": [
                                 {
@@ -1260,30 +1433,33 @@ const docTemplate = `{
                                     "type": "object",
                                     "properties": {
                                         "data": {
-                                            "$ref": "#/definitions/model.PaginatedAchievements"
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.AchievementMember"
+                                            }
                                         }
                                     }
                                 }
                             ]
                         }
                     },
-                    "403": {
-                        "description": "Forbidden",
+                    "400": {
+                        "description": "Invalid request",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/students/{id}/advisor": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Assign or change academic advisor for a student",
+                ]
+            }
+        },
+        "/achievements/{id}/members/respond": {
+            "post": {
+                "description": "Confirm or decline participation in a team achievement (Invited student only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1291,57 +1467,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Students"
+                    "Achievement Members"
                 ],
-                "summary": "Update student advisor",
+                "summary": "Respond to team invitation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "Student ID",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "Advisor ID",
+                        "description": "Accept or decline",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateAdvisorRequest"
+                            "$ref": "#/definitions/model.RespondInvitationRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Advisor updated successfully",
+                        "description": "Invitation answered",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
-                    "400": {
-                        "description": "Invalid request",
-                        "schema": {
-                            "$ref": "#/definitions/helper.ErrorResponse"
-                        }
-                    },
                     "404": {
-                        "description": "Student or lecturer not found",
+                        "description": "Invitation not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/users": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get paginated list of users with optional filtering",
+                ]
+            }
+        },
+        "/achievements/{id}/members/{memberId}": {
+            "delete": {
+                "description": "Remove a co-member from a draft team achievement (Owner only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1349,86 +1519,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievement Members"
                 ],
-                "summary": "List all users",
+                "summary": "Remove team member",
                 "parameters": [
-                    {
-                        "type": "integer",
-                        "default": 1,
-                        "description": "Page number",
-                        "name": "page",
-                        "in": "query"
-                    },
-                    {
-                        "type": "integer",
-                        "default": 10,
-                        "description": "Items per page",
-                        "name": "limit",
-                        "in": "query"
-                    },
                     {
                         "type": "string",
-                        "description": "Search by name, email, or username",
-                        "name": "search",
-                        "in": "query"
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
                     },
                     {
-                        "enum": [
-                            "created_at",
-                            "full_name",
-                            "email"
-                        ],
-                        "type": "string",
-                        "description": "Sort by field",
-                        "name": "sort_by",
-                        "in": "query"
-                    },
-                    {
-                        "enum": [
-                            "asc",
-                            "desc"
-                        ],
                         "type": "string",
-                        "description": "Sort order",
-                        "name": "sort_order",
-                        "in": "query"
+                        "description": "Member ID",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Users retrieved",
+                        "description": "Member removed",
                         "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helper.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/model.PaginatedUsers"
-                                        }
-                                    }
-                                }
-                            ]
+                            "$ref": "#/definitions/helper.Response"
                         }
                     },
-                    "401": {
-                        "description": "Unauthorized",
+                    "400": {
+                        "description": "Can only change members of draft achievements",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            },
-            "post": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Create a new user with role-specific profile (Student or Lecturer)",
+                ]
+            }
+        },
+        "/achievements/{id}/members/{memberId}/reject": {
+            "post": {
+                "description": "Reject a co-member's participation with a note (Advisor of that member only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1436,62 +1569,58 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievement Members"
                 ],
-                "summary": "Create new user",
+                "summary": "Reject team member participation",
                 "parameters": [
                     {
-                        "description": "User creation data",
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Rejection reason",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.CreateUserRequest"
+                            "$ref": "#/definitions/model.RejectMemberRequest"
                         }
                     }
                 ],
                 "responses": {
-                    "201": {
-                        "description": "User created successfully",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helper.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/model.UserDetailDTO"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request or validation error",
+                    "200": {
+                        "description": "Participation rejected",
                         "schema": {
-                            "$ref": "#/definitions/helper.ErrorResponse"
+                            "$ref": "#/definitions/helper.Response"
                         }
                     },
-                    "409": {
-                        "description": "Username or email already exists",
+                    "403": {
+                        "description": "Not your advisee",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/users/{id}": {
-            "get": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Get detailed information about a specific user",
+                ]
+            }
+        },
+        "/achievements/{id}/members/{memberId}/verify": {
+            "post": {
+                "description": "Verify a co-member's participation (Advisor of that member only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1499,58 +1628,49 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievement Members"
                 ],
-                "summary": "Get user by ID",
+                "summary": "Verify team member participation",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Member ID",
+                        "name": "memberId",
+                        "in": "path",
+                        "required": true
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User details retrieved",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helper.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/model.UserDetailDTO"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid ID format",
+                        "description": "Participation verified",
                         "schema": {
-                            "$ref": "#/definitions/helper.ErrorResponse"
+                            "$ref": "#/definitions/helper.Response"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "403": {
+                        "description": "Not your advisee",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            },
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Update user information and role-specific profile",
+                ]
+            }
+        },
+        "/achievements/{id}/reject": {
+            "post": {
+                "description": "Reject achievement with reason (Advisor only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1558,67 +1678,51 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "Update user",
+                "summary": "Reject achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
                     },
                     {
-                        "description": "User update data",
+                        "description": "Rejection reason",
                         "name": "request",
                         "in": "body",
                         "required": true,
                         "schema": {
-                            "$ref": "#/definitions/model.UpdateUserRequest"
+                            "$ref": "#/definitions/model.RejectAchievementRequest"
                         }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "User updated successfully",
-                        "schema": {
-                            "allOf": [
-                                {
-                                    "$ref": "#/definitions/helper.Response"
-                                },
-                                {
-                                    "type": "object",
-                                    "properties": {
-                                        "data": {
-                                            "$ref": "#/definitions/model.UserDetailDTO"
-                                        }
-                                    }
-                                }
-                            ]
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
+                        "description": "Achievement rejected",
                         "schema": {
-                            "$ref": "#/definitions/helper.ErrorResponse"
+                            "$ref": "#/definitions/helper.Response"
                         }
                     },
-                    "404": {
-                        "description": "User not found",
+                    "403": {
+                        "description": "Not your advisee",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            },
-            "delete": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Soft delete a user and their associated profile",
+                ]
+            }
+        },
+        "/achievements/{id}/restore": {
+            "post": {
+                "description": "Move a soft-deleted achievement out of the trash back to draft (Admin only)",
                 "consumes": [
                     "application/json"
                 ],
@@ -1626,13 +1730,13 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievements"
                 ],
-                "summary": "Delete user",
+                "summary": "Restore deleted achievement",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
@@ -1640,34 +1744,28 @@ const docTemplate = `{
                 ],
                 "responses": {
                     "200": {
-                        "description": "User deleted successfully",
+                        "description": "Achievement restored",
                         "schema": {
                             "$ref": "#/definitions/helper.Response"
                         }
                     },
-                    "400": {
-                        "description": "Invalid ID",
-                        "schema": {
-                            "$ref": "#/definitions/helper.ErrorResponse"
-                        }
-                    },
                     "404": {
-                        "description": "User not found",
+                        "description": "Achievement not in trash",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
-            }
-        },
-        "/users/{id}/role": {
-            "put": {
+                },
                 "security": [
                     {
                         "BearerAuth": []
                     }
-                ],
-                "description": "Change user's role (requires profile recreation if role type changes)",
+                ]
+            }
+        },
+        "/achievements/{id}/revisions": {
+            "get": {
+                "description": "Get the immutable revision history of an achievement document",
                 "consumes": [
                     "application/json"
                 ],
@@ -1675,50 +1773,2367 @@ const docTemplate = `{
                     "application/json"
                 ],
                 "tags": [
-                    "Users"
+                    "Achievement Revisions"
                 ],
-                "summary": "Update user role",
+                "summary": "List achievement revisions",
                 "parameters": [
                     {
                         "type": "string",
-                        "description": "User ID (UUID)",
+                        "description": "Achievement ID",
                         "name": "id",
                         "in": "path",
                         "required": true
-                    },
-                    {
-                        "description": "New role ID",
-                        "name": "request",
-                        "in": "body",
-                        "required": true,
-                        "schema": {
-                            "$ref": "#/definitions/model.UpdateRoleRequest"
-                        }
                     }
                 ],
                 "responses": {
                     "200": {
-                        "description": "Role updated successfully",
-                        "schema": {
-                            "$ref": "#/definitions/helper.Response"
-                        }
-                    },
-                    "400": {
-                        "description": "Invalid request",
+                        "description": "Revisions retrieved",
                         "schema": {
-                            "$ref": "#/definitions/helper.ErrorResponse"
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.AchievementRevision"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
                         }
                     },
                     "404": {
-                        "description": "User or role not found",
+                        "description": "Not found",
                         "schema": {
                             "$ref": "#/definitions/helper.ErrorResponse"
                         }
                     }
-                }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
             }
-        }
-    },
+        },
+        "/achievements/{id}/revisions/diff": {
+            "get": {
+                "description": "Field-level diff between two revisions. Defaults to the latest revision against the one before it.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement Revisions"
+                ],
+                "summary": "Diff achievement revisions",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Base revision version",
+                        "name": "from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Target revision version",
+                        "name": "to",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Diff computed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.RevisionDiff"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Revision not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/achievements/{id}/submit": {
+            "post": {
+                "description": "Submit achievement for advisor verification",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Submit achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Achievement submitted",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Can only submit draft achievements",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/achievements/{id}/uploads": {
+            "post": {
+                "description": "Start a chunked upload for a large attachment. Size and type limits follow the achievement type policy. Send the file with PATCH requests carrying Upload-Offset and Upload-Checksum headers; unfinished sessions expire after 24 hours of inactivity.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement Uploads"
+                ],
+                "summary": "Start resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "File name, total size in bytes and optional SHA-256 (hex) of the whole file",
+                        "name": "body",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateUploadSessionRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "Upload session created",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.UploadSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/achievements/{id}/uploads/{uploadId}": {
+            "get": {
+                "description": "Return the number of bytes received so far (also in the Upload-Offset header) so an interrupted upload can resume",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement Uploads"
+                ],
+                "summary": "Get resumable upload status",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upload session ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload status",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.UploadSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Not found or expired",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Abort an upload session and delete the chunks received so far",
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement Uploads"
+                ],
+                "summary": "Cancel resumable upload",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upload session ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Upload cancelled",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Upload is being completed",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "patch": {
+                "description": "Append a chunk at Upload-Offset. The Upload-Checksum header (\"sha256 \u003cbase64\u003e\") must match the chunk. When the last chunk arrives the file is checked against the achievement type policy and attached to the achievement.",
+                "consumes": [
+                    "application/octet-stream"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievement Uploads"
+                ],
+                "summary": "Upload a chunk",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Upload session ID",
+                        "name": "uploadId",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Byte offset of this chunk",
+                        "name": "Upload-Offset",
+                        "in": "header",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "sha256 \u003cbase64 digest of the chunk\u003e",
+                        "name": "Upload-Checksum",
+                        "in": "header",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Chunk accepted",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.UploadSession"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Checksum mismatch or invalid file",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Offset mismatch or upload is being completed",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/achievements/{id}/verify": {
+            "post": {
+                "description": "Verify and approve achievement with points (Advisor only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Verify achievement",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Achievement ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Points to award",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.VerifyAchievementRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Achievement verified",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "403": {
+                        "description": "Not your advisee",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/login": {
+            "post": {
+                "description": "Authenticate user with username and password",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Login user",
+                "parameters": [
+                    {
+                        "description": "Login credentials",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.LoginRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Login successful",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request format",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid credentials",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/auth/logout": {
+            "post": {
+                "description": "Logout current user (placeholder for token invalidation)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Logout user",
+                "responses": {
+                    "200": {
+                        "description": "Logout successful",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/profile": {
+            "get": {
+                "description": "Get current authenticated user's profile information",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Get user profile",
+                "responses": {
+                    "200": {
+                        "description": "Profile retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.UserProfileDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/auth/refresh": {
+            "post": {
+                "description": "Generate new access token using refresh token",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Auth"
+                ],
+                "summary": "Refresh access token",
+                "parameters": [
+                    {
+                        "description": "Refresh token",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.RefreshTokenRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Token refreshed",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.LoginResponse"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Invalid or expired refresh token",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/files/{key}": {
+            "get": {
+                "description": "Stream a file using a short-lived signed URL (no login required)",
+                "produces": [
+                    "application/octet-stream"
+                ],
+                "tags": [
+                    "Files"
+                ],
+                "summary": "Download file via signed URL",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Storage key",
+                        "name": "key",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Expiry (unix seconds)",
+                        "name": "expires",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "HMAC signature",
+                        "name": "signature",
+                        "in": "query",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "File content",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "403": {
+                        "description": "Invalid or expired signature",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                }
+            }
+        },
+        "/lecturers": {
+            "get": {
+                "description": "Get paginated list of lecturers (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturers"
+                ],
+                "summary": "List all lecturers",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name or lecturer ID",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort_order",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Lecturers retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.PaginatedLecturers"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Admin only",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/lecturers/{id}/advisees": {
+            "get": {
+                "description": "Get paginated list of students under a lecturer's guidance",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Lecturers"
+                ],
+                "summary": "Get lecturer's advisees",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Lecturer ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque keyset cursor from next_cursor/prev_cursor; send empty to start cursor pagination (total, page and total_pages are not computed)",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Advisees retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.PaginatedStudents"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Not your advisees",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/reports/accreditation": {
+            "get": {
+                "description": "Count verified achievements of one program study per year (TS-n ... TS) and level (international, national, regional/local) for the academic (Tabel 8.b.1) and non-academic (Tabel 8.b.2) accreditation tables (Admin only). Team achievements are counted once. The year is taken from the event date in the achievement details, or the verification date when missing; the level from details level/tingkat. With format=xlsx the full pack is exported: summary, both tables in the standard format and the evidence list.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "text/csv",
+                    "application/pdf",
+                    "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get accreditation report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Program study",
+                        "name": "program_study",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First year (inclusive), default year_to - 2",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last year (TS, inclusive), default current year",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx",
+                            "pdf",
+                            "docx"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format; alternatively send an Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Accreditation report",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.AccreditationReport"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/reports/accreditation/evidence": {
+            "get": {
+                "description": "List the verified achievements behind the accreditation report, with participants and evidence (uploaded files and links), optionally narrowed to one table cell by category, level and year (Admin only).",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "text/csv",
+                    "application/pdf",
+                    "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get accreditation evidence",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Program study",
+                        "name": "program_study",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "description": "First year (inclusive), default year_to - 2",
+                        "name": "year_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Last year (TS, inclusive), default current year",
+                        "name": "year_to",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "academic",
+                            "non_academic"
+                        ],
+                        "type": "string",
+                        "description": "Accreditation table",
+                        "name": "category",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "international",
+                            "national",
+                            "regional",
+                            "unknown"
+                        ],
+                        "type": "string",
+                        "description": "Achievement level",
+                        "name": "level",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "description": "Only achievements obtained in this year",
+                        "name": "year",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx",
+                            "pdf",
+                            "docx"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format; alternatively send an Accept header",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Evidence list",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.AccreditationAchievement"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/reports/statistics": {
+            "get": {
+                "description": "Get global statistics for the dashboard (Admin only): totals, status breakdown, top students by verified points, breakdowns by achievement type, program study and academic year, and verification turnaround. Deleted achievements are excluded.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf",
+                    "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get dashboard statistics",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Reported on or after (YYYY-MM-DD)",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Reported on or before (YYYY-MM-DD)",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only students whose program study belongs to this faculty (must be mapped via /study-programs)",
+                        "name": "faculty",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Number of top students",
+                        "name": "top",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx",
+                            "pdf",
+                            "docx"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format; alternatively send an Accept header (text/csv, application/pdf or the XLSX/DOCX media type)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Statistics retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.DashboardStatistics"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid filter",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/reports/student/{id}": {
+            "get": {
+                "description": "Get achievement report for a specific student with RBAC",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf",
+                    "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get student report",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx",
+                            "pdf",
+                            "docx"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format; alternatively send an Accept header (text/csv, application/pdf or the XLSX/DOCX media type)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student report retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.StudentReportDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden - Not authorized to view this report",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/reports/timeseries": {
+            "get": {
+                "description": "Get achievement trends per time bucket (Admin only). Each metric is counted at its own timestamp: created (reported), submitted, verified/rejected (decision time) and points (awarded at verification). Empty buckets are returned with zero values.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf",
+                    "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+                ],
+                "tags": [
+                    "Reports"
+                ],
+                "summary": "Get achievement time series",
+                "parameters": [
+                    {
+                        "enum": [
+                            "day",
+                            "week",
+                            "month",
+                            "semester"
+                        ],
+                        "type": "string",
+                        "default": "month",
+                        "description": "Bucket size; semesters run August-January (Ganjil) and February-July (Genap)",
+                        "name": "bucket",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "default": "submitted,verified",
+                        "description": "Comma-separated metrics: created, submitted, verified, rejected, points",
+                        "name": "metrics",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Comma-separated dimensions (max 2): achievement_type, program_study, academic_year, faculty",
+                        "name": "group_by",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Start date (YYYY-MM-DD), default 12 months before date_to",
+                        "name": "date_from",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "End date inclusive (YYYY-MM-DD), default today",
+                        "name": "date_to",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only students whose program study belongs to this faculty (must be mapped via /study-programs)",
+                        "name": "faculty",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx",
+                            "pdf",
+                            "docx"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format; alternatively send an Accept header (text/csv, application/pdf or the XLSX/DOCX media type)",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Time series retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.TimeseriesResult"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/skpi/batch": {
+            "get": {
+                "description": "Generate the SKPI of every student in a cohort (academic year, optionally one program study) as a ZIP archive with one file per student plus daftar-skpi.csv listing each file (Admin only). The archive is streamed; students whose SKPI fails are marked in daftar-skpi.csv.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/zip"
+                ],
+                "tags": [
+                    "SKPI"
+                ],
+                "summary": "Generate SKPI for a graduating cohort",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Academic year (angkatan), e.g. 2021",
+                        "name": "academic_year",
+                        "in": "query",
+                        "required": true
+                    },
+                    {
+                        "type": "string",
+                        "description": "Only students of this program study",
+                        "name": "program_study",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "pdf",
+                            "docx"
+                        ],
+                        "type": "string",
+                        "default": "pdf",
+                        "description": "Document format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Template name from SKPI_CONFIG",
+                        "name": "template",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "ZIP archive",
+                        "schema": {
+                            "type": "file"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid parameter",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "No students in the cohort",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/skpi/students/{id}": {
+            "get": {
+                "description": "Generate the bilingual SKPI (Surat Keterangan Pendamping Ijazah / Diploma Supplement) of a student from their verified non-academic achievements, grouped by the template's category mapping (Admin only). format=json returns the document content for preview.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/pdf",
+                    "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+                    "application/json"
+                ],
+                "tags": [
+                    "SKPI"
+                ],
+                "summary": "Generate a student's SKPI",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "enum": [
+                            "pdf",
+                            "docx",
+                            "json"
+                        ],
+                        "type": "string",
+                        "default": "pdf",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Template name from SKPI_CONFIG; defaults to the configured default template",
+                        "name": "template",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "SKPI content (format=json)",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.SKPIDocument"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid format or template",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/students": {
+            "get": {
+                "description": "Get paginated list of students with optional filtering and sorting. With format=csv|xlsx|pdf|docx (or a matching Accept header) every matching student is exported; page, limit and cursor are ignored.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json",
+                    "text/csv",
+                    "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+                    "application/pdf",
+                    "application/vnd.openxmlformats-officedocument.wordprocessingml.document"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "List all students",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name or student ID",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Sort field",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque keyset cursor from next_cursor/prev_cursor; send empty to start cursor pagination (total, page and total_pages are not computed)",
+                        "name": "cursor",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "json",
+                            "csv",
+                            "xlsx",
+                            "pdf",
+                            "docx"
+                        ],
+                        "type": "string",
+                        "default": "json",
+                        "description": "Output format",
+                        "name": "format",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Students retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.PaginatedStudents"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/students/{id}": {
+            "get": {
+                "description": "Get detailed information about a specific student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Get student by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student details retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.StudentDetailDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "404": {
+                        "description": "Student not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/students/{id}/achievements": {
+            "get": {
+                "description": "Get all achievements for a specific student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Achievements"
+                ],
+                "summary": "Get student achievement history",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student User ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Filter by status",
+                        "name": "status",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Student achievements",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.PaginatedAchievements"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "403": {
+                        "description": "Forbidden",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/students/{id}/advisor": {
+            "put": {
+                "description": "Assign or change academic advisor for a student",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Students"
+                ],
+                "summary": "Update student advisor",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Student ID",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Advisor ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateAdvisorRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Advisor updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Student or lecturer not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/study-programs": {
+            "get": {
+                "description": "Get every study program with its faculty mapping and number of students (Admin only). Program studies of new or updated students are added automatically with an empty faculty.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Study Programs"
+                ],
+                "summary": "List study programs",
+                "responses": {
+                    "200": {
+                        "description": "Study programs retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "type": "array",
+                                            "items": {
+                                                "$ref": "#/definitions/model.StudyProgram"
+                                            }
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/study-programs/{name}": {
+            "put": {
+                "description": "Set the faculty of a study program, adding the program if it does not exist yet (Admin only). Used by the faculty filter and grouping in reports.",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Study Programs"
+                ],
+                "summary": "Map study program to faculty",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Study program name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "Faculty mapping",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateStudyProgramRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Study program updated",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.StudyProgram"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Remove a study program that no student uses anymore (Admin only)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Study Programs"
+                ],
+                "summary": "Delete study program",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "Study program name",
+                        "name": "name",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Study program deleted",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Study program still used by students",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "Not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users": {
+            "get": {
+                "description": "Get paginated list of users with optional filtering",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "List all users",
+                "parameters": [
+                    {
+                        "type": "integer",
+                        "default": 1,
+                        "description": "Page number",
+                        "name": "page",
+                        "in": "query"
+                    },
+                    {
+                        "type": "integer",
+                        "default": 10,
+                        "description": "Items per page",
+                        "name": "limit",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Search by name, email, or username",
+                        "name": "search",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "created_at",
+                            "full_name",
+                            "email"
+                        ],
+                        "type": "string",
+                        "description": "Sort by field",
+                        "name": "sort_by",
+                        "in": "query"
+                    },
+                    {
+                        "enum": [
+                            "asc",
+                            "desc"
+                        ],
+                        "type": "string",
+                        "description": "Sort order",
+                        "name": "sort_order",
+                        "in": "query"
+                    },
+                    {
+                        "type": "string",
+                        "description": "Opaque keyset cursor from next_cursor/prev_cursor; send empty to start cursor pagination (total, page and total_pages are not computed)",
+                        "name": "cursor",
+                        "in": "query"
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Users retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.PaginatedUsers"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "401": {
+                        "description": "Unauthorized",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "post": {
+                "description": "Create a new user with role-specific profile (Student or Lecturer)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Create new user",
+                "parameters": [
+                    {
+                        "description": "User creation data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.CreateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "201": {
+                        "description": "User created successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.UserDetailDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request or validation error",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "409": {
+                        "description": "Username or email already exists",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/{id}": {
+            "get": {
+                "description": "Get detailed information about a specific user",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Get user by ID",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User details retrieved",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.UserDetailDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID format",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "put": {
+                "description": "Update user information and role-specific profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "User update data",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateUserRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User updated successfully",
+                        "schema": {
+                            "allOf": [
+                                {
+                                    "$ref": "#/definitions/helper.Response"
+                                },
+                                {
+                                    "type": "object",
+                                    "properties": {
+                                        "data": {
+                                            "$ref": "#/definitions/model.UserDetailDTO"
+                                        }
+                                    }
+                                }
+                            ]
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            },
+            "delete": {
+                "description": "Soft delete a user and their associated profile",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Delete user",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "User deleted successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid ID",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        },
+        "/users/{id}/role": {
+            "put": {
+                "description": "Change user's role (requires profile recreation if role type changes)",
+                "consumes": [
+                    "application/json"
+                ],
+                "produces": [
+                    "application/json"
+                ],
+                "tags": [
+                    "Users"
+                ],
+                "summary": "Update user role",
+                "parameters": [
+                    {
+                        "type": "string",
+                        "description": "User ID (UUID)",
+                        "name": "id",
+                        "in": "path",
+                        "required": true
+                    },
+                    {
+                        "description": "New role ID",
+                        "name": "request",
+                        "in": "body",
+                        "required": true,
+                        "schema": {
+                            "$ref": "#/definitions/model.UpdateRoleRequest"
+                        }
+                    }
+                ],
+                "responses": {
+                    "200": {
+                        "description": "Role updated successfully",
+                        "schema": {
+                            "$ref": "#/definitions/helper.Response"
+                        }
+                    },
+                    "400": {
+                        "description": "Invalid request",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    },
+                    "404": {
+                        "description": "User or role not found",
+                        "schema": {
+                            "$ref": "#/definitions/helper.ErrorResponse"
+                        }
+                    }
+                },
+                "security": [
+                    {
+                        "BearerAuth": []
+                    }
+                ]
+            }
+        }
+    },
     "definitions": {
         "helper.ErrorResponse": {
             "type": "object",
@@ -1727,37 +4142,296 @@ const docTemplate = `{
                 "message": {
                     "type": "string"
                 },
-                "status": {
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "helper.Response": {
+            "type": "object",
+            "properties": {
+                "data": {},
+                "errors": {},
+                "message": {
+                    "type": "string"
+                },
+                "status": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AccreditationAchievement": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "achievement_type": {
+                    "type": "string"
+                },
+                "category": {
+                    "type": "string"
+                },
+                "event_date": {
+                    "type": "string"
+                },
+                "evidence": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AccreditationEvidence"
+                    }
+                },
+                "level": {
+                    "type": "string"
+                },
+                "level_text": {
+                    "description": "isian tingkat apa adanya",
+                    "type": "string"
+                },
+                "rank": {
+                    "type": "string"
+                },
+                "student_ids": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "student_names": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AccreditationEvidence": {
+            "type": "object",
+            "properties": {
+                "kind": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AccreditationLevelCount": {
+            "type": "object",
+            "properties": {
+                "international": {
+                    "type": "integer"
+                },
+                "national": {
+                    "type": "integer"
+                },
+                "regional": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unknown": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AccreditationReport": {
+            "type": "object",
+            "properties": {
+                "achievements": {
+                    "type": "integer"
+                },
+                "generated_at": {
+                    "type": "string"
+                },
+                "program_study": {
+                    "type": "string"
+                },
+                "summaries": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AccreditationSummary"
+                    }
+                },
+                "unclassified": {
+                    "description": "prestasi tanpa tingkat yang dikenali",
+                    "type": "integer"
+                },
+                "year_from": {
+                    "type": "integer"
+                },
+                "year_to": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AccreditationSummary": {
+            "type": "object",
+            "properties": {
+                "category": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "total": {
+                    "$ref": "#/definitions/model.AccreditationLevelCount"
+                },
+                "years": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AccreditationYear"
+                    }
+                }
+            }
+        },
+        "model.AccreditationYear": {
+            "type": "object",
+            "properties": {
+                "international": {
+                    "type": "integer"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "national": {
+                    "type": "integer"
+                },
+                "regional": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "unknown": {
+                    "type": "integer"
+                },
+                "year": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AchievementAttachment": {
+            "type": "object",
+            "properties": {
+                "content_hash": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "file_type": {
+                    "type": "string"
+                },
+                "file_url": {
+                    "type": "string"
+                },
+                "frozen_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "link_checked_at": {
+                    "type": "string"
+                },
+                "link_error": {
+                    "type": "string"
+                },
+                "link_status": {
+                    "type": "integer"
+                },
+                "link_title": {
+                    "type": "string"
+                },
+                "preview_url": {
+                    "type": "string"
+                },
+                "scan_error": {
+                    "type": "string"
+                },
+                "scan_signature": {
                     "type": "string"
-                }
-            }
-        },
-        "helper.Response": {
-            "type": "object",
-            "properties": {
-                "data": {},
-                "errors": {},
-                "message": {
+                },
+                "scan_status": {
                     "type": "string"
                 },
-                "status": {
+                "scanned_at": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "thumbnail_url": {
+                    "type": "string"
+                },
+                "uploaded_at": {
                     "type": "string"
                 }
             }
         },
-        "model.AchievementAttachment": {
+        "model.AchievementComment": {
             "type": "object",
             "properties": {
-                "file_name": {
+                "achievement_id": {
                     "type": "string"
                 },
-                "file_type": {
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementAttachment"
+                    }
+                },
+                "author_id": {
                     "type": "string"
                 },
-                "file_url": {
+                "author_name": {
                     "type": "string"
                 },
-                "uploaded_at": {
+                "author_role": {
+                    "type": "string"
+                },
+                "body": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "kind": {
+                    "type": "string"
+                },
+                "parent_id": {
+                    "type": "string"
+                },
+                "replies": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementComment"
+                    }
+                },
+                "resolved": {
+                    "type": "boolean"
+                },
+                "resolved_at": {
                     "type": "string"
                 }
             }
@@ -1784,9 +4458,33 @@ const docTemplate = `{
                     "type": "object",
                     "additionalProperties": true
                 },
+                "duplicate_warnings": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.DuplicateWarning"
+                    }
+                },
+                "evidence_digest": {
+                    "type": "string"
+                },
+                "evidence_frozen_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
+                "members": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementMember"
+                    }
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "points_mode": {
+                    "type": "string"
+                },
                 "rejection_note": {
                     "type": "string"
                 },
@@ -1822,6 +4520,9 @@ const docTemplate = `{
                 "created_at": {
                     "type": "string"
                 },
+                "deleted_at": {
+                    "type": "string"
+                },
                 "id": {
                     "type": "string"
                 },
@@ -1842,6 +4543,44 @@ const docTemplate = `{
                 }
             }
         },
+        "model.AchievementMember": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "advisor_id": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "invitation_status": {
+                    "type": "string"
+                },
+                "rejection_note": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "verification_status": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
+                },
+                "verified_by": {
+                    "type": "string"
+                }
+            }
+        },
         "model.AchievementReference": {
             "type": "object",
             "properties": {
@@ -1865,6 +4604,141 @@ const docTemplate = `{
                 }
             }
         },
+        "model.AchievementRevision": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "changed_by": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "event": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "snapshot": {
+                    "$ref": "#/definitions/model.RevisionSnapshot"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "version": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.AchievementType": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowed_file_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_file_size_mb": {
+                    "type": "integer"
+                },
+                "max_points": {
+                    "type": "integer"
+                },
+                "min_points": {
+                    "type": "integer"
+                },
+                "name_en": {
+                    "type": "string"
+                },
+                "name_id": {
+                    "type": "string"
+                },
+                "required_attachments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AddEvidenceLinkRequest": {
+            "type": "object",
+            "required": [
+                "url"
+            ],
+            "properties": {
+                "title": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.AttachmentURLResponse": {
+            "type": "object",
+            "properties": {
+                "expires_at": {
+                    "type": "string"
+                },
+                "url": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.BilingualText": {
+            "type": "object",
+            "properties": {
+                "en": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.CommentThreads": {
+            "type": "object",
+            "properties": {
+                "comments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementComment"
+                    }
+                },
+                "open_clarifications": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.CreateAchievementRequest": {
             "type": "object",
             "required": [
@@ -1882,6 +4756,13 @@ const docTemplate = `{
                     "type": "object",
                     "additionalProperties": true
                 },
+                "points_mode": {
+                    "type": "string",
+                    "enum": [
+                        "per_member",
+                        "split"
+                    ]
+                },
                 "tags": {
                     "type": "array",
                     "items": {
@@ -1893,6 +4774,70 @@ const docTemplate = `{
                 }
             }
         },
+        "model.CreateAchievementTypeRequest": {
+            "type": "object",
+            "required": [
+                "code",
+                "name_en",
+                "name_id"
+            ],
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowed_file_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "code": {
+                    "type": "string"
+                },
+                "description": {
+                    "type": "string"
+                },
+                "max_file_size_mb": {
+                    "type": "integer"
+                },
+                "max_points": {
+                    "type": "integer"
+                },
+                "min_points": {
+                    "type": "integer"
+                },
+                "name_en": {
+                    "type": "string"
+                },
+                "name_id": {
+                    "type": "string"
+                },
+                "required_attachments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
+        "model.CreateUploadSessionRequest": {
+            "type": "object",
+            "properties": {
+                "checksum": {
+                    "description": "SHA-256 (hex) seluruh file, opsional",
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "size": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.CreateUserRequest": {
             "type": "object",
             "required": [
@@ -1948,6 +4893,24 @@ const docTemplate = `{
                         "type": "integer"
                     }
                 },
+                "by_academic_year": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StatBreakdown"
+                    }
+                },
+                "by_program_study": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StatBreakdown"
+                    }
+                },
+                "by_type": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StatBreakdown"
+                    }
+                },
                 "top_students": {
                     "type": "array",
                     "items": {
@@ -1962,6 +4925,56 @@ const docTemplate = `{
                 },
                 "total_students": {
                     "type": "integer"
+                },
+                "verification_turnaround": {
+                    "$ref": "#/definitions/model.VerificationTurnaround"
+                }
+            }
+        },
+        "model.DuplicateWarning": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "detected_at": {
+                    "type": "string"
+                },
+                "reasons": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "score": {
+                    "type": "number"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "student_name": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.InviteMembersRequest": {
+            "type": "object",
+            "required": [
+                "student_ids"
+            ],
+            "properties": {
+                "student_ids": {
+                    "type": "array",
+                    "minItems": 1,
+                    "items": {
+                        "type": "string"
+                    }
                 }
             }
         },
@@ -2036,117 +5049,345 @@ const docTemplate = `{
                         "$ref": "#/definitions/model.AchievementListDTO"
                     }
                 },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedLecturers": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.LecturerListDTO"
+                    }
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedStudents": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.StudentListDTO"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
+                "page": {
+                    "type": "integer"
+                },
+                "page_size": {
+                    "type": "integer"
+                },
+                "prev_cursor": {
+                    "type": "string"
+                },
+                "total": {
+                    "type": "integer"
+                },
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.PaginatedUsers": {
+            "type": "object",
+            "properties": {
+                "data": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.UserListDTO"
+                    }
+                },
+                "next_cursor": {
+                    "type": "string"
+                },
                 "page": {
                     "type": "integer"
                 },
                 "page_size": {
                     "type": "integer"
                 },
+                "prev_cursor": {
+                    "type": "string"
+                },
                 "total": {
                     "type": "integer"
                 },
-                "total_pages": {
-                    "type": "integer"
+                "total_pages": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.RefreshTokenRequest": {
+            "type": "object",
+            "properties": {
+                "refreshToken": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.RejectAchievementRequest": {
+            "type": "object",
+            "required": [
+                "rejection_note"
+            ],
+            "properties": {
+                "rejection_note": {
+                    "type": "string",
+                    "minLength": 5
+                }
+            }
+        },
+        "model.RejectMemberRequest": {
+            "type": "object",
+            "required": [
+                "rejection_note"
+            ],
+            "properties": {
+                "rejection_note": {
+                    "type": "string",
+                    "minLength": 5
+                }
+            }
+        },
+        "model.RespondInvitationRequest": {
+            "type": "object",
+            "properties": {
+                "accept": {
+                    "type": "boolean"
+                }
+            }
+        },
+        "model.RevisionDiff": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "changes": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.RevisionFieldChange"
+                    }
+                },
+                "from": {
+                    "$ref": "#/definitions/model.AchievementRevision"
+                },
+                "to": {
+                    "$ref": "#/definitions/model.AchievementRevision"
+                }
+            }
+        },
+        "model.RevisionFieldChange": {
+            "type": "object",
+            "properties": {
+                "change": {
+                    "description": "added, removed, changed",
+                    "type": "string"
+                },
+                "field": {
+                    "type": "string"
+                },
+                "new": {},
+                "old": {}
+            }
+        },
+        "model.RevisionSnapshot": {
+            "type": "object",
+            "properties": {
+                "achievement_type": {
+                    "type": "string"
+                },
+                "attachments": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.AchievementAttachment"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "details": {
+                    "type": "object",
+                    "additionalProperties": true
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "points_mode": {
+                    "type": "string"
+                },
+                "tags": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "title": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.Role": {
+            "type": "object",
+            "properties": {
+                "id": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                }
+            }
+        },
+        "model.SKPIAchievement": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "achievement_type": {
+                    "type": "string"
+                },
+                "event_date": {
+                    "type": "string"
+                },
+                "level": {
+                    "type": "string"
+                },
+                "title": {
+                    "type": "string"
+                },
+                "title_en": {
+                    "type": "string"
+                },
+                "verified_at": {
+                    "type": "string"
                 }
             }
         },
-        "model.PaginatedLecturers": {
+        "model.SKPICategory": {
             "type": "object",
             "properties": {
-                "data": {
+                "key": {
+                    "type": "string"
+                },
+                "name": {
+                    "$ref": "#/definitions/model.BilingualText"
+                },
+                "types": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.LecturerListDTO"
+                        "type": "string"
                     }
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "page_size": {
-                    "type": "integer"
-                },
-                "total": {
-                    "type": "integer"
-                },
-                "total_pages": {
-                    "type": "integer"
                 }
             }
         },
-        "model.PaginatedStudents": {
+        "model.SKPIDocument": {
             "type": "object",
             "properties": {
-                "data": {
+                "issued_at": {
+                    "type": "string"
+                },
+                "number": {
+                    "type": "string"
+                },
+                "sections": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.StudentListDTO"
+                        "$ref": "#/definitions/model.SKPISection"
                     }
                 },
-                "page": {
-                    "type": "integer"
-                },
-                "page_size": {
-                    "type": "integer"
-                },
-                "total": {
-                    "type": "integer"
+                "student": {
+                    "$ref": "#/definitions/model.SKPIStudent"
                 },
-                "total_pages": {
-                    "type": "integer"
+                "template": {
+                    "type": "string"
                 }
             }
         },
-        "model.PaginatedUsers": {
+        "model.SKPISection": {
             "type": "object",
             "properties": {
-                "data": {
+                "category": {
+                    "$ref": "#/definitions/model.SKPICategory"
+                },
+                "items": {
                     "type": "array",
                     "items": {
-                        "$ref": "#/definitions/model.UserListDTO"
+                        "$ref": "#/definitions/model.SKPIAchievement"
                     }
-                },
-                "page": {
-                    "type": "integer"
-                },
-                "page_size": {
-                    "type": "integer"
-                },
-                "total": {
-                    "type": "integer"
-                },
-                "total_pages": {
-                    "type": "integer"
                 }
             }
         },
-        "model.RefreshTokenRequest": {
+        "model.SKPIStudent": {
             "type": "object",
             "properties": {
-                "refreshToken": {
+                "academic_year": {
+                    "type": "string"
+                },
+                "full_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "program_study": {
+                    "type": "string"
+                },
+                "student_id": {
+                    "type": "string"
+                },
+                "user_id": {
                     "type": "string"
                 }
             }
         },
-        "model.RejectAchievementRequest": {
-            "type": "object",
-            "required": [
-                "rejection_note"
-            ],
-            "properties": {
-                "rejection_note": {
-                    "type": "string",
-                    "minLength": 5
-                }
-            }
-        },
-        "model.Role": {
+        "model.StatBreakdown": {
             "type": "object",
             "properties": {
-                "id": {
-                    "type": "string"
+                "achievements": {
+                    "type": "integer"
                 },
-                "name": {
+                "key": {
                     "type": "string"
+                },
+                "points": {
+                    "type": "integer"
+                },
+                "verified": {
+                    "type": "integer"
                 }
             }
         },
@@ -2275,12 +5516,107 @@ const docTemplate = `{
                 }
             }
         },
+        "model.StudyProgram": {
+            "type": "object",
+            "properties": {
+                "faculty": {
+                    "type": "string"
+                },
+                "name": {
+                    "type": "string"
+                },
+                "students": {
+                    "type": "integer"
+                }
+            }
+        },
+        "model.TimeseriesPoint": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string"
+                },
+                "label": {
+                    "type": "string"
+                },
+                "values": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
+        "model.TimeseriesResult": {
+            "type": "object",
+            "properties": {
+                "bucket": {
+                    "type": "string"
+                },
+                "date_from": {
+                    "type": "string"
+                },
+                "date_to": {
+                    "type": "string"
+                },
+                "group_by": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "metrics": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "series": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TimeseriesSeries"
+                    }
+                }
+            }
+        },
+        "model.TimeseriesSeries": {
+            "type": "object",
+            "properties": {
+                "group": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "string"
+                    }
+                },
+                "points": {
+                    "type": "array",
+                    "items": {
+                        "$ref": "#/definitions/model.TimeseriesPoint"
+                    }
+                },
+                "totals": {
+                    "type": "object",
+                    "additionalProperties": {
+                        "type": "integer"
+                    }
+                }
+            }
+        },
         "model.TopStudent": {
             "type": "object",
             "properties": {
+                "achievements": {
+                    "type": "integer"
+                },
+                "id": {
+                    "type": "string"
+                },
                 "name": {
                     "type": "string"
                 },
+                "program_study": {
+                    "type": "string"
+                },
                 "student_id": {
                     "type": "string"
                 },
@@ -2302,6 +5638,13 @@ const docTemplate = `{
                     "type": "object",
                     "additionalProperties": true
                 },
+                "points_mode": {
+                    "type": "string",
+                    "enum": [
+                        "per_member",
+                        "split"
+                    ]
+                },
                 "tags": {
                     "type": "array",
                     "items": {
@@ -2313,6 +5656,50 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UpdateAchievementTypeRequest": {
+            "type": "object",
+            "properties": {
+                "aliases": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "allowed_file_types": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                },
+                "description": {
+                    "type": "string"
+                },
+                "is_active": {
+                    "type": "boolean"
+                },
+                "max_file_size_mb": {
+                    "type": "integer"
+                },
+                "max_points": {
+                    "type": "integer"
+                },
+                "min_points": {
+                    "type": "integer"
+                },
+                "name_en": {
+                    "type": "string"
+                },
+                "name_id": {
+                    "type": "string"
+                },
+                "required_attachments": {
+                    "type": "array",
+                    "items": {
+                        "type": "string"
+                    }
+                }
+            }
+        },
         "model.UpdateAdvisorRequest": {
             "type": "object",
             "properties": {
@@ -2332,6 +5719,15 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UpdateStudyProgramRequest": {
+            "type": "object",
+            "properties": {
+                "faculty": {
+                    "description": "kosong berarti belum dipetakan",
+                    "type": "string"
+                }
+            }
+        },
         "model.UpdateUserRequest": {
             "type": "object",
             "properties": {
@@ -2367,6 +5763,47 @@ const docTemplate = `{
                 }
             }
         },
+        "model.UploadSession": {
+            "type": "object",
+            "properties": {
+                "achievement_id": {
+                    "type": "string"
+                },
+                "attachment": {
+                    "$ref": "#/definitions/model.AchievementAttachment"
+                },
+                "checksum": {
+                    "type": "string"
+                },
+                "chunk_size": {
+                    "type": "integer"
+                },
+                "created_at": {
+                    "type": "string"
+                },
+                "expires_at": {
+                    "type": "string"
+                },
+                "file_name": {
+                    "type": "string"
+                },
+                "id": {
+                    "type": "string"
+                },
+                "offset": {
+                    "type": "integer"
+                },
+                "size": {
+                    "type": "integer"
+                },
+                "status": {
+                    "type": "string"
+                },
+                "updated_at": {
+                    "type": "string"
+                }
+            }
+        },
         "model.UserDetailDTO": {
             "type": "object",
             "properties": {
@@ -2483,6 +5920,23 @@ const docTemplate = `{
                 }
             }
         },
+        "model.VerificationTurnaround": {
+            "type": "object",
+            "properties": {
+                "average_hours": {
+                    "type": "number"
+                },
+                "median_hours": {
+                    "type": "number"
+                },
+                "p90_hours": {
+                    "type": "number"
+                },
+                "verified": {
+                    "type": "integer"
+                }
+            }
+        },
         "model.VerifyAchievementRequest": {
             "type": "object",
             "required": [
@@ -0,0 +1,52 @@
+package export
+
+import (
+	"encoding/csv"
+	"io"
+)
+
+// csvWriter menulis tabel berturut-turut dipisah satu baris kosong. Judul tabel (bila ada) ditulis sebagai
+// satu baris sebelum header; kop surat dan judul dokumen tidak ikut agar file tetap mudah diimpor.
+type csvWriter struct {
+	w      *csv.Writer
+	tables int
+}
+
+func newCSVWriter(w io.Writer) *csvWriter {
+	// BOM agar Excel membaca file sebagai UTF-8
+	w.Write([]byte("\xEF\xBB\xBF"))
+	return &csvWriter{w: csv.NewWriter(w)}
+}
+
+func (c *csvWriter) Table(title string, columns []Column) error {
+	if c.tables > 0 {
+		c.w.Write(nil)
+	}
+	c.tables++
+	if title != "" {
+		c.w.Write([]string{title})
+	}
+	header := make([]string, len(columns))
+	for i, col := range columns {
+		header[i] = col.Header
+	}
+	return c.w.Write(header)
+}
+
+func (c *csvWriter) Row(cells ...interface{}) error {
+	record := make([]string, len(cells))
+	for i, v := range cells {
+		record[i] = cellText(v)
+	}
+	return c.w.Write(record)
+}
+
+// Text menulis paragraf sebagai baris satu sel
+func (c *csvWriter) Text(style TextStyle, text string) error {
+	return c.w.Write([]string{text})
+}
+
+func (c *csvWriter) Close() error {
+	c.w.Flush()
+	return c.w.Error()
+}
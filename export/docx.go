@@ -0,0 +1,290 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+)
+
+// Ukuran dalam twip (1/20 point); A4 dengan margin setengah inci seperti PDF
+const (
+	docxA4Long    = 16838
+	docxA4Short   = 11906
+	docxMargin    = 720
+	docxEMU       = 12700 // EMU per point untuk gambar
+	docxNamespace = `xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships" xmlns:wp="http://schemas.openxmlformats.org/drawingml/2006/wordprocessingDrawing"`
+)
+
+const docxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:styles xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main">
+<w:docDefaults><w:rPrDefault><w:rPr><w:rFonts w:ascii="Arial" w:hAnsi="Arial" w:cs="Arial"/><w:sz w:val="19"/><w:szCs w:val="19"/><w:lang w:val="id-ID"/></w:rPr></w:rPrDefault><w:pPrDefault><w:pPr><w:spacing w:after="40" w:line="259" w:lineRule="auto"/></w:pPr></w:pPrDefault></w:docDefaults>
+<w:style w:type="paragraph" w:default="1" w:styleId="Normal"><w:name w:val="Normal"/><w:qFormat/></w:style>
+<w:style w:type="table" w:default="1" w:styleId="TableNormal"><w:name w:val="Normal Table"/><w:tblPr><w:tblInd w:w="0" w:type="dxa"/><w:tblCellMar><w:top w:w="40" w:type="dxa"/><w:left w:w="60" w:type="dxa"/><w:bottom w:w="40" w:type="dxa"/><w:right w:w="60" w:type="dxa"/></w:tblCellMar></w:tblPr></w:style>
+</w:styles>`
+
+// docxWriter menulis word/document.xml langsung ke zip; bagian lain (style, footer, relasi, logo) ditulis
+// saat Close karena zip hanya bisa menulis satu file dalam satu waktu.
+type docxWriter struct {
+	zw      *zip.Writer
+	body    *bufio.Writer
+	doc     Document
+	logo    *jpegMeta
+	width   int // lebar area teks dalam twip
+	columns []Column
+	widths  []int
+	rows    int
+	closed  bool
+}
+
+func newDOCXWriter(w io.Writer, doc Document) (*docxWriter, error) {
+	x := &docxWriter{zw: zip.NewWriter(w), doc: doc, width: docxA4Long - 2*docxMargin}
+	if doc.Portrait {
+		x.width = docxA4Short - 2*docxMargin
+	}
+	if len(doc.Letterhead.Logo) > 0 {
+		meta, err := jpegInfo(doc.Letterhead.Logo)
+		if err != nil {
+			return nil, err
+		}
+		x.logo = &meta
+	}
+
+	f, err := x.zw.Create("word/document.xml")
+	if err != nil {
+		return nil, err
+	}
+	x.body = bufio.NewWriter(f)
+	x.body.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" + `<w:document ` + docxNamespace + `><w:body>`)
+	x.heading()
+	return x, x.body.Flush()
+}
+
+// heading menulis kop surat, garis ganda, judul dan subjudul di awal dokumen
+func (x *docxWriter) heading() {
+	lh := x.doc.Letterhead
+	if x.logo != nil {
+		x.body.WriteString(`<w:p><w:pPr><w:jc w:val="center"/></w:pPr><w:r>` + x.logoDrawing() + `</w:r></w:p>`)
+	}
+	if lh.Institution != "" {
+		x.paragraph(TextStyle{Size: 16, Bold: true, Align: AlignCenter}, lh.Institution, "")
+	}
+	if lh.Unit != "" {
+		x.paragraph(TextStyle{Size: 12, Bold: true, Align: AlignCenter}, lh.Unit, "")
+	}
+	for _, line := range lh.Lines {
+		x.paragraph(TextStyle{Size: 9, Align: AlignCenter}, line, "")
+	}
+	if lh.Institution != "" || x.logo != nil {
+		x.body.WriteString(`<w:p><w:pPr><w:pBdr><w:bottom w:val="thinThickSmallGap" w:sz="12" w:space="1" w:color="000000"/></w:pBdr><w:spacing w:after="240"/></w:pPr></w:p>`)
+	}
+	if x.doc.Title != "" {
+		x.paragraph(TextStyle{Size: 13, Bold: true, Align: AlignCenter}, x.doc.Title, "")
+	}
+	for _, line := range x.doc.Subtitle {
+		x.paragraph(TextStyle{Size: 9, Align: AlignCenter}, line, "")
+	}
+	if x.doc.Title != "" || len(x.doc.Subtitle) > 0 {
+		x.body.WriteString(`<w:p/>`)
+	}
+}
+
+func (x *docxWriter) logoDrawing() string {
+	h := pdfLogoHeight * docxEMU
+	w := h * float64(x.logo.width) / float64(x.logo.height)
+	return fmt.Sprintf(`<w:drawing><wp:inline distT="0" distB="0" distL="0" distR="0"><wp:extent cx="%d" cy="%d"/><wp:docPr id="1" name="Logo"/>`+
+		`<a:graphic xmlns:a="http://schemas.openxmlformats.org/drawingml/2006/main"><a:graphicData uri="http://schemas.openxmlformats.org/drawingml/2006/picture">`+
+		`<pic:pic xmlns:pic="http://schemas.openxmlformats.org/drawingml/2006/picture"><pic:nvPicPr><pic:cNvPr id="1" name="logo.jpeg"/><pic:cNvPicPr/></pic:nvPicPr>`+
+		`<pic:blipFill><a:blip r:embed="rIdLogo"/><a:stretch><a:fillRect/></a:stretch></pic:blipFill>`+
+		`<pic:spPr><a:xfrm><a:off x="0" y="0"/><a:ext cx="%d" cy="%d"/></a:xfrm><a:prstGeom prst="rect"><a:avLst/></a:prstGeom></pic:spPr></pic:pic>`+
+		`</a:graphicData></a:graphic></wp:inline></w:drawing>`, int(w), int(h), int(w), int(h))
+}
+
+func (x *docxWriter) Table(title string, columns []Column) error {
+	x.endTable()
+	if title != "" {
+		x.paragraph(TextStyle{Size: 10, Bold: true}, title, `<w:keepNext/>`)
+	}
+
+	total := 0.0
+	for _, col := range columns {
+		total += columnWeight(col)
+	}
+	x.columns, x.widths, x.rows = columns, make([]int, len(columns)), 0
+	x.body.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="5000" w:type="pct"/><w:tblBorders>`)
+	for _, side := range []string{"top", "bottom", "insideH"} {
+		fmt.Fprintf(x.body, `<w:%s w:val="single" w:sz="4" w:space="0" w:color="BFBFBF"/>`, side)
+	}
+	x.body.WriteString(`</w:tblBorders><w:tblLayout w:type="fixed"/></w:tblPr><w:tblGrid>`)
+	for i, col := range columns {
+		x.widths[i] = int(float64(x.width) * columnWeight(col) / total)
+		fmt.Fprintf(x.body, `<w:gridCol w:w="%d"/>`, x.widths[i])
+	}
+	x.body.WriteString(`</w:tblGrid>`)
+
+	// Baris header diulang di setiap halaman
+	x.body.WriteString(`<w:tr><w:trPr><w:tblHeader/><w:cantSplit/></w:trPr>`)
+	for i, col := range columns {
+		x.cell(i, "D9D9D9", true, col.Header)
+	}
+	_, err := x.body.WriteString(`</w:tr>`)
+	return err
+}
+
+func (x *docxWriter) Row(cells ...interface{}) error {
+	if x.columns == nil {
+		columns := make([]Column, len(cells))
+		for i := range columns {
+			columns[i] = Column{Width: 1}
+		}
+		if err := x.Table("", columns); err != nil {
+			return err
+		}
+	}
+
+	fill := ""
+	if x.rows%2 == 1 {
+		fill = "F2F2F2"
+	}
+	x.body.WriteString(`<w:tr><w:trPr><w:cantSplit/></w:trPr>`)
+	for i := range x.columns {
+		var v interface{}
+		if i < len(cells) {
+			v = cells[i]
+		}
+		x.cell(i, fill, false, cellText(v))
+	}
+	x.rows++
+	_, err := x.body.WriteString(`</w:tr>`)
+	return err
+}
+
+func (x *docxWriter) cell(i int, fill string, bold bool, text string) {
+	fmt.Fprintf(x.body, `<w:tc><w:tcPr><w:tcW w:w="%d" w:type="dxa"/>`, x.widths[i])
+	if fill != "" {
+		fmt.Fprintf(x.body, `<w:shd w:val="clear" w:color="auto" w:fill="%s"/>`, fill)
+	}
+	x.body.WriteString(`</w:tcPr>`)
+	x.paragraph(TextStyle{Size: pdfFontSize, Bold: bold, Align: x.columns[i].Align}, text, `<w:spacing w:after="0"/>`)
+	x.body.WriteString(`</w:tc>`)
+}
+
+// Text menulis paragraf; teks kosong menjadi paragraf kosong. Tabel sebelumnya berakhir.
+func (x *docxWriter) Text(style TextStyle, text string) error {
+	x.endTable()
+	if style.Size == 0 {
+		style.Size = pdfTextSize
+	}
+	x.paragraph(style, text, "")
+	return x.body.Flush()
+}
+
+// paragraph menulis satu w:p; baris baru di teks menjadi w:br. pPr tambahan disisipkan apa adanya.
+func (x *docxWriter) paragraph(style TextStyle, text, pPr string) {
+	x.body.WriteString(`<w:p><w:pPr>` + pPr)
+	switch style.Align {
+	case AlignRight:
+		x.body.WriteString(`<w:jc w:val="right"/>`)
+	case AlignCenter:
+		x.body.WriteString(`<w:jc w:val="center"/>`)
+	}
+	x.body.WriteString(`</w:pPr>`)
+	if text == "" {
+		x.body.WriteString(`</w:p>`)
+		return
+	}
+
+	x.body.WriteString(`<w:r><w:rPr>`)
+	if style.Bold {
+		x.body.WriteString(`<w:b/>`)
+	}
+	if style.Italic {
+		x.body.WriteString(`<w:i/>`)
+	}
+	if style.Size > 0 {
+		// w:sz dalam setengah point
+		fmt.Fprintf(x.body, `<w:sz w:val="%d"/><w:szCs w:val="%d"/>`, int(style.Size*2), int(style.Size*2))
+	}
+	x.body.WriteString(`</w:rPr>`)
+	for i, line := range strings.Split(text, "\n") {
+		if i > 0 {
+			x.body.WriteString(`<w:br/>`)
+		}
+		fmt.Fprintf(x.body, `<w:t xml:space="preserve">%s</w:t>`, xmlText(line))
+	}
+	x.body.WriteString(`</w:r></w:p>`)
+}
+
+// endTable menutup tabel aktif. Paragraf kosong sesudahnya mencegah dua tabel berurutan digabung Word.
+func (x *docxWriter) endTable() {
+	if x.columns == nil {
+		return
+	}
+	x.body.WriteString(`</w:tbl><w:p/>`)
+	x.columns, x.widths = nil, nil
+}
+
+func (x *docxWriter) Close() error {
+	if x.closed {
+		return nil
+	}
+	x.closed = true
+	x.endTable()
+
+	pageW, pageH, orient := docxA4Long, docxA4Short, ` w:orient="landscape"`
+	if x.doc.Portrait {
+		pageW, pageH, orient = docxA4Short, docxA4Long, ""
+	}
+	fmt.Fprintf(x.body, `<w:sectPr><w:footerReference w:type="default" r:id="rIdFooter"/><w:pgSz w:w="%d" w:h="%d"%s/>`+
+		`<w:pgMar w:top="%d" w:right="%d" w:bottom="%d" w:left="%d" w:header="360" w:footer="360" w:gutter="0"/></w:sectPr></w:body></w:document>`,
+		pageW, pageH, orient, docxMargin, docxMargin, docxMargin, docxMargin)
+	if err := x.body.Flush(); err != nil {
+		return err
+	}
+
+	printed := "Dicetak " + x.doc.GeneratedAt.Format("02-01-2006 15:04")
+	if x.doc.Letterhead.Institution != "" {
+		printed += " · " + x.doc.Letterhead.Institution
+	}
+	footer := fmt.Sprintf(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<w:ftr `+docxNamespace+`><w:p><w:pPr><w:tabs><w:tab w:val="right" w:pos="%d"/></w:tabs><w:spacing w:after="0"/></w:pPr>`+
+		`<w:r><w:rPr><w:sz w:val="15"/></w:rPr><w:t xml:space="preserve">%s</w:t><w:tab/><w:t xml:space="preserve">Halaman </w:t></w:r>`+
+		`<w:r><w:rPr><w:sz w:val="15"/></w:rPr><w:fldChar w:fldCharType="begin"/></w:r><w:r><w:rPr><w:sz w:val="15"/></w:rPr><w:instrText xml:space="preserve"> PAGE </w:instrText></w:r>`+
+		`<w:r><w:rPr><w:sz w:val="15"/></w:rPr><w:fldChar w:fldCharType="separate"/></w:r><w:r><w:rPr><w:sz w:val="15"/></w:rPr><w:t>1</w:t></w:r>`+
+		`<w:r><w:rPr><w:sz w:val="15"/></w:rPr><w:fldChar w:fldCharType="end"/></w:r></w:p></w:ftr>`, x.width, xmlText(printed))
+
+	logoRel, logoType := "", ""
+	if x.logo != nil {
+		logoRel = `<Relationship Id="rIdLogo" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/image" Target="media/logo.jpeg"/>`
+		logoType = `<Default Extension="jpeg" ContentType="image/jpeg"/>`
+	}
+
+	files := []struct{ name, body string }{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/>` + logoType +
+			`<Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/><Override PartName="/word/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.styles+xml"/><Override PartName="/word/footer1.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.footer+xml"/><Override PartName="/docProps/core.xml" ContentType="application/vnd.openxmlformats-package.core-properties+xml"/></Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/><Relationship Id="rId2" Type="http://schemas.openxmlformats.org/package/2006/relationships/metadata/core-properties" Target="docProps/core.xml"/></Relationships>`},
+		{"docProps/core.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<cp:coreProperties xmlns:cp="http://schemas.openxmlformats.org/package/2006/metadata/core-properties" xmlns:dc="http://purl.org/dc/elements/1.1/" xmlns:dcterms="http://purl.org/dc/terms/" xmlns:xsi="http://www.w3.org/2001/XMLSchema-instance"><dc:title>` + xmlText(x.doc.Title) +
+			`</dc:title><dc:creator>sistem-pelaporan-prestasi-mahasiswa</dc:creator><dcterms:created xsi:type="dcterms:W3CDTF">` + x.doc.GeneratedAt.UTC().Format("2006-01-02T15:04:05Z") + `</dcterms:created></cp:coreProperties>`},
+		{"word/_rels/document.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rIdStyles" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/><Relationship Id="rIdFooter" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/footer" Target="footer1.xml"/>` + logoRel + `</Relationships>`},
+		{"word/styles.xml", docxStyles},
+		{"word/footer1.xml", footer},
+	}
+	if x.logo != nil {
+		files = append(files, struct{ name, body string }{"word/media/logo.jpeg", string(x.doc.Letterhead.Logo)})
+	}
+	for _, file := range files {
+		f, err := x.zw.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, file.body); err != nil {
+			return err
+		}
+	}
+	return x.zw.Close()
+}
@@ -0,0 +1,208 @@
+package export
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Format adalah bentuk keluaran laporan
+type Format string
+
+const (
+	FormatJSON Format = "json"
+	FormatCSV  Format = "csv"
+	FormatXLSX Format = "xlsx"
+	FormatPDF  Format = "pdf"
+	FormatDOCX Format = "docx"
+)
+
+// ErrUnsupportedFormat dikembalikan untuk nilai format= yang tidak dikenal
+var ErrUnsupportedFormat = errors.New("export: format tidak didukung")
+
+var contentTypes = map[Format]string{
+	FormatJSON: "application/json",
+	FormatCSV:  "text/csv; charset=utf-8",
+	FormatXLSX: "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet",
+	FormatPDF:  "application/pdf",
+	FormatDOCX: "application/vnd.openxmlformats-officedocument.wordprocessingml.document",
+}
+
+// Negotiate memilih format dari parameter format= atau, bila kosong, dari header Accept. Tanpa keduanya
+// hasilnya JSON sehingga klien lama tidak berubah.
+func Negotiate(format, accept string) (Format, error) {
+	if format = strings.ToLower(strings.TrimSpace(format)); format != "" {
+		f := Format(format)
+		if _, ok := contentTypes[f]; !ok {
+			return "", ErrUnsupportedFormat
+		}
+		return f, nil
+	}
+
+	for _, part := range strings.Split(accept, ",") {
+		mediaType, _, err := mime.ParseMediaType(strings.TrimSpace(part))
+		if err != nil {
+			continue
+		}
+		switch mediaType {
+		case "text/csv":
+			return FormatCSV, nil
+		case contentTypes[FormatXLSX]:
+			return FormatXLSX, nil
+		case "application/pdf":
+			return FormatPDF, nil
+		case contentTypes[FormatDOCX]:
+			return FormatDOCX, nil
+		case "application/json", "*/*":
+			return FormatJSON, nil
+		}
+	}
+	return FormatJSON, nil
+}
+
+func (f Format) ContentType() string {
+	return contentTypes[f]
+}
+
+// Filename menyusun nama file unduhan, misalnya "prestasi-20240131.xlsx"
+func (f Format) Filename(base string, at time.Time) string {
+	return fmt.Sprintf("%s-%s.%s", base, at.Format("20060102"), f)
+}
+
+// Align adalah perataan teks kolom dan paragraf pada PDF dan DOCX
+type Align int
+
+const (
+	AlignLeft Align = iota
+	AlignRight
+	AlignCenter
+)
+
+// Column adalah satu kolom tabel. Width adalah perkiraan lebar dalam karakter: dipakai apa adanya di
+// XLSX dan sebagai proporsi lebar di PDF.
+type Column struct {
+	Header string
+	Width  float64
+	Align  Align
+}
+
+// TextStyle adalah gaya paragraf yang ditulis dengan Writer.Text. Size dalam point; 0 berarti ukuran bawaan.
+type TextStyle struct {
+	Size   float64
+	Bold   bool
+	Italic bool
+	Align  Align
+}
+
+// Document adalah metadata yang ditulis di atas laporan
+type Document struct {
+	Title       string
+	Subtitle    []string // misalnya filter yang dipakai
+	Letterhead  Letterhead
+	GeneratedAt time.Time
+	Portrait    bool // PDF dan DOCX memakai halaman potret; bawaannya landscape untuk tabel lebar
+}
+
+// Writer menulis laporan secara bertahap: satu atau lebih tabel, masing-masing diikuti baris-barisnya,
+// diselingi paragraf teks bila perlu. Baris langsung diteruskan ke io.Writer sehingga ekspor besar tidak
+// ditampung di memori. Sel boleh berupa string, bilangan bulat, float64, time.Time, *time.Time atau nil.
+type Writer interface {
+	Table(title string, columns []Column) error
+	Row(cells ...interface{}) error
+	Text(style TextStyle, text string) error
+	Close() error
+}
+
+// New membuat Writer untuk format CSV, XLSX, PDF atau DOCX
+func New(f Format, w io.Writer, doc Document) (Writer, error) {
+	if doc.GeneratedAt.IsZero() {
+		doc.GeneratedAt = time.Now()
+	}
+	switch f {
+	case FormatCSV:
+		return newCSVWriter(w), nil
+	case FormatXLSX:
+		return newXLSXWriter(w, doc), nil
+	case FormatPDF:
+		return newPDFWriter(w, doc)
+	case FormatDOCX:
+		return newDOCXWriter(w, doc)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+// Letterhead adalah kop surat pada halaman pertama PDF dan DOCX
+type Letterhead struct {
+	Institution string
+	Unit        string
+	Lines       []string // alamat, kontak
+	Logo        []byte   // JPEG
+}
+
+// LetterheadFromEnv membaca REPORT_INSTITUTION, REPORT_UNIT, REPORT_ADDRESS, REPORT_CONTACT dan
+// REPORT_LOGO (path file JPEG)
+func LetterheadFromEnv() (Letterhead, error) {
+	lh := Letterhead{
+		Institution: os.Getenv("REPORT_INSTITUTION"),
+		Unit:        os.Getenv("REPORT_UNIT"),
+	}
+	if lh.Institution == "" {
+		lh.Institution = "Sistem Pelaporan Prestasi Mahasiswa"
+	}
+	for _, key := range []string{"REPORT_ADDRESS", "REPORT_CONTACT"} {
+		if v := strings.TrimSpace(os.Getenv(key)); v != "" {
+			lh.Lines = append(lh.Lines, v)
+		}
+	}
+
+	if path := os.Getenv("REPORT_LOGO"); path != "" {
+		logo, err := os.ReadFile(path)
+		if err != nil {
+			return lh, fmt.Errorf("export: gagal membaca logo kop surat: %w", err)
+		}
+		if _, err := jpegInfo(logo); err != nil {
+			return lh, fmt.Errorf("export: logo kop surat harus JPEG: %w", err)
+		}
+		lh.Logo = logo
+	}
+	return lh, nil
+}
+
+// cellText adalah representasi teks sel untuk CSV dan PDF
+func cellText(v interface{}) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int:
+		return strconv.Itoa(v)
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case time.Time:
+		return formatTime(v)
+	case *time.Time:
+		if v == nil {
+			return ""
+		}
+		return formatTime(*v)
+	}
+	return fmt.Sprint(v)
+}
+
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	if h, m, s := t.Clock(); h == 0 && m == 0 && s == 0 {
+		return t.Format("2006-01-02")
+	}
+	return t.Format("2006-01-02 15:04")
+}
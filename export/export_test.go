@@ -0,0 +1,337 @@
+package export
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"io"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+var testColumns = []Column{
+	{Header: "NIM", Width: 12},
+	{Header: "Judul", Width: 40},
+	{Header: "Poin", Width: 6, Align: AlignRight},
+	{Header: "Tanggal", Width: 12},
+}
+
+func writeTestReport(t *testing.T, f Format, doc Document, rows int) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	w, err := New(f, &buf, doc)
+	if err != nil {
+		t.Fatalf("New(%s): %v", f, err)
+	}
+	if err := w.Table("Daftar Prestasi", testColumns); err != nil {
+		t.Fatalf("Table: %v", err)
+	}
+	verified := time.Date(2024, 3, 5, 14, 30, 0, 0, time.UTC)
+	for i := 0; i < rows; i++ {
+		if err := w.Row(fmt.Sprintf("2100%04d", i), "Juara 1 Lomba <Robotik> & \"Inovasi\" Nasional", i*10, &verified); err != nil {
+			t.Fatalf("Row: %v", err)
+		}
+	}
+	if err := w.Table("Ringkasan", []Column{{Header: "Status"}, {Header: "Jumlah", Align: AlignRight}}); err != nil {
+		t.Fatalf("Table: %v", err)
+	}
+	w.Row("verified", rows)
+	if err := w.Close(); err != nil {
+		t.Fatalf("Close: %v", err)
+	}
+	return buf.Bytes()
+}
+
+func TestNegotiate(t *testing.T) {
+	cases := []struct {
+		format, accept string
+		want           Format
+		wantErr        bool
+	}{
+		{"", "", FormatJSON, false},
+		{"CSV", "application/json", FormatCSV, false},
+		{"pdf", "", FormatPDF, false},
+		{"docx", "", FormatDOCX, false},
+		{"odt", "", "", true},
+		{"", "text/csv", FormatCSV, false},
+		{"", "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet", FormatXLSX, false},
+		{"", "text/html, application/pdf;q=0.9", FormatPDF, false},
+		{"", "application/vnd.openxmlformats-officedocument.wordprocessingml.document", FormatDOCX, false},
+		{"", "application/json, text/plain, */*", FormatJSON, false},
+	}
+	for _, tc := range cases {
+		got, err := Negotiate(tc.format, tc.accept)
+		if (err != nil) != tc.wantErr || got != tc.want {
+			t.Errorf("Negotiate(%q, %q) = %q, %v; want %q", tc.format, tc.accept, got, err, tc.want)
+		}
+	}
+}
+
+func TestCSV(t *testing.T) {
+	out := string(writeTestReport(t, FormatCSV, Document{Title: "Laporan"}, 2))
+	if !strings.HasPrefix(out, "\xEF\xBB\xBF") {
+		t.Error("Expected UTF-8 BOM")
+	}
+	want := "Daftar Prestasi\nNIM,Judul,Poin,Tanggal\n" +
+		"21000000,\"Juara 1 Lomba <Robotik> & \"\"Inovasi\"\" Nasional\",0,2024-03-05 14:30\n" +
+		"21000001,\"Juara 1 Lomba <Robotik> & \"\"Inovasi\"\" Nasional\",10,2024-03-05 14:30\n" +
+		"\nRingkasan\nStatus,Jumlah\nverified,2\n"
+	if got := strings.TrimPrefix(out, "\xEF\xBB\xBF"); got != want {
+		t.Errorf("Unexpected CSV:\n%s", got)
+	}
+}
+
+func TestXLSX(t *testing.T) {
+	data := writeTestReport(t, FormatXLSX, Document{Title: "Laporan", Subtitle: []string{"Periode 2024"}}, 3)
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Not a zip file: %v", err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(body)
+
+		// Setiap bagian harus XML yang valid
+		dec := xml.NewDecoder(bytes.NewReader(body))
+		for {
+			if _, err := dec.Token(); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatalf("%s is not valid XML: %v", f.Name, err)
+			}
+		}
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "xl/workbook.xml", "xl/_rels/workbook.xml.rels", "xl/styles.xml", "xl/worksheets/sheet1.xml", "xl/worksheets/sheet2.xml"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Missing part %s", name)
+		}
+	}
+	if !strings.Contains(files["xl/workbook.xml"], `<sheet name="Daftar Prestasi" sheetId="1" r:id="rId1"/>`) {
+		t.Errorf("Unexpected workbook: %s", files["xl/workbook.xml"])
+	}
+
+	sheet := files["xl/worksheets/sheet1.xml"]
+	// Judul, subjudul, baris kosong, judul tabel, header: baris data pertama ada di baris 6
+	for _, want := range []string{
+		`<pane ySplit="5" topLeftCell="A6"`,
+		`<c r="B6" s="0" t="inlineStr"><is><t xml:space="preserve">Juara 1 Lomba &lt;Robotik&gt; &amp; &quot;Inovasi&quot; Nasional</t></is></c>`,
+		`<c r="C7" s="0"><v>10</v></c>`,
+		`<c r="D6" s="3"><v>45356.604166666664</v></c>`,
+	} {
+		if !strings.Contains(sheet, want) {
+			t.Errorf("Sheet is missing %s", want)
+		}
+	}
+}
+
+func TestPDF(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	var logo bytes.Buffer
+	jpeg.Encode(&logo, img, nil)
+
+	doc := Document{
+		Title:      "Laporan Prestasi",
+		Subtitle:   []string{"Periode 2024"},
+		Letterhead: Letterhead{Institution: "Universitas Contoh", Unit: "Direktorat Kemahasiswaan", Lines: []string{"Jl. Kampus No. 1"}, Logo: logo.Bytes()},
+	}
+	data := writeTestReport(t, FormatPDF, doc, 120)
+
+	if !bytes.HasPrefix(data, []byte("%PDF-1.4")) || !bytes.HasSuffix(data, []byte("%%EOF\n")) {
+		t.Fatal("Missing PDF header or trailer")
+	}
+
+	// Setiap entri xref harus menunjuk ke awal objeknya
+	m := regexp.MustCompile(`startxref\n(\d+)\n`).FindSubmatch(data)
+	if m == nil {
+		t.Fatal("Missing startxref")
+	}
+	xref, _ := strconv.Atoi(string(m[1]))
+	lines := strings.Split(string(data[xref:]), "\n")
+	count, _ := strconv.Atoi(strings.Fields(lines[1])[1])
+	for num := 1; num < count; num++ {
+		offset, _ := strconv.Atoi(lines[2+num][:10])
+		if !bytes.HasPrefix(data[offset:], []byte(fmt.Sprintf("%d 0 obj", num))) {
+			t.Errorf("xref entry %d does not point at its object", num)
+		}
+	}
+
+	pages := regexp.MustCompile(`/Type /Pages /Kids \[[^\]]*\] /Count (\d+)`).FindSubmatch(data)
+	if pages == nil {
+		t.Fatal("Missing page tree")
+	}
+	if n, _ := strconv.Atoi(string(pages[1])); n < 2 {
+		t.Errorf("Expected 120 rows to span several pages, got %d", n)
+	}
+	if !bytes.Contains(data, []byte("/Subtype /Image /Width 40 /Height 20 /ColorSpace /DeviceRGB")) {
+		t.Error("Expected the letterhead logo to be embedded")
+	}
+}
+
+func TestDOCX(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 40, 20))
+	var logo bytes.Buffer
+	jpeg.Encode(&logo, img, nil)
+
+	doc := Document{
+		Title:      "Laporan Prestasi",
+		Letterhead: Letterhead{Institution: "Universitas Contoh", Logo: logo.Bytes()},
+	}
+	data := writeTestReport(t, FormatDOCX, doc, 3)
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("Not a zip file: %v", err)
+	}
+	files := map[string]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		body, _ := io.ReadAll(rc)
+		rc.Close()
+		files[f.Name] = string(body)
+		if strings.HasSuffix(f.Name, ".xml") || strings.HasSuffix(f.Name, ".rels") {
+			dec := xml.NewDecoder(bytes.NewReader(body))
+			for {
+				if _, err := dec.Token(); err == io.EOF {
+					break
+				} else if err != nil {
+					t.Fatalf("%s is not valid XML: %v", f.Name, err)
+				}
+			}
+		}
+	}
+
+	for _, name := range []string{"[Content_Types].xml", "_rels/.rels", "word/document.xml", "word/_rels/document.xml.rels", "word/styles.xml", "word/footer1.xml", "word/media/logo.jpeg"} {
+		if _, ok := files[name]; !ok {
+			t.Errorf("Missing part %s", name)
+		}
+	}
+	body := files["word/document.xml"]
+	for _, want := range []string{
+		`<w:t xml:space="preserve">Universitas Contoh</w:t>`,
+		`<a:blip r:embed="rIdLogo"/>`,
+		`<w:tblHeader/>`,
+		`Juara 1 Lomba &lt;Robotik&gt; &amp; &quot;Inovasi&quot; Nasional`,
+		`w:orient="landscape"`,
+	} {
+		if !strings.Contains(body, want) {
+			t.Errorf("Document is missing %s", want)
+		}
+	}
+	if n := strings.Count(body, "<w:tbl>"); n != 2 {
+		t.Errorf("Expected 2 tables, got %d", n)
+	}
+	if !strings.Contains(files["word/footer1.xml"], " PAGE ") {
+		t.Error("Expected a page number field in the footer")
+	}
+}
+
+func TestText(t *testing.T) {
+	for _, f := range []Format{FormatPDF, FormatDOCX} {
+		var buf bytes.Buffer
+		w, err := New(f, &buf, Document{Title: "Surat Keterangan", Portrait: true})
+		if err != nil {
+			t.Fatalf("New(%s): %v", f, err)
+		}
+		w.Text(TextStyle{Size: 12, Bold: true, Align: AlignCenter}, "Bagian A")
+		w.Text(TextStyle{Italic: true}, "Section A")
+		w.Table("", []Column{{Header: "No"}, {Header: "Kegiatan"}})
+		w.Row(1, "Juara 1")
+		for i := 0; i < 80; i++ {
+			w.Text(TextStyle{}, strings.Repeat("Paragraf panjang yang harus dipecah menjadi beberapa baris. ", 3))
+		}
+		if err := w.Close(); err != nil {
+			t.Fatalf("Close(%s): %v", f, err)
+		}
+
+		switch f {
+		case FormatPDF:
+			data := buf.Bytes()
+			if !bytes.Contains(data, []byte("/MediaBox [0 0 595.28 841.89]")) {
+				t.Error("Expected portrait pages")
+			}
+			if !bytes.Contains(data, []byte("/BaseFont /Helvetica-Oblique")) {
+				t.Error("Expected the oblique font to be embedded")
+			}
+			if n := bytes.Count(data, []byte("/Type /Page ")); n < 2 {
+				t.Errorf("Expected long text to span several pages, got %d", n)
+			}
+		case FormatDOCX:
+			zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+			if err != nil {
+				t.Fatalf("Not a zip file: %v", err)
+			}
+			var body string
+			for _, file := range zr.File {
+				if file.Name == "word/document.xml" {
+					rc, _ := file.Open()
+					b, _ := io.ReadAll(rc)
+					rc.Close()
+					body = string(b)
+				}
+			}
+			for _, want := range []string{`<w:jc w:val="center"/></w:pPr><w:r><w:rPr><w:b/><w:sz w:val="24"/>`, `<w:i/>`, `</w:tbl><w:p/>`} {
+				if !strings.Contains(body, want) {
+					t.Errorf("Document is missing %s", want)
+				}
+			}
+			if strings.Contains(body, "landscape") {
+				t.Error("Expected a portrait section")
+			}
+		}
+	}
+}
+
+func TestWrapText(t *testing.T) {
+	lines := wrapText("Juara 1 Lomba Karya Tulis Ilmiah Nasional", 60, 8, false, pdfMaxLines)
+	if len(lines) < 2 {
+		t.Fatalf("Expected text to wrap, got %q", lines)
+	}
+	for _, line := range lines {
+		if w := textWidth(line, 8, false); w > 60 {
+			t.Errorf("Line %q is %.1fpt wide", line, w)
+		}
+	}
+
+	long := wrapText(strings.Repeat("kata ", 100), 60, 8, false, pdfMaxLines)
+	if len(long) != pdfMaxLines || !strings.HasSuffix(long[pdfMaxLines-1], "...") {
+		t.Errorf("Expected truncation to %d lines, got %q", pdfMaxLines, long)
+	}
+	if all := wrapText(strings.Repeat("kata ", 100), 60, 8, false, 0); len(all) <= pdfMaxLines {
+		t.Errorf("Expected paragraphs to wrap without a line limit, got %d lines", len(all))
+	}
+
+	if got := winAnsi("Café – “Ok” 漢"); got != "Caf\xe9 \x96 \x93Ok\x94 ?" {
+		t.Errorf("Unexpected WinAnsi conversion %q", got)
+	}
+}
+
+func TestXLSXHelpers(t *testing.T) {
+	for i, want := range map[int]string{0: "A", 25: "Z", 26: "AA", 701: "ZZ", 702: "AAA"} {
+		if got := columnName(i); got != want {
+			t.Errorf("columnName(%d) = %s, want %s", i, got, want)
+		}
+	}
+
+	if got := excelSerial(time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)); got != 45292.5 {
+		t.Errorf("excelSerial = %v, want 45292.5", got)
+	}
+
+	used := []string{"Daftar Prestasi"}
+	if got := sheetName("Daftar Prestasi", 2, used); got != "Daftar Prestasi (2)" {
+		t.Errorf("Expected a unique sheet name, got %q", got)
+	}
+	if got := sheetName("Prestasi: per/jenis [2024] dan program studi", 1, nil); got != "Prestasi  per jenis  2024  dan " {
+		t.Errorf("Unexpected sheet name %q", got)
+	}
+}
@@ -0,0 +1,552 @@
+package export
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"image/color"
+	"image/jpeg"
+	"io"
+	"strings"
+)
+
+// Ukuran A4 dalam point; tabel memakai landscape, dokumen Portrait memakai potret
+const (
+	pdfA4Long  = 841.89
+	pdfA4Short = 595.28
+	pdfMargin  = 36.0
+	pdfFooter  = 20.0
+
+	pdfFontSize   = 8.0
+	pdfLineHeight = 10.0
+	pdfTextSize   = 9.5
+	pdfCellPad    = 3.0
+	pdfMaxLines   = 4 // sel yang lebih panjang dipotong dengan "..."
+	pdfLogoHeight = 56.0
+)
+
+// Nomor objek tetap; objek halaman dan konten dialokasikan setelahnya
+const (
+	pdfObjCatalog = iota + 1
+	pdfObjPages
+	pdfObjFont
+	pdfObjFontBold
+	pdfObjFontItalic
+)
+
+// Nama resource font di content stream
+const (
+	pdfFontRegular = "F1"
+	pdfFontBold    = "F2"
+	pdfFontItalic  = "F3"
+)
+
+// Lebar glyph Helvetica (per 1000 unit) untuk karakter 32–126 dari AFM standar. Helvetica-Oblique memakai
+// lebar yang sama.
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+// Karakter di luar Latin-1 yang punya tempat di WinAnsiEncoding
+var winAnsiExtra = map[rune]byte{
+	'€': 0x80, '‚': 0x82, '„': 0x84, '…': 0x85, '‘': 0x91, '’': 0x92,
+	'“': 0x93, '”': 0x94, '•': 0x95, '–': 0x96, '—': 0x97, '™': 0x99,
+}
+
+type jpegMeta struct {
+	width, height, components int
+}
+
+func jpegInfo(data []byte) (jpegMeta, error) {
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return jpegMeta{}, err
+	}
+	meta := jpegMeta{width: cfg.Width, height: cfg.Height, components: 3}
+	switch cfg.ColorModel {
+	case color.GrayModel:
+		meta.components = 1
+	case color.CMYKModel:
+		meta.components = 4
+	}
+	return meta, nil
+}
+
+type countingWriter struct {
+	w   io.Writer
+	n   int64
+	err error
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	if c.err != nil {
+		return 0, c.err
+	}
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	c.err = err
+	return n, err
+}
+
+// pdfWriter menulis PDF secara bertahap: setiap halaman yang penuh langsung ditulis sebagai objek,
+// sehingga yang ditampung di memori hanya halaman aktif dan tabel offset xref.
+type pdfWriter struct {
+	out     *countingWriter
+	doc     Document
+	offsets map[int]int64
+	nextObj int
+	pages   []int
+	logo    *jpegMeta
+	logoObj int
+
+	width, height float64
+
+	page    *bytes.Buffer
+	y       float64 // jarak dari tepi atas halaman
+	columns []Column
+	widths  []float64
+	rows    int
+}
+
+func newPDFWriter(w io.Writer, doc Document) (*pdfWriter, error) {
+	p := &pdfWriter{
+		out:     &countingWriter{w: w},
+		doc:     doc,
+		offsets: map[int]int64{},
+		nextObj: pdfObjFontItalic + 1,
+		width:   pdfA4Long,
+		height:  pdfA4Short,
+	}
+	if doc.Portrait {
+		p.width, p.height = pdfA4Short, pdfA4Long
+	}
+
+	io.WriteString(p.out, "%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+	p.writeObj(pdfObjFont, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	p.writeObj(pdfObjFontBold, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+	p.writeObj(pdfObjFontItalic, "<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Oblique /Encoding /WinAnsiEncoding >>")
+
+	if len(doc.Letterhead.Logo) > 0 {
+		meta, err := jpegInfo(doc.Letterhead.Logo)
+		if err != nil {
+			return nil, err
+		}
+		colorSpace := map[int]string{1: "/DeviceGray", 3: "/DeviceRGB", 4: "/DeviceCMYK /Decode [1 0 1 0 1 0 1 0]"}[meta.components]
+		p.logoObj = p.allocObj()
+		p.writeStream(p.logoObj, fmt.Sprintf("/Type /XObject /Subtype /Image /Width %d /Height %d /ColorSpace %s /BitsPerComponent 8 /Filter /DCTDecode",
+			meta.width, meta.height, colorSpace), doc.Letterhead.Logo)
+		p.logo = &meta
+	}
+	return p, p.out.err
+}
+
+func (p *pdfWriter) writeObj(num int, body string) {
+	p.offsets[num] = p.out.n
+	fmt.Fprintf(p.out, "%d 0 obj\n%s\nendobj\n", num, body)
+}
+
+func (p *pdfWriter) writeStream(num int, dict string, data []byte) {
+	p.offsets[num] = p.out.n
+	fmt.Fprintf(p.out, "%d 0 obj\n<< %s /Length %d >>\nstream\n", num, dict, len(data))
+	p.out.Write(data)
+	io.WriteString(p.out, "\nendstream\nendobj\n")
+}
+
+func (p *pdfWriter) allocObj() int {
+	p.nextObj++
+	return p.nextObj - 1
+}
+
+func (p *pdfWriter) Table(title string, columns []Column) error {
+	if p.page == nil {
+		p.startPage()
+	}
+	// Judul, header dan minimal satu baris harus muat di halaman yang sama
+	p.columns = nil
+	if p.y+16+3*pdfLineHeight+4*pdfCellPad > p.bottom() {
+		p.newPage()
+	} else if p.y > pdfMargin+pdfLineHeight {
+		p.y += 8
+	}
+	p.columns, p.widths, p.rows = columns, p.columnWidths(columns), 0
+
+	if title != "" {
+		p.text(pdfFontBold, 10, pdfMargin, p.y+10, title)
+		p.y += 16
+	}
+	p.header()
+	return p.out.err
+}
+
+func (p *pdfWriter) Row(cells ...interface{}) error {
+	if p.page == nil || p.columns == nil {
+		columns := make([]Column, len(cells))
+		for i := range columns {
+			columns[i] = Column{Width: 1}
+		}
+		p.Table("", columns)
+	}
+
+	lines := make([][]string, len(p.widths))
+	height := 1
+	for i := range p.widths {
+		var v interface{}
+		if i < len(cells) {
+			v = cells[i]
+		}
+		lines[i] = wrapText(winAnsi(cellText(v)), p.widths[i]-2*pdfCellPad, pdfFontSize, false, pdfMaxLines)
+		if len(lines[i]) > height {
+			height = len(lines[i])
+		}
+	}
+	rowHeight := float64(height)*pdfLineHeight + 2*pdfCellPad
+	if p.y+rowHeight > p.bottom() {
+		p.newPage()
+	}
+
+	if p.rows%2 == 1 {
+		p.fillRect(0.95, pdfMargin, p.y, p.width-2*pdfMargin, rowHeight)
+	}
+	p.cells(false, lines)
+	p.y += rowHeight
+	p.hline(0.8, 0.3, p.y)
+	p.rows++
+	return p.out.err
+}
+
+// Text menulis paragraf selebar halaman; teks kosong menjadi jarak satu baris. Tabel sebelumnya berakhir.
+func (p *pdfWriter) Text(style TextStyle, text string) error {
+	if p.page == nil {
+		p.startPage()
+	}
+	p.columns = nil
+
+	size := style.Size
+	if size == 0 {
+		size = pdfTextSize
+	}
+	lineHeight := size * 1.35
+	font := pdfFontRegular
+	switch {
+	case style.Bold:
+		font = pdfFontBold
+	case style.Italic:
+		font = pdfFontItalic
+	}
+
+	if strings.TrimSpace(text) == "" {
+		p.y += lineHeight
+		return p.out.err
+	}
+	for _, line := range wrapText(winAnsi(text), p.width-2*pdfMargin, size, style.Bold, 0) {
+		if p.y+lineHeight > p.bottom() {
+			p.newPage()
+		}
+		x := pdfMargin
+		switch style.Align {
+		case AlignRight:
+			x = p.width - pdfMargin - textWidth(line, size, style.Bold)
+		case AlignCenter:
+			x = (p.width - textWidth(line, size, style.Bold)) / 2
+		}
+		p.rawText(font, size, x, p.y+size, line)
+		p.y += lineHeight
+	}
+	return p.out.err
+}
+
+func (p *pdfWriter) Close() error {
+	if p.page == nil {
+		p.startPage()
+	}
+	p.finishPage()
+
+	kids := make([]string, len(p.pages))
+	for i, num := range p.pages {
+		kids[i] = fmt.Sprintf("%d 0 R", num)
+	}
+	p.writeObj(pdfObjPages, fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), len(p.pages)))
+	p.writeObj(pdfObjCatalog, fmt.Sprintf("<< /Type /Catalog /Pages %d 0 R >>", pdfObjPages))
+	info := p.allocObj()
+	p.writeObj(info, fmt.Sprintf("<< /Title %s /Producer (sistem-pelaporan-prestasi-mahasiswa) /CreationDate (D:%s) >>",
+		pdfString(winAnsi(p.doc.Title)), p.doc.GeneratedAt.Format("20060102150405")))
+
+	xref := p.out.n
+	fmt.Fprintf(p.out, "xref\n0 %d\n0000000000 65535 f \n", p.nextObj)
+	for num := 1; num < p.nextObj; num++ {
+		fmt.Fprintf(p.out, "%010d 00000 n \n", p.offsets[num])
+	}
+	fmt.Fprintf(p.out, "trailer\n<< /Size %d /Root %d 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", p.nextObj, pdfObjCatalog, info, xref)
+	return p.out.err
+}
+
+func (p *pdfWriter) bottom() float64 {
+	return p.height - pdfMargin - pdfFooter
+}
+
+func (p *pdfWriter) newPage() {
+	p.finishPage()
+	p.startPage()
+	if p.columns != nil {
+		p.header()
+	}
+}
+
+// startPage memulai halaman baru. Halaman pertama memuat kop surat dan judul; halaman berikutnya hanya judul kecil.
+func (p *pdfWriter) startPage() {
+	p.page = &bytes.Buffer{}
+	p.y = pdfMargin
+
+	if len(p.pages) > 0 {
+		if p.doc.Title != "" {
+			p.text(pdfFontBold, 9, pdfMargin, p.y+9, p.doc.Title)
+			p.y += 14
+			p.hline(0.5, 0.5, p.y)
+			p.y += 8
+		}
+		return
+	}
+
+	lh := p.doc.Letterhead
+	if lh.Institution != "" || p.logo != nil {
+		top := p.y
+		if p.logo != nil {
+			w := pdfLogoHeight * float64(p.logo.width) / float64(p.logo.height)
+			fmt.Fprintf(p.page, "q %.2f 0 0 %.2f %.2f %.2f cm /Im1 Do Q\n", w, pdfLogoHeight, pdfMargin, p.height-top-pdfLogoHeight)
+		}
+		if lh.Institution != "" {
+			p.centered(pdfFontBold, 16, p.y+16, lh.Institution)
+			p.y += 22
+		}
+		if lh.Unit != "" {
+			p.centered(pdfFontBold, 12, p.y+12, lh.Unit)
+			p.y += 16
+		}
+		for _, line := range lh.Lines {
+			p.centered(pdfFontRegular, 9, p.y+9, line)
+			p.y += 12
+		}
+		if p.logo != nil && p.y < top+pdfLogoHeight {
+			p.y = top + pdfLogoHeight
+		}
+		p.y += 4
+		p.hline(0, 1.5, p.y)
+		p.hline(0, 0.5, p.y+2.5)
+		p.y += 16
+	}
+
+	if p.doc.Title != "" {
+		p.centered(pdfFontBold, 13, p.y+13, p.doc.Title)
+		p.y += 20
+	}
+	for _, line := range p.doc.Subtitle {
+		p.centered(pdfFontRegular, 9, p.y+9, line)
+		p.y += 12
+	}
+	p.y += 6
+}
+
+// finishPage menulis footer lalu objek konten dan objek halaman
+func (p *pdfWriter) finishPage() {
+	if p.page == nil {
+		return
+	}
+	footerY := p.height - pdfMargin + 4
+	printed := "Dicetak " + p.doc.GeneratedAt.Format("02-01-2006 15:04")
+	if p.doc.Letterhead.Institution != "" {
+		printed += " · " + p.doc.Letterhead.Institution
+	}
+	p.text(pdfFontRegular, 7.5, pdfMargin, footerY, printed)
+	pageLabel := fmt.Sprintf("Halaman %d", len(p.pages)+1)
+	p.text(pdfFontRegular, 7.5, p.width-pdfMargin-textWidth(pageLabel, 7.5, false), footerY, pageLabel)
+
+	var compressed bytes.Buffer
+	zw := zlib.NewWriter(&compressed)
+	zw.Write(p.page.Bytes())
+	zw.Close()
+
+	content := p.allocObj()
+	p.writeStream(content, "/Filter /FlateDecode", compressed.Bytes())
+
+	xobject := ""
+	if p.logo != nil {
+		xobject = fmt.Sprintf(" /XObject << /Im1 %d 0 R >>", p.logoObj)
+	}
+	page := p.allocObj()
+	p.writeObj(page, fmt.Sprintf("<< /Type /Page /Parent %d 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /%s %d 0 R /%s %d 0 R /%s %d 0 R >>%s >> /Contents %d 0 R >>",
+		pdfObjPages, p.width, p.height, pdfFontRegular, pdfObjFont, pdfFontBold, pdfObjFontBold, pdfFontItalic, pdfObjFontItalic, xobject, content))
+	p.pages = append(p.pages, page)
+	p.page = nil
+}
+
+func (p *pdfWriter) header() {
+	lines := make([][]string, len(p.columns))
+	height := 1
+	for i, col := range p.columns {
+		lines[i] = wrapText(winAnsi(col.Header), p.widths[i]-2*pdfCellPad, pdfFontSize, true, pdfMaxLines)
+		if len(lines[i]) > height {
+			height = len(lines[i])
+		}
+	}
+	rowHeight := float64(height)*pdfLineHeight + 2*pdfCellPad
+	p.fillRect(0.85, pdfMargin, p.y, p.width-2*pdfMargin, rowHeight)
+	p.cells(true, lines)
+	p.y += rowHeight
+	p.hline(0, 0.6, p.y)
+}
+
+func (p *pdfWriter) cells(bold bool, lines [][]string) {
+	font := pdfFontRegular
+	if bold {
+		font = pdfFontBold
+	}
+	x := pdfMargin
+	for i, cell := range lines {
+		for j, line := range cell {
+			tx := x + pdfCellPad
+			switch p.columns[i].Align {
+			case AlignRight:
+				tx = x + p.widths[i] - pdfCellPad - textWidth(line, pdfFontSize, bold)
+			case AlignCenter:
+				tx = x + (p.widths[i]-textWidth(line, pdfFontSize, bold))/2
+			}
+			p.rawText(font, pdfFontSize, tx, p.y+pdfCellPad+float64(j)*pdfLineHeight+pdfFontSize, line)
+		}
+		x += p.widths[i]
+	}
+}
+
+// text menulis teks UTF-8 dengan baseline pada jarak baseline dari tepi atas halaman
+func (p *pdfWriter) text(font string, size, x, baseline float64, s string) {
+	p.rawText(font, size, x, baseline, winAnsi(s))
+}
+
+func (p *pdfWriter) centered(font string, size, baseline float64, s string) {
+	s = winAnsi(s)
+	p.rawText(font, size, (p.width-textWidth(s, size, font == pdfFontBold))/2, baseline, s)
+}
+
+func (p *pdfWriter) rawText(font string, size, x, baseline float64, s string) {
+	fmt.Fprintf(p.page, "BT /%s %.1f Tf %.2f %.2f Td %s Tj ET\n", font, size, x, p.height-baseline, pdfString(s))
+}
+
+func (p *pdfWriter) fillRect(gray, x, top, w, h float64) {
+	fmt.Fprintf(p.page, "%.2f g %.2f %.2f %.2f %.2f re f 0 g\n", gray, x, p.height-top-h, w, h)
+}
+
+func (p *pdfWriter) hline(gray, width, top float64) {
+	y := p.height - top
+	fmt.Fprintf(p.page, "%.2f G %.2f w %.2f %.2f m %.2f %.2f l S 0 G\n", gray, width, pdfMargin, y, p.width-pdfMargin, y)
+}
+
+// columnWidths membagi lebar halaman sesuai proporsi Column.Width
+func (p *pdfWriter) columnWidths(columns []Column) []float64 {
+	total := 0.0
+	for _, col := range columns {
+		total += columnWeight(col)
+	}
+	widths := make([]float64, len(columns))
+	for i, col := range columns {
+		widths[i] = (p.width - 2*pdfMargin) * columnWeight(col) / total
+	}
+	return widths
+}
+
+func columnWeight(col Column) float64 {
+	if col.Width <= 0 {
+		return 12
+	}
+	return col.Width
+}
+
+// winAnsi mengubah teks UTF-8 menjadi byte WinAnsiEncoding; karakter yang tidak tersedia menjadi "?"
+func winAnsi(s string) string {
+	b := make([]byte, 0, len(s))
+	for _, r := range s {
+		switch {
+		case r == '\n':
+			b = append(b, '\n')
+		case r < 0x20:
+			b = append(b, ' ')
+		case r < 0x7F || (r >= 0xA0 && r <= 0xFF):
+			b = append(b, byte(r))
+		default:
+			if c, ok := winAnsiExtra[r]; ok {
+				b = append(b, c)
+			} else {
+				b = append(b, '?')
+			}
+		}
+	}
+	return string(b)
+}
+
+// textWidth menghitung lebar teks WinAnsi dalam point. Huruf tebal didekati 5% lebih lebar.
+func textWidth(s string, size float64, bold bool) float64 {
+	units := 0
+	for i := 0; i < len(s); i++ {
+		if c := s[i]; c >= 32 && c <= 126 {
+			units += helveticaWidths[c-32]
+		} else {
+			units += 556
+		}
+	}
+	w := float64(units) * size / 1000
+	if bold {
+		w *= 1.05
+	}
+	return w
+}
+
+// wrapText memecah teks WinAnsi per kata agar muat di lebar yang tersedia. Bila maxLines > 0, baris
+// selebihnya dipotong dan baris terakhir diakhiri "...".
+func wrapText(s string, width, size float64, bold bool, maxLines int) []string {
+	var lines []string
+	for _, paragraph := range strings.Split(s, "\n") {
+		line := ""
+		for _, word := range strings.Fields(paragraph) {
+			candidate := word
+			if line != "" {
+				candidate = line + " " + word
+			}
+			if textWidth(candidate, size, bold) <= width {
+				line = candidate
+				continue
+			}
+			if line != "" {
+				lines = append(lines, line)
+			}
+			// Kata yang lebih lebar dari kolom dipotong per karakter
+			for textWidth(word, size, bold) > width && len(word) > 1 {
+				n := len(word) - 1
+				for n > 1 && textWidth(word[:n], size, bold) > width {
+					n--
+				}
+				lines = append(lines, word[:n])
+				word = word[n:]
+			}
+			line = word
+		}
+		lines = append(lines, line)
+	}
+
+	if maxLines > 0 && len(lines) > maxLines {
+		last := lines[maxLines-1]
+		for last != "" && textWidth(last+"...", size, bold) > width {
+			last = last[:len(last)-1]
+		}
+		lines = append(lines[:maxLines-1], last+"...")
+	}
+	return lines
+}
+
+// pdfString meng-escape string literal PDF
+func pdfString(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`, "\r", `\r`, "\n", " ")
+	return "(" + r.Replace(s) + ")"
+}
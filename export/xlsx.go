@@ -0,0 +1,287 @@
+package export
+
+import (
+	"archive/zip"
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Indeks gaya sel di styles.xml
+const (
+	xlsxStyleDefault = iota
+	xlsxStyleBold
+	xlsxStyleDate
+	xlsxStyleDateTime
+	xlsxStyleTitle
+)
+
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">
+<numFmts count="1"><numFmt numFmtId="164" formatCode="yyyy-mm-dd hh:mm"/></numFmts>
+<fonts count="3"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="14"/><name val="Calibri"/></font></fonts>
+<fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="solid"><fgColor rgb="FFD9D9D9"/><bgColor indexed="64"/></patternFill></fill></fills>
+<borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders>
+<cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs>
+<cellXfs count="5">
+<xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/>
+<xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/>
+<xf numFmtId="14" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="164" fontId="0" fillId="0" borderId="0" xfId="0" applyNumberFormat="1"/>
+<xf numFmtId="0" fontId="2" fillId="0" borderId="0" xfId="0" applyFont="1"/>
+</cellXfs>
+</styleSheet>`
+
+// xlsxWriter menulis workbook langsung ke zip: setiap tabel menjadi satu sheet yang ditulis baris demi
+// baris. String ditulis sebagai inline string sehingga tidak perlu menampung shared strings table.
+type xlsxWriter struct {
+	zw     *zip.Writer
+	sheet  *bufio.Writer
+	doc    Document
+	names  []string
+	row    int
+	closed bool
+}
+
+func newXLSXWriter(w io.Writer, doc Document) *xlsxWriter {
+	return &xlsxWriter{zw: zip.NewWriter(w), doc: doc}
+}
+
+func (x *xlsxWriter) Table(title string, columns []Column) error {
+	if err := x.endSheet(); err != nil {
+		return err
+	}
+
+	x.names = append(x.names, sheetName(title, len(x.names)+1, x.names))
+	f, err := x.zw.Create(fmt.Sprintf("xl/worksheets/sheet%d.xml", len(x.names)))
+	if err != nil {
+		return err
+	}
+	x.sheet = bufio.NewWriter(f)
+	x.row = 0
+
+	// Judul dokumen, subjudul, satu baris kosong, lalu header yang dibekukan
+	headerRow := 1
+	if x.doc.Title != "" {
+		headerRow += len(x.doc.Subtitle) + 2
+	}
+	if title != "" && title != x.doc.Title {
+		headerRow++
+	}
+
+	x.sheet.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>` + "\n" +
+		`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	fmt.Fprintf(x.sheet, `<sheetViews><sheetView workbookViewId="0"><pane ySplit="%d" topLeftCell="A%d" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`, headerRow, headerRow+1)
+	if len(columns) > 0 {
+		x.sheet.WriteString("<cols>")
+		for i, col := range columns {
+			width := col.Width
+			if width <= 0 {
+				width = 12
+			}
+			fmt.Fprintf(x.sheet, `<col min="%d" max="%d" width="%g" customWidth="1"/>`, i+1, i+1, width+2)
+		}
+		x.sheet.WriteString("</cols>")
+	}
+	x.sheet.WriteString("<sheetData>")
+
+	if x.doc.Title != "" {
+		x.writeRow(xlsxStyleTitle, x.doc.Title)
+		for _, line := range x.doc.Subtitle {
+			x.writeRow(xlsxStyleDefault, line)
+		}
+		x.writeRow(xlsxStyleDefault)
+	}
+	if title != "" && title != x.doc.Title {
+		x.writeRow(xlsxStyleBold, title)
+	}
+
+	header := make([]interface{}, len(columns))
+	for i, col := range columns {
+		header[i] = col.Header
+	}
+	return x.writeRow(xlsxStyleBold, header...)
+}
+
+func (x *xlsxWriter) Row(cells ...interface{}) error {
+	if x.sheet == nil {
+		if err := x.Table("", nil); err != nil {
+			return err
+		}
+	}
+	return x.writeRow(xlsxStyleDefault, cells...)
+}
+
+// Text menulis paragraf sebagai baris satu sel di sheet aktif
+func (x *xlsxWriter) Text(style TextStyle, text string) error {
+	if x.sheet == nil {
+		if err := x.Table("", nil); err != nil {
+			return err
+		}
+	}
+	if style.Bold && style.Size >= 12 {
+		return x.writeRow(xlsxStyleTitle, text)
+	}
+	return x.writeRow(xlsxStyleDefault, text)
+}
+
+func (x *xlsxWriter) writeRow(style int, cells ...interface{}) error {
+	x.row++
+	fmt.Fprintf(x.sheet, `<row r="%d">`, x.row)
+	for i, v := range cells {
+		ref := columnName(i) + strconv.Itoa(x.row)
+		switch v := v.(type) {
+		case nil:
+			continue
+		case int, int64:
+			fmt.Fprintf(x.sheet, `<c r="%s" s="%d"><v>%d</v></c>`, ref, style, v)
+		case float64:
+			fmt.Fprintf(x.sheet, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, strconv.FormatFloat(v, 'f', -1, 64))
+		case time.Time:
+			x.writeTime(ref, v)
+		case *time.Time:
+			if v != nil {
+				x.writeTime(ref, *v)
+			}
+		default:
+			fmt.Fprintf(x.sheet, `<c r="%s" s="%d" t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, xmlText(cellText(v)))
+		}
+	}
+	_, err := x.sheet.WriteString("</row>")
+	return err
+}
+
+func (x *xlsxWriter) writeTime(ref string, t time.Time) {
+	if t.IsZero() {
+		return
+	}
+	style := xlsxStyleDateTime
+	if h, m, s := t.Clock(); h == 0 && m == 0 && s == 0 {
+		style = xlsxStyleDate
+	}
+	fmt.Fprintf(x.sheet, `<c r="%s" s="%d"><v>%s</v></c>`, ref, style, strconv.FormatFloat(excelSerial(t), 'f', -1, 64))
+}
+
+func (x *xlsxWriter) endSheet() error {
+	if x.sheet == nil {
+		return nil
+	}
+	x.sheet.WriteString("</sheetData></worksheet>")
+	err := x.sheet.Flush()
+	x.sheet = nil
+	return err
+}
+
+func (x *xlsxWriter) Close() error {
+	if x.closed {
+		return nil
+	}
+	x.closed = true
+	if len(x.names) == 0 {
+		if err := x.Table("", nil); err != nil {
+			return err
+		}
+	}
+	if err := x.endSheet(); err != nil {
+		return err
+	}
+
+	var sheets, rels, overrides strings.Builder
+	for i, name := range x.names {
+		fmt.Fprintf(&sheets, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, xmlText(name), i+1, i+1)
+		fmt.Fprintf(&rels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, i+1, i+1)
+		fmt.Fprintf(&overrides, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, i+1)
+	}
+	n := len(x.names)
+
+	files := []struct{ name, body string }{
+		{"[Content_Types].xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>` + overrides.String() + `</Types>`},
+		{"_rels/.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`},
+		{"xl/workbook.xml", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>` + sheets.String() + `</sheets></workbook>`},
+		{"xl/_rels/workbook.xml.rels", `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">` + rels.String() +
+			fmt.Sprintf(`<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, n+1) + `</Relationships>`},
+		{"xl/styles.xml", xlsxStyles},
+	}
+	for _, file := range files {
+		f, err := x.zw.Create(file.name)
+		if err != nil {
+			return err
+		}
+		if _, err := io.WriteString(f, file.body); err != nil {
+			return err
+		}
+	}
+	return x.zw.Close()
+}
+
+// sheetName membuat nama sheet yang valid (maksimal 31 karakter, tanpa []:*?/\) dan unik
+func sheetName(title string, index int, used []string) string {
+	name := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(`[]:*?/\`, r) {
+			return ' '
+		}
+		return r
+	}, strings.TrimSpace(title))
+	if name == "" {
+		name = fmt.Sprintf("Sheet%d", index)
+	}
+	for utf8.RuneCountInString(name) > 31 {
+		_, size := utf8.DecodeLastRuneInString(name)
+		name = name[:len(name)-size]
+	}
+	for _, u := range used {
+		if strings.EqualFold(u, name) {
+			suffix := fmt.Sprintf(" (%d)", index)
+			for utf8.RuneCountInString(name)+len(suffix) > 31 {
+				_, size := utf8.DecodeLastRuneInString(name)
+				name = name[:len(name)-size]
+			}
+			return name + suffix
+		}
+	}
+	return name
+}
+
+// columnName mengubah indeks kolom (mulai 0) menjadi huruf kolom Excel: 0 → A, 26 → AA
+func columnName(i int) string {
+	name := ""
+	for i++; i > 0; i = (i - 1) / 26 {
+		name = string(rune('A'+(i-1)%26)) + name
+	}
+	return name
+}
+
+// excelSerial mengubah waktu menjadi nomor seri tanggal Excel (hari sejak 1899-12-30) pada zona waktunya
+func excelSerial(t time.Time) float64 {
+	y, m, d := t.Date()
+	local := time.Date(y, m, d, t.Hour(), t.Minute(), t.Second(), 0, time.UTC)
+	return local.Sub(time.Date(1899, 12, 30, 0, 0, 0, 0, time.UTC)).Hours() / 24
+}
+
+// xmlText meng-escape teks dan membuang karakter yang tidak diizinkan di XML 1.0
+func xmlText(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '<':
+			b.WriteString("&lt;")
+		case r == '>':
+			b.WriteString("&gt;")
+		case r == '&':
+			b.WriteString("&amp;")
+		case r == '"':
+			b.WriteString("&quot;")
+		case r == '\t' || r == '\n' || r == '\r' || (r >= 0x20 && r != utf8.RuneError && r != 0xFFFE && r != 0xFFFF):
+			b.WriteRune(r)
+		}
+	}
+	return b.String()
+}
@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"encoding/base64"
+	"encoding/json"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+)
+
+// EncodeCursor mengubah posisi keyset menjadi string opaque yang aman dipakai di query string
+func EncodeCursor(cursor *model.PageCursor) string {
+	if cursor == nil {
+		return ""
+	}
+	data, _ := json.Marshal(cursor)
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// DecodeCursor membaca cursor dari klien; nilainya hanya dipakai sebagai parameter query
+func DecodeCursor(raw string) (*model.PageCursor, error) {
+	data, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, model.NewValidationError("Cursor tidak valid")
+	}
+	var cursor model.PageCursor
+	if err := json.Unmarshal(data, &cursor); err != nil || cursor.ID == "" || cursor.SortBy == "" {
+		return nil, model.NewValidationError("Cursor tidak valid")
+	}
+	return &cursor, nil
+}
@@ -1,33 +1,46 @@
 package helper
 
 import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
-	"mime/multipart"
-	"strings"
 
 	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
+
+	"github.com/gofiber/fiber/v2"
 )
 
-func ValidateFile(fileHeader *multipart.FileHeader, maxBytes int64, allowedTypes []string) error {
-	if fileHeader.Size > maxBytes {
-		maxMB := maxBytes / (1024 * 1024)
-		return model.NewValidationError(fmt.Sprintf("Ukuran file terlalu besar. Maksimal %dMB", maxMB))
+// SaveSanitizedFile menyimpan file hasil sanitasi ke storage dan mengembalikan hash SHA-256 isinya
+func SaveSanitizedFile(ctx context.Context, store storage.Storage, key string, file *SanitizedFile) (string, error) {
+	if err := store.Put(ctx, key, bytes.NewReader(file.Data), int64(len(file.Data)), file.ContentType); err != nil {
+		return "", err
 	}
 
-	contentType := fileHeader.Header.Get("Content-Type")
-	isValidType := false
-	
-	for _, t := range allowedTypes {
-		if t == contentType {
-			isValidType = true
-			break
+	sum := sha256.Sum256(file.Data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// StreamFile mengalirkan objek dari storage sebagai respons unduhan privat
+func StreamFile(c *fiber.Ctx, store storage.Storage, key, downloadName string) error {
+	rc, info, err := store.Get(c.Context(), key)
+	if err != nil {
+		if err == storage.ErrNotFound {
+			return HandleError(c, model.NewNotFoundError("File tidak ditemukan"))
 		}
+		return HandleError(c, model.ErrDatabaseError)
 	}
 
-	if !isValidType {
-		allowedStr := strings.Join(allowedTypes, ", ")
-		return model.NewValidationError(fmt.Sprintf("Format file tidak didukung. Tipe yang diizinkan: %s", allowedStr))
+	contentType := info.ContentType
+	if contentType == "" {
+		contentType = "application/octet-stream"
 	}
 
-	return nil
-}
\ No newline at end of file
+	c.Set(fiber.HeaderContentType, contentType)
+	c.Set(fiber.HeaderContentDisposition, fmt.Sprintf("inline; filename=%q", downloadName))
+	c.Set(fiber.HeaderCacheControl, "private, no-store")
+	c.Set(fiber.HeaderXContentTypeOptions, "nosniff")
+	return c.SendStream(rc, int(info.Size))
+}
@@ -0,0 +1,425 @@
+package helper
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"math"
+	"mime/multipart"
+	"path/filepath"
+	"strings"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+)
+
+const (
+	FileTypePDF  = "application/pdf"
+	FileTypeJPEG = "image/jpeg"
+	FileTypePNG  = "image/png"
+	FileTypeMP4  = "video/mp4"
+
+	DefaultMaxFileSizeMB = 5
+)
+
+// SupportedFileTypes adalah tipe yang bisa dikenali dari isinya dan disanitasi
+var SupportedFileTypes = []string{FileTypePDF, FileTypeJPEG, FileTypePNG, FileTypeMP4}
+
+// DefaultFileTypes dipakai bila jenis prestasi tidak mengatur tipe file; video harus diizinkan per jenis
+var DefaultFileTypes = []string{FileTypePDF, FileTypeJPEG, FileTypePNG}
+
+var fileTypeExtensions = map[string][]string{
+	FileTypePDF:  {".pdf"},
+	FileTypeJPEG: {".jpg", ".jpeg"},
+	FileTypePNG:  {".png"},
+	FileTypeMP4:  {".mp4"},
+}
+
+var fileTypeLabels = map[string]string{
+	FileTypePDF:  "PDF",
+	FileTypeJPEG: "JPEG",
+	FileTypePNG:  "PNG",
+	FileTypeMP4:  "MP4",
+}
+
+// FilePolicy adalah batas ukuran dan tipe file untuk satu jenis upload
+type FilePolicy struct {
+	MaxBytes     int64
+	AllowedTypes []string
+}
+
+func DefaultFilePolicy() FilePolicy {
+	return FilePolicy{MaxBytes: DefaultMaxFileSizeMB * 1024 * 1024, AllowedTypes: DefaultFileTypes}
+}
+
+// CheckFileName menolak lebih awal nama file yang ekstensinya tidak termasuk tipe yang diizinkan kebijakan,
+// misalnya sebelum upload bertahap dimulai
+func CheckFileName(fileName string, policy FilePolicy) error {
+	ext := strings.ToLower(filepath.Ext(fileName))
+	var labels []string
+	for _, t := range policy.AllowedTypes {
+		if containsString(fileTypeExtensions[t], ext) {
+			return nil
+		}
+		labels = append(labels, fileTypeLabels[t])
+	}
+	return model.NewValidationError(fmt.Sprintf("Format file tidak didukung. Tipe yang diizinkan: %s", strings.Join(labels, ", ")))
+}
+
+// SanitizedFile adalah isi file yang sudah diperiksa dan dibersihkan dari metadata
+type SanitizedFile struct {
+	Data        []byte
+	ContentType string
+	Extension   string
+}
+
+func IsSupportedFileType(contentType string) bool {
+	_, ok := fileTypeExtensions[contentType]
+	return ok
+}
+
+// DetectFileType mengenali tipe file dari magic bytes, bukan dari header Content-Type atau ekstensi
+func DetectFileType(data []byte) string {
+	switch {
+	case bytes.HasPrefix(data, []byte("%PDF-")):
+		return FileTypePDF
+	case bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}):
+		return FileTypeJPEG
+	case bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")):
+		return FileTypePNG
+	case len(data) >= 12 && string(data[4:8]) == "ftyp":
+		return FileTypeMP4
+	}
+	return ""
+}
+
+// SanitizeUpload membaca file upload, mencocokkan isi dengan ekstensi dan kebijakan, lalu membersihkan metadata
+func SanitizeUpload(fileHeader *multipart.FileHeader, policy FilePolicy) (*SanitizedFile, error) {
+	maxMB := policy.MaxBytes / (1024 * 1024)
+	if fileHeader.Size > policy.MaxBytes {
+		return nil, model.NewValidationError(fmt.Sprintf("Ukuran file terlalu besar. Maksimal %dMB", maxMB))
+	}
+
+	src, err := fileHeader.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer src.Close()
+
+	data, err := io.ReadAll(io.LimitReader(src, policy.MaxBytes+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > policy.MaxBytes {
+		return nil, model.NewValidationError(fmt.Sprintf("Ukuran file terlalu besar. Maksimal %dMB", maxMB))
+	}
+
+	return SanitizeBytes(data, fileHeader.Filename, policy)
+}
+
+// SanitizeBytes menerapkan pemeriksaan yang sama dengan SanitizeUpload untuk isi file yang sudah ada di memori
+func SanitizeBytes(data []byte, fileName string, policy FilePolicy) (*SanitizedFile, error) {
+	var out bytes.Buffer
+	out.Grow(len(data))
+	contentType, ext, err := SanitizeStream(&out, bytes.NewReader(data), fileName, policy)
+	if err != nil {
+		return nil, err
+	}
+	return &SanitizedFile{Data: out.Bytes(), ContentType: contentType, Extension: ext}, nil
+}
+
+// SanitizeStream mengenali tipe file dari byte awal src, mencocokkannya dengan ekstensi dan kebijakan, lalu
+// menyalin isi yang sudah dibersihkan ke dst tanpa memuat seluruh file ke memori. Bila error dikembalikan,
+// sebagian isi mungkin sudah tertulis ke dst dan harus dibuang pemanggil.
+func SanitizeStream(dst io.Writer, src io.Reader, fileName string, policy FilePolicy) (contentType, ext string, err error) {
+	// Buffer cukup besar untuk Peek satu segmen JPEG utuh (maksimal 64KB + marker)
+	r := bufio.NewReaderSize(src, 128*1024)
+	head, err := r.Peek(sniffLen)
+	if err != nil && err != io.EOF {
+		return "", "", err
+	}
+
+	contentType = DetectFileType(head)
+	if contentType == "" || !containsString(policy.AllowedTypes, contentType) {
+		var labels []string
+		for _, t := range policy.AllowedTypes {
+			labels = append(labels, fileTypeLabels[t])
+		}
+		return "", "", model.NewValidationError(fmt.Sprintf("Format file tidak didukung. Tipe yang diizinkan: %s", strings.Join(labels, ", ")))
+	}
+
+	ext = strings.ToLower(filepath.Ext(fileName))
+	if !containsString(fileTypeExtensions[contentType], ext) {
+		return "", "", model.NewValidationError(fmt.Sprintf("Ekstensi file (%s) tidak sesuai dengan isi file (%s)", ext, fileTypeLabels[contentType]))
+	}
+
+	switch contentType {
+	case FileTypePDF:
+		err = copyPDF(dst, r)
+	case FileTypeJPEG:
+		err = copyJPEGWithoutMetadata(dst, r)
+	case FileTypePNG:
+		err = copyPNGWithoutMetadata(dst, r)
+	case FileTypeMP4:
+		err = copyMP4(dst, r)
+	}
+	if err != nil {
+		return "", "", err
+	}
+	return contentType, ext, nil
+}
+
+// sniffLen adalah jumlah byte awal yang dibutuhkan DetectFileType
+const sniffLen = 12
+
+func containsString(list []string, value string) bool {
+	for _, v := range list {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
+
+// truncated memetakan akhir data yang tidak terduga ke error validasi; error baca lain diteruskan apa adanya
+func truncated(err, invalid error) error {
+	if err == io.EOF || err == io.ErrUnexpectedEOF {
+		return invalid
+	}
+	return err
+}
+
+const pdfTailSize = 2048
+
+var (
+	pdfRequiredKeywords  = []string{" obj", "endobj"}
+	pdfForbiddenKeywords = []string{"/JavaScript", "/Launch", "/EmbeddedFile"}
+)
+
+// pdfChecker memeriksa PDF yang dialirkan lewat Write: header, kata kunci objek dan konten aktif di seluruh
+// isi (termasuk yang terpotong di antara dua Write), serta %%EOF dan startxref di 2KB terakhir
+type pdfChecker struct {
+	head  []byte
+	tail  []byte
+	carry []byte
+	found map[string]bool
+	size  int64
+}
+
+func (c *pdfChecker) Write(p []byte) (int, error) {
+	if len(c.head) < 16 {
+		n := 16 - len(c.head)
+		if n > len(p) {
+			n = len(p)
+		}
+		c.head = append(c.head, p[:n]...)
+	}
+	c.size += int64(len(p))
+
+	window := append(c.carry, p...)
+	for _, keyword := range append(pdfRequiredKeywords, pdfForbiddenKeywords...) {
+		if !c.found[keyword] && bytes.Contains(window, []byte(keyword)) {
+			c.found[keyword] = true
+		}
+	}
+	keep := len("/EmbeddedFile") - 1
+	if len(window) > keep {
+		window = window[len(window)-keep:]
+	}
+	c.carry = append(c.carry[:0], window...)
+
+	c.tail = append(c.tail, p...)
+	if len(c.tail) > pdfTailSize {
+		c.tail = append(c.tail[:0], c.tail[len(c.tail)-pdfTailSize:]...)
+	}
+	return len(p), nil
+}
+
+func (c *pdfChecker) check() error {
+	invalid := model.NewValidationError("File PDF rusak atau tidak valid")
+
+	if c.size < 16 || !(bytes.HasPrefix(c.head, []byte("%PDF-1.")) || bytes.HasPrefix(c.head, []byte("%PDF-2."))) {
+		return invalid
+	}
+	if !bytes.Contains(c.tail, []byte("%%EOF")) || !bytes.Contains(c.tail, []byte("startxref")) {
+		return invalid
+	}
+	for _, keyword := range pdfRequiredKeywords {
+		if !c.found[keyword] {
+			return invalid
+		}
+	}
+	for _, keyword := range pdfForbiddenKeywords {
+		if c.found[keyword] {
+			return model.NewValidationError("PDF mengandung konten aktif atau file sisipan yang tidak diizinkan")
+		}
+	}
+	return nil
+}
+
+// copyPDF menyalin PDF apa adanya sambil memeriksa struktur dasarnya dan menolak konten aktif
+func copyPDF(dst io.Writer, r io.Reader) error {
+	checker := &pdfChecker{found: make(map[string]bool)}
+	if _, err := io.Copy(io.MultiWriter(dst, checker), r); err != nil {
+		return err
+	}
+	return checker.check()
+}
+
+// copyJPEGWithoutMetadata menyalin JPEG tanpa segmen APP1 (EXIF/XMP, termasuk GPS), APP13 (IPTC) dan komentar
+func copyJPEGWithoutMetadata(dst io.Writer, r *bufio.Reader) error {
+	invalid := model.NewValidationError("File JPEG rusak atau tidak valid")
+
+	if _, err := r.Discard(2); err != nil {
+		return truncated(err, invalid)
+	}
+	if _, err := dst.Write([]byte{0xFF, 0xD8}); err != nil {
+		return err
+	}
+
+	for {
+		b, err := r.Peek(2)
+		if err != nil {
+			return truncated(err, invalid)
+		}
+		if b[0] != 0xFF {
+			return invalid
+		}
+		marker := b[1]
+
+		switch {
+		case marker == 0xFF:
+			r.Discard(1)
+			continue
+		case marker == 0xD9:
+			_, err := io.Copy(dst, r)
+			return err
+		case marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7):
+			if _, err := io.CopyN(dst, r, 2); err != nil {
+				return err
+			}
+			continue
+		}
+
+		b, err = r.Peek(4)
+		if err != nil {
+			return truncated(err, invalid)
+		}
+		segLen := int64(binary.BigEndian.Uint16(b[2:4]))
+		if segLen < 2 {
+			return invalid
+		}
+
+		if marker == 0xDA {
+			// Start of scan: sisanya adalah data gambar, setelah header scan dipastikan utuh
+			if _, err := r.Peek(int(2 + segLen)); err != nil {
+				return truncated(err, invalid)
+			}
+			_, err := io.Copy(dst, r)
+			return err
+		}
+
+		out := dst
+		if marker == 0xE1 || marker == 0xED || marker == 0xFE {
+			out = io.Discard
+		}
+		if _, err := io.CopyN(out, r, 2+segLen); err != nil {
+			return truncated(err, invalid)
+		}
+	}
+}
+
+// copyMP4 menyalin MP4 sambil memastikan file terdiri dari box ISO BMFF yang utuh dan memiliki ftyp serta moov
+func copyMP4(dst io.Writer, r *bufio.Reader) error {
+	invalid := model.NewValidationError("File MP4 rusak atau tidak valid")
+
+	hasMoov := false
+	for first := true; ; first = false {
+		b, err := r.Peek(8)
+		if err == io.EOF && len(b) == 0 {
+			break
+		}
+		if err != nil {
+			return truncated(err, invalid)
+		}
+		size := uint64(binary.BigEndian.Uint32(b[0:4]))
+		boxType := string(b[4:8])
+		header := uint64(8)
+
+		if first && boxType != "ftyp" {
+			return invalid
+		}
+		if boxType == "moov" {
+			hasMoov = true
+		}
+
+		switch size {
+		case 0:
+			// Box terakhir yang berlanjut sampai akhir file
+			if _, err := io.Copy(dst, r); err != nil {
+				return err
+			}
+			if !hasMoov {
+				return invalid
+			}
+			return nil
+		case 1:
+			b, err = r.Peek(16)
+			if err != nil {
+				return truncated(err, invalid)
+			}
+			size = binary.BigEndian.Uint64(b[8:16])
+			header = 16
+		}
+		if size < header || size > math.MaxInt64 {
+			return invalid
+		}
+		if _, err := io.CopyN(dst, r, int64(size)); err != nil {
+			return truncated(err, invalid)
+		}
+	}
+
+	if !hasMoov {
+		return invalid
+	}
+	return nil
+}
+
+var pngMetadataChunks = map[string]bool{
+	"eXIf": true,
+	"tEXt": true,
+	"zTXt": true,
+	"iTXt": true,
+	"tIME": true,
+}
+
+// copyPNGWithoutMetadata menyalin PNG sampai chunk IEND tanpa chunk teks, waktu, dan EXIF
+func copyPNGWithoutMetadata(dst io.Writer, r *bufio.Reader) error {
+	invalid := model.NewValidationError("File PNG rusak atau tidak valid")
+
+	if _, err := io.CopyN(dst, r, 8); err != nil {
+		return truncated(err, invalid)
+	}
+
+	for {
+		b, err := r.Peek(8)
+		if err != nil {
+			return truncated(err, invalid)
+		}
+		length := int64(binary.BigEndian.Uint32(b[0:4]))
+		chunkType := string(b[4:8])
+
+		out := dst
+		if pngMetadataChunks[chunkType] {
+			out = io.Discard
+		}
+		if _, err := io.CopyN(out, r, 12+length); err != nil {
+			return truncated(err, invalid)
+		}
+
+		if chunkType == "IEND" {
+			return nil
+		}
+	}
+}
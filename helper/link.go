@@ -0,0 +1,90 @@
+package helper
+
+import (
+	"net"
+	"net/url"
+	"os"
+	"regexp"
+	"strings"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+)
+
+const maxLinkLength = 2048
+
+var doiPattern = regexp.MustCompile(`^10\.\d{4,9}/\S+$`)
+
+// LinkPolicy membatasi domain tautan bukti. Allowed kosong berarti semua domain publik diizinkan;
+// Denied selalu menang. Domain mencakup subdomainnya (example.com juga berlaku untuk news.example.com).
+type LinkPolicy struct {
+	Allowed []string
+	Denied  []string
+}
+
+// LinkPolicyFromEnv membaca LINK_ALLOWED_DOMAINS dan LINK_DENIED_DOMAINS (dipisah koma)
+func LinkPolicyFromEnv() LinkPolicy {
+	return LinkPolicy{
+		Allowed: splitDomains(os.Getenv("LINK_ALLOWED_DOMAINS")),
+		Denied:  splitDomains(os.Getenv("LINK_DENIED_DOMAINS")),
+	}
+}
+
+func splitDomains(value string) []string {
+	var domains []string
+	for _, d := range strings.Split(value, ",") {
+		d = strings.Trim(strings.ToLower(strings.TrimSpace(d)), ".")
+		if d != "" {
+			domains = append(domains, d)
+		}
+	}
+	return domains
+}
+
+func domainMatches(host string, domains []string) bool {
+	for _, d := range domains {
+		if host == d || strings.HasSuffix(host, "."+d) {
+			return true
+		}
+	}
+	return false
+}
+
+// NormalizeEvidenceURL memvalidasi tautan bukti dan mengembalikan bentuk bakunya.
+// DOI ("10.xxxx/..." atau "doi:10.xxxx/...") diubah menjadi https://doi.org/<doi>.
+func NormalizeEvidenceURL(raw string, policy LinkPolicy) (string, error) {
+	raw = strings.TrimSpace(raw)
+	if raw == "" {
+		return "", model.NewValidationError("URL tautan wajib diisi")
+	}
+	if len(raw) > maxLinkLength {
+		return "", model.NewValidationError("URL tautan terlalu panjang")
+	}
+
+	if doi := strings.TrimPrefix(strings.TrimPrefix(raw, "doi:"), "DOI:"); doiPattern.MatchString(doi) {
+		raw = "https://doi.org/" + doi
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Hostname() == "" {
+		return "", model.NewValidationError("URL tautan harus diawali http:// atau https:// dan memiliki domain yang valid")
+	}
+	if u.User != nil {
+		return "", model.NewValidationError("URL tautan tidak boleh berisi username atau password")
+	}
+
+	host := strings.TrimSuffix(strings.ToLower(u.Hostname()), ".")
+	if net.ParseIP(host) != nil || host == "localhost" || !strings.Contains(host, ".") {
+		return "", model.NewValidationError("URL tautan harus menggunakan nama domain publik")
+	}
+	if domainMatches(host, policy.Denied) {
+		return "", model.NewValidationError("Domain " + host + " tidak diizinkan sebagai bukti prestasi")
+	}
+	if len(policy.Allowed) > 0 && !domainMatches(host, policy.Allowed) {
+		return "", model.NewValidationError("Domain " + host + " tidak termasuk daftar domain yang diizinkan")
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	u.Fragment = ""
+	return u.String(), nil
+}
@@ -67,6 +67,14 @@ func NotFound(c *fiber.Ctx, message string) error {
 	})
 }
 
+func Conflict(c *fiber.Ctx, message string, data interface{}) error {
+	return c.Status(fiber.StatusConflict).JSON(MetaInfo{
+		Status:  "error",
+		Message: message,
+		Data:    data,
+	})
+}
+
 func InternalServerError(c *fiber.Ctx, message string) error {
 	return c.Status(fiber.StatusInternalServerError).JSON(MetaInfo{
 		Status:  "error",
@@ -0,0 +1,55 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+
+	"sistem-pelaporan-prestasi-mahasiswa/app/model"
+)
+
+// SKPIConfigFromEnv membaca template SKPI dari file JSON di SKPI_CONFIG. Tanpa SKPI_CONFIG dipakai
+// model.DefaultSKPIConfig; penanda tangan dan tempat terbit bisa diisi lewat SKPI_SIGNATORY_NAME,
+// SKPI_SIGNATORY_ID dan SKPI_PLACE untuk template yang belum mengisinya.
+func SKPIConfigFromEnv() (model.SKPIConfig, error) {
+	cfg := model.DefaultSKPIConfig()
+	if path := os.Getenv("SKPI_CONFIG"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return cfg, fmt.Errorf("gagal membaca SKPI_CONFIG: %w", err)
+		}
+		cfg = model.SKPIConfig{}
+		if err := json.Unmarshal(data, &cfg); err != nil {
+			return cfg, fmt.Errorf("SKPI_CONFIG bukan JSON yang valid: %w", err)
+		}
+	}
+
+	if len(cfg.Templates) == 0 {
+		return cfg, fmt.Errorf("SKPI_CONFIG tidak memuat template")
+	}
+	if cfg.DefaultTemplate == "" && len(cfg.Templates) == 1 {
+		for name := range cfg.Templates {
+			cfg.DefaultTemplate = name
+		}
+	}
+	if _, ok := cfg.Templates[cfg.DefaultTemplate]; !ok {
+		return cfg, fmt.Errorf("template SKPI bawaan '%s' tidak ada di SKPI_CONFIG", cfg.DefaultTemplate)
+	}
+
+	for name, t := range cfg.Templates {
+		if t.Title.ID == "" || len(t.Categories) == 0 {
+			return cfg, fmt.Errorf("template SKPI '%s' harus memiliki judul dan minimal satu kategori", name)
+		}
+		if t.SignatoryName == "" {
+			t.SignatoryName = os.Getenv("SKPI_SIGNATORY_NAME")
+		}
+		if t.SignatoryID == "" {
+			t.SignatoryID = os.Getenv("SKPI_SIGNATORY_ID")
+		}
+		if t.Place == "" {
+			t.Place = os.Getenv("SKPI_PLACE")
+		}
+		cfg.Templates[name] = t
+	}
+	return cfg, nil
+}
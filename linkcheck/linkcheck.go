@@ -0,0 +1,154 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"html"
+	"io"
+	"log"
+	"net"
+	"net/http"
+	"os"
+	"regexp"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	userAgent     = "SistemPrestasi-LinkChecker/1.0"
+	maxTitleBytes = 256 * 1024
+	maxTitleRunes = 300
+	maxRedirects  = 5
+)
+
+var (
+	titlePattern = regexp.MustCompile(`(?is)<title[^>]*>(.*?)</title>`)
+
+	// ErrPrivateAddress dikembalikan saat tautan mengarah ke alamat jaringan internal
+	ErrPrivateAddress = errors.New("linkcheck: alamat jaringan internal tidak diizinkan")
+)
+
+// Doer adalah klien HTTP yang dipakai Checker; *http.Client memenuhi interface ini
+type Doer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Result adalah snapshot satu tautan: kode status HTTP, judul halaman, atau pesan error bila tidak bisa diakses
+type Result struct {
+	StatusCode int
+	Title      string
+	Error      string
+}
+
+// Alive menandakan tautan masih bisa dibuka
+func (r Result) Alive() bool {
+	return r.Error == "" && r.StatusCode >= 200 && r.StatusCode < 400
+}
+
+// Checker mengecek apakah tautan bukti masih bisa dibuka
+type Checker interface {
+	Check(ctx context.Context, rawURL string) Result
+}
+
+// NewFromEnv memilih checker sesuai LINK_CHECK_DRIVER (http, none); timeout diatur lewat LINK_CHECK_TIMEOUT (default 15s).
+// Checker nil berarti pengecekan tautan dimatikan.
+func NewFromEnv() (Checker, error) {
+	driver := strings.ToLower(os.Getenv("LINK_CHECK_DRIVER"))
+	if driver == "" {
+		driver = "http"
+	}
+
+	switch driver {
+	case "http":
+		timeout, err := time.ParseDuration(os.Getenv("LINK_CHECK_TIMEOUT"))
+		if err != nil || timeout <= 0 {
+			timeout = 15 * time.Second
+		}
+		return NewDefault(timeout), nil
+
+	case "none":
+		log.Println("⚠️  LINK_CHECK_DRIVER=none: tautan bukti tidak dicek secara berkala")
+		return nil, nil
+	}
+
+	return nil, fmt.Errorf("LINK_CHECK_DRIVER '%s' tidak dikenal (http, none)", driver)
+}
+
+type HTTPChecker struct {
+	client Doer
+}
+
+// NewHTTP membuat checker dengan klien HTTP tertentu (misalnya klien palsu pada test)
+func NewHTTP(client Doer) *HTTPChecker {
+	return &HTTPChecker{client: client}
+}
+
+// NewDefault membuat checker dengan klien HTTP yang menolak alamat privat/loopback agar tidak bisa dipakai untuk SSRF
+func NewDefault(timeout time.Duration) *HTTPChecker {
+	dialer := &net.Dialer{Timeout: timeout, Control: refusePrivateAddress}
+	transport := &http.Transport{
+		Proxy:                 http.ProxyFromEnvironment,
+		DialContext:           dialer.DialContext,
+		TLSHandshakeTimeout:   timeout,
+		ResponseHeaderTimeout: timeout,
+	}
+	return NewHTTP(&http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			if len(via) >= maxRedirects {
+				return fmt.Errorf("terlalu banyak redirect")
+			}
+			return nil
+		},
+	})
+}
+
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || ip.IsLoopback() || ip.IsPrivate() || ip.IsLinkLocalUnicast() || ip.IsLinkLocalMulticast() || ip.IsUnspecified() || ip.IsMulticast() {
+		return ErrPrivateAddress
+	}
+	return nil
+}
+
+// Check membuka tautan dengan GET dan mengambil judul halaman HTML bila ada
+func (c *HTTPChecker) Check(ctx context.Context, rawURL string) Result {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, rawURL, nil)
+	if err != nil {
+		return Result{Error: err.Error()}
+	}
+	req.Header.Set("User-Agent", userAgent)
+	req.Header.Set("Accept", "text/html,application/xhtml+xml,*/*;q=0.8")
+
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return Result{Error: err.Error()}
+	}
+	defer resp.Body.Close()
+
+	result := Result{StatusCode: resp.StatusCode}
+	if strings.Contains(strings.ToLower(resp.Header.Get("Content-Type")), "html") {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, maxTitleBytes))
+		result.Title = extractTitle(body)
+	}
+	return result
+}
+
+func extractTitle(body []byte) string {
+	m := titlePattern.FindSubmatch(body)
+	if m == nil {
+		return ""
+	}
+	title := strings.Join(strings.Fields(html.UnescapeString(string(m[1]))), " ")
+	if runes := []rune(title); len(runes) > maxTitleRunes {
+		title = string(runes[:maxTitleRunes])
+	}
+	return title
+}
@@ -0,0 +1,77 @@
+package linkcheck
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+type fakeDoer struct {
+	status  int
+	ctype   string
+	body    string
+	err     error
+	lastURL string
+}
+
+func (f *fakeDoer) Do(req *http.Request) (*http.Response, error) {
+	f.lastURL = req.URL.String()
+	if f.err != nil {
+		return nil, f.err
+	}
+	return &http.Response{
+		StatusCode: f.status,
+		Header:     http.Header{"Content-Type": []string{f.ctype}},
+		Body:       io.NopCloser(strings.NewReader(f.body)),
+	}, nil
+}
+
+func TestCheck(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("HTML Title", func(t *testing.T) {
+		doer := &fakeDoer{status: 200, ctype: "text/html; charset=utf-8", body: "<html><head><TITLE>\n Juara 1 &amp; Medali Emas\n</TITLE></head></html>"}
+		result := NewHTTP(doer).Check(ctx, "https://news.example.com/juara")
+		if !result.Alive() || result.Title != "Juara 1 & Medali Emas" {
+			t.Errorf("Unexpected result %+v", result)
+		}
+	})
+
+	t.Run("Dead Link", func(t *testing.T) {
+		result := NewHTTP(&fakeDoer{status: 404, ctype: "text/html"}).Check(ctx, "https://example.com/hilang")
+		if result.Alive() || result.StatusCode != 404 {
+			t.Errorf("Expected dead link, got %+v", result)
+		}
+	})
+
+	t.Run("Network Error", func(t *testing.T) {
+		result := NewHTTP(&fakeDoer{err: errors.New("no such host")}).Check(ctx, "https://tidak-ada.example")
+		if result.Alive() || result.Error == "" {
+			t.Errorf("Expected error result, got %+v", result)
+		}
+	})
+
+	t.Run("Non HTML Has No Title", func(t *testing.T) {
+		result := NewHTTP(&fakeDoer{status: 200, ctype: "application/pdf", body: "<title>x</title>"}).Check(ctx, "https://example.com/a.pdf")
+		if result.Title != "" {
+			t.Errorf("Expected empty title, got %q", result.Title)
+		}
+	})
+}
+
+func TestDefaultClientRefusesPrivateAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("<title>internal</title>"))
+	}))
+	defer srv.Close()
+
+	result := NewDefault(2*time.Second).Check(context.Background(), srv.URL)
+	if result.Alive() || !strings.Contains(result.Error, ErrPrivateAddress.Error()) {
+		t.Errorf("Expected loopback address to be refused, got %+v", result)
+	}
+}
@@ -1,13 +1,21 @@
 package main
 
 import (
+	"context"
 	"log"
 	"os"
+	"time"
 
 	"sistem-pelaporan-prestasi-mahasiswa/app/repository"
 	"sistem-pelaporan-prestasi-mahasiswa/app/service"
+	"sistem-pelaporan-prestasi-mahasiswa/command"
 	"sistem-pelaporan-prestasi-mahasiswa/database"
+	"sistem-pelaporan-prestasi-mahasiswa/export"
+	"sistem-pelaporan-prestasi-mahasiswa/helper"
+	"sistem-pelaporan-prestasi-mahasiswa/linkcheck"
 	"sistem-pelaporan-prestasi-mahasiswa/route"
+	"sistem-pelaporan-prestasi-mahasiswa/scanner"
+	"sistem-pelaporan-prestasi-mahasiswa/storage"
 
 	"github.com/gofiber/fiber/v2"
 	"github.com/gofiber/fiber/v2/middleware/cors"
@@ -53,28 +61,123 @@ func main() {
 	if err != nil {
 		log.Fatal("❌ Gagal konek MongoDB: ", err)
 	}
+	if err := database.EnsureMongoIndexes(context.Background(), mongoDB); err != nil {
+		log.Printf("⚠️  %v", err)
+	}
+
+	store, err := storage.NewFromEnv()
+	if err != nil {
+		log.Fatal("❌ Gagal menyiapkan storage: ", err)
+	}
+
+	virusScanner, err := scanner.NewFromEnv()
+	if err != nil {
+		log.Fatal("❌ Gagal menyiapkan antivirus scanner: ", err)
+	}
+
+	linkChecker, err := linkcheck.NewFromEnv()
+	if err != nil {
+		log.Fatal("❌ Gagal menyiapkan link checker: ", err)
+	}
+
+	letterhead, err := export.LetterheadFromEnv()
+	if err != nil {
+		log.Fatal("❌ Gagal menyiapkan kop surat laporan: ", err)
+	}
+
+	skpiConfig, err := helper.SKPIConfigFromEnv()
+	if err != nil {
+		log.Fatal("❌ Gagal menyiapkan template SKPI: ", err)
+	}
 
 	userRepo := repository.NewUserRepository(pgDB)
 	studentRepo := repository.NewStudentRepository(pgDB)
 	lecturerRepo := repository.NewLecturerRepository(pgDB)
 	authRepo := repository.NewAuthRepository(pgDB)
 	achievementRepo := repository.NewAchievementRepository(pgDB, mongoDB)
-	reportRepo := repository.NewReportRepository(pgDB, mongoDB)
-
+	reportRepo := repository.NewReportRepository(pgDB)
+	achievementTypeRepo := repository.NewAchievementTypeRepository(pgDB)
+	achievementMemberRepo := repository.NewAchievementMemberRepository(pgDB)
+	achievementCommentRepo := repository.NewAchievementCommentRepository(mongoDB)
+	achievementRevisionRepo := repository.NewAchievementRevisionRepository(pgDB, mongoDB)
+	attachmentScanRepo := repository.NewAttachmentScanRepository(mongoDB)
+	attachmentRepo := repository.NewAttachmentRepository(mongoDB)
+	uploadSessionRepo := repository.NewUploadSessionRepository(mongoDB)
+	evidenceLinkRepo := repository.NewEvidenceLinkRepository(mongoDB)
+	achievementReadModelRepo := repository.NewAchievementReadModelRepository(pgDB, mongoDB)
+	outboxRepo := repository.NewOutboxRepository(pgDB, mongoDB)
+	reconcileRepo := repository.NewReconcileRepository(pgDB, mongoDB)
+	achievementTrashRepo := repository.NewAchievementTrashRepository(pgDB, mongoDB)
+	skpiRepo := repository.NewSKPIRepository(pgDB, mongoDB)
+	accreditationRepo := repository.NewAccreditationRepository(pgDB, mongoDB)
+	studyProgramRepo := repository.NewStudyProgramRepository(pgDB)
 
 	lecturerSvc := service.NewLecturerService(lecturerRepo)
-	studentSvc := service.NewStudentService(studentRepo, lecturerSvc)
+	studentSvc := service.NewStudentService(studentRepo, lecturerSvc, letterhead)
 	userSvc := service.NewUserService(userRepo, studentSvc, lecturerSvc, pgDB)
 	authSvc := service.NewAuthService(authRepo)
-	achievementSvc := service.NewAchievementService(achievementRepo, studentRepo, lecturerSvc)
-	reportSvc := service.NewReportService(reportRepo, studentRepo, lecturerSvc)
+	achievementSvc := service.NewAchievementService(achievementRepo, studentRepo, achievementTypeRepo, achievementMemberRepo, achievementRevisionRepo, lecturerSvc, store, letterhead)
+	achievementMemberSvc := service.NewAchievementMemberService(achievementRepo, achievementMemberRepo, studentRepo, lecturerSvc)
+	achievementCommentSvc := service.NewAchievementCommentService(achievementCommentRepo, achievementRepo, achievementMemberRepo, studentRepo, lecturerSvc, store)
+	achievementRevisionSvc := service.NewAchievementRevisionService(achievementRevisionRepo, achievementRepo, achievementMemberRepo, studentRepo, lecturerSvc)
+	uploadSessionSvc := service.NewUploadSessionService(uploadSessionRepo, achievementRepo, studentRepo, achievementTypeRepo, achievementRevisionRepo, store)
+	evidenceLinkSvc := service.NewEvidenceLinkService(evidenceLinkRepo, achievementRepo, studentRepo, achievementRevisionRepo, linkChecker, helper.LinkPolicyFromEnv())
+	reportSvc := service.NewReportService(reportRepo, studentRepo, lecturerSvc, letterhead)
+	achievementTypeSvc := service.NewAchievementTypeService(achievementTypeRepo, achievementRepo)
+	fileSvc := service.NewFileService(store, attachmentRepo)
+	attachmentScanSvc := service.NewAttachmentScanService(attachmentScanRepo, store, virusScanner)
+	attachmentGCSvc := service.NewAttachmentGCService(attachmentRepo, store)
+	attachmentIntegritySvc := service.NewAttachmentIntegrityService(attachmentRepo, store)
+	achievementReadModelSvc := service.NewAchievementReadModelService(achievementReadModelRepo)
+	outboxSvc := service.NewOutboxService(outboxRepo)
+	reconcileSvc := service.NewReconcileService(reconcileRepo)
+	achievementTrashSvc := service.NewAchievementTrashService(achievementTrashRepo, achievementRepo, store)
+	skpiSvc := service.NewSKPIService(skpiRepo, studentRepo, skpiConfig, letterhead)
+	accreditationSvc := service.NewAccreditationService(accreditationRepo, letterhead)
+	studyProgramSvc := service.NewStudyProgramService(studyProgramRepo)
+
+	// Prestasi di tempat sampah dihapus permanen setelah masa retensi (default 30 hari)
+	trashRetention, err := time.ParseDuration(os.Getenv("TRASH_RETENTION"))
+	if err != nil || trashRetention <= 0 {
+		trashRetention = 30 * 24 * time.Hour
+	}
+
+	if len(os.Args) > 1 {
+		deps := &command.Deps{
+			PgDB:                    pgDB,
+			MongoDB:                 mongoDB,
+			AchievementTypeSvc:      achievementTypeSvc,
+			AttachmentScanSvc:       attachmentScanSvc,
+			AttachmentGCSvc:         attachmentGCSvc,
+			AttachmentIntegritySvc:  attachmentIntegritySvc,
+			AchievementReadModelSvc: achievementReadModelSvc,
+			ReconcileSvc:            reconcileSvc,
+			AchievementTrashSvc:     achievementTrashSvc,
+			SKPISvc:                 skpiSvc,
+			TrashRetention:          trashRetention,
+		}
+		if err := command.Run(context.Background(), deps, os.Args[1:]); err != nil {
+			log.Fatal("❌ ", err)
+		}
+		return
+	}
+
+	scanInterval, err := time.ParseDuration(os.Getenv("SCAN_INTERVAL"))
+	if err != nil || scanInterval <= 0 {
+		scanInterval = 10 * time.Second
+	}
+	go attachmentScanSvc.Run(context.Background(), scanInterval)
+	go uploadSessionSvc.Run(context.Background(), time.Hour)
+	go outboxSvc.Run(context.Background(), 15*time.Second)
+	go achievementTrashSvc.Run(context.Background(), 24*time.Hour, trashRetention)
+	if linkChecker != nil {
+		go evidenceLinkSvc.Run(context.Background(), 5*time.Minute)
+	}
 
 	app := fiber.New()
 	app.Use(cors.New())
 	app.Use(logger.New())
 
-	app.Static("/uploads", "./uploads")
-
 	app.Get("/", func(c *fiber.Ctx) error {
 		return c.JSON(fiber.Map{
 			"message": "Server berjalan dengan koneksi Hybrid (Postgres + Mongo)",
@@ -90,8 +193,12 @@ func main() {
 	route.RegisterUserRoutes(api, userSvc)
 	route.RegisterStudentRoutes(api, studentSvc, achievementSvc)
 	route.RegisterLecturerRoutes(api, lecturerSvc)
-	route.RegisterAchievementRoutes(api, achievementSvc)
-	route.RegisterReportRoutes(api, reportSvc)
+	route.RegisterAchievementRoutes(api, achievementSvc, achievementMemberSvc, achievementCommentSvc, achievementRevisionSvc, uploadSessionSvc, evidenceLinkSvc, achievementTrashSvc)
+	route.RegisterAchievementTypeRoutes(api, achievementTypeSvc)
+	route.RegisterReportRoutes(api, reportSvc, accreditationSvc)
+	route.RegisterFileRoutes(api, fileSvc)
+	route.RegisterSKPIRoutes(api, skpiSvc)
+	route.RegisterStudyProgramRoutes(api, studyProgramSvc)
 
 	port := os.Getenv("APP_PORT")
 	if port == "" {
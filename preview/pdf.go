@@ -0,0 +1,392 @@
+package preview
+
+import (
+	"bytes"
+	"compress/zlib"
+	"image"
+	"image/color"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+)
+
+const (
+	// Gambar dengan kedua sisi minimal sebesar ini dianggap isi halaman, bukan logo atau ikon
+	minPageImageSide = 200
+	// Batas kedalaman pohon halaman dan Form XObject bersarang, sekaligus pengaman dari referensi melingkar
+	maxPDFNesting = 32
+)
+
+var (
+	pdfImageSubtype  = regexp.MustCompile(`/Subtype\s*/Image`)
+	pdfObjHeader     = regexp.MustCompile(`(\d+)\s+\d+\s+obj\b`)
+	pdfSMaskRef      = regexp.MustCompile(`/SMask\s+(\d+)\s+\d+\s+R`)
+	pdfWidth         = regexp.MustCompile(`/Width\s+(\d+)`)
+	pdfHeight        = regexp.MustCompile(`/Height\s+(\d+)`)
+	pdfBits          = regexp.MustCompile(`/BitsPerComponent\s+(\d+)`)
+	pdfFilter        = regexp.MustCompile(`/Filter\s*\[?\s*/(\w+)`)
+	pdfColorSpace    = regexp.MustCompile(`/ColorSpace\s*(\[\s*)?/(\w+)`)
+	pdfPredictor     = regexp.MustCompile(`/Predictor\s+(\d+)`)
+	pdfImageMaskTrue = regexp.MustCompile(`/ImageMask\s+true`)
+	pdfRootRef       = regexp.MustCompile(`/Root\s+(\d+)\s+\d+\s+R`)
+	pdfPagesRef      = regexp.MustCompile(`/Pages\s+(\d+)\s+\d+\s+R`)
+	pdfFirstKid      = regexp.MustCompile(`/Kids\s*\[\s*(\d+)\s+\d+\s+R`)
+	pdfResources     = regexp.MustCompile(`/Resources\s*(?:(\d+)\s+\d+\s+R|<<)`)
+	pdfXObject       = regexp.MustCompile(`/XObject\s*(?:(\d+)\s+\d+\s+R|<<)`)
+	pdfRef           = regexp.MustCompile(`(\d+)\s+\d+\s+R\b`)
+	pdfFormSubtype   = regexp.MustCompile(`/Subtype\s*/Form`)
+)
+
+type pdfImage struct {
+	objNum     string
+	width      int
+	height     int
+	bits       int
+	filter     string
+	colorSpace string
+	indexed    bool
+	predictor  int
+	data       []byte
+}
+
+// firstPDFImage mengambil gambar raster pertama yang cukup besar di halaman 1 PDF (DCTDecode atau FlateDecode
+// 8 bit). Halaman tidak dirender: teks dan grafik vektor diabaikan, sehingga halaman 1 tanpa gambar raster
+// tidak punya pratinjau walaupun halaman berikutnya punya. Bila pohon halaman tidak bisa dibaca (misalnya
+// tersimpan di object stream terkompresi), gambar dicari di seluruh dokumen.
+func firstPDFImage(data []byte) (image.Image, error) {
+	candidates := pdfImages(data)
+	if onPage, ok := pdfFirstPageImages(data); ok {
+		filtered := candidates[:0]
+		for _, c := range candidates {
+			if onPage[c.objNum] {
+				filtered = append(filtered, c)
+			}
+		}
+		candidates = filtered
+	}
+	if len(candidates) == 0 {
+		return nil, ErrNoImage
+	}
+
+	// Urutan dokumen dipertahankan untuk gambar seukuran halaman; sisanya diurutkan dari yang terbesar
+	sort.SliceStable(candidates, func(i, j int) bool {
+		bi := candidates[i].width >= minPageImageSide && candidates[i].height >= minPageImageSide
+		bj := candidates[j].width >= minPageImageSide && candidates[j].height >= minPageImageSide
+		if bi != bj {
+			return bi
+		}
+		if bi {
+			return false
+		}
+		return candidates[i].width*candidates[i].height > candidates[j].width*candidates[j].height
+	})
+
+	for _, c := range candidates {
+		if img, err := c.decode(); err == nil {
+			return img, nil
+		}
+	}
+	return nil, ErrNoImage
+}
+
+// pdfFirstPageImages mengembalikan nomor objek XObject yang dipakai halaman 1, termasuk yang ada di dalam
+// Form XObject. ok bernilai false bila halaman 1 tidak bisa ditemukan.
+func pdfFirstPageImages(data []byte) (map[string]bool, bool) {
+	objs := pdfObjects(data)
+
+	roots := pdfRootRef.FindAllSubmatch(data, -1)
+	if len(roots) == 0 {
+		return nil, false
+	}
+	pages := pdfPagesRef.FindSubmatch(objs[string(roots[len(roots)-1][1])])
+	if pages == nil {
+		return nil, false
+	}
+
+	// Turun lewat anak pertama sampai daun; Resources boleh diwarisi dari node Pages di atasnya
+	node := objs[string(pages[1])]
+	var resources []byte
+	for depth := 0; ; depth++ {
+		if node == nil || depth > maxPDFNesting {
+			return nil, false
+		}
+		if res := pdfSubDict(objs, node, pdfResources); res != nil {
+			resources = res
+		}
+		kid := pdfFirstKid.FindSubmatch(node)
+		if kid == nil {
+			break
+		}
+		node = objs[string(kid[1])]
+	}
+
+	used := make(map[string]bool)
+	var collect func(resources []byte, depth int)
+	collect = func(resources []byte, depth int) {
+		for _, ref := range pdfRef.FindAllSubmatch(pdfSubDict(objs, resources, pdfXObject), -1) {
+			num := string(ref[1])
+			if used[num] {
+				continue
+			}
+			used[num] = true
+			if obj := objs[num]; pdfFormSubtype.Match(obj) && depth < maxPDFNesting {
+				collect(pdfSubDict(objs, obj, pdfResources), depth+1)
+			}
+		}
+	}
+	collect(resources, 0)
+	return used, true
+}
+
+// pdfObjects memetakan nomor objek ke dictionary-nya (bagian sebelum stream). Objek yang muncul lagi
+// karena incremental update menimpa versi sebelumnya.
+func pdfObjects(data []byte) map[string][]byte {
+	objs := make(map[string][]byte)
+	for _, h := range pdfObjHeader.FindAllSubmatchIndex(data, -1) {
+		body := data[h[1]:]
+		if end := bytes.Index(body, []byte("endobj")); end >= 0 {
+			body = body[:end]
+		}
+		if end := bytes.Index(body, []byte("stream")); end >= 0 {
+			body = body[:end]
+		}
+		objs[string(data[h[2]:h[3]])] = body
+	}
+	return objs
+}
+
+// pdfSubDict mengambil nilai entri dict (mis. /Resources), baik ditulis langsung maupun sebagai referensi objek
+func pdfSubDict(objs map[string][]byte, dict []byte, key *regexp.Regexp) []byte {
+	m := key.FindSubmatchIndex(dict)
+	if m == nil {
+		return nil
+	}
+	if m[2] >= 0 {
+		return objs[string(dict[m[2]:m[3]])]
+	}
+
+	start, depth := m[1]-2, 0
+	for i := start; i+1 < len(dict); i++ {
+		switch {
+		case dict[i] == '<' && dict[i+1] == '<':
+			depth++
+			i++
+		case dict[i] == '>' && dict[i+1] == '>':
+			depth--
+			i++
+			if depth == 0 {
+				return dict[start : i+1]
+			}
+		}
+	}
+	return dict[start:]
+}
+
+func pdfImages(data []byte) []pdfImage {
+	masks := make(map[string]bool)
+	for _, m := range pdfSMaskRef.FindAllSubmatch(data, -1) {
+		masks[string(m[1])] = true
+	}
+
+	var images []pdfImage
+	for _, loc := range pdfImageSubtype.FindAllIndex(data, -1) {
+		headers := pdfObjHeader.FindAllSubmatchIndex(data[:loc[0]], -1)
+		if len(headers) == 0 {
+			continue
+		}
+		header := headers[len(headers)-1]
+		objNum := string(data[header[2]:header[3]])
+
+		streamAt := bytes.Index(data[loc[1]:], []byte("stream"))
+		if streamAt < 0 {
+			continue
+		}
+		streamAt += loc[1]
+		dict := data[header[1]:streamAt]
+		if masks[objNum] || pdfImageMaskTrue.Match(dict) {
+			continue
+		}
+
+		start := streamAt + len("stream")
+		if bytes.HasPrefix(data[start:], []byte("\r\n")) {
+			start += 2
+		} else if bytes.HasPrefix(data[start:], []byte("\n")) {
+			start++
+		}
+		end := bytes.Index(data[start:], []byte("endstream"))
+		if end < 0 {
+			continue
+		}
+
+		img := pdfImage{
+			objNum:    objNum,
+			width:     pdfInt(pdfWidth, dict),
+			height:    pdfInt(pdfHeight, dict),
+			bits:      pdfInt(pdfBits, dict),
+			predictor: pdfInt(pdfPredictor, dict),
+			data:      data[start : start+end],
+		}
+		if m := pdfFilter.FindSubmatch(dict); m != nil {
+			img.filter = string(m[1])
+		}
+		if m := pdfColorSpace.FindSubmatch(dict); m != nil {
+			img.colorSpace = string(m[2])
+			img.indexed = len(m[1]) > 0 && img.colorSpace == "Indexed"
+		}
+		if img.width > 0 && img.height > 0 {
+			images = append(images, img)
+		}
+	}
+	return images
+}
+
+func pdfInt(re *regexp.Regexp, dict []byte) int {
+	m := re.FindSubmatch(dict)
+	if m == nil {
+		return 0
+	}
+	n, _ := strconv.Atoi(string(m[1]))
+	return n
+}
+
+func (p pdfImage) decode() (image.Image, error) {
+	if err := checkDimensions(p.width, p.height); err != nil {
+		return nil, err
+	}
+
+	switch p.filter {
+	case "DCTDecode":
+		return decodeImage(p.data)
+	case "FlateDecode":
+		return p.decodeFlate()
+	}
+	return nil, ErrNoImage
+}
+
+// decodeFlate mendukung piksel 8 bit Gray/RGB/CMYK, dengan atau tanpa PNG predictor
+func (p pdfImage) decodeFlate() (image.Image, error) {
+	if p.indexed || (p.bits != 0 && p.bits != 8) {
+		return nil, ErrNoImage
+	}
+
+	components := map[string]int{"DeviceGray": 1, "DeviceRGB": 3, "DeviceCMYK": 4}[p.colorSpace]
+
+	zr, err := zlib.NewReader(bytes.NewReader(p.data))
+	if err != nil {
+		return nil, err
+	}
+	defer zr.Close()
+
+	limit := int64(p.width*p.height*4 + p.height)
+	raw, err := io.ReadAll(io.LimitReader(zr, limit+1))
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return nil, err
+	}
+	if int64(len(raw)) > limit {
+		return nil, ErrTooLarge
+	}
+
+	pixels := p.width * p.height
+	if components == 0 {
+		// ColorSpace berupa referensi (mis. ICCBased): jumlah komponen diturunkan dari ukuran data
+		rowExtra := 0
+		if p.predictor >= 10 {
+			rowExtra = p.height
+		}
+		if (len(raw)-rowExtra)%pixels != 0 {
+			return nil, ErrNoImage
+		}
+		components = (len(raw) - rowExtra) / pixels
+	}
+	if components != 1 && components != 3 && components != 4 {
+		return nil, ErrNoImage
+	}
+
+	if p.predictor >= 10 {
+		raw, err = unfilterPNGRows(raw, p.width*components, components, p.height)
+		if err != nil {
+			return nil, err
+		}
+	}
+	if len(raw) < pixels*components {
+		return nil, ErrNoImage
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, p.width, p.height))
+	for i := 0; i < pixels; i++ {
+		px := raw[i*components : (i+1)*components]
+		var c color.RGBA
+		switch components {
+		case 1:
+			c = color.RGBA{px[0], px[0], px[0], 0xFF}
+		case 3:
+			c = color.RGBA{px[0], px[1], px[2], 0xFF}
+		case 4:
+			r, g, b := color.CMYKToRGB(px[0], px[1], px[2], px[3])
+			c = color.RGBA{r, g, b, 0xFF}
+		}
+		img.Pix[i*4], img.Pix[i*4+1], img.Pix[i*4+2], img.Pix[i*4+3] = c.R, c.G, c.B, c.A
+	}
+	return img, nil
+}
+
+// unfilterPNGRows membalik PNG predictor (None, Sub, Up, Average, Paeth) per baris
+func unfilterPNGRows(raw []byte, rowLen, bpp, rows int) ([]byte, error) {
+	if len(raw) < rows*(rowLen+1) {
+		return nil, ErrNoImage
+	}
+
+	out := make([]byte, rows*rowLen)
+	prev := make([]byte, rowLen)
+	for y := 0; y < rows; y++ {
+		filter := raw[y*(rowLen+1)]
+		line := raw[y*(rowLen+1)+1 : (y+1)*(rowLen+1)]
+		cur := out[y*rowLen : (y+1)*rowLen]
+
+		for x := 0; x < rowLen; x++ {
+			var left, upLeft byte
+			if x >= bpp {
+				left = cur[x-bpp]
+				upLeft = prev[x-bpp]
+			}
+			up := prev[x]
+
+			switch filter {
+			case 0:
+				cur[x] = line[x]
+			case 1:
+				cur[x] = line[x] + left
+			case 2:
+				cur[x] = line[x] + up
+			case 3:
+				cur[x] = line[x] + byte((int(left)+int(up))/2)
+			case 4:
+				cur[x] = line[x] + paeth(left, up, upLeft)
+			default:
+				return nil, ErrNoImage
+			}
+		}
+		prev = cur
+	}
+	return out, nil
+}
+
+func paeth(a, b, c byte) byte {
+	p := int(a) + int(b) - int(c)
+	pa, pb, pc := abs(p-int(a)), abs(p-int(b)), abs(p-int(c))
+	if pa <= pb && pa <= pc {
+		return a
+	}
+	if pb <= pc {
+		return b
+	}
+	return c
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
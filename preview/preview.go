@@ -0,0 +1,148 @@
+package preview
+
+import (
+	"bytes"
+	"errors"
+	"image"
+	"image/color"
+	"image/draw"
+	"image/jpeg"
+	_ "image/png"
+)
+
+const (
+	// ThumbnailSize dan PreviewSize adalah sisi terpanjang gambar hasil (piksel)
+	ThumbnailSize = 320
+	PreviewSize   = 1024
+
+	// Batas piksel sumber untuk mencegah decompression bomb
+	maxSourcePixels = 40_000_000
+
+	jpegQuality = 80
+)
+
+var (
+	// ErrUnsupported dikembalikan untuk tipe file yang tidak punya pratinjau (misalnya video)
+	ErrUnsupported = errors.New("preview: tipe file tidak didukung")
+	// ErrNoImage dikembalikan bila PDF tidak memuat gambar raster yang bisa dijadikan pratinjau
+	ErrNoImage = errors.New("preview: tidak ada gambar yang bisa ditampilkan")
+	// ErrTooLarge dikembalikan bila dimensi gambar sumber melebihi batas
+	ErrTooLarge = errors.New("preview: dimensi gambar terlalu besar")
+)
+
+// Result berisi thumbnail dan gambar pratinjau dalam format JPEG
+type Result struct {
+	Thumbnail []byte
+	Preview   []byte
+}
+
+// Generate membuat thumbnail dan pratinjau dari file yang sudah lolos sanitasi.
+// Gambar diperkecil langsung; untuk PDF dipakai gambar raster di halaman 1 (umumnya hasil pindai
+// sertifikat), karena merender teks dan grafik vektor PDF membutuhkan renderer eksternal.
+func Generate(data []byte, contentType string) (*Result, error) {
+	var (
+		img image.Image
+		err error
+	)
+	switch contentType {
+	case "image/jpeg", "image/png":
+		img, err = decodeImage(data)
+	case "application/pdf":
+		img, err = firstPDFImage(data)
+	default:
+		return nil, ErrUnsupported
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	preview, err := encodeJPEG(resize(img, PreviewSize))
+	if err != nil {
+		return nil, err
+	}
+	thumbnail, err := encodeJPEG(resize(img, ThumbnailSize))
+	if err != nil {
+		return nil, err
+	}
+	return &Result{Thumbnail: thumbnail, Preview: preview}, nil
+}
+
+func decodeImage(data []byte) (image.Image, error) {
+	cfg, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return nil, err
+	}
+	if err := checkDimensions(cfg.Width, cfg.Height); err != nil {
+		return nil, err
+	}
+	img, _, err := image.Decode(bytes.NewReader(data))
+	return img, err
+}
+
+func checkDimensions(width, height int) error {
+	if width <= 0 || height <= 0 {
+		return ErrNoImage
+	}
+	if int64(width)*int64(height) > maxSourcePixels {
+		return ErrTooLarge
+	}
+	return nil
+}
+
+func encodeJPEG(img image.Image) ([]byte, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: jpegQuality}); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// resize memperkecil gambar (rata-rata area) agar sisi terpanjangnya maksimal size.
+// Gambar ditempel di atas latar putih supaya PNG transparan tidak menjadi hitam saat disimpan sebagai JPEG.
+func resize(src image.Image, size int) *image.RGBA {
+	b := src.Bounds()
+	srcW, srcH := b.Dx(), b.Dy()
+
+	dstW, dstH := srcW, srcH
+	if srcW > size || srcH > size {
+		if srcW >= srcH {
+			dstW, dstH = size, max(1, srcH*size/srcW)
+		} else {
+			dstW, dstH = max(1, srcW*size/srcH), size
+		}
+	}
+
+	flat := image.NewRGBA(image.Rect(0, 0, srcW, srcH))
+	draw.Draw(flat, flat.Bounds(), &image.Uniform{C: color.White}, image.Point{}, draw.Src)
+	draw.Draw(flat, flat.Bounds(), src, b.Min, draw.Over)
+	if dstW == srcW && dstH == srcH {
+		return flat
+	}
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := y*srcH/dstH, max((y+1)*srcH/dstH, y*srcH/dstH+1)
+		for x := 0; x < dstW; x++ {
+			x0, x1 := x*srcW/dstW, max((x+1)*srcW/dstW, x*srcW/dstW+1)
+
+			var r, g, bl, n uint64
+			for sy := y0; sy < y1; sy++ {
+				row := flat.Pix[sy*flat.Stride:]
+				for sx := x0; sx < x1; sx++ {
+					p := row[sx*4 : sx*4+3]
+					r += uint64(p[0])
+					g += uint64(p[1])
+					bl += uint64(p[2])
+					n++
+				}
+			}
+
+			i := dst.PixOffset(x, y)
+			dst.Pix[i] = uint8(r / n)
+			dst.Pix[i+1] = uint8(g / n)
+			dst.Pix[i+2] = uint8(bl / n)
+			dst.Pix[i+3] = 0xFF
+		}
+	}
+	return dst
+}
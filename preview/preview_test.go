@@ -0,0 +1,196 @@
+package preview
+
+import (
+	"bytes"
+	"compress/zlib"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"testing"
+)
+
+func testImage(w, h int, c color.Color) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, w, h))
+	for y := 0; y < h; y++ {
+		for x := 0; x < w; x++ {
+			img.Set(x, y, c)
+		}
+	}
+	return img
+}
+
+func decodedSize(t *testing.T, data []byte) (int, int) {
+	t.Helper()
+	cfg, err := jpeg.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("Output is not a JPEG: %v", err)
+	}
+	return cfg.Width, cfg.Height
+}
+
+func buildPDF(objects ...string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString("%PDF-1.4\n")
+	for i, obj := range objects {
+		fmt.Fprintf(&buf, "%d 0 obj\n%s\nendobj\n", i+1, obj)
+	}
+	buf.WriteString("trailer\n<< /Root 1 0 R >>\nstartxref\n0\n%%EOF\n")
+	return buf.Bytes()
+}
+
+func imageObject(dict string, data []byte) string {
+	return fmt.Sprintf("<< /Type /XObject /Subtype /Image %s /Length %d >>\nstream\n%s\nendstream", dict, len(data), data)
+}
+
+func TestGenerateImage(t *testing.T) {
+	var buf bytes.Buffer
+	png.Encode(&buf, testImage(2000, 1000, color.RGBA{0, 0, 0, 0}))
+
+	result, err := Generate(buf.Bytes(), "image/png")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if w, h := decodedSize(t, result.Thumbnail); w != ThumbnailSize || h != ThumbnailSize/2 {
+		t.Errorf("Expected thumbnail %dx%d, got %dx%d", ThumbnailSize, ThumbnailSize/2, w, h)
+	}
+	if w, h := decodedSize(t, result.Preview); w != PreviewSize || h != PreviewSize/2 {
+		t.Errorf("Expected preview %dx%d, got %dx%d", PreviewSize, PreviewSize/2, w, h)
+	}
+
+	img, _ := jpeg.Decode(bytes.NewReader(result.Thumbnail))
+	if r, g, b, _ := img.At(10, 10).RGBA(); r>>8 < 240 || g>>8 < 240 || b>>8 < 240 {
+		t.Errorf("Expected transparent pixels on white background, got %d,%d,%d", r>>8, g>>8, b>>8)
+	}
+}
+
+func TestGenerateSmallImageNotUpscaled(t *testing.T) {
+	var buf bytes.Buffer
+	jpeg.Encode(&buf, testImage(100, 50, color.RGBA{200, 0, 0, 255}), nil)
+
+	result, err := Generate(buf.Bytes(), "image/jpeg")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if w, h := decodedSize(t, result.Thumbnail); w != 100 || h != 50 {
+		t.Errorf("Expected original size 100x50, got %dx%d", w, h)
+	}
+}
+
+func TestGeneratePDF(t *testing.T) {
+	var scan bytes.Buffer
+	jpeg.Encode(&scan, testImage(800, 600, color.RGBA{0, 0, 200, 255}), nil)
+	var logo bytes.Buffer
+	jpeg.Encode(&logo, testImage(40, 40, color.RGBA{0, 200, 0, 255}), nil)
+
+	t.Run("DCT Scan Skips Logo And Mask", func(t *testing.T) {
+		pdf := buildPDF(
+			"<< /Type /Catalog >>",
+			imageObject("/Width 40 /Height 40 /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode", logo.Bytes()),
+			imageObject("/Width 900 /Height 900 /ColorSpace /DeviceGray /BitsPerComponent 8 /Filter /FlateDecode", nil),
+			imageObject("/Width 800 /Height 600 /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode /SMask 3 0 R", scan.Bytes()),
+		)
+
+		result, err := Generate(pdf, "application/pdf")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if w, h := decodedSize(t, result.Preview); w != 800 || h != 600 {
+			t.Errorf("Expected the scanned page (800x600), got %dx%d", w, h)
+		}
+	})
+
+	t.Run("Flate RGB With PNG Predictor", func(t *testing.T) {
+		const w, h = 300, 200
+		var raw bytes.Buffer
+		for y := 0; y < h; y++ {
+			raw.WriteByte(2) // Up
+			for x := 0; x < w; x++ {
+				if y == 0 {
+					raw.Write([]byte{255, 128, 0})
+				} else {
+					raw.Write([]byte{0, 0, 0})
+				}
+			}
+		}
+		var compressed bytes.Buffer
+		zw := zlib.NewWriter(&compressed)
+		zw.Write(raw.Bytes())
+		zw.Close()
+
+		pdf := buildPDF(imageObject(
+			fmt.Sprintf("/Width %d /Height %d /ColorSpace 5 0 R /BitsPerComponent 8 /Filter /FlateDecode /DecodeParms << /Predictor 15 /Colors 3 /Columns %d >>", w, h, w),
+			compressed.Bytes(),
+		))
+
+		result, err := Generate(pdf, "application/pdf")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		img, _ := jpeg.Decode(bytes.NewReader(result.Preview))
+		if r, g, b, _ := img.At(150, 150).RGBA(); r>>8 < 230 || g>>8 < 100 || g>>8 > 160 || b>>8 > 30 {
+			t.Errorf("Expected orange pixel, got %d,%d,%d", r>>8, g>>8, b>>8)
+		}
+	})
+
+	t.Run("Text Only PDF", func(t *testing.T) {
+		pdf := buildPDF("<< /Type /Catalog >>", "<< /Length 10 >>\nstream\nBT ET\nendstream")
+		if _, err := Generate(pdf, "application/pdf"); !errors.Is(err, ErrNoImage) {
+			t.Errorf("Expected ErrNoImage, got %v", err)
+		}
+	})
+
+	var cover bytes.Buffer
+	jpeg.Encode(&cover, testImage(400, 300, color.RGBA{200, 0, 0, 255}), nil)
+
+	t.Run("Only Images On Page One", func(t *testing.T) {
+		pdf := buildPDF(
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 /Resources << /ProcSet [/PDF] >> >>",
+			"<< /Type /Page /Parent 2 0 R /Resources << /XObject << /Fm1 5 0 R >> >> >>",
+			"<< /Type /Page /Parent 2 0 R /Resources 7 0 R >>",
+			"<< /Type /XObject /Subtype /Form /Resources << /XObject << /Im1 8 0 R >> >> /Length 0 >>\nstream\n\nendstream",
+			imageObject("/Width 800 /Height 600 /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode", scan.Bytes()),
+			"<< /XObject << /Im2 6 0 R >> >>",
+			imageObject("/Width 400 /Height 300 /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode", cover.Bytes()),
+		)
+
+		result, err := Generate(pdf, "application/pdf")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if w, h := decodedSize(t, result.Preview); w != 400 || h != 300 {
+			t.Errorf("Expected the image drawn on page 1 (400x300), got %dx%d", w, h)
+		}
+	})
+
+	t.Run("Page One Without Images", func(t *testing.T) {
+		pdf := buildPDF(
+			"<< /Type /Catalog /Pages 2 0 R >>",
+			"<< /Type /Pages /Kids [3 0 R 4 0 R] /Count 2 >>",
+			"<< /Type /Page /Parent 2 0 R /Resources << /Font << /F1 6 0 R >> >> >>",
+			"<< /Type /Page /Parent 2 0 R /Resources << /XObject << /Im1 5 0 R >> >> >>",
+			imageObject("/Width 800 /Height 600 /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /DCTDecode", scan.Bytes()),
+			"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica >>",
+		)
+		if _, err := Generate(pdf, "application/pdf"); !errors.Is(err, ErrNoImage) {
+			t.Errorf("Expected ErrNoImage when page 1 has no raster image, got %v", err)
+		}
+	})
+}
+
+func TestGenerateUnsupported(t *testing.T) {
+	if _, err := Generate([]byte("\x00\x00\x00\x10ftypisom"), "video/mp4"); !errors.Is(err, ErrUnsupported) {
+		t.Errorf("Expected ErrUnsupported, got %v", err)
+	}
+}
+
+func TestGenerateRejectsHugeDimensions(t *testing.T) {
+	pdf := buildPDF(imageObject("/Width 100000 /Height 100000 /ColorSpace /DeviceRGB /BitsPerComponent 8 /Filter /FlateDecode", []byte("x")))
+	if _, err := Generate(pdf, "application/pdf"); err == nil {
+		t.Errorf("Expected huge image to be rejected")
+	}
+}
@@ -7,11 +7,12 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func RegisterAchievementRoutes(router fiber.Router, achSvc service.IAchievementService) {
+func RegisterAchievementRoutes(router fiber.Router, achSvc service.IAchievementService, memberSvc service.IAchievementMemberService, commentSvc service.IAchievementCommentService, revisionSvc service.IAchievementRevisionService, uploadSvc service.IUploadSessionService, linkSvc service.IEvidenceLinkService, trashSvc service.IAchievementTrashService) {
 	ach := router.Group("/achievements")
 	ach.Use(middleware.AuthProtected())
 
 	ach.Get("/", achSvc.GetAll)
+	ach.Get("/trash", middleware.PermissionCheck("achievement:trash"), trashSvc.List)
 	ach.Get("/:id", achSvc.GetDetail)
 	ach.Post("/", middleware.PermissionCheck("achievement:create"), achSvc.Create)
 	ach.Put("/:id", middleware.PermissionCheck("achievement:create"), achSvc.Edit)
@@ -19,6 +20,30 @@ func RegisterAchievementRoutes(router fiber.Router, achSvc service.IAchievementS
 	ach.Delete("/:id", middleware.PermissionCheck("achievement:create"), achSvc.Delete)
 	ach.Post("/:id/verify", middleware.PermissionCheck("achievement:verify"), achSvc.Verify)
 	ach.Post("/:id/reject", middleware.PermissionCheck("achievement:verify"), achSvc.Reject)
+	ach.Post("/:id/restore", middleware.PermissionCheck("achievement:trash"), trashSvc.Restore)
 	ach.Post("/:id/attachments", middleware.PermissionCheck("achievement:create"), achSvc.UploadAttachment)
+	ach.Put("/:id/attachments/:attachmentId", middleware.PermissionCheck("achievement:create"), achSvc.ReplaceAttachment)
+	ach.Delete("/:id/attachments/:attachmentId", middleware.PermissionCheck("achievement:create"), achSvc.DeleteAttachment)
+	ach.Get("/:id/attachments/:fileName", achSvc.DownloadAttachment)
+	ach.Get("/:id/attachments/:fileName/signed-url", achSvc.GetAttachmentURL)
 	ach.Get("/:id/history", achSvc.GetByStudent)
+	ach.Post("/:id/links", middleware.PermissionCheck("achievement:create"), linkSvc.AddLink)
+
+	ach.Post("/:id/uploads", middleware.PermissionCheck("achievement:create"), uploadSvc.Create)
+	ach.Get("/:id/uploads/:uploadId", middleware.PermissionCheck("achievement:create"), uploadSvc.Status)
+	ach.Patch("/:id/uploads/:uploadId", middleware.PermissionCheck("achievement:create"), uploadSvc.UploadChunk)
+	ach.Delete("/:id/uploads/:uploadId", middleware.PermissionCheck("achievement:create"), uploadSvc.Cancel)
+
+	ach.Post("/:id/members", middleware.PermissionCheck("achievement:create"), memberSvc.Invite)
+	ach.Post("/:id/members/respond", middleware.PermissionCheck("achievement:create"), memberSvc.Respond)
+	ach.Delete("/:id/members/:memberId", middleware.PermissionCheck("achievement:create"), memberSvc.Remove)
+	ach.Post("/:id/members/:memberId/verify", middleware.PermissionCheck("achievement:verify"), memberSvc.VerifyMember)
+	ach.Post("/:id/members/:memberId/reject", middleware.PermissionCheck("achievement:verify"), memberSvc.RejectMember)
+
+	ach.Get("/:id/comments", commentSvc.List)
+	ach.Post("/:id/comments", commentSvc.Create)
+	ach.Get("/:id/comments/:commentId/attachments/:fileName", commentSvc.DownloadAttachment)
+
+	ach.Get("/:id/revisions", revisionSvc.List)
+	ach.Get("/:id/revisions/diff", revisionSvc.Diff)
 }
@@ -0,0 +1,18 @@
+package route
+
+import (
+	"sistem-pelaporan-prestasi-mahasiswa/app/service"
+	"sistem-pelaporan-prestasi-mahasiswa/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterAchievementTypeRoutes(router fiber.Router, typeSvc service.IAchievementTypeService) {
+	types := router.Group("/achievement-types", middleware.AuthProtected())
+
+	types.Get("/", typeSvc.GetAll)
+	types.Get("/:id", typeSvc.GetByID)
+	types.Post("/", middleware.PermissionCheck("achievement_type:manage"), typeSvc.Create)
+	types.Put("/:id", middleware.PermissionCheck("achievement_type:manage"), typeSvc.Update)
+	types.Delete("/:id", middleware.PermissionCheck("achievement_type:manage"), typeSvc.Delete)
+}
@@ -0,0 +1,11 @@
+package route
+
+import (
+	"sistem-pelaporan-prestasi-mahasiswa/app/service"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterFileRoutes(router fiber.Router, fileSvc service.IFileService) {
+	router.Get("/files/*", fileSvc.ServeSigned)
+}
@@ -7,9 +7,12 @@ import (
 	"github.com/gofiber/fiber/v2"
 )
 
-func RegisterReportRoutes(router fiber.Router, reportSvc service.IReportService) {
+func RegisterReportRoutes(router fiber.Router, reportSvc service.IReportService, accreditationSvc service.IAccreditationService) {
 	rep := router.Group("/reports", middleware.AuthProtected())
 
 	rep.Get("/statistics", middleware.PermissionCheck("report:view_global"), reportSvc.GetDashboardStats)
+	rep.Get("/timeseries", middleware.PermissionCheck("report:view_global"), reportSvc.GetTimeseries)
 	rep.Get("/student/:id", middleware.PermissionCheck("report:view_student"), reportSvc.GetStudentReport)
+	rep.Get("/accreditation", middleware.PermissionCheck("report:view_global"), accreditationSvc.GetReport)
+	rep.Get("/accreditation/evidence", middleware.PermissionCheck("report:view_global"), accreditationSvc.GetEvidence)
 }
\ No newline at end of file
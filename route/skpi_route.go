@@ -0,0 +1,15 @@
+package route
+
+import (
+	"sistem-pelaporan-prestasi-mahasiswa/app/service"
+	"sistem-pelaporan-prestasi-mahasiswa/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterSKPIRoutes(router fiber.Router, skpiSvc service.ISKPIService) {
+	skpi := router.Group("/skpi", middleware.AuthProtected(), middleware.PermissionCheck("skpi:generate"))
+
+	skpi.Get("/batch", skpiSvc.GenerateBatch)
+	skpi.Get("/students/:id", skpiSvc.GenerateStudent)
+}
@@ -0,0 +1,16 @@
+package route
+
+import (
+	"sistem-pelaporan-prestasi-mahasiswa/app/service"
+	"sistem-pelaporan-prestasi-mahasiswa/middleware"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func RegisterStudyProgramRoutes(router fiber.Router, programSvc service.IStudyProgramService) {
+	programs := router.Group("/study-programs", middleware.AuthProtected(), middleware.PermissionCheck("study_program:manage"))
+
+	programs.Get("/", programSvc.GetAll)
+	programs.Put("/:name", programSvc.Update)
+	programs.Delete("/:name", programSvc.Delete)
+}
@@ -0,0 +1,28 @@
+package scanner
+
+import (
+	"bytes"
+	"context"
+	"io"
+)
+
+// EICAR adalah file uji antivirus standar yang dikenali semua engine
+const EICAR = `X5O!P%@AP[4\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*`
+
+// Fake menandai file yang mengandung string EICAR sebagai terinfeksi; dipakai untuk pengujian
+type Fake struct{}
+
+func NewFake() *Fake {
+	return &Fake{}
+}
+
+func (f *Fake) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return Result{}, err
+	}
+	if bytes.Contains(data, []byte(EICAR)) {
+		return Result{Clean: false, Signature: "Eicar-Test-Signature"}, nil
+	}
+	return Result{Clean: true}, nil
+}
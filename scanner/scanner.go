@@ -0,0 +1,63 @@
+package scanner
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"strings"
+	"time"
+)
+
+// Result adalah hasil pemindaian satu file
+type Result struct {
+	Clean     bool
+	Signature string
+}
+
+// Scanner memindai isi file untuk malware
+type Scanner interface {
+	Scan(ctx context.Context, r io.Reader) (Result, error)
+}
+
+// NewFromEnv memilih scanner sesuai SCANNER_DRIVER (clamd, fake, none). Tanpa konfigurasi, clamd dipakai bila CLAMD_ADDRESS diisi.
+func NewFromEnv() (Scanner, error) {
+	driver := strings.ToLower(os.Getenv("SCANNER_DRIVER"))
+	address := os.Getenv("CLAMD_ADDRESS")
+	if driver == "" {
+		driver = "none"
+		if address != "" {
+			driver = "clamd"
+		}
+	}
+
+	switch driver {
+	case "clamd":
+		if address == "" {
+			address = "tcp://127.0.0.1:3310"
+		}
+		network, addr := "tcp", address
+		if i := strings.Index(address, "://"); i >= 0 {
+			network, addr = address[:i], address[i+3:]
+		}
+		return NewClamd(network, addr, 2*time.Minute), nil
+
+	case "fake":
+		return NewFake(), nil
+
+	case "none":
+		log.Println("⚠️  SCANNER_DRIVER=none: lampiran tidak dipindai antivirus")
+		return Noop{}, nil
+	}
+
+	return nil, fmt.Errorf("SCANNER_DRIVER '%s' tidak dikenal (clamd, fake, none)", driver)
+}
+
+// Noop menganggap semua file bersih; hanya untuk lingkungan tanpa antivirus
+type Noop struct{}
+
+func (Noop) Scan(ctx context.Context, r io.Reader) (Result, error) {
+	_, err := io.Copy(io.Discard, r)
+	return Result{Clean: true}, err
+}
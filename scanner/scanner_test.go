@@ -0,0 +1,91 @@
+package scanner
+
+import (
+	"bufio"
+	"context"
+	"encoding/binary"
+	"io"
+	"net"
+	"strings"
+	"testing"
+	"time"
+)
+
+// fakeClamd menjawab perintah INSTREAM seperti clamd, memakai Fake untuk menilai isi
+func fakeClamd(t *testing.T) string {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	t.Cleanup(func() { ln.Close() })
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			go func(conn net.Conn) {
+				defer conn.Close()
+				r := bufio.NewReader(conn)
+				cmd, _ := r.ReadString(0)
+				if cmd != "zINSTREAM\x00" {
+					conn.Write([]byte("UNKNOWN COMMAND\x00"))
+					return
+				}
+
+				var data []byte
+				size := make([]byte, 4)
+				for {
+					if _, err := io.ReadFull(r, size); err != nil {
+						return
+					}
+					n := binary.BigEndian.Uint32(size)
+					if n == 0 {
+						break
+					}
+					chunk := make([]byte, n)
+					if _, err := io.ReadFull(r, chunk); err != nil {
+						return
+					}
+					data = append(data, chunk...)
+				}
+
+				result, _ := NewFake().Scan(context.Background(), strings.NewReader(string(data)))
+				if result.Clean {
+					conn.Write([]byte("stream: OK\x00"))
+				} else {
+					conn.Write([]byte("stream: " + result.Signature + " FOUND\x00"))
+				}
+			}(conn)
+		}
+	}()
+
+	return ln.Addr().String()
+}
+
+func TestClamd(t *testing.T) {
+	client := NewClamd("tcp", fakeClamd(t), 5*time.Second)
+
+	clean, err := client.Scan(context.Background(), strings.NewReader(strings.Repeat("sertifikat ", 20000)))
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if !clean.Clean {
+		t.Errorf("Expected clean result, got %+v", clean)
+	}
+
+	infected, err := client.Scan(context.Background(), strings.NewReader("prefix "+EICAR))
+	if err != nil {
+		t.Fatalf("Scan: %v", err)
+	}
+	if infected.Clean || infected.Signature != "Eicar-Test-Signature" {
+		t.Errorf("Expected EICAR to be detected, got %+v", infected)
+	}
+}
+
+func TestParseClamdReply(t *testing.T) {
+	if _, err := parseClamdReply("stream: INSTREAM size limit exceeded. ERROR"); err == nil {
+		t.Errorf("Expected error reply to return an error")
+	}
+}
@@ -0,0 +1,57 @@
+package storage
+
+import (
+	"context"
+	"log"
+)
+
+// CopyResult merangkum hasil penyalinan antar backend
+type CopyResult struct {
+	Copied  int
+	Skipped int
+	Failed  int
+	Bytes   int64
+}
+
+// Copy menyalin semua objek berprefix tertentu dari src ke dst. Objek yang sudah ada di dst dengan ukuran sama dilewati,
+// sehingga aman dijalankan ulang bila terputus di tengah jalan.
+func Copy(ctx context.Context, src, dst Storage, prefix string, dryRun bool) (CopyResult, error) {
+	var result CopyResult
+
+	err := src.List(ctx, prefix, func(obj ObjectInfo) error {
+		existing, err := dst.Stat(ctx, obj.Key)
+		if err == nil && existing.Size == obj.Size {
+			result.Skipped++
+			return nil
+		}
+		if err != nil && err != ErrNotFound {
+			return err
+		}
+
+		if dryRun {
+			result.Copied++
+			result.Bytes += obj.Size
+			return nil
+		}
+
+		rc, info, err := src.Get(ctx, obj.Key)
+		if err != nil {
+			log.Printf("⚠️  Gagal membaca %s: %v", obj.Key, err)
+			result.Failed++
+			return nil
+		}
+		defer rc.Close()
+
+		if err := dst.Put(ctx, obj.Key, rc, info.Size, info.ContentType); err != nil {
+			log.Printf("⚠️  Gagal menyalin %s: %v", obj.Key, err)
+			result.Failed++
+			return nil
+		}
+
+		result.Copied++
+		result.Bytes += info.Size
+		return nil
+	})
+
+	return result, err
+}
@@ -0,0 +1,71 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+)
+
+// ErrNotFound dikembalikan bila objek dengan key tersebut tidak ada
+var ErrNotFound = errors.New("storage: objek tidak ditemukan")
+
+// ObjectInfo adalah metadata objek yang tersimpan
+type ObjectInfo struct {
+	Key         string
+	Size        int64
+	ContentType string
+	ModTime     time.Time
+}
+
+// Storage adalah backend penyimpanan file lampiran. Key berbentuk path relatif, misalnya "achievements/ACH-1.pdf".
+type Storage interface {
+	Put(ctx context.Context, key string, r io.Reader, size int64, contentType string) error
+	Get(ctx context.Context, key string) (io.ReadCloser, *ObjectInfo, error)
+	Stat(ctx context.Context, key string) (*ObjectInfo, error)
+	Delete(ctx context.Context, key string) error
+	List(ctx context.Context, prefix string, fn func(ObjectInfo) error) error
+}
+
+// NewFromEnv membuat backend sesuai STORAGE_DRIVER (default: local)
+func NewFromEnv() (Storage, error) {
+	return New(os.Getenv("STORAGE_DRIVER"))
+}
+
+// New membuat backend berdasarkan nama driver; konfigurasi lain dibaca dari environment
+func New(driver string) (Storage, error) {
+	switch strings.ToLower(driver) {
+	case "", "local":
+		root := os.Getenv("STORAGE_LOCAL_DIR")
+		if root == "" {
+			root = "./uploads"
+		}
+		return NewLocal(root), nil
+
+	case "s3":
+		cfg := S3Config{
+			Endpoint:     os.Getenv("S3_ENDPOINT"),
+			Region:       os.Getenv("S3_REGION"),
+			Bucket:       os.Getenv("S3_BUCKET"),
+			AccessKey:    os.Getenv("S3_ACCESS_KEY"),
+			SecretKey:    os.Getenv("S3_SECRET_KEY"),
+			UsePathStyle: os.Getenv("S3_USE_PATH_STYLE") != "false",
+		}
+		return NewS3(cfg)
+	}
+
+	return nil, fmt.Errorf("STORAGE_DRIVER '%s' tidak dikenal (local, s3)", driver)
+}
+
+// CleanKey menormalkan key dan menolak key yang keluar dari root penyimpanan
+func CleanKey(key string) (string, error) {
+	key = strings.TrimPrefix(path.Clean("/"+strings.ReplaceAll(key, "\\", "/")), "/")
+	if key == "" || key == "." {
+		return "", fmt.Errorf("storage: key tidak valid")
+	}
+	return key, nil
+}
@@ -0,0 +1,192 @@
+package storage
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeS3 adalah pengganti MinIO minimal untuk pengujian: path-style, satu bucket, ListObjectsV2 dengan paginasi
+type fakeS3 struct {
+	mu      sync.Mutex
+	bucket  string
+	objects map[string][]byte
+	types   map[string]string
+	pageMax int
+}
+
+func newFakeS3(bucket string) *fakeS3 {
+	return &fakeS3{bucket: bucket, objects: make(map[string][]byte), types: make(map[string]string), pageMax: 2}
+}
+
+func (f *fakeS3) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	auth := r.Header.Get("Authorization")
+	if !strings.HasPrefix(auth, "AWS4-HMAC-SHA256 Credential=test-key/") || !strings.Contains(auth, "Signature=") ||
+		r.Header.Get("x-amz-date") == "" {
+		w.WriteHeader(http.StatusForbidden)
+		return
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	prefix := "/" + f.bucket
+	if !strings.HasPrefix(r.URL.Path, prefix) {
+		w.WriteHeader(http.StatusNotFound)
+		return
+	}
+	key := strings.TrimPrefix(strings.TrimPrefix(r.URL.Path, prefix), "/")
+
+	if key == "" && r.Method == http.MethodGet {
+		f.list(w, r)
+		return
+	}
+
+	switch r.Method {
+	case http.MethodPut:
+		body, _ := io.ReadAll(r.Body)
+		f.objects[key] = body
+		f.types[key] = r.Header.Get("Content-Type")
+	case http.MethodGet, http.MethodHead:
+		body, ok := f.objects[key]
+		if !ok {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", f.types[key])
+		w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+		w.Header().Set("Last-Modified", time.Now().UTC().Format(http.TimeFormat))
+		if r.Method == http.MethodGet {
+			w.Write(body)
+		}
+	case http.MethodDelete:
+		delete(f.objects, key)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func (f *fakeS3) list(w http.ResponseWriter, r *http.Request) {
+	var keys []string
+	for k := range f.objects {
+		if strings.HasPrefix(k, r.URL.Query().Get("prefix")) && k > r.URL.Query().Get("continuation-token") {
+			keys = append(keys, k)
+		}
+	}
+	sort.Strings(keys)
+
+	var result listBucketResult
+	if len(keys) > f.pageMax {
+		keys = keys[:f.pageMax]
+		result.IsTruncated = true
+		result.NextContinuationToken = keys[len(keys)-1]
+	}
+	for _, k := range keys {
+		result.Contents = append(result.Contents, struct {
+			Key          string    `xml:"Key"`
+			Size         int64     `xml:"Size"`
+			LastModified time.Time `xml:"LastModified"`
+		}{Key: k, Size: int64(len(f.objects[k])), LastModified: time.Now().UTC()})
+	}
+
+	xml.NewEncoder(w).Encode(result)
+}
+
+func exerciseStorage(t *testing.T, store Storage) {
+	ctx := context.Background()
+
+	files := map[string]string{
+		"achievements/ACH-1.pdf":   "%PDF-1.4 satu",
+		"achievements/ACH-2 a.png": "png dua",
+		"comments/CMT-1.pdf":       "%PDF-1.4 tiga",
+	}
+	for key, content := range files {
+		if err := store.Put(ctx, key, strings.NewReader(content), int64(len(content)), "application/pdf"); err != nil {
+			t.Fatalf("Put %s: %v", key, err)
+		}
+	}
+
+	rc, info, err := store.Get(ctx, "achievements/ACH-2 a.png")
+	if err != nil {
+		t.Fatalf("Get: %v", err)
+	}
+	body, _ := io.ReadAll(rc)
+	rc.Close()
+	if string(body) != "png dua" || info.Size != int64(len("png dua")) {
+		t.Errorf("Unexpected object: %q size=%d", body, info.Size)
+	}
+
+	var listed []string
+	err = store.List(ctx, "achievements/", func(o ObjectInfo) error {
+		listed = append(listed, o.Key)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("List: %v", err)
+	}
+	if len(listed) != 2 {
+		t.Errorf("Expected 2 objects under achievements/, got %v", listed)
+	}
+
+	if err := store.Delete(ctx, "achievements/ACH-1.pdf"); err != nil {
+		t.Fatalf("Delete: %v", err)
+	}
+	if _, err := store.Stat(ctx, "achievements/ACH-1.pdf"); err != ErrNotFound {
+		t.Errorf("Expected ErrNotFound after delete, got %v", err)
+	}
+	if _, _, err := store.Get(ctx, "../etc/passwd"); err != ErrNotFound {
+		t.Errorf("Expected key outside root to be treated as missing, got %v", err)
+	}
+}
+
+func TestLocalStorage(t *testing.T) {
+	exerciseStorage(t, NewLocal(t.TempDir()))
+}
+
+func TestS3Storage(t *testing.T) {
+	fake := newFakeS3("prestasi")
+	server := httptest.NewServer(fake)
+	defer server.Close()
+
+	store, err := NewS3(S3Config{
+		Endpoint: server.URL, Region: "us-east-1", Bucket: "prestasi",
+		AccessKey: "test-key", SecretKey: "test-secret", UsePathStyle: true,
+	})
+	if err != nil {
+		t.Fatalf("NewS3: %v", err)
+	}
+
+	exerciseStorage(t, store)
+
+	if _, ok := fake.objects["comments/CMT-1.pdf"]; !ok {
+		t.Errorf("Expected object to be stored in the fake bucket")
+	}
+}
+
+func TestCopy(t *testing.T) {
+	ctx := context.Background()
+	src, dst := NewLocal(t.TempDir()), NewLocal(t.TempDir())
+
+	src.Put(ctx, "achievements/a.pdf", bytes.NewReader([]byte("a")), 1, "application/pdf")
+	src.Put(ctx, "achievements/b.pdf", bytes.NewReader([]byte("bb")), 2, "application/pdf")
+	dst.Put(ctx, "achievements/b.pdf", bytes.NewReader([]byte("bb")), 2, "application/pdf")
+
+	result, err := Copy(ctx, src, dst, "", false)
+	if err != nil {
+		t.Fatalf("Copy: %v", err)
+	}
+	if result.Copied != 1 || result.Skipped != 1 {
+		t.Errorf("Expected 1 copied and 1 skipped, got %+v", result)
+	}
+	if _, err := dst.Stat(ctx, "achievements/a.pdf"); err != nil {
+		t.Errorf("Expected a.pdf to be copied: %v", err)
+	}
+}
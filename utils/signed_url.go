@@ -0,0 +1,52 @@
+package utils
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"os"
+	"strconv"
+	"time"
+)
+
+// SignedFilePath adalah prefix endpoint publik yang melayani URL bertanda tangan
+const SignedFilePath = "/api/v1/files/"
+
+func getFileURLSecret() []byte {
+	if secret := os.Getenv("FILE_URL_SECRET"); secret != "" {
+		return []byte(secret)
+	}
+	return getSecret()
+}
+
+func signFileKey(key string, expires int64) string {
+	mac := hmac.New(sha256.New, getFileURLSecret())
+	mac.Write([]byte(key + "\n" + strconv.FormatInt(expires, 10)))
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SignFileURL membuat URL unduhan berumur pendek untuk key storage tertentu
+func SignFileURL(key string, ttl time.Duration) (string, time.Time) {
+	expiresAt := time.Now().Add(ttl)
+	expires := expiresAt.Unix()
+
+	query := url.Values{}
+	query.Set("expires", strconv.FormatInt(expires, 10))
+	query.Set("signature", signFileKey(key, expires))
+
+	escaped := (&url.URL{Path: key}).EscapedPath()
+	return fmt.Sprintf("%s%s?%s", SignedFilePath, escaped, query.Encode()), expiresAt
+}
+
+// VerifyFileSignature memeriksa tanda tangan dan masa berlaku URL unduhan
+func VerifyFileSignature(key, expires, signature string, now time.Time) bool {
+	exp, err := strconv.ParseInt(expires, 10, 64)
+	if err != nil || now.Unix() > exp {
+		return false
+	}
+
+	expected := signFileKey(key, exp)
+	return hmac.Equal([]byte(expected), []byte(signature))
+}
@@ -0,0 +1,59 @@
+package utils
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizeText menurunkan huruf dan membuang tanda baca agar perbandingan teks tidak sensitif format
+func NormalizeText(s string) string {
+	var b strings.Builder
+	for _, r := range strings.ToLower(s) {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			b.WriteRune(r)
+		} else {
+			b.WriteRune(' ')
+		}
+	}
+	return strings.Join(strings.Fields(b.String()), " ")
+}
+
+func bigrams(s string) map[string]int {
+	grams := make(map[string]int)
+	runes := []rune(s)
+	for i := 0; i < len(runes)-1; i++ {
+		grams[string(runes[i:i+2])]++
+	}
+	return grams
+}
+
+// DiceSimilarity menghitung koefisien Sørensen–Dice atas bigram karakter (0..1)
+func DiceSimilarity(a, b string) float64 {
+	a, b = NormalizeText(a), NormalizeText(b)
+	if a == "" || b == "" {
+		return 0
+	}
+	if a == b {
+		return 1
+	}
+
+	ga, gb := bigrams(a), bigrams(b)
+	total, overlap := 0, 0
+	for g, ca := range ga {
+		total += ca
+		if cb, ok := gb[g]; ok {
+			if ca < cb {
+				overlap += ca
+			} else {
+				overlap += cb
+			}
+		}
+	}
+	for _, cb := range gb {
+		total += cb
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(2*overlap) / float64(total)
+}